@@ -0,0 +1,43 @@
+package moira
+
+// Acknowledgement represents user confirmation that problem of trigger or metric is being handled.
+// Acknowledgement is active until state of trigger or metric changes or until expiry if expiry is set
+type Acknowledgement struct {
+	User      string `json:"user"`
+	Timestamp int64  `json:"timestamp"`
+	Expiry    int64  `json:"expiry,omitempty"`
+}
+
+// IsActive checks that acknowledgement is set and not expired at given timestamp
+func (ack *Acknowledgement) IsActive(timestamp int64) bool {
+	return ack != nil && (ack.Expiry == 0 || timestamp < ack.Expiry)
+}
+
+// SetAcknowledgement sets acknowledgement of given metrics, if metrics are empty, acknowledgement is set
+// for trigger and all metrics in bad state. Nil acknowledgement removes existing acknowledgements
+func (checkData *CheckData) SetAcknowledgement(metrics []string, ack *Acknowledgement) {
+	if len(metrics) == 0 {
+		checkData.Acknowledgement = ack
+		for metric, metricState := range checkData.Metrics {
+			if ack == nil || metricState.State != StateOK {
+				metricState.Acknowledgement = ack
+				checkData.Metrics[metric] = metricState
+			}
+		}
+		return
+	}
+	for _, metric := range metrics {
+		metricState, ok := checkData.Metrics[metric]
+		if !ok {
+			continue
+		}
+		metricState.Acknowledgement = ack
+		checkData.Metrics[metric] = metricState
+	}
+}
+
+// CanBeAcknowledged checks that notification events are not test ones and the most critical of them is a problem state
+func (events NotificationEvents) CanBeAcknowledged() bool {
+	subjectState := events.GetSubjectState()
+	return subjectState != StateOK && subjectState != StateTEST
+}
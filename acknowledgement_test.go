@@ -0,0 +1,69 @@
+package moira
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAcknowledgementIsActive(t *testing.T) {
+	Convey("Acknowledgement is active", t, func() {
+		var ack *Acknowledgement
+		So(ack.IsActive(100), ShouldBeFalse)
+
+		ack = &Acknowledgement{User: "user", Timestamp: 10}
+		So(ack.IsActive(100), ShouldBeTrue)
+
+		ack.Expiry = 100
+		So(ack.IsActive(99), ShouldBeTrue)
+		So(ack.IsActive(100), ShouldBeFalse)
+	})
+}
+
+func TestCheckDataSetAcknowledgement(t *testing.T) {
+	ack := &Acknowledgement{User: "user", Timestamp: 10}
+	newCheckData := func() *CheckData {
+		return &CheckData{
+			State: StateOK,
+			Metrics: map[string]MetricState{
+				"ok":    {State: StateOK},
+				"error": {State: StateERROR},
+				"warn":  {State: StateWARN},
+			},
+		}
+	}
+
+	Convey("Acknowledge trigger sets acknowledgement of trigger and bad state metrics", t, func() {
+		checkData := newCheckData()
+		checkData.SetAcknowledgement(nil, ack)
+		So(checkData.Acknowledgement, ShouldEqual, ack)
+		So(checkData.Metrics["ok"].Acknowledgement, ShouldBeNil)
+		So(checkData.Metrics["error"].Acknowledgement, ShouldEqual, ack)
+		So(checkData.Metrics["warn"].Acknowledgement, ShouldEqual, ack)
+
+		Convey("Remove trigger acknowledgement removes all acknowledgements", func() {
+			checkData.SetAcknowledgement(nil, nil)
+			So(checkData.Acknowledgement, ShouldBeNil)
+			So(checkData.Metrics["error"].Acknowledgement, ShouldBeNil)
+			So(checkData.Metrics["warn"].Acknowledgement, ShouldBeNil)
+		})
+	})
+
+	Convey("Acknowledge metrics", t, func() {
+		checkData := newCheckData()
+		checkData.SetAcknowledgement([]string{"error", "unknown"}, ack)
+		So(checkData.Acknowledgement, ShouldBeNil)
+		So(checkData.Metrics["error"].Acknowledgement, ShouldEqual, ack)
+		So(checkData.Metrics["warn"].Acknowledgement, ShouldBeNil)
+		So(checkData.Metrics, ShouldNotContainKey, "unknown")
+	})
+}
+
+func TestNotificationEventsCanBeAcknowledged(t *testing.T) {
+	Convey("Notification events can be acknowledged", t, func() {
+		So(NotificationEvents{{State: StateOK}}.CanBeAcknowledged(), ShouldBeFalse)
+		So(NotificationEvents{{State: StateTEST}}.CanBeAcknowledged(), ShouldBeFalse)
+		So(NotificationEvents{{State: StateOK}, {State: StateWARN}}.CanBeAcknowledged(), ShouldBeTrue)
+		So(NotificationEvents{{State: StateERROR}, {State: StateNODATA}}.CanBeAcknowledged(), ShouldBeTrue)
+	})
+}
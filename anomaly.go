@@ -0,0 +1,44 @@
+package moira
+
+const (
+	// DefaultAnomalySeasons is the default number of previous seasons used to calculate anomaly trigger baseline
+	DefaultAnomalySeasons = 7
+	// DefaultAnomalySeasonLength is the default anomaly trigger season length in seconds, one day
+	DefaultAnomalySeasonLength int64 = 24 * 60 * 60
+	// DefaultAnomalyWindow is the default half-width of anomaly trigger baseline window in seconds
+	DefaultAnomalyWindow int64 = 30 * 60
+)
+
+// AnomalySettings represents the way anomaly trigger calculates baseline of metric value.
+// Baseline of value at timestamp ts is calculated from metric values in windows
+// [ts - k*SeasonLength - Window, ts - k*SeasonLength + Window] for k from 1 to Seasons.
+// Local triggers can use only history which is kept by checker, see checker metrics_ttl
+type AnomalySettings struct {
+	Seasons      int   `json:"seasons"`
+	SeasonLength int64 `json:"season_length"`
+	Window       int64 `json:"window"`
+}
+
+// GetDefaultAnomalySettings returns anomaly settings used when trigger has no ones
+func GetDefaultAnomalySettings() *AnomalySettings {
+	return &AnomalySettings{
+		Seasons:      DefaultAnomalySeasons,
+		SeasonLength: DefaultAnomalySeasonLength,
+		Window:       DefaultAnomalyWindow,
+	}
+}
+
+// GetHistoryRange returns the range of metric history required to calculate baselines of values in given range
+func (settings *AnomalySettings) GetHistoryRange(from, until int64) (int64, int64) {
+	historyFrom := from - int64(settings.Seasons)*settings.SeasonLength - settings.Window
+	historyUntil := until - settings.SeasonLength + settings.Window
+	return historyFrom, historyUntil
+}
+
+// GetAnomalySettings returns trigger anomaly settings or default ones if trigger has no settings
+func (trigger *Trigger) GetAnomalySettings() *AnomalySettings {
+	if trigger.Anomaly == nil {
+		return GetDefaultAnomalySettings()
+	}
+	return trigger.Anomaly
+}
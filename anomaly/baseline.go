@@ -0,0 +1,101 @@
+package anomaly
+
+import (
+	"math"
+
+	"github.com/moira-alert/moira"
+	metricSource "github.com/moira-alert/moira/metric_source"
+)
+
+// minBaselineValues is the minimum number of history values baseline can be calculated from
+const minBaselineValues = 3
+
+// Baseline represents expected metric value calculated from its values at the same time of previous seasons
+type Baseline struct {
+	Mean   float64
+	StdDev float64
+}
+
+// GetBaseline calculates baseline of metric value at given timestamp from metric history,
+// returns false if history has not enough values to calculate it
+func GetBaseline(history *metricSource.MetricData, timestamp int64, settings *moira.AnomalySettings) (Baseline, bool) {
+	if history == nil || history.StepTime <= 0 || settings == nil {
+		return Baseline{}, false
+	}
+	values := make([]float64, 0)
+	for season := 1; season <= settings.Seasons; season++ {
+		seasonTimestamp := timestamp - int64(season)*settings.SeasonLength
+		values = appendWindowValues(values, history, seasonTimestamp-settings.Window, seasonTimestamp+settings.Window)
+	}
+	if len(values) < minBaselineValues {
+		return Baseline{}, false
+	}
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	mean := sum / float64(len(values))
+	var squaresSum float64
+	for _, value := range values {
+		squaresSum += (value - mean) * (value - mean)
+	}
+	return Baseline{
+		Mean:   mean,
+		StdDev: math.Sqrt(squaresSum / float64(len(values))),
+	}, true
+}
+
+// appendWindowValues appends valid history values with timestamps from given range
+func appendWindowValues(values []float64, history *metricSource.MetricData, from, until int64) []float64 {
+	if until < history.StartTime {
+		return values
+	}
+	firstIndex := int64(0)
+	if from > history.StartTime {
+		firstIndex = (from - history.StartTime) / history.StepTime
+	}
+	lastIndex := (until - history.StartTime) / history.StepTime
+	if lastIndex >= int64(len(history.Values)) {
+		lastIndex = int64(len(history.Values)) - 1
+	}
+	for index := firstIndex; index <= lastIndex; index++ {
+		if value := history.Values[index]; moira.IsValidFloat64(value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// GetDeviation returns absolute number of standard deviations given value deviates from baseline mean.
+// Any deviation from baseline with zero standard deviation is infinite
+func (baseline Baseline) GetDeviation(value float64) float64 {
+	deviation := math.Abs(value - baseline.Mean)
+	if baseline.StdDev == 0 {
+		if deviation == 0 {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	return deviation / baseline.StdDev
+}
+
+// GetBand returns lower and upper bounds of metric values which deviate from their baselines
+// less than given number of standard deviations, bounds are NaN where baseline can't be calculated
+func GetBand(metricData, history *metricSource.MetricData, settings *moira.AnomalySettings, deviations float64) (lower, upper *metricSource.MetricData) {
+	lowerValues := make([]float64, len(metricData.Values))
+	upperValues := make([]float64, len(metricData.Values))
+	for index := range metricData.Values {
+		timestamp := metricData.StartTime + int64(index)*metricData.StepTime
+		baseline, ok := GetBaseline(history, timestamp, settings)
+		if !ok {
+			lowerValues[index] = math.NaN()
+			upperValues[index] = math.NaN()
+			continue
+		}
+		lowerValues[index] = baseline.Mean - deviations*baseline.StdDev
+		upperValues[index] = baseline.Mean + deviations*baseline.StdDev
+	}
+	lower = metricSource.MakeMetricData(metricData.Name, lowerValues, metricData.StepTime, metricData.StartTime)
+	upper = metricSource.MakeMetricData(metricData.Name, upperValues, metricData.StepTime, metricData.StartTime)
+	return lower, upper
+}
@@ -0,0 +1,81 @@
+package anomaly
+
+import (
+	"math"
+	"testing"
+
+	"github.com/moira-alert/moira"
+	metricSource "github.com/moira-alert/moira/metric_source"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetBaseline(t *testing.T) {
+	settings := &moira.AnomalySettings{Seasons: 2, SeasonLength: 100, Window: 10}
+	history := metricSource.MakeMetricData("metric", []float64{
+		8, 10, 12, math.NaN(), 0, 0, 0, 0, 0, 0,
+		9, 10, 11, 0, 0, 0, 0, 0, 0, 0,
+	}, 10, 0)
+
+	Convey("Baseline is calculated from window values of all seasons", t, func() {
+		baseline, ok := GetBaseline(history, 210, settings)
+		So(ok, ShouldBeTrue)
+		So(baseline.Mean, ShouldEqual, 10)
+		So(baseline.StdDev, ShouldAlmostEqual, math.Sqrt(10.0/6))
+	})
+
+	Convey("NaN values are skipped", t, func() {
+		baseline, ok := GetBaseline(history, 220, settings)
+		So(ok, ShouldBeTrue)
+		So(baseline.Mean, ShouldEqual, 43.0/5)
+	})
+
+	Convey("Not enough history", t, func() {
+		_, ok := GetBaseline(history, 110, &moira.AnomalySettings{Seasons: 1, SeasonLength: 100, Window: 0})
+		So(ok, ShouldBeFalse)
+
+		_, ok = GetBaseline(history, 1000, settings)
+		So(ok, ShouldBeFalse)
+
+		_, ok = GetBaseline(nil, 210, settings)
+		So(ok, ShouldBeFalse)
+	})
+}
+
+func TestGetDeviation(t *testing.T) {
+	Convey("Deviation is absolute number of standard deviations", t, func() {
+		baseline := Baseline{Mean: 10, StdDev: 2}
+		So(baseline.GetDeviation(14), ShouldEqual, 2)
+		So(baseline.GetDeviation(7), ShouldEqual, 1.5)
+	})
+
+	Convey("Baseline with zero standard deviation", t, func() {
+		baseline := Baseline{Mean: 10}
+		So(baseline.GetDeviation(10), ShouldEqual, 0)
+		So(math.IsInf(baseline.GetDeviation(11), 1), ShouldBeTrue)
+	})
+}
+
+func TestGetBand(t *testing.T) {
+	settings := &moira.AnomalySettings{Seasons: 1, SeasonLength: 100, Window: 10}
+	history := metricSource.MakeMetricData("metric", []float64{9, 10, 11, 10, 10}, 10, 0)
+	metricData := metricSource.MakeMetricData("metric", []float64{1, 2, 3}, 10, 110)
+
+	Convey("Band bounds are calculated for each metric value", t, func() {
+		lower, upper := GetBand(metricData, history, settings, 2)
+		So(lower.Name, ShouldEqual, "metric")
+		So(lower.StartTime, ShouldEqual, 110)
+		So(lower.StepTime, ShouldEqual, 10)
+		So(lower.Values[0], ShouldAlmostEqual, 10-2*math.Sqrt(2.0/3))
+		So(upper.Values[0], ShouldAlmostEqual, 10+2*math.Sqrt(2.0/3))
+		So(lower.Values[1], ShouldAlmostEqual, 31.0/3-2*math.Sqrt(2.0/9))
+		So(math.IsNaN(lower.Values[2]), ShouldBeFalse)
+	})
+
+	Convey("Band bounds are NaN without history", t, func() {
+		lower, upper := GetBand(metricData, nil, settings, 2)
+		for index := range metricData.Values {
+			So(math.IsNaN(lower.Values[index]), ShouldBeTrue)
+			So(math.IsNaN(upper.Values[index]), ShouldBeTrue)
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package moira
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAnomalySettings(t *testing.T) {
+	Convey("History range covers windows of all previous seasons", t, func() {
+		settings := &AnomalySettings{Seasons: 3, SeasonLength: 1000, Window: 50}
+		from, until := settings.GetHistoryRange(5000, 5600)
+		So(from, ShouldEqual, 1950)
+		So(until, ShouldEqual, 4650)
+	})
+
+	Convey("Trigger without anomaly settings uses default ones", t, func() {
+		trigger := Trigger{}
+		So(trigger.GetAnomalySettings(), ShouldResemble, GetDefaultAnomalySettings())
+
+		trigger.Anomaly = &AnomalySettings{Seasons: 1, SeasonLength: 60}
+		So(trigger.GetAnomalySettings(), ShouldEqual, trigger.Anomaly)
+	})
+}
@@ -1,16 +1,24 @@
 package api
 
+import "github.com/moira-alert/moira"
+
 // Config for api configuration variables
 type Config struct {
-	EnableCORS bool
-	Listen     string
+	EnableCORS         bool
+	Listen             string
+	SlackSigningSecret string
+	Admins             []string
+	LocalMetricsTTL    int64
+	MaxBacktestWindow  int64
 }
 
 // WebConfig is container for web ui configuration parameters
 type WebConfig struct {
-	SupportEmail  string       `json:"supportEmail,omitempty"`
-	RemoteAllowed bool         `json:"remoteAllowed"`
-	Contacts      []WebContact `json:"contacts"`
+	SupportEmail      string            `json:"supportEmail,omitempty"`
+	RemoteAllowed     bool              `json:"remoteAllowed"`
+	RemoteClusters    []moira.ClusterID `json:"remoteClusters"`
+	PrometheusAllowed bool              `json:"prometheusAllowed"`
+	Contacts          []WebContact      `json:"contacts"`
 }
 
 // WebContact is container for web ui contact validation
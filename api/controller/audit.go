@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+)
+
+// GetAuditLog gets audit records of all entities from current page and all audit records count
+func GetAuditLog(database moira.Database, page int64, size int64) (*dto.AuditRecordsList, *api.ErrorResponse) {
+	records, err := database.GetAuditRecords(page*size, size)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	total, err := database.GetAuditRecordsCount()
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	return createAuditRecordsList(records, page, size, total), nil
+}
+
+// GetTriggerHistory gets trigger audit records from current page and all trigger audit records count
+func GetTriggerHistory(database moira.Database, triggerID string, page int64, size int64) (*dto.AuditRecordsList, *api.ErrorResponse) {
+	records, err := database.GetEntityAuditRecords(moira.AuditEntityTrigger, triggerID, page*size, size)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	total, err := database.GetEntityAuditRecordsCount(moira.AuditEntityTrigger, triggerID)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	return createAuditRecordsList(records, page, size, total), nil
+}
+
+// GetTriggerVersion gets trigger version stored in given audit record of trigger. Version must be validated before restore
+func GetTriggerVersion(dataBase moira.Database, triggerID string, auditRecordID string) (*dto.Trigger, *api.ErrorResponse) {
+	record, err := dataBase.GetAuditRecord(auditRecordID)
+	if err != nil {
+		if err == database.ErrNil {
+			return nil, api.ErrorNotFound(fmt.Sprintf("audit record with ID = '%s' does not exists", auditRecordID))
+		}
+		return nil, api.ErrorInternalServer(err)
+	}
+	if record.EntityType != moira.AuditEntityTrigger || record.EntityID != triggerID {
+		return nil, api.ErrorNotFound(fmt.Sprintf("audit record with ID = '%s' does not belong to trigger '%s'", auditRecordID, triggerID))
+	}
+	if !record.Action.HasEntityVersion() {
+		return nil, api.ErrorInvalidRequest(fmt.Errorf("audit record with ID = '%s' has no trigger version to restore", auditRecordID))
+	}
+
+	version := record.After
+	if len(version) == 0 {
+		version = record.Before
+	}
+	if len(version) == 0 {
+		return nil, api.ErrorInvalidRequest(fmt.Errorf("audit record with ID = '%s' has no trigger version to restore", auditRecordID))
+	}
+	trigger := &moira.Trigger{}
+	if err = json.Unmarshal(version, trigger); err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	trigger.ID = triggerID
+	return &dto.Trigger{TriggerModel: dto.CreateTriggerModel(trigger)}, nil
+}
+
+// RestoreTrigger saves validated trigger version, trigger metrics in last state are kept.
+// User must be permitted to give trigger to team of restored version
+func RestoreTrigger(dataBase moira.Database, version *dto.TriggerModel, timeSeriesNames map[string]bool, userLogin string, isAdmin bool) (*dto.SaveTriggerResponse, *api.ErrorResponse) {
+	if errorResponse := CheckTeamAssignment(dataBase, version.TeamID, userLogin, isAdmin); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	existing, err := getExistingTrigger(dataBase, version.ID)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+
+	restored := version.ToMoiraTrigger()
+	resp, errorResponse := saveTrigger(dataBase, restored, version.ID, timeSeriesNames)
+	if errorResponse != nil {
+		return nil, errorResponse
+	}
+	if errorResponse = saveAuditRecord(dataBase, userLogin, moira.AuditEntityTrigger, version.ID, moira.AuditActionRestore, existing, restored); errorResponse != nil {
+		return nil, errorResponse
+	}
+	resp.Message = "trigger restored"
+	return resp, nil
+}
+
+// saveAuditRecord records change of entity made by user, nil before or after means that entity does not exist
+func saveAuditRecord(dataBase moira.Database, userLogin string, entityType moira.AuditEntityType, entityID string, action moira.AuditAction, before, after interface{}) *api.ErrorResponse {
+	uuid4, err := uuid.NewV4()
+	if err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	record, err := moira.NewAuditRecord(uuid4.String(), time.Now().Unix(), userLogin, entityType, entityID, action, before, after)
+	if err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	if err = dataBase.SaveAuditRecord(record); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	return nil
+}
+
+// getExistingTrigger returns trigger by given ID or nil if trigger does not exist
+func getExistingTrigger(dataBase moira.Database, triggerID string) (*moira.Trigger, error) {
+	trigger, err := dataBase.GetTrigger(triggerID)
+	if err == database.ErrNil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &trigger, nil
+}
+
+func createAuditRecordsList(records []*moira.AuditRecord, page int64, size int64, total int64) *dto.AuditRecordsList {
+	recordsList := &dto.AuditRecordsList{
+		Size:  size,
+		Page:  page,
+		Total: total,
+		List:  make([]moira.AuditRecord, 0, len(records)),
+	}
+	for _, record := range records {
+		if record != nil {
+			recordsList.List = append(recordsList.List, *record)
+		}
+	}
+	return recordsList
+}
@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetAuditLog(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	record := &moira.AuditRecord{ID: uuid.Must(uuid.NewV4()).String(), EntityType: moira.AuditEntityContact}
+
+	Convey("Has records", t, func() {
+		dataBase.EXPECT().GetAuditRecords(int64(20), int64(10)).Return([]*moira.AuditRecord{record, nil}, nil)
+		dataBase.EXPECT().GetAuditRecordsCount().Return(int64(21), nil)
+		list, err := GetAuditLog(dataBase, 2, 10)
+		So(err, ShouldBeNil)
+		So(list, ShouldResemble, &dto.AuditRecordsList{Page: 2, Size: 10, Total: 21, List: []moira.AuditRecord{*record}})
+	})
+
+	Convey("Error get records", t, func() {
+		expected := fmt.Errorf("oooops! Can not get audit records")
+		dataBase.EXPECT().GetAuditRecords(int64(0), int64(10)).Return(nil, expected)
+		list, err := GetAuditLog(dataBase, 0, 10)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(list, ShouldBeNil)
+	})
+}
+
+func TestGetTriggerHistory(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	triggerID := uuid.Must(uuid.NewV4()).String()
+
+	Convey("Has no records", t, func() {
+		dataBase.EXPECT().GetEntityAuditRecords(moira.AuditEntityTrigger, triggerID, int64(0), int64(100)).Return(make([]*moira.AuditRecord, 0), nil)
+		dataBase.EXPECT().GetEntityAuditRecordsCount(moira.AuditEntityTrigger, triggerID).Return(int64(0), nil)
+		list, err := GetTriggerHistory(dataBase, triggerID, 0, 100)
+		So(err, ShouldBeNil)
+		So(list, ShouldResemble, &dto.AuditRecordsList{Page: 0, Size: 100, Total: 0, List: make([]moira.AuditRecord, 0)})
+	})
+
+	Convey("Error get records count", t, func() {
+		expected := fmt.Errorf("oooops! Can not get audit records count")
+		dataBase.EXPECT().GetEntityAuditRecords(moira.AuditEntityTrigger, triggerID, int64(0), int64(100)).Return(make([]*moira.AuditRecord, 0), nil)
+		dataBase.EXPECT().GetEntityAuditRecordsCount(moira.AuditEntityTrigger, triggerID).Return(int64(0), expected)
+		list, err := GetTriggerHistory(dataBase, triggerID, 0, 100)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(list, ShouldBeNil)
+	})
+}
+
+func TestGetTriggerVersion(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	triggerID := uuid.Must(uuid.NewV4()).String()
+	recordID := uuid.Must(uuid.NewV4()).String()
+	previous := moira.Trigger{ID: "oldID", Name: "previous", Targets: []string{"my.metric"}, Tags: []string{"tag"}, TTL: 600}
+	previousBytes, _ := json.Marshal(previous)
+
+	Convey("Updated trigger version", t, func() {
+		record := moira.AuditRecord{ID: recordID, EntityType: moira.AuditEntityTrigger, EntityID: triggerID, Action: moira.AuditActionUpdate, After: previousBytes}
+		dataBase.EXPECT().GetAuditRecord(recordID).Return(record, nil)
+		version, err := GetTriggerVersion(dataBase, triggerID, recordID)
+		So(err, ShouldBeNil)
+		previous.ID = triggerID
+		So(version, ShouldResemble, &dto.Trigger{TriggerModel: dto.CreateTriggerModel(&previous)})
+	})
+
+	Convey("Removed trigger version", t, func() {
+		record := moira.AuditRecord{ID: recordID, EntityType: moira.AuditEntityTrigger, EntityID: triggerID, Action: moira.AuditActionRemove, Before: previousBytes}
+		dataBase.EXPECT().GetAuditRecord(recordID).Return(record, nil)
+		version, err := GetTriggerVersion(dataBase, triggerID, recordID)
+		So(err, ShouldBeNil)
+		So(version.Name, ShouldEqual, "previous")
+	})
+
+	Convey("Audit record without trigger version", t, func() {
+		record := moira.AuditRecord{ID: recordID, EntityType: moira.AuditEntityTrigger, EntityID: triggerID, Action: moira.AuditActionMaintenance, After: []byte(`{"trigger": 100}`)}
+		dataBase.EXPECT().GetAuditRecord(recordID).Return(record, nil)
+		version, err := GetTriggerVersion(dataBase, triggerID, recordID)
+		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("audit record with ID = '%s' has no trigger version to restore", recordID)))
+		So(version, ShouldBeNil)
+	})
+
+	Convey("Audit record does not exist", t, func() {
+		dataBase.EXPECT().GetAuditRecord(recordID).Return(moira.AuditRecord{}, database.ErrNil)
+		version, err := GetTriggerVersion(dataBase, triggerID, recordID)
+		So(err, ShouldResemble, api.ErrorNotFound(fmt.Sprintf("audit record with ID = '%s' does not exists", recordID)))
+		So(version, ShouldBeNil)
+	})
+
+	Convey("Audit record of another entity", t, func() {
+		record := moira.AuditRecord{ID: recordID, EntityType: moira.AuditEntityContact, EntityID: triggerID, After: previousBytes}
+		dataBase.EXPECT().GetAuditRecord(recordID).Return(record, nil)
+		version, err := GetTriggerVersion(dataBase, triggerID, recordID)
+		So(err, ShouldResemble, api.ErrorNotFound(fmt.Sprintf("audit record with ID = '%s' does not belong to trigger '%s'", recordID, triggerID)))
+		So(version, ShouldBeNil)
+	})
+}
+
+func TestRestoreTrigger(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	triggerID := uuid.Must(uuid.NewV4()).String()
+	userLogin := "user"
+	version := dto.CreateTriggerModel(&moira.Trigger{ID: triggerID, Name: "previous", Targets: []string{"my.metric"}, Tags: []string{"tag"}, Patterns: []string{"my.metric"}, TTL: 600})
+	current := moira.Trigger{ID: triggerID, Name: "current"}
+	timeSeriesNames := map[string]bool{"my.metric": true}
+
+	Convey("Success restore", t, func() {
+		lastCheck := moira.CheckData{Metrics: map[string]moira.MetricState{"my.metric": {}}}
+		dataBase.EXPECT().GetTrigger(triggerID).Return(current, nil)
+		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(lastCheck, nil)
+		dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 10)
+		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
+		dataBase.EXPECT().SetTriggerLastCheck(triggerID, &lastCheck, version.ToMoiraTrigger().ClusterKey()).Return(nil)
+		dataBase.EXPECT().SaveTrigger(triggerID, version.ToMoiraTrigger()).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Do(func(restoreRecord *moira.AuditRecord) {
+			So(restoreRecord.Action, ShouldEqual, moira.AuditActionRestore)
+			So(restoreRecord.User, ShouldEqual, userLogin)
+			So(restoreRecord.Diff, ShouldContainKey, "name")
+		}).Return(nil)
+		resp, err := RestoreTrigger(dataBase, &version, timeSeriesNames, userLogin, false)
+		So(err, ShouldBeNil)
+		So(resp, ShouldResemble, &dto.SaveTriggerResponse{ID: triggerID, Message: "trigger restored"})
+	})
+
+	Convey("Restore removed trigger", t, func() {
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{}, database.ErrNil)
+		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
+		dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 10)
+		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
+		dataBase.EXPECT().SetTriggerLastCheck(triggerID, gomock.Any(), version.ToMoiraTrigger().ClusterKey()).Return(nil)
+		dataBase.EXPECT().SaveTrigger(triggerID, version.ToMoiraTrigger()).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		_, err := RestoreTrigger(dataBase, &version, timeSeriesNames, userLogin, false)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Restore version of team user is not permitted to change", t, func() {
+		teamVersion := version
+		teamVersion.TeamID = "teamID"
+		dataBase.EXPECT().GetTeam("teamID").Return(moira.Team{ID: "teamID", Members: map[string]moira.TeamRole{userLogin: moira.TeamRoleViewer}}, nil)
+		resp, err := RestoreTrigger(dataBase, &teamVersion, timeSeriesNames, userLogin, false)
+		So(err, ShouldResemble, api.ErrorForbidden("you are not permitted to change triggers and subscriptions of team 'teamID'"))
+		So(resp, ShouldBeNil)
+	})
+}
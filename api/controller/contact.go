@@ -29,10 +29,12 @@ func GetAllContacts(database moira.Database) (*dto.ContactList, *api.ErrorRespon
 // CreateContact creates new notification contact for current user
 func CreateContact(dataBase moira.Database, contact *dto.Contact, userLogin string) *api.ErrorResponse {
 	contactData := moira.ContactData{
-		ID:    contact.ID,
-		User:  userLogin,
-		Type:  contact.Type,
-		Value: contact.Value,
+		ID:              contact.ID,
+		User:            userLogin,
+		Type:            contact.Type,
+		Value:           contact.Value,
+		DigestInterval:  contact.DigestInterval,
+		MessageTemplate: contact.MessageTemplate,
 	}
 	if contactData.ID == "" {
 		uuid4, err := uuid.NewV4()
@@ -55,29 +57,35 @@ func CreateContact(dataBase moira.Database, contact *dto.Contact, userLogin stri
 	}
 	contact.User = userLogin
 	contact.ID = contactData.ID
-	return nil
+	return saveAuditRecord(dataBase, userLogin, moira.AuditEntityContact, contactData.ID, moira.AuditActionCreate, nil, &contactData)
 }
 
 // UpdateContact updates notification contact for current user
-func UpdateContact(dataBase moira.Database, contactDTO dto.Contact, contactData moira.ContactData) (dto.Contact, *api.ErrorResponse) {
+func UpdateContact(dataBase moira.Database, contactDTO dto.Contact, contactData moira.ContactData, userLogin string) (dto.Contact, *api.ErrorResponse) {
+	existing := contactData
 	contactData.Type = contactDTO.Type
 	contactData.Value = contactDTO.Value
+	contactData.DigestInterval = contactDTO.DigestInterval
+	contactData.MessageTemplate = contactDTO.MessageTemplate
 	if err := dataBase.SaveContact(&contactData); err != nil {
 		return contactDTO, api.ErrorInternalServer(err)
 	}
 	contactDTO.User = contactData.User
 	contactDTO.ID = contactData.ID
+	if err := saveAuditRecord(dataBase, userLogin, moira.AuditEntityContact, contactData.ID, moira.AuditActionUpdate, &existing, &contactData); err != nil {
+		return contactDTO, err
+	}
 	return contactDTO, nil
 }
 
 // RemoveContact deletes notification contact for current user and remove contactID from all subscriptions
-func RemoveContact(database moira.Database, contactID string, userLogin string) *api.ErrorResponse {
-	subscriptionIDs, err := database.GetUserSubscriptionIDs(userLogin)
+func RemoveContact(dataBase moira.Database, contactID string, userLogin string) *api.ErrorResponse {
+	subscriptionIDs, err := dataBase.GetUserSubscriptionIDs(userLogin)
 	if err != nil {
 		return api.ErrorInternalServer(err)
 	}
 
-	subscriptions, err := database.GetSubscriptions(subscriptionIDs)
+	subscriptions, err := dataBase.GetSubscriptions(subscriptionIDs)
 	if err != nil {
 		return api.ErrorInternalServer(err)
 	}
@@ -88,6 +96,10 @@ func RemoveContact(database moira.Database, contactID string, userLogin string)
 		if subscription == nil {
 			continue
 		}
+		if subscription.IsEscalationContact(contactID) {
+			subscriptionsWithDeletingContact = append(subscriptionsWithDeletingContact, subscription)
+			continue
+		}
 		for i, contact := range subscription.Contacts {
 			if contact == contactID {
 				subscription.Contacts = append(subscription.Contacts[:i], subscription.Contacts[i+1:]...)
@@ -116,11 +128,17 @@ func RemoveContact(database moira.Database, contactID string, userLogin string)
 		return api.ErrorInvalidRequest(fmt.Errorf(errBuffer.String()))
 	}
 
-	if err := database.RemoveContact(contactID); err != nil {
+	existing, err := dataBase.GetContact(contactID)
+	if err != nil && err != database.ErrNil {
 		return api.ErrorInternalServer(err)
 	}
-
-	return nil
+	if err := dataBase.RemoveContact(contactID); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	if err == database.ErrNil {
+		return nil
+	}
+	return saveAuditRecord(dataBase, userLogin, moira.AuditEntityContact, contactID, moira.AuditActionRemove, &existing, nil)
 }
 
 // SendTestContactNotification push test notification to verify the correct contact settings
@@ -70,6 +70,7 @@ func TestCreateContact(t *testing.T) {
 			Type:  "mail",
 		}
 		dataBase.EXPECT().SaveContact(gomock.Any()).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
 		err := CreateContact(dataBase, contact, userLogin)
 		So(err, ShouldBeNil)
 		So(contact.User, ShouldResemble, userLogin)
@@ -89,6 +90,7 @@ func TestCreateContact(t *testing.T) {
 		}
 		dataBase.EXPECT().GetContact(contact.ID).Return(moira.ContactData{}, database.ErrNil)
 		dataBase.EXPECT().SaveContact(&expectedContact).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
 		err := CreateContact(dataBase, &contact, userLogin)
 		So(err, ShouldBeNil)
 		So(contact.User, ShouldResemble, userLogin)
@@ -154,12 +156,33 @@ func TestUpdateContact(t *testing.T) {
 			User:  userLogin,
 		}
 		dataBase.EXPECT().SaveContact(&contact).Return(nil)
-		expectedContact, err := UpdateContact(dataBase, contactDTO, moira.ContactData{ID: contactID, User: userLogin})
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		expectedContact, err := UpdateContact(dataBase, contactDTO, moira.ContactData{ID: contactID, User: userLogin}, userLogin)
 		So(err, ShouldBeNil)
 		So(expectedContact.User, ShouldResemble, userLogin)
 		So(expectedContact.ID, ShouldResemble, contactID)
 	})
 
+	Convey("Success update digest interval", t, func() {
+		contactDTO := dto.Contact{
+			Value:          "some@mail.com",
+			Type:           "mail",
+			DigestInterval: 3600,
+		}
+		contactID := uuid.Must(uuid.NewV4()).String()
+		contact := moira.ContactData{
+			Value:          contactDTO.Value,
+			Type:           contactDTO.Type,
+			ID:             contactID,
+			User:           userLogin,
+			DigestInterval: 3600,
+		}
+		dataBase.EXPECT().SaveContact(&contact).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		_, err := UpdateContact(dataBase, contactDTO, moira.ContactData{ID: contactID, User: userLogin, Value: contactDTO.Value, Type: contactDTO.Type}, userLogin)
+		So(err, ShouldBeNil)
+	})
+
 	Convey("Error save", t, func() {
 		contactDTO := dto.Contact{
 			Value: "some@mail.com",
@@ -174,7 +197,7 @@ func TestUpdateContact(t *testing.T) {
 		}
 		err := fmt.Errorf("oooops")
 		dataBase.EXPECT().SaveContact(&contact).Return(err)
-		expectedContact, actual := UpdateContact(dataBase, contactDTO, contact)
+		expectedContact, actual := UpdateContact(dataBase, contactDTO, contact, userLogin)
 		So(actual, ShouldResemble, api.ErrorInternalServer(err))
 		So(expectedContact.User, ShouldResemble, contactDTO.User)
 		So(expectedContact.ID, ShouldResemble, contactDTO.ID)
@@ -191,7 +214,9 @@ func TestRemoveContact(t *testing.T) {
 	Convey("Delete contact without user subscriptions", t, func() {
 		dataBase.EXPECT().GetUserSubscriptionIDs(userLogin).Return(make([]string, 0), nil)
 		dataBase.EXPECT().GetSubscriptions(make([]string, 0)).Return(make([]*moira.SubscriptionData, 0), nil)
+		dataBase.EXPECT().GetContact(contactID).Return(moira.ContactData{ID: contactID, User: userLogin}, nil)
 		dataBase.EXPECT().RemoveContact(contactID).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
 		err := RemoveContact(dataBase, contactID, userLogin)
 		So(err, ShouldBeNil)
 	})
@@ -204,7 +229,9 @@ func TestRemoveContact(t *testing.T) {
 
 		dataBase.EXPECT().GetUserSubscriptionIDs(userLogin).Return([]string{subscription.ID}, nil)
 		dataBase.EXPECT().GetSubscriptions([]string{subscription.ID}).Return([]*moira.SubscriptionData{subscription}, nil)
+		dataBase.EXPECT().GetContact(contactID).Return(moira.ContactData{ID: contactID, User: userLogin}, nil)
 		dataBase.EXPECT().RemoveContact(contactID).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
 		err := RemoveContact(dataBase, contactID, userLogin)
 		So(err, ShouldBeNil)
 	})
@@ -236,6 +263,19 @@ func TestRemoveContact(t *testing.T) {
 			err := RemoveContact(dataBase, contactID, userLogin)
 			So(err, ShouldResemble, api.ErrorInvalidRequest(expectedError))
 		})
+		Convey("Subscription escalation has contact", func() {
+			subscription := moira.SubscriptionData{
+				Contacts:    []string{uuid.Must(uuid.NewV4()).String()},
+				ID:          uuid.Must(uuid.NewV4()).String(),
+				Tags:        []string{"Tag1"},
+				Escalations: []moira.EscalationData{{Contacts: []string{contactID}, OffsetInMinutes: 10}},
+			}
+			expectedError := fmt.Errorf("this contact is being used in following subscriptions: %s (tags: Tag1)", subscription.ID)
+			dataBase.EXPECT().GetUserSubscriptionIDs(userLogin).Return([]string{subscription.ID}, nil)
+			dataBase.EXPECT().GetSubscriptions([]string{subscription.ID}).Return([]*moira.SubscriptionData{&subscription}, nil)
+			err := RemoveContact(dataBase, contactID, userLogin)
+			So(err, ShouldResemble, api.ErrorInvalidRequest(expectedError))
+		})
 	})
 }
 
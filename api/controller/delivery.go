@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+)
+
+// GetContactDeliveries gets contact notification deliveries from current page and all contact deliveries count
+func GetContactDeliveries(database moira.Database, contactID string, page int64, size int64) (*dto.DeliveriesList, *api.ErrorResponse) {
+	deliveries, total, err := database.GetContactDeliveries(contactID, page*size, size)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	return createDeliveriesList(deliveries, page, size, total), nil
+}
+
+// GetTriggerDeliveries gets trigger notification deliveries from current page and all trigger deliveries count
+func GetTriggerDeliveries(database moira.Database, triggerID string, page int64, size int64) (*dto.DeliveriesList, *api.ErrorResponse) {
+	deliveries, total, err := database.GetTriggerDeliveries(triggerID, page*size, size)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	return createDeliveriesList(deliveries, page, size, total), nil
+}
+
+func createDeliveriesList(deliveries []*moira.NotificationDelivery, page int64, size int64, total int64) *dto.DeliveriesList {
+	if deliveries == nil {
+		deliveries = make([]*moira.NotificationDelivery, 0)
+	}
+	return &dto.DeliveriesList{
+		Page:  page,
+		Size:  size,
+		Total: total,
+		List:  deliveries,
+	}
+}
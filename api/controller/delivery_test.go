@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetContactDeliveries(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	var page int64 = 2
+	var size int64 = 10
+
+	Convey("Has deliveries", t, func() {
+		deliveries := []*moira.NotificationDelivery{
+			{ContactID: "contactID", TriggerIDs: []string{"triggerID"}, Result: moira.DeliverySent, Attempt: 1},
+			{ContactID: "contactID", TriggerIDs: []string{"triggerID"}, Result: moira.DeliveryFailed, Error: "timeout", Attempt: 1},
+		}
+		dataBase.EXPECT().GetContactDeliveries("contactID", page*size, size).Return(deliveries, int64(22), nil)
+		list, err := GetContactDeliveries(dataBase, "contactID", page, size)
+		So(err, ShouldBeNil)
+		So(list, ShouldResemble, &dto.DeliveriesList{Page: page, Size: size, Total: 22, List: deliveries})
+	})
+
+	Convey("No deliveries", t, func() {
+		dataBase.EXPECT().GetContactDeliveries("contactID", page*size, size).Return(nil, int64(0), nil)
+		list, err := GetContactDeliveries(dataBase, "contactID", page, size)
+		So(err, ShouldBeNil)
+		So(list, ShouldResemble, &dto.DeliveriesList{Page: page, Size: size, Total: 0, List: make([]*moira.NotificationDelivery, 0)})
+	})
+
+	Convey("Error", t, func() {
+		expected := fmt.Errorf("oooops! Can not get deliveries")
+		dataBase.EXPECT().GetContactDeliveries("contactID", page*size, size).Return(nil, int64(0), expected)
+		list, err := GetContactDeliveries(dataBase, "contactID", page, size)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(list, ShouldBeNil)
+	})
+}
+
+func TestGetTriggerDeliveries(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	var page int64
+	var size int64 = 100
+
+	Convey("Has deliveries", t, func() {
+		deliveries := []*moira.NotificationDelivery{{ContactID: "contactID", TriggerIDs: []string{"triggerID"}, Result: moira.DeliverySent, Attempt: 2}}
+		dataBase.EXPECT().GetTriggerDeliveries("triggerID", page*size, size).Return(deliveries, int64(1), nil)
+		list, err := GetTriggerDeliveries(dataBase, "triggerID", page, size)
+		So(err, ShouldBeNil)
+		So(list, ShouldResemble, &dto.DeliveriesList{Page: page, Size: size, Total: 1, List: deliveries})
+	})
+
+	Convey("Error", t, func() {
+		expected := fmt.Errorf("oooops! Can not get deliveries")
+		dataBase.EXPECT().GetTriggerDeliveries("triggerID", page*size, size).Return(nil, int64(0), expected)
+		list, err := GetTriggerDeliveries(dataBase, "triggerID", page, size)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(list, ShouldBeNil)
+	})
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+)
+
+// GetFailedNotifications gets notifications from dead-letter queue page, if end==-1 && start==0 gets all failed notifications
+func GetFailedNotifications(database moira.Database, start int64, end int64) (*dto.FailedNotificationsList, *api.ErrorResponse) {
+	notifications, total, err := database.GetFailedNotifications(start, end)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	return &dto.FailedNotificationsList{List: notifications, Total: total}, nil
+}
+
+// RetryFailedNotification schedules failed notification with given id to be sent again
+func RetryFailedNotification(database moira.Database, id string) (*dto.FailedNotificationsRetryResponse, *api.ErrorResponse) {
+	return retryFailedNotifications(database, []string{id})
+}
+
+// RetryAllFailedNotifications schedules all failed notifications to be sent again
+func RetryAllFailedNotifications(database moira.Database) (*dto.FailedNotificationsRetryResponse, *api.ErrorResponse) {
+	notifications, _, err := database.GetFailedNotifications(0, -1)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	ids := make([]string, 0, len(notifications))
+	for _, notification := range notifications {
+		ids = append(ids, notification.ID)
+	}
+	return retryFailedNotifications(database, ids)
+}
+
+func retryFailedNotifications(database moira.Database, ids []string) (*dto.FailedNotificationsRetryResponse, *api.ErrorResponse) {
+	notifications, err := database.FetchFailedNotifications(ids)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	if len(notifications) == 0 {
+		return &dto.FailedNotificationsRetryResponse{Result: 0}, nil
+	}
+	now := time.Now().Unix()
+	scheduledNotifications := make([]*moira.ScheduledNotification, 0, len(notifications))
+	for _, notification := range notifications {
+		scheduledNotifications = append(scheduledNotifications, notification.ToScheduledNotification(now))
+	}
+	if err := database.AddNotifications(scheduledNotifications, now); err != nil {
+		// return notifications to dead-letter queue not to lose them
+		if err := database.AddFailedNotifications(notifications); err != nil {
+			return nil, api.ErrorInternalServer(err)
+		}
+		return nil, api.ErrorInternalServer(err)
+	}
+	return &dto.FailedNotificationsRetryResponse{Result: int64(len(notifications))}, nil
+}
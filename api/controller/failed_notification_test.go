@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetFailedNotifications(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+	Convey("Has failed notifications", t, func() {
+		notifications := []*moira.FailedNotification{{ID: "id1", Error: "timeout"}, {ID: "id2", Error: "timeout"}}
+		dataBase.EXPECT().GetFailedNotifications(int64(0), int64(-1)).Return(notifications, int64(2), nil)
+		list, err := GetFailedNotifications(dataBase, 0, -1)
+		So(err, ShouldBeNil)
+		So(list, ShouldResemble, &dto.FailedNotificationsList{List: notifications, Total: 2})
+	})
+
+	Convey("Test error", t, func() {
+		expected := fmt.Errorf("oooops! Can not get failed notifications")
+		dataBase.EXPECT().GetFailedNotifications(int64(0), int64(-1)).Return(nil, int64(0), expected)
+		list, err := GetFailedNotifications(dataBase, 0, -1)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(list, ShouldBeNil)
+	})
+}
+
+func TestRetryFailedNotifications(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+	notification := &moira.FailedNotification{
+		ID:       "id1",
+		Event:    moira.NotificationEvent{Metric: "metric"},
+		Trigger:  moira.TriggerData{ID: "triggerID"},
+		Contact:  moira.ContactData{ID: "contactID"},
+		SendFail: 1441,
+		Error:    "timeout",
+	}
+
+	Convey("Retry notification", t, func() {
+		Convey("Notification is scheduled again", func() {
+			dataBase.EXPECT().FetchFailedNotifications([]string{"id1"}).Return([]*moira.FailedNotification{notification}, nil)
+			dataBase.EXPECT().AddNotifications(gomock.Any(), gomock.Any()).Do(func(notifications []*moira.ScheduledNotification, timestamp int64) {
+				So(notifications, ShouldResemble, []*moira.ScheduledNotification{notification.ToScheduledNotification(timestamp)})
+			}).Return(nil)
+			actual, err := RetryFailedNotification(dataBase, "id1")
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, &dto.FailedNotificationsRetryResponse{Result: 1})
+		})
+
+		Convey("Not existing notification", func() {
+			dataBase.EXPECT().FetchFailedNotifications([]string{"id1"}).Return([]*moira.FailedNotification{}, nil)
+			actual, err := RetryFailedNotification(dataBase, "id1")
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, &dto.FailedNotificationsRetryResponse{Result: 0})
+		})
+
+		Convey("Notification is returned to queue if it can not be scheduled", func() {
+			expected := fmt.Errorf("oooops! Can not add notifications")
+			dataBase.EXPECT().FetchFailedNotifications([]string{"id1"}).Return([]*moira.FailedNotification{notification}, nil)
+			dataBase.EXPECT().AddNotifications(gomock.Any(), gomock.Any()).Return(expected)
+			dataBase.EXPECT().AddFailedNotifications([]*moira.FailedNotification{notification}).Return(nil)
+			actual, err := RetryFailedNotification(dataBase, "id1")
+			So(err, ShouldResemble, api.ErrorInternalServer(expected))
+			So(actual, ShouldBeNil)
+		})
+	})
+
+	Convey("Retry all notifications", t, func() {
+		Convey("All notifications are scheduled again", func() {
+			other := &moira.FailedNotification{ID: "id2"}
+			dataBase.EXPECT().GetFailedNotifications(int64(0), int64(-1)).Return([]*moira.FailedNotification{notification, other}, int64(2), nil)
+			dataBase.EXPECT().FetchFailedNotifications([]string{"id1", "id2"}).Return([]*moira.FailedNotification{notification, other}, nil)
+			dataBase.EXPECT().AddNotifications(gomock.Any(), gomock.Any()).Return(nil)
+			actual, err := RetryAllFailedNotifications(dataBase)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, &dto.FailedNotificationsRetryResponse{Result: 2})
+		})
+
+		Convey("Database error", func() {
+			expected := fmt.Errorf("oooops! Can not get failed notifications")
+			dataBase.EXPECT().GetFailedNotifications(int64(0), int64(-1)).Return(nil, int64(0), expected)
+			actual, err := RetryAllFailedNotifications(dataBase)
+			So(err, ShouldResemble, api.ErrorInternalServer(expected))
+			So(actual, ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+)
+
+// GetAllSilences gets all silences including not started yet
+func GetAllSilences(dataBase moira.Database) (*dto.SilencesList, *api.ErrorResponse) {
+	silences, err := dataBase.GetSilences()
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	return &dto.SilencesList{List: silences}, nil
+}
+
+// GetSilence gets silence by given ID
+func GetSilence(dataBase moira.Database, silenceID string) (*dto.Silence, *api.ErrorResponse) {
+	silence, err := getExistingSilence(dataBase, silenceID)
+	if err != nil {
+		return nil, err
+	}
+	silenceDTO := dto.Silence(*silence)
+	return &silenceDTO, nil
+}
+
+// CreateSilence creates new silence authored by current user
+func CreateSilence(dataBase moira.Database, silenceDTO *dto.Silence, userLogin string) *api.ErrorResponse {
+	uuid4, err := uuid.NewV4()
+	if err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	silenceDTO.ID = uuid4.String()
+	silenceDTO.User = userLogin
+	silenceDTO.CreatedAt = time.Now().Unix()
+
+	silence := moira.Silence(*silenceDTO)
+	if err := dataBase.SaveSilence(&silence); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	return saveAuditRecord(dataBase, userLogin, moira.AuditEntitySilence, silence.ID, moira.AuditActionCreate, nil, &silence)
+}
+
+// UpdateSilence updates matchers, time and comment of existing silence, author and creation time are kept
+func UpdateSilence(dataBase moira.Database, silenceDTO *dto.Silence, silenceID string, userLogin string) *api.ErrorResponse {
+	existing, errResponse := getExistingSilence(dataBase, silenceID)
+	if errResponse != nil {
+		return errResponse
+	}
+	silenceDTO.ID = silenceID
+	silenceDTO.User = existing.User
+	silenceDTO.CreatedAt = existing.CreatedAt
+
+	silence := moira.Silence(*silenceDTO)
+	if err := dataBase.SaveSilence(&silence); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	return saveAuditRecord(dataBase, userLogin, moira.AuditEntitySilence, silence.ID, moira.AuditActionUpdate, existing, &silence)
+}
+
+// RemoveSilence deletes silence by given ID
+func RemoveSilence(dataBase moira.Database, silenceID string, userLogin string) *api.ErrorResponse {
+	existing, errResponse := getExistingSilence(dataBase, silenceID)
+	if errResponse != nil {
+		return errResponse
+	}
+	if err := dataBase.RemoveSilence(silenceID); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	return saveAuditRecord(dataBase, userLogin, moira.AuditEntitySilence, silenceID, moira.AuditActionRemove, existing, nil)
+}
+
+// CheckUserPermissionsForSilence checks silence for existence and that user is its author, administrators can change any silence
+func CheckUserPermissionsForSilence(dataBase moira.Database, silenceID string, userLogin string, isAdmin bool) (*moira.Silence, *api.ErrorResponse) {
+	silence, errResponse := getExistingSilence(dataBase, silenceID)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+	if !isAdmin && silence.User != userLogin {
+		return silence, api.ErrorForbidden("you are not permitted")
+	}
+	return silence, nil
+}
+
+// CheckUserPermissionsForSilencedTriggers checks that user can mute triggers silence matches: user must be editor of teams
+// of all matched team triggers. Silence without tags can match metric of any trigger, so teams of all triggers are checked
+func CheckUserPermissionsForSilencedTriggers(dataBase moira.Database, silence *dto.Silence, userLogin string, isAdmin bool) *api.ErrorResponse {
+	if isAdmin {
+		return nil
+	}
+	var triggerIDs []string
+	var err error
+	if len(silence.Tags) > 0 {
+		triggerIDs, err = dataBase.GetTagTriggerIDs(silence.Tags[0])
+	} else {
+		triggerIDs, err = dataBase.GetAllTriggerIDs()
+	}
+	if err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	triggers, err := dataBase.GetTriggers(triggerIDs)
+	if err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	checkedTeams := make(map[string]bool)
+	for _, trigger := range triggers {
+		if trigger == nil || trigger.TeamID == "" || checkedTeams[trigger.TeamID] || !moira.Subset(silence.Tags, trigger.Tags) {
+			continue
+		}
+		checkedTeams[trigger.TeamID] = true
+		team, err := getExistingTeam(dataBase, trigger.TeamID)
+		if err != nil {
+			return api.ErrorInternalServer(err)
+		}
+		if team != nil && !team.GetRole(userLogin).Includes(moira.TeamRoleEditor) {
+			return api.ErrorForbidden(fmt.Sprintf("silence matches triggers of team '%s' you are not permitted to change", trigger.TeamID))
+		}
+	}
+	return nil
+}
+
+func getExistingSilence(dataBase moira.Database, silenceID string) (*moira.Silence, *api.ErrorResponse) {
+	silence, err := dataBase.GetSilence(silenceID)
+	if err != nil {
+		if err == database.ErrNil {
+			return nil, api.ErrorNotFound(fmt.Sprintf("silence with ID = '%s' does not exists", silenceID))
+		}
+		return nil, api.ErrorInternalServer(err)
+	}
+	return &silence, nil
+}
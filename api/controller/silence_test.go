@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+)
+
+func TestGetAllSilences(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+	Convey("Get silences", t, func() {
+		silences := []*moira.Silence{{ID: "silence-id", Tags: []string{"tag"}, StartTime: 100, EndTime: 200}}
+		dataBase.EXPECT().GetSilences().Return(silences, nil)
+		actual, err := GetAllSilences(dataBase)
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, &dto.SilencesList{List: silences})
+	})
+
+	Convey("Error get silences", t, func() {
+		expected := fmt.Errorf("oooops! Can not get silences")
+		dataBase.EXPECT().GetSilences().Return(nil, expected)
+		actual, err := GetAllSilences(dataBase)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(actual, ShouldBeNil)
+	})
+}
+
+func TestCreateSilence(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	userLogin := "user"
+
+	Convey("Success create", t, func() {
+		silence := &dto.Silence{Tags: []string{"tag"}, StartTime: 100, EndTime: 200, Comment: "planned works"}
+		dataBase.EXPECT().SaveSilence(gomock.Any()).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		err := CreateSilence(dataBase, silence, userLogin)
+		So(err, ShouldBeNil)
+		So(silence.ID, ShouldNotBeEmpty)
+		So(silence.User, ShouldEqual, userLogin)
+		So(silence.CreatedAt, ShouldNotBeZeroValue)
+	})
+
+	Convey("Error save silence", t, func() {
+		silence := &dto.Silence{Tags: []string{"tag"}, StartTime: 100, EndTime: 200}
+		expected := fmt.Errorf("oooops! Can not save silence")
+		dataBase.EXPECT().SaveSilence(gomock.Any()).Return(expected)
+		err := CreateSilence(dataBase, silence, userLogin)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+	})
+}
+
+func TestUpdateSilence(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	silenceID := "silence-id"
+	existing := moira.Silence{ID: silenceID, Tags: []string{"tag"}, StartTime: 100, EndTime: 200, User: "author", CreatedAt: 50}
+
+	Convey("Success update keeps author and creation time", t, func() {
+		silence := &dto.Silence{Metric: "my.*", StartTime: 100, EndTime: 300}
+		dataBase.EXPECT().GetSilence(silenceID).Return(existing, nil)
+		dataBase.EXPECT().SaveSilence(&moira.Silence{ID: silenceID, Metric: "my.*", StartTime: 100, EndTime: 300, User: "author", CreatedAt: 50}).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		err := UpdateSilence(dataBase, silence, silenceID, "user")
+		So(err, ShouldBeNil)
+		So(silence.ID, ShouldEqual, silenceID)
+		So(silence.User, ShouldEqual, "author")
+	})
+
+	Convey("Silence does not exist", t, func() {
+		dataBase.EXPECT().GetSilence(silenceID).Return(moira.Silence{}, database.ErrNil)
+		err := UpdateSilence(dataBase, &dto.Silence{}, silenceID, "user")
+		So(err, ShouldResemble, api.ErrorNotFound("silence with ID = 'silence-id' does not exists"))
+	})
+}
+
+func TestRemoveSilence(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	silenceID := "silence-id"
+
+	Convey("Success remove", t, func() {
+		dataBase.EXPECT().GetSilence(silenceID).Return(moira.Silence{ID: silenceID}, nil)
+		dataBase.EXPECT().RemoveSilence(silenceID).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		err := RemoveSilence(dataBase, silenceID, "user")
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Error get silence", t, func() {
+		expected := fmt.Errorf("oooops! Can not get silence")
+		dataBase.EXPECT().GetSilence(silenceID).Return(moira.Silence{}, expected)
+		err := RemoveSilence(dataBase, silenceID, "user")
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+	})
+
+	Convey("Error remove silence", t, func() {
+		expected := fmt.Errorf("oooops! Can not remove silence")
+		dataBase.EXPECT().GetSilence(silenceID).Return(moira.Silence{ID: silenceID}, nil)
+		dataBase.EXPECT().RemoveSilence(silenceID).Return(expected)
+		err := RemoveSilence(dataBase, silenceID, "user")
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+	})
+}
+
+func TestCheckUserPermissionsForSilence(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	silence := moira.Silence{ID: "silence-id", Tags: []string{"tag"}, User: "author"}
+
+	Convey("Author can change silence", t, func() {
+		dataBase.EXPECT().GetSilence(silence.ID).Return(silence, nil)
+		actual, err := CheckUserPermissionsForSilence(dataBase, silence.ID, "author", false)
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, &silence)
+	})
+
+	Convey("Another user can not change silence", t, func() {
+		dataBase.EXPECT().GetSilence(silence.ID).Return(silence, nil)
+		_, err := CheckUserPermissionsForSilence(dataBase, silence.ID, "user", false)
+		So(err, ShouldResemble, api.ErrorForbidden("you are not permitted"))
+	})
+
+	Convey("Administrator can change any silence", t, func() {
+		dataBase.EXPECT().GetSilence(silence.ID).Return(silence, nil)
+		_, err := CheckUserPermissionsForSilence(dataBase, silence.ID, "admin", true)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Silence does not exist", t, func() {
+		dataBase.EXPECT().GetSilence(silence.ID).Return(moira.Silence{}, database.ErrNil)
+		_, err := CheckUserPermissionsForSilence(dataBase, silence.ID, "author", false)
+		So(err, ShouldResemble, api.ErrorNotFound("silence with ID = 'silence-id' does not exists"))
+	})
+}
+
+func TestCheckUserPermissionsForSilencedTriggers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	team := moira.Team{ID: "teamID", Members: map[string]moira.TeamRole{"editor": moira.TeamRoleEditor, "viewer": moira.TeamRoleViewer}}
+	triggers := []*moira.Trigger{
+		{ID: "teamTriggerID", Tags: []string{"tag", "team"}, TeamID: team.ID},
+		{ID: "triggerID", Tags: []string{"tag"}},
+		nil,
+	}
+
+	Convey("Silence with tags", t, func() {
+		silence := &dto.Silence{Tags: []string{"tag", "team"}}
+		dataBase.EXPECT().GetTagTriggerIDs("tag").Return([]string{"teamTriggerID", "triggerID", "removedTriggerID"}, nil)
+		dataBase.EXPECT().GetTriggers([]string{"teamTriggerID", "triggerID", "removedTriggerID"}).Return(triggers, nil)
+		dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+
+		Convey("Team editor", func() {
+			So(CheckUserPermissionsForSilencedTriggers(dataBase, silence, "editor", false), ShouldBeNil)
+		})
+
+		Convey("Team viewer", func() {
+			err := CheckUserPermissionsForSilencedTriggers(dataBase, silence, "viewer", false)
+			So(err, ShouldResemble, api.ErrorForbidden("silence matches triggers of team 'teamID' you are not permitted to change"))
+		})
+	})
+
+	Convey("Silence does not match team triggers", t, func() {
+		silence := &dto.Silence{Tags: []string{"tag", "other"}}
+		dataBase.EXPECT().GetTagTriggerIDs("tag").Return([]string{"teamTriggerID", "triggerID"}, nil)
+		dataBase.EXPECT().GetTriggers([]string{"teamTriggerID", "triggerID"}).Return(triggers[:2], nil)
+		So(CheckUserPermissionsForSilencedTriggers(dataBase, silence, "viewer", false), ShouldBeNil)
+	})
+
+	Convey("Silence without tags checks all triggers", t, func() {
+		silence := &dto.Silence{Metric: "my.*"}
+		dataBase.EXPECT().GetAllTriggerIDs().Return([]string{"teamTriggerID", "triggerID"}, nil)
+		dataBase.EXPECT().GetTriggers([]string{"teamTriggerID", "triggerID"}).Return(triggers[:2], nil)
+		dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+		err := CheckUserPermissionsForSilencedTriggers(dataBase, silence, "user", false)
+		So(err, ShouldResemble, api.ErrorForbidden("silence matches triggers of team 'teamID' you are not permitted to change"))
+	})
+
+	Convey("Administrator", t, func() {
+		So(CheckUserPermissionsForSilencedTriggers(dataBase, &dto.Silence{Metric: "my.*"}, "admin", true), ShouldBeNil)
+	})
+
+	Convey("Error get triggers", t, func() {
+		expected := fmt.Errorf("oooops! Can not get triggers")
+		dataBase.EXPECT().GetTagTriggerIDs("tag").Return(nil, expected)
+		err := CheckUserPermissionsForSilencedTriggers(dataBase, &dto.Silence{Tags: []string{"tag"}}, "user", false)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+	})
+}
@@ -57,26 +57,59 @@ func CreateSubscription(dataBase moira.Database, userLogin string, subscription
 	if err := dataBase.SaveSubscription(&data); err != nil {
 		return api.ErrorInternalServer(err)
 	}
-	return nil
+	return saveAuditRecord(dataBase, userLogin, moira.AuditEntitySubscription, data.ID, moira.AuditActionCreate, nil, &data)
 }
 
 // UpdateSubscription updates existing subscription
 func UpdateSubscription(dataBase moira.Database, subscriptionID string, userLogin string, subscription *dto.Subscription) *api.ErrorResponse {
+	existing, err := getExistingSubscription(dataBase, subscriptionID)
+	if err != nil {
+		return api.ErrorInternalServer(err)
+	}
 	subscription.ID = subscriptionID
 	subscription.User = userLogin
 	data := moira.SubscriptionData(*subscription)
 	if err := dataBase.SaveSubscription(&data); err != nil {
 		return api.ErrorInternalServer(err)
 	}
-	return nil
+	return saveAuditRecord(dataBase, userLogin, moira.AuditEntitySubscription, subscriptionID, moira.AuditActionUpdate, existing, &data)
+}
+
+// GetSubscriptionEscalations gets escalations of given subscription
+func GetSubscriptionEscalations(subscription moira.SubscriptionData) *dto.SubscriptionEscalations {
+	escalations := &dto.SubscriptionEscalations{Escalations: subscription.Escalations}
+	if escalations.Escalations == nil {
+		escalations.Escalations = make([]moira.EscalationData, 0)
+	}
+	return escalations
+}
+
+// UpdateSubscriptionEscalations replaces escalations of given subscription, other subscription settings are kept
+func UpdateSubscriptionEscalations(dataBase moira.Database, subscription moira.SubscriptionData, escalations *dto.SubscriptionEscalations, userLogin string) *api.ErrorResponse {
+	existing := subscription
+	subscription.Escalations = escalations.Escalations
+	if len(subscription.Escalations) == 0 {
+		subscription.Escalations = nil
+	}
+	if err := dataBase.SaveSubscription(&subscription); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	return saveAuditRecord(dataBase, userLogin, moira.AuditEntitySubscription, subscription.ID, moira.AuditActionUpdate, &existing, &subscription)
 }
 
 // RemoveSubscription deletes subscription
-func RemoveSubscription(database moira.Database, subscriptionID string) *api.ErrorResponse {
+func RemoveSubscription(database moira.Database, subscriptionID string, userLogin string) *api.ErrorResponse {
+	existing, err := getExistingSubscription(database, subscriptionID)
+	if err != nil {
+		return api.ErrorInternalServer(err)
+	}
 	if err := database.RemoveSubscription(subscriptionID); err != nil {
 		return api.ErrorInternalServer(err)
 	}
-	return nil
+	if existing == nil {
+		return nil
+	}
+	return saveAuditRecord(database, userLogin, moira.AuditEntitySubscription, subscriptionID, moira.AuditActionRemove, existing, nil)
 }
 
 // SendTestNotification push test notification to verify the correct notification settings
@@ -98,8 +131,9 @@ func SendTestNotification(database moira.Database, subscriptionID string) *api.E
 	return nil
 }
 
-// CheckUserPermissionsForSubscription checks subscription for existence and permissions for given user
-func CheckUserPermissionsForSubscription(dataBase moira.Database, subscriptionID string, userLogin string) (moira.SubscriptionData, *api.ErrorResponse) {
+// CheckUserPermissionsForSubscription checks subscription for existence and permissions for given user.
+// Team subscriptions can be also changed by team editors and administrators
+func CheckUserPermissionsForSubscription(dataBase moira.Database, subscriptionID string, userLogin string, isAdmin bool) (moira.SubscriptionData, *api.ErrorResponse) {
 	subscription, err := dataBase.GetSubscription(subscriptionID)
 	if err != nil {
 		if err == database.ErrNil {
@@ -107,10 +141,27 @@ func CheckUserPermissionsForSubscription(dataBase moira.Database, subscriptionID
 		}
 		return subscription, api.ErrorInternalServer(err)
 	}
-	if subscription.User != userLogin {
-		return subscription, api.ErrorForbidden("you are not permitted")
+	if subscription.User == userLogin {
+		return subscription, nil
+	}
+	if subscription.TeamID != "" {
+		if _, err := CheckUserPermissionsForTeam(dataBase, subscription.TeamID, userLogin, isAdmin, moira.TeamRoleEditor); err == nil {
+			return subscription, nil
+		}
+	}
+	return subscription, api.ErrorForbidden("you are not permitted")
+}
+
+// getExistingSubscription returns subscription by given ID or nil if subscription does not exist
+func getExistingSubscription(dataBase moira.Database, subscriptionID string) (*moira.SubscriptionData, error) {
+	subscription, err := dataBase.GetSubscription(subscriptionID)
+	if err == database.ErrNil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
 	}
-	return subscription, nil
+	return &subscription, nil
 }
 
 func isSubscriptionExists(dataBase moira.Database, subscriptionID string) (bool, error) {
@@ -74,7 +74,9 @@ func TestUpdateSubscription(t *testing.T) {
 			ID:   subscriptionID,
 			User: userLogin,
 		}
+		dataBase.EXPECT().GetSubscription(subscriptionID).Return(moira.SubscriptionData{ID: subscriptionID, User: userLogin}, nil)
 		dataBase.EXPECT().SaveSubscription(&subscription).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
 		err := UpdateSubscription(dataBase, subscriptionID, userLogin, subscriptionDTO)
 		So(err, ShouldBeNil)
 		So(subscriptionDTO.User, ShouldResemble, userLogin)
@@ -89,6 +91,7 @@ func TestUpdateSubscription(t *testing.T) {
 			User: userLogin,
 		}
 		err := fmt.Errorf("oooops")
+		dataBase.EXPECT().GetSubscription(subscriptionID).Return(moira.SubscriptionData{ID: subscriptionID, User: userLogin}, nil)
 		dataBase.EXPECT().SaveSubscription(&subscription).Return(err)
 		actual := UpdateSubscription(dataBase, subscriptionID, userLogin, subscriptionDTO)
 		So(actual, ShouldResemble, api.ErrorInternalServer(err))
@@ -97,22 +100,73 @@ func TestUpdateSubscription(t *testing.T) {
 	})
 }
 
+func TestSubscriptionEscalations(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	userLogin := "user"
+	subscription := moira.SubscriptionData{ID: "subscription-id", User: userLogin, Contacts: []string{"first"}}
+	escalations := []moira.EscalationData{{Contacts: []string{"second"}, OffsetInMinutes: 15}}
+
+	Convey("Get escalations of subscription without escalations", t, func() {
+		actual := GetSubscriptionEscalations(subscription)
+		So(actual, ShouldResemble, &dto.SubscriptionEscalations{Escalations: make([]moira.EscalationData, 0)})
+	})
+
+	Convey("Update escalations", t, func() {
+		expected := subscription
+		expected.Escalations = escalations
+		dataBase.EXPECT().SaveSubscription(&expected).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		err := UpdateSubscriptionEscalations(dataBase, subscription, &dto.SubscriptionEscalations{Escalations: escalations}, userLogin)
+		So(err, ShouldBeNil)
+		So(GetSubscriptionEscalations(expected).Escalations, ShouldResemble, escalations)
+	})
+
+	Convey("Remove escalations", t, func() {
+		escalated := subscription
+		escalated.Escalations = escalations
+		dataBase.EXPECT().SaveSubscription(&subscription).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		err := UpdateSubscriptionEscalations(dataBase, escalated, &dto.SubscriptionEscalations{Escalations: make([]moira.EscalationData, 0)}, userLogin)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Error save subscription", t, func() {
+		expected := fmt.Errorf("oooops! Can not save subscription")
+		dataBase.EXPECT().SaveSubscription(gomock.Any()).Return(expected)
+		err := UpdateSubscriptionEscalations(dataBase, subscription, &dto.SubscriptionEscalations{Escalations: escalations}, userLogin)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+	})
+}
+
 func TestRemoveSubscription(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 	db := mock_moira_alert.NewMockDatabase(mockCtrl)
 	id := uuid.Must(uuid.NewV4()).String()
+	userLogin := "user"
 
 	Convey("Success", t, func() {
+		db.EXPECT().GetSubscription(id).Return(moira.SubscriptionData{ID: id, User: userLogin}, nil)
 		db.EXPECT().RemoveSubscription(id).Return(nil)
-		err := RemoveSubscription(db, id)
+		db.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		err := RemoveSubscription(db, id, userLogin)
 		So(err, ShouldBeNil)
 	})
 
 	Convey("Error", t, func() {
 		expected := fmt.Errorf("oooops! Can not remove subscription")
+		db.EXPECT().GetSubscription(id).Return(moira.SubscriptionData{ID: id, User: userLogin}, nil)
 		db.EXPECT().RemoveSubscription(id).Return(expected)
-		err := RemoveSubscription(db, id)
+		err := RemoveSubscription(db, id, userLogin)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+	})
+
+	Convey("Error get subscription", t, func() {
+		expected := fmt.Errorf("oooops! Can not read subscription")
+		db.EXPECT().GetSubscription(id).Return(moira.SubscriptionData{}, expected)
+		err := RemoveSubscription(db, id, userLogin)
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 	})
 }
@@ -146,6 +200,7 @@ func TestCreateSubscription(t *testing.T) {
 	Convey("Success create", t, func() {
 		subscription := dto.Subscription{ID: ""}
 		dataBase.EXPECT().SaveSubscription(gomock.Any()).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
 		err := CreateSubscription(dataBase, login, &subscription)
 		So(err, ShouldBeNil)
 	})
@@ -156,6 +211,7 @@ func TestCreateSubscription(t *testing.T) {
 		}
 		dataBase.EXPECT().GetSubscription(sub.ID).Return(moira.SubscriptionData{}, database.ErrNil)
 		dataBase.EXPECT().SaveSubscription(gomock.Any()).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
 		err := CreateSubscription(dataBase, login, sub)
 		So(err, ShouldBeNil)
 		So(sub.User, ShouldResemble, login)
@@ -199,7 +255,7 @@ func TestCheckUserPermissionsForSubscription(t *testing.T) {
 
 	Convey("No subscription", t, func() {
 		dataBase.EXPECT().GetSubscription(id).Return(moira.SubscriptionData{}, database.ErrNil)
-		expectedSub, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin)
+		expectedSub, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin, false)
 		So(expected, ShouldResemble, api.ErrorNotFound(fmt.Sprintf("subscription with ID '%s' does not exists", id)))
 		So(expectedSub, ShouldResemble, moira.SubscriptionData{})
 	})
@@ -207,15 +263,41 @@ func TestCheckUserPermissionsForSubscription(t *testing.T) {
 	Convey("Different user", t, func() {
 		actualSub := moira.SubscriptionData{User: "diffUser"}
 		dataBase.EXPECT().GetSubscription(id).Return(actualSub, nil)
-		expectedSub, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin)
+		expectedSub, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin, false)
 		So(expected, ShouldResemble, api.ErrorForbidden("you are not permitted"))
 		So(expectedSub, ShouldResemble, actualSub)
 	})
 
+	Convey("Team subscription", t, func() {
+		actualSub := moira.SubscriptionData{ID: id, User: "diffUser", TeamID: "teamID"}
+
+		Convey("Team editor", func() {
+			dataBase.EXPECT().GetSubscription(id).Return(actualSub, nil)
+			dataBase.EXPECT().GetTeam("teamID").Return(moira.Team{ID: "teamID", Members: map[string]moira.TeamRole{userLogin: moira.TeamRoleEditor}}, nil)
+			expectedSub, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin, false)
+			So(expected, ShouldBeNil)
+			So(expectedSub, ShouldResemble, actualSub)
+		})
+
+		Convey("Team viewer", func() {
+			dataBase.EXPECT().GetSubscription(id).Return(actualSub, nil)
+			dataBase.EXPECT().GetTeam("teamID").Return(moira.Team{ID: "teamID", Members: map[string]moira.TeamRole{userLogin: moira.TeamRoleViewer}}, nil)
+			_, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin, false)
+			So(expected, ShouldResemble, api.ErrorForbidden("you are not permitted"))
+		})
+
+		Convey("Administrator", func() {
+			dataBase.EXPECT().GetSubscription(id).Return(actualSub, nil)
+			dataBase.EXPECT().GetTeam("teamID").Return(moira.Team{ID: "teamID"}, nil)
+			_, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin, true)
+			So(expected, ShouldBeNil)
+		})
+	})
+
 	Convey("Has subscription", t, func() {
 		actualSub := moira.SubscriptionData{ID: id, User: userLogin}
 		dataBase.EXPECT().GetSubscription(id).Return(actualSub, nil)
-		expectedSub, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin)
+		expectedSub, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin, false)
 		So(expected, ShouldBeNil)
 		So(expectedSub, ShouldResemble, actualSub)
 	})
@@ -223,7 +305,7 @@ func TestCheckUserPermissionsForSubscription(t *testing.T) {
 	Convey("Error get contact", t, func() {
 		err := fmt.Errorf("oooops! Can not read contact")
 		dataBase.EXPECT().GetSubscription(id).Return(moira.SubscriptionData{}, err)
-		expectedSub, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin)
+		expectedSub, expected := CheckUserPermissionsForSubscription(dataBase, id, userLogin, false)
 		So(expected, ShouldResemble, api.ErrorInternalServer(err))
 		So(expectedSub, ShouldResemble, moira.SubscriptionData{})
 	})
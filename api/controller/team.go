@@ -0,0 +1,225 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+)
+
+// GetUserTeams gets all teams user is member of, administrators get all teams
+func GetUserTeams(dataBase moira.Database, userLogin string, isAdmin bool) (*dto.TeamsList, *api.ErrorResponse) {
+	var teamIDs []string
+	var err error
+	if isAdmin {
+		teamIDs, err = dataBase.GetAllTeamIDs()
+	} else {
+		teamIDs, err = dataBase.GetUserTeamIDs(userLogin)
+	}
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	teams, err := dataBase.GetTeams(teamIDs)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	teamsList := &dto.TeamsList{
+		List: make([]moira.Team, 0),
+	}
+	for _, team := range teams {
+		if team != nil {
+			teamsList.List = append(teamsList.List, *team)
+		}
+	}
+	return teamsList, nil
+}
+
+// CreateTeam creates new team, user creating team without administrators becomes team administrator
+func CreateTeam(dataBase moira.Database, team *dto.Team, userLogin string) *api.ErrorResponse {
+	if team.ID == "" {
+		uuid4, err := uuid.NewV4()
+		if err != nil {
+			return api.ErrorInternalServer(err)
+		}
+		team.ID = uuid4.String()
+	} else {
+		existing, err := getExistingTeam(dataBase, team.ID)
+		if err != nil {
+			return api.ErrorInternalServer(err)
+		}
+		if existing != nil {
+			return api.ErrorInvalidRequest(fmt.Errorf("team with this ID already exists"))
+		}
+	}
+	if !hasTeamAdmin(team) {
+		team.Members[userLogin] = moira.TeamRoleAdmin
+	}
+
+	data := moira.Team(*team)
+	if err := dataBase.SaveTeam(&data); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	return saveAuditRecord(dataBase, userLogin, moira.AuditEntityTeam, data.ID, moira.AuditActionCreate, nil, &data)
+}
+
+// UpdateTeam updates existing team settings and members, team must keep at least one administrator
+func UpdateTeam(dataBase moira.Database, team *dto.Team, existing moira.Team, userLogin string) *api.ErrorResponse {
+	if !hasTeamAdmin(team) {
+		return api.ErrorInvalidRequest(fmt.Errorf("team must have at least one admin"))
+	}
+	team.ID = existing.ID
+	data := moira.Team(*team)
+	if err := dataBase.SaveTeam(&data); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	return saveAuditRecord(dataBase, userLogin, moira.AuditEntityTeam, data.ID, moira.AuditActionUpdate, &existing, &data)
+}
+
+// RemoveTeam deletes team, team owning triggers or subscriptions can not be removed
+func RemoveTeam(dataBase moira.Database, existing moira.Team, userLogin string) *api.ErrorResponse {
+	triggerIDs, err := dataBase.GetTeamTriggerIDs(existing.ID)
+	if err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	subscriptionIDs, err := dataBase.GetTeamSubscriptionIDs(existing.ID)
+	if err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	if len(triggerIDs) > 0 || len(subscriptionIDs) > 0 {
+		return api.ErrorInvalidRequest(fmt.Errorf("team owns %d triggers and %d subscriptions, change their team before removing", len(triggerIDs), len(subscriptionIDs)))
+	}
+	if err := dataBase.RemoveTeam(existing.ID); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	return saveAuditRecord(dataBase, userLogin, moira.AuditEntityTeam, existing.ID, moira.AuditActionRemove, &existing, nil)
+}
+
+// GetTeamTriggers gets all triggers owned by team
+func GetTeamTriggers(dataBase moira.Database, teamID string) (*dto.TriggersList, *api.ErrorResponse) {
+	triggerIDs, err := dataBase.GetTeamTriggerIDs(teamID)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	triggerChecks, err := dataBase.GetTriggerChecks(triggerIDs)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	triggersList := &dto.TriggersList{
+		List: make([]moira.TriggerCheck, 0),
+	}
+	for _, triggerCheck := range triggerChecks {
+		if triggerCheck != nil {
+			triggersList.List = append(triggersList.List, *triggerCheck)
+		}
+	}
+	return triggersList, nil
+}
+
+// GetTeamSubscriptions gets all subscriptions owned by team
+func GetTeamSubscriptions(dataBase moira.Database, teamID string) (*dto.SubscriptionList, *api.ErrorResponse) {
+	subscriptionIDs, err := dataBase.GetTeamSubscriptionIDs(teamID)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	subscriptions, err := dataBase.GetSubscriptions(subscriptionIDs)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	subscriptionsList := &dto.SubscriptionList{
+		List: make([]moira.SubscriptionData, 0),
+	}
+	for _, subscription := range subscriptions {
+		if subscription != nil {
+			subscriptionsList.List = append(subscriptionsList.List, *subscription)
+		}
+	}
+	return subscriptionsList, nil
+}
+
+// CheckUserPermissionsForTeam checks team for existence and that user has at least given role in it.
+// Administrators have all permissions in every team
+func CheckUserPermissionsForTeam(dataBase moira.Database, teamID string, userLogin string, isAdmin bool, role moira.TeamRole) (moira.Team, *api.ErrorResponse) {
+	team, err := dataBase.GetTeam(teamID)
+	if err != nil {
+		if err == database.ErrNil {
+			return team, api.ErrorNotFound(fmt.Sprintf("team with ID '%s' does not exists", teamID))
+		}
+		return team, api.ErrorInternalServer(err)
+	}
+	if !isAdmin && !team.GetRole(userLogin).Includes(role) {
+		return team, api.ErrorForbidden("you are not permitted")
+	}
+	return team, nil
+}
+
+// CheckUserPermissionsForTrigger checks that user can change trigger. Triggers without team can be changed by any user,
+// team triggers can be changed only by team editors and administrators
+func CheckUserPermissionsForTrigger(dataBase moira.Database, triggerID string, userLogin string, isAdmin bool) *api.ErrorResponse {
+	trigger, err := getExistingTrigger(dataBase, triggerID)
+	if err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	if trigger == nil || trigger.TeamID == "" {
+		return nil
+	}
+	_, errorResponse := CheckUserPermissionsForTeam(dataBase, trigger.TeamID, userLogin, isAdmin, moira.TeamRoleEditor)
+	return errorResponse
+}
+
+// CheckUserPermissionsForTriggersBundle checks that user can change all existing triggers of bundle and assign them to bundle teams
+func CheckUserPermissionsForTriggersBundle(dataBase moira.Database, bundle *dto.TriggersBundle, userLogin string, isAdmin bool) *api.ErrorResponse {
+	for _, trigger := range bundle.Triggers {
+		if trigger.ID != "" {
+			if err := CheckUserPermissionsForTrigger(dataBase, trigger.ID, userLogin, isAdmin); err != nil {
+				return err
+			}
+		}
+		if err := CheckTeamAssignment(dataBase, trigger.TeamID, userLogin, isAdmin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckTeamAssignment checks that user can give trigger or subscription to team with given ID, empty ID means no team
+func CheckTeamAssignment(dataBase moira.Database, teamID string, userLogin string, isAdmin bool) *api.ErrorResponse {
+	if teamID == "" {
+		return nil
+	}
+	team, err := getExistingTeam(dataBase, teamID)
+	if err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	if team == nil {
+		return api.ErrorInvalidRequest(fmt.Errorf("team with ID '%s' does not exists", teamID))
+	}
+	if !isAdmin && !team.GetRole(userLogin).Includes(moira.TeamRoleEditor) {
+		return api.ErrorForbidden(fmt.Sprintf("you are not permitted to change triggers and subscriptions of team '%s'", teamID))
+	}
+	return nil
+}
+
+func hasTeamAdmin(team *dto.Team) bool {
+	for _, role := range team.Members {
+		if role == moira.TeamRoleAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// getExistingTeam returns team by given ID or nil if team does not exist
+func getExistingTeam(dataBase moira.Database, teamID string) (*moira.Team, error) {
+	team, err := dataBase.GetTeam(teamID)
+	if err == database.ErrNil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
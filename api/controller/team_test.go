@@ -0,0 +1,228 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+)
+
+func TestGetUserTeams(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	team := moira.Team{ID: "teamID", Name: "Team", Members: map[string]moira.TeamRole{"user": moira.TeamRoleViewer}}
+
+	Convey("User gets own teams", t, func() {
+		dataBase.EXPECT().GetUserTeamIDs("user").Return([]string{team.ID, "removedTeamID"}, nil)
+		dataBase.EXPECT().GetTeams([]string{team.ID, "removedTeamID"}).Return([]*moira.Team{&team, nil}, nil)
+		teams, err := GetUserTeams(dataBase, "user", false)
+		So(err, ShouldBeNil)
+		So(teams, ShouldResemble, &dto.TeamsList{List: []moira.Team{team}})
+	})
+
+	Convey("Administrator gets all teams", t, func() {
+		dataBase.EXPECT().GetAllTeamIDs().Return([]string{}, nil)
+		dataBase.EXPECT().GetTeams([]string{}).Return([]*moira.Team{}, nil)
+		teams, err := GetUserTeams(dataBase, "admin", true)
+		So(err, ShouldBeNil)
+		So(teams, ShouldResemble, &dto.TeamsList{List: []moira.Team{}})
+	})
+
+	Convey("Error get teams", t, func() {
+		expected := fmt.Errorf("oooops! Can not get teams")
+		dataBase.EXPECT().GetUserTeamIDs("user").Return(nil, expected)
+		teams, err := GetUserTeams(dataBase, "user", false)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(teams, ShouldBeNil)
+	})
+}
+
+func TestCreateTeam(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+	Convey("Creator becomes admin of team without admins", t, func() {
+		team := &dto.Team{Name: "Team", Members: map[string]moira.TeamRole{"member": moira.TeamRoleEditor}}
+		dataBase.EXPECT().SaveTeam(gomock.Any()).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		err := CreateTeam(dataBase, team, "user")
+		So(err, ShouldBeNil)
+		So(team.ID, ShouldNotBeEmpty)
+		So(team.Members, ShouldResemble, map[string]moira.TeamRole{"member": moira.TeamRoleEditor, "user": moira.TeamRoleAdmin})
+	})
+
+	Convey("Team with admins is saved as is", t, func() {
+		team := &dto.Team{ID: "teamID", Name: "Team", Members: map[string]moira.TeamRole{"member": moira.TeamRoleAdmin}}
+		dataBase.EXPECT().GetTeam(team.ID).Return(moira.Team{}, database.ErrNil)
+		dataBase.EXPECT().SaveTeam(&moira.Team{ID: "teamID", Name: "Team", Members: map[string]moira.TeamRole{"member": moira.TeamRoleAdmin}}).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		err := CreateTeam(dataBase, team, "user")
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Team with this ID already exists", t, func() {
+		team := &dto.Team{ID: "teamID", Name: "Team"}
+		dataBase.EXPECT().GetTeam(team.ID).Return(moira.Team{ID: team.ID}, nil)
+		err := CreateTeam(dataBase, team, "user")
+		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("team with this ID already exists")))
+	})
+}
+
+func TestUpdateTeam(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	existing := moira.Team{ID: "teamID", Name: "Team", Members: map[string]moira.TeamRole{"user": moira.TeamRoleAdmin}}
+
+	Convey("Update team", t, func() {
+		team := &dto.Team{Name: "New name", Members: map[string]moira.TeamRole{"user": moira.TeamRoleAdmin}}
+		dataBase.EXPECT().SaveTeam(&moira.Team{ID: existing.ID, Name: "New name", Members: team.Members}).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		err := UpdateTeam(dataBase, team, existing, "user")
+		So(err, ShouldBeNil)
+		So(team.ID, ShouldEqual, existing.ID)
+	})
+
+	Convey("Team without admins", t, func() {
+		team := &dto.Team{Name: "Team", Members: map[string]moira.TeamRole{"user": moira.TeamRoleEditor}}
+		err := UpdateTeam(dataBase, team, existing, "user")
+		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("team must have at least one admin")))
+	})
+}
+
+func TestRemoveTeam(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	existing := moira.Team{ID: "teamID", Name: "Team"}
+
+	Convey("Remove team", t, func() {
+		dataBase.EXPECT().GetTeamTriggerIDs(existing.ID).Return([]string{}, nil)
+		dataBase.EXPECT().GetTeamSubscriptionIDs(existing.ID).Return([]string{}, nil)
+		dataBase.EXPECT().RemoveTeam(existing.ID).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		err := RemoveTeam(dataBase, existing, "user")
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Team owns triggers", t, func() {
+		dataBase.EXPECT().GetTeamTriggerIDs(existing.ID).Return([]string{"triggerID"}, nil)
+		dataBase.EXPECT().GetTeamSubscriptionIDs(existing.ID).Return([]string{}, nil)
+		err := RemoveTeam(dataBase, existing, "user")
+		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("team owns 1 triggers and 0 subscriptions, change their team before removing")))
+	})
+}
+
+func TestCheckUserPermissionsForTeam(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	team := moira.Team{ID: "teamID", Members: map[string]moira.TeamRole{"editor": moira.TeamRoleEditor}}
+
+	Convey("No team", t, func() {
+		dataBase.EXPECT().GetTeam(team.ID).Return(moira.Team{}, database.ErrNil)
+		_, err := CheckUserPermissionsForTeam(dataBase, team.ID, "editor", false, moira.TeamRoleViewer)
+		So(err, ShouldResemble, api.ErrorNotFound("team with ID 'teamID' does not exists"))
+	})
+
+	Convey("Member has required role", t, func() {
+		dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+		actual, err := CheckUserPermissionsForTeam(dataBase, team.ID, "editor", false, moira.TeamRoleViewer)
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, team)
+	})
+
+	Convey("Member has not required role", t, func() {
+		dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+		_, err := CheckUserPermissionsForTeam(dataBase, team.ID, "editor", false, moira.TeamRoleAdmin)
+		So(err, ShouldResemble, api.ErrorForbidden("you are not permitted"))
+	})
+
+	Convey("Not a member", t, func() {
+		dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+		_, err := CheckUserPermissionsForTeam(dataBase, team.ID, "user", false, moira.TeamRoleViewer)
+		So(err, ShouldResemble, api.ErrorForbidden("you are not permitted"))
+	})
+
+	Convey("Administrator", t, func() {
+		dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+		_, err := CheckUserPermissionsForTeam(dataBase, team.ID, "user", true, moira.TeamRoleAdmin)
+		So(err, ShouldBeNil)
+	})
+}
+
+func TestCheckUserPermissionsForTrigger(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	team := moira.Team{ID: "teamID", Members: map[string]moira.TeamRole{"editor": moira.TeamRoleEditor, "viewer": moira.TeamRoleViewer}}
+
+	Convey("Not existing trigger", t, func() {
+		dataBase.EXPECT().GetTrigger("triggerID").Return(moira.Trigger{}, database.ErrNil)
+		So(CheckUserPermissionsForTrigger(dataBase, "triggerID", "user", false), ShouldBeNil)
+	})
+
+	Convey("Trigger without team", t, func() {
+		dataBase.EXPECT().GetTrigger("triggerID").Return(moira.Trigger{ID: "triggerID"}, nil)
+		So(CheckUserPermissionsForTrigger(dataBase, "triggerID", "user", false), ShouldBeNil)
+	})
+
+	Convey("Team trigger", t, func() {
+		dataBase.EXPECT().GetTrigger("triggerID").Return(moira.Trigger{ID: "triggerID", TeamID: team.ID}, nil)
+		dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+
+		Convey("Editor", func() {
+			So(CheckUserPermissionsForTrigger(dataBase, "triggerID", "editor", false), ShouldBeNil)
+		})
+
+		Convey("Viewer", func() {
+			So(CheckUserPermissionsForTrigger(dataBase, "triggerID", "viewer", false), ShouldResemble, api.ErrorForbidden("you are not permitted"))
+		})
+	})
+
+	Convey("Error get trigger", t, func() {
+		expected := fmt.Errorf("oooops! Can not get trigger")
+		dataBase.EXPECT().GetTrigger("triggerID").Return(moira.Trigger{}, expected)
+		So(CheckUserPermissionsForTrigger(dataBase, "triggerID", "user", false), ShouldResemble, api.ErrorInternalServer(expected))
+	})
+}
+
+func TestCheckTeamAssignment(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	team := moira.Team{ID: "teamID", Members: map[string]moira.TeamRole{"editor": moira.TeamRoleEditor, "viewer": moira.TeamRoleViewer}}
+
+	Convey("Without team", t, func() {
+		So(CheckTeamAssignment(dataBase, "", "user", false), ShouldBeNil)
+	})
+
+	Convey("Not existing team", t, func() {
+		dataBase.EXPECT().GetTeam(team.ID).Return(moira.Team{}, database.ErrNil)
+		So(CheckTeamAssignment(dataBase, team.ID, "editor", false), ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("team with ID 'teamID' does not exists")))
+	})
+
+	Convey("Editor", t, func() {
+		dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+		So(CheckTeamAssignment(dataBase, team.ID, "editor", false), ShouldBeNil)
+	})
+
+	Convey("Viewer", t, func() {
+		dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+		So(CheckTeamAssignment(dataBase, team.ID, "viewer", false), ShouldResemble, api.ErrorForbidden("you are not permitted to change triggers and subscriptions of team 'teamID'"))
+	})
+
+	Convey("Administrator", t, func() {
+		dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+		So(CheckTeamAssignment(dataBase, team.ID, "user", true), ShouldBeNil)
+	})
+}
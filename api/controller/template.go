@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/templating"
+)
+
+// PreviewTemplate renders message template against notification package given in request
+// or sample package if request has no trigger or events
+func PreviewTemplate(request *dto.TemplatePreviewRequest, frontURI string) (*dto.TemplatePreview, *api.ErrorResponse) {
+	tmpl, err := templating.Parse("preview", request.Template)
+	if err != nil {
+		return nil, api.ErrorInvalidRequest(err)
+	}
+	trigger := getSampleTrigger()
+	if request.Trigger != nil {
+		trigger = *request.Trigger
+	}
+	events := moira.NotificationEvents(request.Events)
+	if len(events) == 0 {
+		events = getSampleEvents(trigger.ID)
+	}
+	data := templating.NewData(events, request.Contact, trigger, frontURI, time.UTC, request.Throttled)
+	message, err := templating.Execute(tmpl, data)
+	if err != nil {
+		return nil, api.ErrorInvalidRequest(err)
+	}
+	return &dto.TemplatePreview{Message: message}, nil
+}
+
+func getSampleTrigger() moira.TriggerData {
+	return moira.TriggerData{
+		ID:   "sample-trigger-id",
+		Name: "Sample trigger",
+		Desc: "Sample trigger description",
+		Tags: []string{"sample", "preview"},
+	}
+}
+
+func getSampleEvents(triggerID string) moira.NotificationEvents {
+	warnValue, errorValue := float64(15), float64(42)
+	timestamp := time.Now().Unix()
+	return moira.NotificationEvents{
+		{
+			TriggerID: triggerID,
+			Metric:    "sample.metric.first",
+			Value:     &warnValue,
+			OldState:  moira.StateOK,
+			State:     moira.StateWARN,
+			Timestamp: timestamp - 60,
+		},
+		{
+			TriggerID: triggerID,
+			Metric:    "sample.metric.second",
+			Value:     &errorValue,
+			OldState:  moira.StateWARN,
+			State:     moira.StateERROR,
+			Timestamp: timestamp,
+		},
+	}
+}
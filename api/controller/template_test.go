@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api/dto"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPreviewTemplate(t *testing.T) {
+	Convey("Preview with sample package", t, func() {
+		request := &dto.TemplatePreviewRequest{
+			Template: "{{ .State }} {{ .Trigger.Name }} {{ len .Events }} {{ .Trigger.URI }}",
+		}
+		preview, err := PreviewTemplate(request, "http://moira.url")
+		So(err, ShouldBeNil)
+		So(preview.Message, ShouldEqual, "ERROR Sample trigger 2 http://moira.url/trigger/sample-trigger-id")
+	})
+
+	Convey("Preview with given package", t, func() {
+		value := float64(1)
+		request := &dto.TemplatePreviewRequest{
+			Template:  "{{ .Contact.Value }} {{ .Trigger.Name }}{{ range .Events }} {{ .Metric }} {{ .Value }} {{ .Time }}{{ end }} {{ .Throttled }}",
+			Contact:   moira.ContactData{Type: "mail", Value: "mail@example.com"},
+			Trigger:   &moira.TriggerData{ID: "triggerID", Name: "Trigger"},
+			Events:    []moira.NotificationEvent{{Metric: "metric", Value: &value, Timestamp: 150000000, State: moira.StateWARN}},
+			Throttled: true,
+		}
+		preview, err := PreviewTemplate(request, "http://moira.url")
+		So(err, ShouldBeNil)
+		So(preview.Message, ShouldEqual, "mail@example.com Trigger metric 1 02:40 true")
+	})
+
+	Convey("Template execution error", t, func() {
+		request := &dto.TemplatePreviewRequest{
+			Template: "{{ .Trigger.Unknown }}",
+		}
+		preview, err := PreviewTemplate(request, "http://moira.url")
+		So(err, ShouldNotBeNil)
+		So(preview, ShouldBeNil)
+	})
+}
@@ -11,19 +11,36 @@ import (
 )
 
 // UpdateTrigger update trigger data and trigger metrics in last state
-func UpdateTrigger(dataBase moira.Database, trigger *dto.TriggerModel, triggerID string, timeSeriesNames map[string]bool) (*dto.SaveTriggerResponse, *api.ErrorResponse) {
-	_, err := dataBase.GetTrigger(triggerID)
+func UpdateTrigger(dataBase moira.Database, trigger *dto.TriggerModel, triggerID string, timeSeriesNames map[string]bool, userLogin string) (*dto.SaveTriggerResponse, *api.ErrorResponse) {
+	existing, err := dataBase.GetTrigger(triggerID)
 	if err != nil {
 		if err == database.ErrNil {
 			return nil, api.ErrorNotFound(fmt.Sprintf("trigger with ID = '%s' does not exists", triggerID))
 		}
 		return nil, api.ErrorInternalServer(err)
 	}
-	return saveTrigger(dataBase, trigger.ToMoiraTrigger(), triggerID, timeSeriesNames)
+	updated := trigger.ToMoiraTrigger()
+	updated.ID = triggerID
+	resp, errorResponse := saveTrigger(dataBase, updated, triggerID, timeSeriesNames)
+	if errorResponse != nil {
+		return nil, errorResponse
+	}
+	if errorResponse = saveAuditRecord(dataBase, userLogin, moira.AuditEntityTrigger, triggerID, moira.AuditActionUpdate, &existing, updated); errorResponse != nil {
+		return nil, errorResponse
+	}
+	return resp, nil
 }
 
 // saveTrigger create or update trigger data and update trigger metrics in last state
 func saveTrigger(dataBase moira.Database, trigger *moira.Trigger, triggerID string, timeSeriesNames map[string]bool) (*dto.SaveTriggerResponse, *api.ErrorResponse) {
+	if errorResponse := checkTriggerDependencies(dataBase, triggerID, trigger.Dependencies); errorResponse != nil {
+		return nil, errorResponse
+	}
+	return writeTrigger(dataBase, trigger, triggerID, timeSeriesNames)
+}
+
+// writeTrigger saves trigger and its last check without checking trigger dependencies
+func writeTrigger(dataBase moira.Database, trigger *moira.Trigger, triggerID string, timeSeriesNames map[string]bool) (*dto.SaveTriggerResponse, *api.ErrorResponse) {
 	if err := dataBase.AcquireTriggerCheckLock(triggerID, 10); err != nil {
 		return nil, api.ErrorInternalServer(err)
 	}
@@ -51,7 +68,7 @@ func saveTrigger(dataBase moira.Database, trigger *moira.Trigger, triggerID stri
 		lastCheck.UpdateScore()
 	}
 
-	if err = dataBase.SetTriggerLastCheck(triggerID, &lastCheck, trigger.IsRemote); err != nil {
+	if err = dataBase.SetTriggerLastCheck(triggerID, &lastCheck, trigger.ClusterKey()); err != nil {
 		return nil, api.ErrorInternalServer(err)
 	}
 
@@ -91,14 +108,21 @@ func GetTrigger(dataBase moira.Database, triggerID string) (*dto.Trigger, *api.E
 }
 
 // RemoveTrigger deletes trigger by given triggerID
-func RemoveTrigger(database moira.Database, triggerID string) *api.ErrorResponse {
+func RemoveTrigger(database moira.Database, triggerID string, userLogin string) *api.ErrorResponse {
+	existing, err := getExistingTrigger(database, triggerID)
+	if err != nil {
+		return api.ErrorInternalServer(err)
+	}
 	if err := database.RemoveTrigger(triggerID); err != nil {
 		return api.ErrorInternalServer(err)
 	}
 	if err := database.RemoveTriggerLastCheck(triggerID); err != nil {
 		return api.ErrorInternalServer(err)
 	}
-	return nil
+	if existing == nil {
+		return nil
+	}
+	return saveAuditRecord(database, userLogin, moira.AuditEntityTrigger, triggerID, moira.AuditActionRemove, existing, nil)
 }
 
 // GetTriggerThrottling gets trigger throttling timestamp
@@ -133,7 +157,8 @@ func GetTriggerLastCheck(dataBase moira.Database, triggerID string) (*dto.Trigge
 }
 
 // DeleteTriggerThrottling deletes trigger throttling
-func DeleteTriggerThrottling(database moira.Database, triggerID string) *api.ErrorResponse {
+func DeleteTriggerThrottling(database moira.Database, triggerID string, userLogin string) *api.ErrorResponse {
+	throttling, _ := GetTriggerThrottling(database, triggerID)
 	if err := database.DeleteTriggerThrottling(triggerID); err != nil {
 		return api.ErrorInternalServer(err)
 	}
@@ -152,7 +177,7 @@ func DeleteTriggerThrottling(database moira.Database, triggerID string) *api.Err
 	if err = database.AddNotifications(notificationsForRewrite, now); err != nil {
 		return api.ErrorInternalServer(err)
 	}
-	return nil
+	return saveAuditRecord(database, userLogin, moira.AuditEntityTrigger, triggerID, moira.AuditActionRemoveThrottling, throttling, nil)
 }
 
 // SetTriggerMaintenance sets maintenance to metrics and whole trigger
@@ -160,5 +185,27 @@ func SetTriggerMaintenance(database moira.Database, triggerID string, triggerMai
 	if err := database.SetTriggerCheckMaintenance(triggerID, triggerMaintenance.Metrics, triggerMaintenance.Trigger, userLogin, timeCallMaintenance); err != nil {
 		return api.ErrorInternalServer(err)
 	}
+	return saveAuditRecord(database, userLogin, moira.AuditEntityTrigger, triggerID, moira.AuditActionMaintenance, nil, &triggerMaintenance)
+}
+
+// AcknowledgeTrigger acknowledges given trigger metrics or whole trigger with all its metrics in bad state if no metrics given.
+// Reminders and escalations are not sent while acknowledgement is active
+func AcknowledgeTrigger(database moira.Database, triggerID string, triggerAck dto.TriggerAcknowledgement, userLogin string, timeCallAck int64) *api.ErrorResponse {
+	ack := &moira.Acknowledgement{
+		User:      userLogin,
+		Timestamp: timeCallAck,
+		Expiry:    triggerAck.Expiry,
+	}
+	if err := database.SetTriggerCheckAcknowledgement(triggerID, triggerAck.Metrics, ack); err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	return nil
+}
+
+// RemoveTriggerAcknowledgement removes acknowledgements of trigger and all its metrics
+func RemoveTriggerAcknowledgement(database moira.Database, triggerID string) *api.ErrorResponse {
+	if err := database.SetTriggerCheckAcknowledgement(triggerID, nil, nil); err != nil {
+		return api.ErrorInternalServer(err)
+	}
 	return nil
 }
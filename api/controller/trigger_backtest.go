@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/checker"
+	metricSource "github.com/moira-alert/moira/metric_source"
+	"github.com/moira-alert/moira/metric_source/prometheus"
+	"github.com/moira-alert/moira/metric_source/remote"
+)
+
+// BacktestTrigger replays checks of given trigger over historical window and returns
+// notification events which would have been fired. Nothing is saved to database.
+// Windows longer than maxWindow seconds are rejected, zero maxWindow means no limit
+func BacktestTrigger(metricSourceProvider *metricSource.SourceProvider, logger moira.Logger, trigger *dto.TriggerModel, from, to, maxWindow int64) (*dto.TriggerBacktest, *api.ErrorResponse) {
+	if from >= to {
+		return nil, api.ErrorInvalidRequest(fmt.Errorf("from must be less than to"))
+	}
+	if maxWindow > 0 && to-from > maxWindow {
+		return nil, api.ErrorInvalidRequest(fmt.Errorf("backtest window must not be longer than %d seconds", maxWindow))
+	}
+	moiraTrigger := trigger.ToMoiraTrigger()
+	source, err := metricSourceProvider.GetTriggerMetricSource(moiraTrigger)
+	if err != nil {
+		return nil, api.ErrorInvalidRequest(err)
+	}
+	result, err := checker.Backtest(moiraTrigger, source, logger, from, to, checker.DefaultBacktestCheckInterval)
+	if err != nil {
+		if err == checker.ErrBacktestTooManyChecks {
+			return nil, api.ErrorInvalidRequest(err)
+		}
+		switch err.(type) {
+		case remote.ErrRemoteTriggerResponse, prometheus.ErrPrometheusTriggerResponse:
+			return nil, api.ErrorRemoteServerUnavailable(err)
+		default:
+			return nil, api.ErrorInternalServer(err)
+		}
+	}
+	return &dto.TriggerBacktest{
+		From:      from,
+		To:        to,
+		Events:    result.Events,
+		LastCheck: result.LastCheck,
+	}, nil
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/op/go-logging"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/checker"
+	metricSource "github.com/moira-alert/moira/metric_source"
+	"github.com/moira-alert/moira/metric_source/remote"
+	mock_metric_source "github.com/moira-alert/moira/mock/metric_source"
+)
+
+func TestBacktestTrigger(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	logger, _ := logging.GetLogger("Test")
+	localSource := mock_metric_source.NewMockMetricSource(mockCtrl)
+	remoteSource := mock_metric_source.NewMockMetricSource(mockCtrl)
+	fetchResult := mock_metric_source.NewMockFetchResult(mockCtrl)
+	sourceProvider := metricSource.CreateMetricSourceProvider(localSource, map[moira.ClusterID]metricSource.MetricSource{moira.DefaultCluster: remoteSource}, nil)
+	pattern := "super.puper.pattern"
+	metric := "super.puper.metric"
+	errorValue := float64(10)
+	trigger := dto.TriggerModel{
+		ID:             "triggerID",
+		Targets:        []string{pattern},
+		ErrorValue:     &errorValue,
+		TriggerType:    moira.RisingTrigger,
+		MuteNewMetrics: true,
+	}
+
+	var from int64 = 600
+	var to int64 = 720
+	var maxWindow int64 = 3600
+
+	Convey("From is not less than to", t, func() {
+		result, err := BacktestTrigger(sourceProvider, logger, &trigger, to, from, maxWindow)
+		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("from must be less than to")))
+		So(result, ShouldBeNil)
+	})
+
+	Convey("Window is longer than max window", t, func() {
+		result, err := BacktestTrigger(sourceProvider, logger, &trigger, from, from+maxWindow+1, maxWindow)
+		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("backtest window must not be longer than 3600 seconds")))
+		So(result, ShouldBeNil)
+	})
+
+	Convey("Window requires too many checks", t, func() {
+		localSource.EXPECT().IsConfigured().Return(true, nil)
+		result, err := BacktestTrigger(sourceProvider, logger, &trigger, from, from+(checker.MaxBacktestChecks+1)*checker.DefaultBacktestCheckInterval, 0)
+		So(err, ShouldResemble, api.ErrorInvalidRequest(checker.ErrBacktestTooManyChecks))
+		So(result, ShouldBeNil)
+	})
+
+	Convey("Remote is not configured", t, func() {
+		remoteTrigger := trigger
+		remoteTrigger.TriggerSource = moira.GraphiteRemote
+		remoteSource.EXPECT().IsConfigured().Return(false, nil)
+		result, err := BacktestTrigger(sourceProvider, logger, &remoteTrigger, from, to, maxWindow)
+		So(err, ShouldResemble, api.ErrorInvalidRequest(metricSource.ErrMetricSourceIsNotConfigured))
+		So(result, ShouldBeNil)
+	})
+
+	Convey("Remote is unavailable", t, func() {
+		remoteTrigger := trigger
+		remoteTrigger.TriggerSource = moira.GraphiteRemote
+		remoteErr := remote.ErrRemoteTriggerResponse{InternalError: fmt.Errorf("timeout"), Target: pattern}
+		remoteSource.EXPECT().IsConfigured().Return(true, nil)
+		remoteSource.EXPECT().Fetch(pattern, int64(0), to, true).Return(nil, remoteErr)
+		result, err := BacktestTrigger(sourceProvider, logger, &remoteTrigger, from, to, maxWindow)
+		So(err, ShouldResemble, api.ErrorRemoteServerUnavailable(remoteErr))
+		So(result, ShouldBeNil)
+	})
+
+	Convey("Has events", t, func() {
+		localSource.EXPECT().IsConfigured().Return(true, nil)
+		localSource.EXPECT().Fetch(pattern, int64(0), to, true).Return(fetchResult, nil)
+		fetchResult.EXPECT().GetMetricsData().Return([]*metricSource.MetricData{metricSource.MakeMetricData(metric, []float64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 20, 20}, 60, 0)})
+		fetchResult.EXPECT().GetPatternMetrics().Return([]string{metric}, nil)
+		result, err := BacktestTrigger(sourceProvider, logger, &trigger, from, to, maxWindow)
+		So(err, ShouldBeNil)
+		value := float64(20)
+		So(result.From, ShouldEqual, from)
+		So(result.To, ShouldEqual, to)
+		So(result.Events, ShouldResemble, []*moira.NotificationEvent{
+			{TriggerID: trigger.ID, Metric: metric, State: moira.StateERROR, OldState: moira.StateOK, Timestamp: 660, Value: &value},
+		})
+		So(result.LastCheck.Metrics[metric].State, ShouldEqual, moira.StateERROR)
+	})
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+)
+
+// GetTriggerDependencies gets all parent triggers which trigger depends on and all child triggers which depend on trigger
+func GetTriggerDependencies(dataBase moira.Database, triggerID string) (*dto.TriggerDependencies, *api.ErrorResponse) {
+	trigger, err := dataBase.GetTrigger(triggerID)
+	if err != nil {
+		if err == database.ErrNil {
+			return nil, api.ErrorNotFound(fmt.Sprintf("trigger with ID = '%s' does not exists", triggerID))
+		}
+		return nil, api.ErrorInternalServer(err)
+	}
+	parents, err := walkTriggerDependencies(dataBase, trigger.Dependencies, func(node dto.TriggerDependencyNode) ([]string, error) {
+		return node.Dependencies, nil
+	})
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	childIDs, err := dataBase.GetTriggerDependents(triggerID)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	children, err := walkTriggerDependencies(dataBase, childIDs, func(node dto.TriggerDependencyNode) ([]string, error) {
+		return dataBase.GetTriggerDependents(node.ID)
+	})
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	return &dto.TriggerDependencies{
+		TriggerID: triggerID,
+		Parents:   parents,
+		Children:  children,
+	}, nil
+}
+
+// checkTriggerDependencies checks that trigger does not become its own ancestor through given dependencies
+func checkTriggerDependencies(dataBase moira.Database, triggerID string, dependencies []string) *api.ErrorResponse {
+	if len(dependencies) == 0 {
+		return nil
+	}
+	ancestors, err := walkTriggerDependencies(dataBase, dependencies, func(node dto.TriggerDependencyNode) ([]string, error) {
+		return node.Dependencies, nil
+	})
+	if err != nil {
+		return api.ErrorInternalServer(err)
+	}
+	for _, ancestor := range ancestors {
+		if ancestor.ID == triggerID {
+			return api.ErrorInvalidRequest(fmt.Errorf("trigger dependencies contain a cycle: trigger %s depends on itself", triggerID))
+		}
+	}
+	return nil
+}
+
+// walkTriggerDependencies visits triggers starting from given ones and following IDs returned by next func,
+// each trigger is visited once, so the walk terminates even if stored dependencies contain a cycle
+func walkTriggerDependencies(dataBase moira.Database, triggerIDs []string, next func(node dto.TriggerDependencyNode) ([]string, error)) ([]dto.TriggerDependencyNode, error) {
+	nodes := make([]dto.TriggerDependencyNode, 0)
+	visited := make(map[string]bool)
+	queue := append(make([]string, 0, len(triggerIDs)), triggerIDs...)
+	for len(queue) > 0 {
+		triggerID := queue[0]
+		queue = queue[1:]
+		if visited[triggerID] {
+			continue
+		}
+		visited[triggerID] = true
+
+		node := dto.TriggerDependencyNode{ID: triggerID, Dependencies: make([]string, 0)}
+		trigger, err := dataBase.GetTrigger(triggerID)
+		if err != nil && err != database.ErrNil {
+			return nil, err
+		}
+		if err == nil {
+			node.Name = trigger.Name
+			if trigger.Dependencies != nil {
+				node.Dependencies = trigger.Dependencies
+			}
+		}
+		nodes = append(nodes, node)
+
+		nextIDs, err := next(node)
+		if err != nil {
+			return nil, err
+		}
+		queue = append(queue, nextIDs...)
+	}
+	return nodes, nil
+}
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetTriggerDependencies(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+	Convey("Dependency graph contains all ancestors and descendants", t, func() {
+		dataBase.EXPECT().GetTrigger("trigger").Return(moira.Trigger{ID: "trigger", Dependencies: []string{"parent", "removed"}}, nil)
+		dataBase.EXPECT().GetTrigger("parent").Return(moira.Trigger{ID: "parent", Name: "Parent", Dependencies: []string{"root"}}, nil)
+		dataBase.EXPECT().GetTrigger("removed").Return(moira.Trigger{}, database.ErrNil)
+		dataBase.EXPECT().GetTrigger("root").Return(moira.Trigger{ID: "root", Name: "Root"}, nil)
+		dataBase.EXPECT().GetTriggerDependents("trigger").Return([]string{"child"}, nil)
+		dataBase.EXPECT().GetTrigger("child").Return(moira.Trigger{ID: "child", Name: "Child", Dependencies: []string{"trigger"}}, nil)
+		dataBase.EXPECT().GetTriggerDependents("child").Return([]string{}, nil)
+
+		actual, err := GetTriggerDependencies(dataBase, "trigger")
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, &dto.TriggerDependencies{
+			TriggerID: "trigger",
+			Parents: []dto.TriggerDependencyNode{
+				{ID: "parent", Name: "Parent", Dependencies: []string{"root"}},
+				{ID: "removed", Dependencies: []string{}},
+				{ID: "root", Name: "Root", Dependencies: []string{}},
+			},
+			Children: []dto.TriggerDependencyNode{
+				{ID: "child", Name: "Child", Dependencies: []string{"trigger"}},
+			},
+		})
+	})
+
+	Convey("Trigger does not exist", t, func() {
+		dataBase.EXPECT().GetTrigger("trigger").Return(moira.Trigger{}, database.ErrNil)
+		actual, err := GetTriggerDependencies(dataBase, "trigger")
+		So(err, ShouldResemble, api.ErrorNotFound("trigger with ID = 'trigger' does not exists"))
+		So(actual, ShouldBeNil)
+	})
+
+	Convey("Get dependents error", t, func() {
+		expected := fmt.Errorf("can't get dependents")
+		dataBase.EXPECT().GetTrigger("trigger").Return(moira.Trigger{ID: "trigger"}, nil)
+		dataBase.EXPECT().GetTriggerDependents("trigger").Return(nil, expected)
+		actual, err := GetTriggerDependencies(dataBase, "trigger")
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(actual, ShouldBeNil)
+	})
+}
+
+func TestCheckTriggerDependencies(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+
+	Convey("Trigger without dependencies", t, func() {
+		So(checkTriggerDependencies(dataBase, "trigger", nil), ShouldBeNil)
+	})
+
+	Convey("Dependencies without cycle", t, func() {
+		dataBase.EXPECT().GetTrigger("parent").Return(moira.Trigger{ID: "parent", Dependencies: []string{"root"}}, nil)
+		dataBase.EXPECT().GetTrigger("root").Return(moira.Trigger{ID: "root"}, nil)
+		So(checkTriggerDependencies(dataBase, "trigger", []string{"parent"}), ShouldBeNil)
+	})
+
+	Convey("Dependencies with cycle", t, func() {
+		dataBase.EXPECT().GetTrigger("parent").Return(moira.Trigger{ID: "parent", Dependencies: []string{"root"}}, nil)
+		dataBase.EXPECT().GetTrigger("root").Return(moira.Trigger{ID: "root", Dependencies: []string{"trigger"}}, nil)
+		dataBase.EXPECT().GetTrigger("trigger").Return(moira.Trigger{ID: "trigger"}, nil)
+		err := checkTriggerDependencies(dataBase, "trigger", []string{"parent"})
+		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("trigger dependencies contain a cycle: trigger trigger depends on itself")))
+	})
+
+	Convey("Get trigger error", t, func() {
+		expected := fmt.Errorf("can't get trigger")
+		dataBase.EXPECT().GetTrigger("parent").Return(moira.Trigger{}, expected)
+		err := checkTriggerDependencies(dataBase, "trigger", []string{"parent"})
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+	})
+
+	Convey("Trigger with cycle is not saved", t, func() {
+		trigger := moira.Trigger{ID: "trigger", Dependencies: []string{"trigger"}}
+		dataBase.EXPECT().GetTrigger("trigger").Return(trigger, nil)
+		resp, err := saveTrigger(dataBase, &trigger, trigger.ID, make(map[string]bool))
+		So(err, ShouldNotBeNil)
+		So(resp, ShouldBeNil)
+	})
+}
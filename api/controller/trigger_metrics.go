@@ -38,14 +38,14 @@ func GetTriggerEvaluationResult(dataBase moira.Database, metricSourceProvider *m
 }
 
 // DeleteTriggerMetric deletes metric from last check and all trigger patterns metrics
-func DeleteTriggerMetric(dataBase moira.Database, metricName string, triggerID string) *api.ErrorResponse {
-	return deleteTriggerMetrics(dataBase, metricName, triggerID, false)
+func DeleteTriggerMetric(dataBase moira.Database, metricName string, triggerID string, userLogin string) *api.ErrorResponse {
+	return deleteTriggerMetrics(dataBase, metricName, triggerID, false, userLogin)
 }
 
 // DeleteTriggerNodataMetrics deletes all metric from last check which are in NODATA state.
 // It also deletes all trigger patterns of those metrics
-func DeleteTriggerNodataMetrics(dataBase moira.Database, triggerID string) *api.ErrorResponse {
-	return deleteTriggerMetrics(dataBase, "", triggerID, true)
+func DeleteTriggerNodataMetrics(dataBase moira.Database, triggerID string, userLogin string) *api.ErrorResponse {
+	return deleteTriggerMetrics(dataBase, "", triggerID, true, userLogin)
 }
 
 // GetTriggerMetrics gets all trigger metrics values, default values from: now - 10min, to: now
@@ -86,7 +86,7 @@ func GetTriggerMetrics(dataBase moira.Database, metricSourceProvider *metricSour
 	return &triggerMetrics, nil
 }
 
-func deleteTriggerMetrics(dataBase moira.Database, metricName string, triggerID string, removeAllNodataMetrics bool) *api.ErrorResponse {
+func deleteTriggerMetrics(dataBase moira.Database, metricName string, triggerID string, removeAllNodataMetrics bool, userLogin string) *api.ErrorResponse {
 	trigger, err := dataBase.GetTrigger(triggerID)
 	if err != nil {
 		if err == database.ErrNil {
@@ -107,15 +107,18 @@ func deleteTriggerMetrics(dataBase moira.Database, metricName string, triggerID
 		}
 		return api.ErrorInternalServer(err)
 	}
+	removedMetrics := make(map[string]moira.MetricState)
 	if removeAllNodataMetrics {
 		for metricName, metricState := range lastCheck.Metrics {
 			if metricState.State == moira.StateNODATA {
+				removedMetrics[metricName] = metricState
 				delete(lastCheck.Metrics, metricName)
 			}
 		}
 	} else {
-		_, ok := lastCheck.Metrics[metricName]
+		metricState, ok := lastCheck.Metrics[metricName]
 		if ok {
+			removedMetrics[metricName] = metricState
 			delete(lastCheck.Metrics, metricName)
 		}
 	}
@@ -123,8 +126,11 @@ func deleteTriggerMetrics(dataBase moira.Database, metricName string, triggerID
 	if err = dataBase.RemovePatternsMetrics(trigger.Patterns); err != nil {
 		return api.ErrorInternalServer(err)
 	}
-	if err = dataBase.SetTriggerLastCheck(triggerID, &lastCheck, trigger.IsRemote); err != nil {
+	if err = dataBase.SetTriggerLastCheck(triggerID, &lastCheck, trigger.ClusterKey()); err != nil {
 		return api.ErrorInternalServer(err)
 	}
-	return nil
+	if len(removedMetrics) == 0 {
+		return nil
+	}
+	return saveAuditRecord(dataBase, userLogin, moira.AuditEntityTrigger, triggerID, moira.AuditActionRemoveMetrics, removedMetrics, nil)
 }
@@ -40,8 +40,12 @@ func TestDeleteTriggerMetric(t *testing.T) {
 		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(expectedLastCheck, nil)
 		dataBase.EXPECT().RemovePatternsMetrics(trigger.Patterns).Return(nil)
-		dataBase.EXPECT().SetTriggerLastCheck(triggerID, &expectedLastCheck, trigger.IsRemote)
-		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID)
+		dataBase.EXPECT().SetTriggerLastCheck(triggerID, &expectedLastCheck, trigger.ClusterKey())
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Do(func(record *moira.AuditRecord) {
+			So(record.Action, ShouldEqual, moira.AuditActionRemoveMetrics)
+			So(record.User, ShouldEqual, "user")
+		}).Return(nil)
+		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID, "user")
 		So(err, ShouldBeNil)
 		So(expectedLastCheck, ShouldResemble, emptyLastCheck)
 	})
@@ -53,15 +57,15 @@ func TestDeleteTriggerMetric(t *testing.T) {
 		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(expectedLastCheck, nil)
 		dataBase.EXPECT().RemovePatternsMetrics(trigger.Patterns).Return(nil)
-		dataBase.EXPECT().SetTriggerLastCheck(triggerID, &expectedLastCheck, trigger.IsRemote)
-		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID)
+		dataBase.EXPECT().SetTriggerLastCheck(triggerID, &expectedLastCheck, trigger.ClusterKey())
+		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID, "user")
 		So(err, ShouldBeNil)
 		So(expectedLastCheck, ShouldResemble, emptyLastCheck)
 	})
 
 	Convey("No trigger", t, func() {
 		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{}, database.ErrNil)
-		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID)
+		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID, "user")
 		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("trigger not found")))
 	})
 
@@ -70,14 +74,14 @@ func TestDeleteTriggerMetric(t *testing.T) {
 		dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 10).Return(nil)
 		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
-		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID)
+		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID, "user")
 		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("trigger check not found")))
 	})
 
 	Convey("Get trigger error", t, func() {
 		expected := fmt.Errorf("get trigger error")
 		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{}, expected)
-		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID)
+		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID, "user")
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 	})
 
@@ -85,7 +89,7 @@ func TestDeleteTriggerMetric(t *testing.T) {
 		expected := fmt.Errorf("acquire error")
 		dataBase.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
 		dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 10).Return(expected)
-		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID)
+		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID, "user")
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 	})
 
@@ -95,7 +99,7 @@ func TestDeleteTriggerMetric(t *testing.T) {
 		dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 10).Return(nil)
 		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, expected)
-		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID)
+		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID, "user")
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 	})
 
@@ -106,7 +110,7 @@ func TestDeleteTriggerMetric(t *testing.T) {
 		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(lastCheck, nil)
 		dataBase.EXPECT().RemovePatternsMetrics(trigger.Patterns).Return(expected)
-		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID)
+		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID, "user")
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 	})
 
@@ -117,8 +121,8 @@ func TestDeleteTriggerMetric(t *testing.T) {
 		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(lastCheck, nil)
 		dataBase.EXPECT().RemovePatternsMetrics(trigger.Patterns).Return(nil)
-		dataBase.EXPECT().SetTriggerLastCheck(triggerID, &lastCheck, trigger.IsRemote).Return(expected)
-		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID)
+		dataBase.EXPECT().SetTriggerLastCheck(triggerID, &lastCheck, trigger.ClusterKey()).Return(expected)
+		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID, "user")
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 	})
 }
@@ -175,8 +179,12 @@ func TestDeleteTriggerNodataMetrics(t *testing.T) {
 		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(expectedLastCheck, nil)
 		dataBase.EXPECT().RemovePatternsMetrics(trigger.Patterns).Return(nil)
-		dataBase.EXPECT().SetTriggerLastCheck(triggerID, &expectedLastCheck, trigger.IsRemote)
-		err := DeleteTriggerNodataMetrics(dataBase, triggerID)
+		dataBase.EXPECT().SetTriggerLastCheck(triggerID, &expectedLastCheck, trigger.ClusterKey())
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Do(func(record *moira.AuditRecord) {
+			So(record.Action, ShouldEqual, moira.AuditActionRemoveMetrics)
+			So(record.User, ShouldEqual, "user")
+		}).Return(nil)
+		err := DeleteTriggerNodataMetrics(dataBase, triggerID, "user")
 		So(err, ShouldBeNil)
 		So(expectedLastCheck, ShouldResemble, emptyLastCheck)
 	})
@@ -188,8 +196,12 @@ func TestDeleteTriggerNodataMetrics(t *testing.T) {
 		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(expectedLastCheck, nil)
 		dataBase.EXPECT().RemovePatternsMetrics(trigger.Patterns).Return(nil)
-		dataBase.EXPECT().SetTriggerLastCheck(triggerID, &expectedLastCheck, trigger.IsRemote)
-		err := DeleteTriggerNodataMetrics(dataBase, triggerID)
+		dataBase.EXPECT().SetTriggerLastCheck(triggerID, &expectedLastCheck, trigger.ClusterKey())
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Do(func(record *moira.AuditRecord) {
+			So(record.Action, ShouldEqual, moira.AuditActionRemoveMetrics)
+			So(record.User, ShouldEqual, "user")
+		}).Return(nil)
+		err := DeleteTriggerNodataMetrics(dataBase, triggerID, "user")
 		So(err, ShouldBeNil)
 		So(expectedLastCheck, ShouldResemble, emptyLastCheck)
 	})
@@ -201,8 +213,9 @@ func TestDeleteTriggerNodataMetrics(t *testing.T) {
 		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(expectedLastCheck, nil)
 		dataBase.EXPECT().RemovePatternsMetrics(trigger.Patterns).Return(nil)
-		dataBase.EXPECT().SetTriggerLastCheck(triggerID, &lastCheckWithoutNodata, trigger.IsRemote)
-		err := DeleteTriggerNodataMetrics(dataBase, triggerID)
+		dataBase.EXPECT().SetTriggerLastCheck(triggerID, &lastCheckWithoutNodata, trigger.ClusterKey())
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		err := DeleteTriggerNodataMetrics(dataBase, triggerID, "user")
 		So(err, ShouldBeNil)
 		So(expectedLastCheck, ShouldResemble, lastCheckWithoutNodata)
 	})
@@ -214,15 +227,15 @@ func TestDeleteTriggerNodataMetrics(t *testing.T) {
 		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(expectedLastCheck, nil)
 		dataBase.EXPECT().RemovePatternsMetrics(trigger.Patterns).Return(nil)
-		dataBase.EXPECT().SetTriggerLastCheck(triggerID, &expectedLastCheck, trigger.IsRemote)
-		err := DeleteTriggerNodataMetrics(dataBase, triggerID)
+		dataBase.EXPECT().SetTriggerLastCheck(triggerID, &expectedLastCheck, trigger.ClusterKey())
+		err := DeleteTriggerNodataMetrics(dataBase, triggerID, "user")
 		So(err, ShouldBeNil)
 		So(expectedLastCheck, ShouldResemble, emptyLastCheck)
 	})
 
 	Convey("No trigger", t, func() {
 		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{}, database.ErrNil)
-		err := DeleteTriggerNodataMetrics(dataBase, triggerID)
+		err := DeleteTriggerNodataMetrics(dataBase, triggerID, "user")
 		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("trigger not found")))
 	})
 
@@ -231,14 +244,14 @@ func TestDeleteTriggerNodataMetrics(t *testing.T) {
 		dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 10).Return(nil)
 		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
-		err := DeleteTriggerNodataMetrics(dataBase, triggerID)
+		err := DeleteTriggerNodataMetrics(dataBase, triggerID, "user")
 		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("trigger check not found")))
 	})
 
 	Convey("Get trigger error", t, func() {
 		expected := fmt.Errorf("get trigger error")
 		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{}, expected)
-		err := DeleteTriggerNodataMetrics(dataBase, triggerID)
+		err := DeleteTriggerNodataMetrics(dataBase, triggerID, "user")
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 	})
 
@@ -246,7 +259,7 @@ func TestDeleteTriggerNodataMetrics(t *testing.T) {
 		expected := fmt.Errorf("acquire error")
 		dataBase.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
 		dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 10).Return(expected)
-		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID)
+		err := DeleteTriggerMetric(dataBase, "super.metric1", triggerID, "user")
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 	})
 
@@ -256,7 +269,7 @@ func TestDeleteTriggerNodataMetrics(t *testing.T) {
 		dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 10).Return(nil)
 		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, expected)
-		err := DeleteTriggerNodataMetrics(dataBase, triggerID)
+		err := DeleteTriggerNodataMetrics(dataBase, triggerID, "user")
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 	})
 }
@@ -269,7 +282,7 @@ func TestGetTriggerMetrics(t *testing.T) {
 	localSource := mock_metric_source.NewMockMetricSource(mockCtrl)
 	remoteSource := mock_metric_source.NewMockMetricSource(mockCtrl)
 	fetchResult := mock_metric_source.NewMockFetchResult(mockCtrl)
-	sourceProvider := metricSource.CreateMetricSourceProvider(localSource, remoteSource)
+	sourceProvider := metricSource.CreateMetricSourceProvider(localSource, map[moira.ClusterID]metricSource.MetricSource{moira.DefaultCluster: remoteSource}, nil)
 	pattern := "super.puper.pattern"
 	metric := "super.puper.metric"
 
@@ -278,7 +291,7 @@ func TestGetTriggerMetrics(t *testing.T) {
 	var retention int64 = 10
 
 	Convey("Trigger is remote but remote is not configured", t, func() {
-		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID, Targets: []string{pattern}, IsRemote: true}, nil)
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID, Targets: []string{pattern}, TriggerSource: moira.GraphiteRemote}, nil)
 		remoteSource.EXPECT().IsConfigured().Return(false, nil)
 		triggerMetrics, err := GetTriggerMetrics(dataBase, sourceProvider, from, until, triggerID)
 		So(err, ShouldResemble, api.ErrorInternalServer(metricSource.ErrMetricSourceIsNotConfigured))
@@ -286,7 +299,7 @@ func TestGetTriggerMetrics(t *testing.T) {
 	})
 
 	Convey("Trigger is remote but remote has bad config", t, func() {
-		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID, Targets: []string{pattern}, IsRemote: true}, nil)
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID, Targets: []string{pattern}, TriggerSource: moira.GraphiteRemote}, nil)
 		remoteSource.EXPECT().IsConfigured().Return(false, remote.ErrRemoteStorageDisabled)
 		triggerMetrics, err := GetTriggerMetrics(dataBase, sourceProvider, from, until, triggerID)
 		So(err, ShouldResemble, api.ErrorInternalServer(remote.ErrRemoteStorageDisabled))
@@ -320,7 +333,7 @@ func TestGetTriggerMetrics(t *testing.T) {
 
 	Convey("Fetch error", t, func() {
 		expectedError := remote.ErrRemoteTriggerResponse{InternalError: fmt.Errorf("some error"), Target: pattern}
-		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID, Targets: []string{pattern}, IsRemote: true}, nil)
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{ID: triggerID, Targets: []string{pattern}, TriggerSource: moira.GraphiteRemote}, nil)
 		remoteSource.EXPECT().IsConfigured().Return(true, nil)
 		remoteSource.EXPECT().Fetch(pattern, from, until, false).Return(nil, expectedError)
 		triggerMetrics, err := GetTriggerMetrics(dataBase, sourceProvider, from, until, triggerID)
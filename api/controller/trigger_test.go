@@ -27,9 +27,10 @@ func TestUpdateTrigger(t *testing.T) {
 		dataBase.EXPECT().AcquireTriggerCheckLock(gomock.Any(), 10)
 		dataBase.EXPECT().DeleteTriggerCheckLock(gomock.Any())
 		dataBase.EXPECT().GetTriggerLastCheck(gomock.Any()).Return(moira.CheckData{}, database.ErrNil)
-		dataBase.EXPECT().SetTriggerLastCheck(gomock.Any(), gomock.Any(), trigger.IsRemote).Return(nil)
+		dataBase.EXPECT().SetTriggerLastCheck(gomock.Any(), gomock.Any(), trigger.ClusterKey()).Return(nil)
 		dataBase.EXPECT().SaveTrigger(gomock.Any(), trigger).Return(nil)
-		resp, err := UpdateTrigger(dataBase, &triggerModel, triggerModel.ID, make(map[string]bool))
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		resp, err := UpdateTrigger(dataBase, &triggerModel, triggerModel.ID, make(map[string]bool), "user")
 		So(err, ShouldBeNil)
 		So(resp.Message, ShouldResemble, "trigger updated")
 	})
@@ -37,7 +38,7 @@ func TestUpdateTrigger(t *testing.T) {
 	Convey("Trigger does not exists", t, func() {
 		trigger := dto.TriggerModel{ID: uuid.Must(uuid.NewV4()).String()}
 		dataBase.EXPECT().GetTrigger(trigger.ID).Return(moira.Trigger{}, database.ErrNil)
-		resp, err := UpdateTrigger(dataBase, &trigger, trigger.ID, make(map[string]bool))
+		resp, err := UpdateTrigger(dataBase, &trigger, trigger.ID, make(map[string]bool), "user")
 		So(err, ShouldResemble, api.ErrorNotFound(fmt.Sprintf("trigger with ID = '%s' does not exists", trigger.ID)))
 		So(resp, ShouldBeNil)
 	})
@@ -46,7 +47,7 @@ func TestUpdateTrigger(t *testing.T) {
 		trigger := dto.TriggerModel{ID: uuid.Must(uuid.NewV4()).String()}
 		expected := fmt.Errorf("soo bad trigger")
 		dataBase.EXPECT().GetTrigger(trigger.ID).Return(moira.Trigger{}, expected)
-		resp, err := UpdateTrigger(dataBase, &trigger, trigger.ID, make(map[string]bool))
+		resp, err := UpdateTrigger(dataBase, &trigger, trigger.ID, make(map[string]bool), "user")
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 		So(resp, ShouldBeNil)
 	})
@@ -73,7 +74,7 @@ func TestSaveTrigger(t *testing.T) {
 			dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 10)
 			dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 			dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
-			dataBase.EXPECT().SetTriggerLastCheck(triggerID, gomock.Any(), trigger.IsRemote).Return(nil)
+			dataBase.EXPECT().SetTriggerLastCheck(triggerID, gomock.Any(), trigger.ClusterKey()).Return(nil)
 			dataBase.EXPECT().SaveTrigger(triggerID, &trigger).Return(nil)
 			resp, err := saveTrigger(dataBase, &trigger, triggerID, make(map[string]bool))
 			So(err, ShouldBeNil)
@@ -84,7 +85,7 @@ func TestSaveTrigger(t *testing.T) {
 			dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 10)
 			dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 			dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(actualLastCheck, nil)
-			dataBase.EXPECT().SetTriggerLastCheck(triggerID, &actualLastCheck, trigger.IsRemote).Return(nil)
+			dataBase.EXPECT().SetTriggerLastCheck(triggerID, &actualLastCheck, trigger.ClusterKey()).Return(nil)
 			dataBase.EXPECT().SaveTrigger(triggerID, &trigger).Return(nil)
 			resp, err := saveTrigger(dataBase, &trigger, triggerID, make(map[string]bool))
 			So(err, ShouldBeNil)
@@ -98,7 +99,7 @@ func TestSaveTrigger(t *testing.T) {
 		dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 10)
 		dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
-		dataBase.EXPECT().SetTriggerLastCheck(triggerID, gomock.Any(), trigger.IsRemote).Return(nil)
+		dataBase.EXPECT().SetTriggerLastCheck(triggerID, gomock.Any(), trigger.ClusterKey()).Return(nil)
 		dataBase.EXPECT().SaveTrigger(triggerID, &trigger).Return(nil)
 		resp, err := saveTrigger(dataBase, &trigger, triggerID, map[string]bool{"super.metric1": true, "super.metric2": true})
 		So(err, ShouldBeNil)
@@ -130,7 +131,7 @@ func TestSaveTrigger(t *testing.T) {
 			dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 10)
 			dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 			dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
-			dataBase.EXPECT().SetTriggerLastCheck(triggerID, gomock.Any(), trigger.IsRemote).Return(expected)
+			dataBase.EXPECT().SetTriggerLastCheck(triggerID, gomock.Any(), trigger.ClusterKey()).Return(expected)
 			resp, err := saveTrigger(dataBase, &trigger, triggerID, make(map[string]bool))
 			So(err, ShouldResemble, api.ErrorInternalServer(expected))
 			So(resp, ShouldBeNil)
@@ -141,7 +142,7 @@ func TestSaveTrigger(t *testing.T) {
 			dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 10)
 			dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 			dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
-			dataBase.EXPECT().SetTriggerLastCheck(triggerID, gomock.Any(), trigger.IsRemote).Return(nil)
+			dataBase.EXPECT().SetTriggerLastCheck(triggerID, gomock.Any(), trigger.ClusterKey()).Return(nil)
 			dataBase.EXPECT().SaveTrigger(triggerID, &trigger).Return(expected)
 			resp, err := saveTrigger(dataBase, &trigger, triggerID, make(map[string]bool))
 			So(err, ShouldResemble, api.ErrorInternalServer(expected))
@@ -172,7 +173,7 @@ func TestVariousTtlState(t *testing.T) {
 			dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 10)
 			dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 			dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
-			dataBase.EXPECT().SetTriggerLastCheck(triggerID, &lastCheck, trigger.IsRemote).Return(nil)
+			dataBase.EXPECT().SetTriggerLastCheck(triggerID, &lastCheck, trigger.ClusterKey()).Return(nil)
 			dataBase.EXPECT().SaveTrigger(triggerID, &trigger).Return(nil)
 			resp, err := saveTrigger(dataBase, &trigger, triggerID, make(map[string]bool))
 			So(err, ShouldBeNil)
@@ -187,7 +188,7 @@ func TestVariousTtlState(t *testing.T) {
 			dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 10)
 			dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 			dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
-			dataBase.EXPECT().SetTriggerLastCheck(triggerID, &lastCheck, trigger.IsRemote).Return(nil)
+			dataBase.EXPECT().SetTriggerLastCheck(triggerID, &lastCheck, trigger.ClusterKey()).Return(nil)
 			dataBase.EXPECT().SaveTrigger(triggerID, &trigger).Return(nil)
 			resp, err := saveTrigger(dataBase, &trigger, triggerID, make(map[string]bool))
 			So(err, ShouldBeNil)
@@ -202,7 +203,7 @@ func TestVariousTtlState(t *testing.T) {
 			dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 10)
 			dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 			dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
-			dataBase.EXPECT().SetTriggerLastCheck(triggerID, &lastCheck, trigger.IsRemote).Return(nil)
+			dataBase.EXPECT().SetTriggerLastCheck(triggerID, &lastCheck, trigger.ClusterKey()).Return(nil)
 			dataBase.EXPECT().SaveTrigger(triggerID, &trigger).Return(nil)
 			resp, err := saveTrigger(dataBase, &trigger, triggerID, make(map[string]bool))
 			So(err, ShouldBeNil)
@@ -217,7 +218,7 @@ func TestVariousTtlState(t *testing.T) {
 			dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 10)
 			dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 			dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
-			dataBase.EXPECT().SetTriggerLastCheck(triggerID, &lastCheck, trigger.IsRemote).Return(nil)
+			dataBase.EXPECT().SetTriggerLastCheck(triggerID, &lastCheck, trigger.ClusterKey()).Return(nil)
 			dataBase.EXPECT().SaveTrigger(triggerID, &trigger).Return(nil)
 			resp, err := saveTrigger(dataBase, &trigger, triggerID, make(map[string]bool))
 			So(err, ShouldBeNil)
@@ -232,7 +233,7 @@ func TestVariousTtlState(t *testing.T) {
 			dataBase.EXPECT().AcquireTriggerCheckLock(triggerID, 10)
 			dataBase.EXPECT().DeleteTriggerCheckLock(triggerID)
 			dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
-			dataBase.EXPECT().SetTriggerLastCheck(triggerID, &lastCheck, trigger.IsRemote).Return(nil)
+			dataBase.EXPECT().SetTriggerLastCheck(triggerID, &lastCheck, trigger.ClusterKey()).Return(nil)
 			dataBase.EXPECT().SaveTrigger(triggerID, &trigger).Return(nil)
 			resp, err := saveTrigger(dataBase, &trigger, triggerID, make(map[string]bool))
 			So(err, ShouldBeNil)
@@ -298,26 +299,47 @@ func TestRemoveTrigger(t *testing.T) {
 	defer mockCtrl.Finish()
 	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
 	triggerID := uuid.Must(uuid.NewV4()).String()
+	trigger := moira.Trigger{ID: triggerID}
+	userLogin := "user"
 
 	Convey("Success", t, func() {
+		dataBase.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
+		dataBase.EXPECT().RemoveTrigger(triggerID).Return(nil)
+		dataBase.EXPECT().RemoveTriggerLastCheck(triggerID).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		err := RemoveTrigger(dataBase, triggerID, userLogin)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Success remove not existing trigger", t, func() {
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{}, database.ErrNil)
 		dataBase.EXPECT().RemoveTrigger(triggerID).Return(nil)
 		dataBase.EXPECT().RemoveTriggerLastCheck(triggerID).Return(nil)
-		err := RemoveTrigger(dataBase, triggerID)
+		err := RemoveTrigger(dataBase, triggerID, userLogin)
 		So(err, ShouldBeNil)
 	})
 
+	Convey("Error get trigger", t, func() {
+		expected := fmt.Errorf("oooops! Error get")
+		dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{}, expected)
+		err := RemoveTrigger(dataBase, triggerID, userLogin)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+	})
+
 	Convey("Error remove trigger", t, func() {
 		expected := fmt.Errorf("oooops! Error delete")
+		dataBase.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
 		dataBase.EXPECT().RemoveTrigger(triggerID).Return(expected)
-		err := RemoveTrigger(dataBase, triggerID)
+		err := RemoveTrigger(dataBase, triggerID, userLogin)
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 	})
 
 	Convey("Error remove last check", t, func() {
 		expected := fmt.Errorf("oooops! Error delete")
+		dataBase.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
 		dataBase.EXPECT().RemoveTrigger(triggerID).Return(nil)
 		dataBase.EXPECT().RemoveTriggerLastCheck(triggerID).Return(expected)
-		err := RemoveTrigger(dataBase, triggerID)
+		err := RemoveTrigger(dataBase, triggerID, userLogin)
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 	})
 }
@@ -387,19 +409,25 @@ func TestDeleteTriggerThrottling(t *testing.T) {
 	triggerID := uuid.Must(uuid.NewV4()).String()
 
 	Convey("Success", t, func() {
+		dataBase.EXPECT().GetTriggerThrottling(triggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
 		dataBase.EXPECT().DeleteTriggerThrottling(triggerID).Return(nil)
 		var total int64
 		var to int64 = -1
 		dataBase.EXPECT().GetNotifications(total, to).Return(make([]*moira.ScheduledNotification, 0), total, nil)
 		dataBase.EXPECT().AddNotifications(make([]*moira.ScheduledNotification, 0), gomock.Any()).Return(nil)
-		err := DeleteTriggerThrottling(dataBase, triggerID)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Do(func(record *moira.AuditRecord) {
+			So(record.Action, ShouldEqual, moira.AuditActionRemoveThrottling)
+			So(record.User, ShouldEqual, "user")
+		}).Return(nil)
+		err := DeleteTriggerThrottling(dataBase, triggerID, "user")
 		So(err, ShouldBeNil)
 	})
 
 	Convey("Error", t, func() {
 		expected := fmt.Errorf("oooops! Error delete")
+		dataBase.EXPECT().GetTriggerThrottling(triggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
 		dataBase.EXPECT().DeleteTriggerThrottling(triggerID).Return(expected)
-		err := DeleteTriggerThrottling(dataBase, triggerID)
+		err := DeleteTriggerThrottling(dataBase, triggerID, "user")
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 	})
 }
@@ -418,6 +446,9 @@ func TestSetTriggerMaintenance(t *testing.T) {
 
 	Convey("Success setting metrics maintenance only", t, func() {
 		dataBase.EXPECT().SetTriggerCheckMaintenance(triggerID, triggerMaintenance.Metrics, triggerMaintenance.Trigger, "", int64(0)).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Do(func(record *moira.AuditRecord) {
+			So(record.Action, ShouldEqual, moira.AuditActionMaintenance)
+		}).Return(nil)
 		err := SetTriggerMaintenance(dataBase, triggerID, triggerMaintenance, "", 0)
 		So(err, ShouldBeNil)
 	})
@@ -426,6 +457,9 @@ func TestSetTriggerMaintenance(t *testing.T) {
 		triggerMaintenance.Trigger = &maintenanceTS
 		triggerMaintenance.Metrics = dto.MetricsMaintenance{}
 		dataBase.EXPECT().SetTriggerCheckMaintenance(triggerID, triggerMaintenance.Metrics, triggerMaintenance.Trigger, "", int64(0)).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Do(func(record *moira.AuditRecord) {
+			So(record.Action, ShouldEqual, moira.AuditActionMaintenance)
+		}).Return(nil)
 		err := SetTriggerMaintenance(dataBase, triggerID, triggerMaintenance, "", 0)
 		So(err, ShouldBeNil)
 	})
@@ -434,6 +468,9 @@ func TestSetTriggerMaintenance(t *testing.T) {
 		triggerMaintenance.Trigger = &maintenanceTS
 		triggerMaintenance.Metrics = metricsMaintenance
 		dataBase.EXPECT().SetTriggerCheckMaintenance(triggerID, triggerMaintenance.Metrics, triggerMaintenance.Trigger, "", int64(0)).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Do(func(record *moira.AuditRecord) {
+			So(record.Action, ShouldEqual, moira.AuditActionMaintenance)
+		}).Return(nil)
 		err := SetTriggerMaintenance(dataBase, triggerID, triggerMaintenance, "", 0)
 		So(err, ShouldBeNil)
 	})
@@ -445,3 +482,50 @@ func TestSetTriggerMaintenance(t *testing.T) {
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 	})
 }
+
+func TestAcknowledgeTrigger(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	triggerID := uuid.Must(uuid.NewV4()).String()
+
+	Convey("Success acknowledging metrics", t, func() {
+		triggerAck := dto.TriggerAcknowledgement{Metrics: []string{"Metric1"}, Expiry: 12400}
+		dataBase.EXPECT().SetTriggerCheckAcknowledgement(triggerID, triggerAck.Metrics, &moira.Acknowledgement{User: "user", Timestamp: 12345, Expiry: 12400}).Return(nil)
+		err := AcknowledgeTrigger(dataBase, triggerID, triggerAck, "user", 12345)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Success acknowledging whole trigger", t, func() {
+		dataBase.EXPECT().SetTriggerCheckAcknowledgement(triggerID, nil, &moira.Acknowledgement{User: "user", Timestamp: 12345}).Return(nil)
+		err := AcknowledgeTrigger(dataBase, triggerID, dto.TriggerAcknowledgement{}, "user", 12345)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Error", t, func() {
+		expected := fmt.Errorf("oooops! Error set")
+		dataBase.EXPECT().SetTriggerCheckAcknowledgement(triggerID, nil, gomock.Any()).Return(expected)
+		err := AcknowledgeTrigger(dataBase, triggerID, dto.TriggerAcknowledgement{}, "user", 12345)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+	})
+}
+
+func TestRemoveTriggerAcknowledgement(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	triggerID := uuid.Must(uuid.NewV4()).String()
+
+	Convey("Success", t, func() {
+		dataBase.EXPECT().SetTriggerCheckAcknowledgement(triggerID, nil, nil).Return(nil)
+		err := RemoveTriggerAcknowledgement(dataBase, triggerID)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Error", t, func() {
+		expected := fmt.Errorf("oooops! Error set")
+		dataBase.EXPECT().SetTriggerCheckAcknowledgement(triggerID, nil, nil).Return(expected)
+		err := RemoveTriggerAcknowledgement(dataBase, triggerID)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+	})
+}
@@ -15,7 +15,7 @@ import (
 const pageSizeUnlimited int64 = -1
 
 // CreateTrigger creates new trigger
-func CreateTrigger(dataBase moira.Database, trigger *dto.TriggerModel, timeSeriesNames map[string]bool) (*dto.SaveTriggerResponse, *api.ErrorResponse) {
+func CreateTrigger(dataBase moira.Database, trigger *dto.TriggerModel, timeSeriesNames map[string]bool, userLogin string) (*dto.SaveTriggerResponse, *api.ErrorResponse) {
 	if trigger.ID == "" {
 		uuid4, err := uuid.NewV4()
 		if err != nil {
@@ -31,11 +31,16 @@ func CreateTrigger(dataBase moira.Database, trigger *dto.TriggerModel, timeSerie
 			return nil, api.ErrorInvalidRequest(fmt.Errorf("trigger with this ID already exists"))
 		}
 	}
-	resp, err := saveTrigger(dataBase, trigger.ToMoiraTrigger(), trigger.ID, timeSeriesNames)
-	if resp != nil {
-		resp.Message = "trigger created"
+	created := trigger.ToMoiraTrigger()
+	resp, err := saveTrigger(dataBase, created, trigger.ID, timeSeriesNames)
+	if err != nil {
+		return nil, err
+	}
+	if err = saveAuditRecord(dataBase, userLogin, moira.AuditEntityTrigger, trigger.ID, moira.AuditActionCreate, nil, created); err != nil {
+		return nil, err
 	}
-	return resp, err
+	resp.Message = "trigger created"
+	return resp, nil
 }
 
 // GetAllTriggers gets all moira triggers
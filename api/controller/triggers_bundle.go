@@ -0,0 +1,250 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+)
+
+// ExportTriggers gets triggers marked with all given tags, all triggers are exported if no tags given
+func ExportTriggers(database moira.Database, tags []string) (*dto.TriggersBundle, *api.ErrorResponse) {
+	triggerIDs, err := getTaggedTriggerIDs(database, tags)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	triggers, err := database.GetTriggers(triggerIDs)
+	if err != nil {
+		return nil, api.ErrorInternalServer(err)
+	}
+	bundle := &dto.TriggersBundle{
+		Triggers: make([]dto.TriggerModel, 0, len(triggers)),
+	}
+	for _, trigger := range triggers {
+		if trigger != nil {
+			bundle.Triggers = append(bundle.Triggers, dto.CreateTriggerModel(trigger))
+		}
+	}
+	return bundle, nil
+}
+
+// ImportTriggers creates new and updates existing bundle triggers. Triggers should be validated before import,
+// timeSeriesNames are time series of every bundle trigger got on validation. Dependencies of the whole bundle
+// are checked before anything is saved, also on dry run.
+// If one of triggers can not be saved, already saved triggers and their last checks are reverted to their previous versions.
+// If dryRun is true, nothing is saved, only changes which would be made are returned
+func ImportTriggers(dataBase moira.Database, bundle *dto.TriggersBundle, timeSeriesNames []map[string]bool, dryRun bool, userLogin string) (*dto.TriggersImportResult, *api.ErrorResponse) {
+	imports, err := planTriggersImport(dataBase, bundle)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkBundleDependencies(dataBase, imports); err != nil {
+		return nil, err
+	}
+	result := &dto.TriggersImportResult{
+		DryRun:   dryRun,
+		Triggers: make([]dto.TriggerImportResult, 0, len(imports)),
+	}
+	for _, triggerImport := range imports {
+		result.Triggers = append(result.Triggers, triggerImport.result)
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	applied := make([]*triggerImport, 0, len(imports))
+	for i, triggerImport := range imports {
+		if triggerImport.result.Action == dto.TriggerImportUnchanged {
+			continue
+		}
+		if triggerImport.result.ID == "" {
+			uuid4, err := uuid.NewV4()
+			if err != nil {
+				revertTriggersImport(dataBase, applied)
+				return nil, api.ErrorInternalServer(err)
+			}
+			triggerImport.result.ID = uuid4.String()
+			triggerImport.trigger.ID = triggerImport.result.ID
+			result.Triggers[i].ID = triggerImport.result.ID
+		}
+		if triggerImport.existing != nil {
+			lastCheck, err := dataBase.GetTriggerLastCheck(triggerImport.result.ID)
+			if err != nil && err != database.ErrNil {
+				revertTriggersImport(dataBase, applied)
+				return nil, api.ErrorInternalServer(err)
+			}
+			if err == nil {
+				triggerImport.existingLastCheck = &lastCheck
+			}
+		}
+		if _, err := writeTrigger(dataBase, triggerImport.trigger, triggerImport.result.ID, timeSeriesNames[i]); err != nil {
+			revertTriggersImport(dataBase, applied)
+			return nil, err
+		}
+		applied = append(applied, triggerImport)
+	}
+
+	for _, triggerImport := range applied {
+		action := moira.AuditActionCreate
+		if triggerImport.existing != nil {
+			action = moira.AuditActionUpdate
+		}
+		if err := saveAuditRecord(dataBase, userLogin, moira.AuditEntityTrigger, triggerImport.result.ID, action, triggerImport.existing, triggerImport.trigger); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+type triggerImport struct {
+	trigger           *moira.Trigger
+	existing          *moira.Trigger
+	existingLastCheck *moira.CheckData
+	result            dto.TriggerImportResult
+}
+
+// planTriggersImport compares bundle triggers with existing ones and determines action made with every trigger
+func planTriggersImport(dataBase moira.Database, bundle *dto.TriggersBundle) ([]*triggerImport, *api.ErrorResponse) {
+	imports := make([]*triggerImport, 0, len(bundle.Triggers))
+	triggerIDs := make(map[string]bool, len(bundle.Triggers))
+	for i := range bundle.Triggers {
+		model := bundle.Triggers[i]
+		if model.ID != "" {
+			if triggerIDs[model.ID] {
+				return nil, api.ErrorInvalidRequest(fmt.Errorf("trigger with ID = '%s' is found in bundle more than once", model.ID))
+			}
+			triggerIDs[model.ID] = true
+		}
+		triggerImport := &triggerImport{
+			trigger: model.ToMoiraTrigger(),
+			result: dto.TriggerImportResult{
+				ID:     model.ID,
+				Name:   model.Name,
+				Action: dto.TriggerImportCreate,
+			},
+		}
+		var before interface{}
+		if model.ID != "" {
+			existing, err := getExistingTrigger(dataBase, model.ID)
+			if err != nil {
+				return nil, api.ErrorInternalServer(err)
+			}
+			if existing != nil {
+				existingModel := dto.CreateTriggerModel(existing)
+				before = &existingModel
+				triggerImport.existing = existing
+				triggerImport.result.Action = dto.TriggerImportUpdate
+			}
+		}
+		diff, err := moira.GetEntitiesDiff(before, &model)
+		if err != nil {
+			return nil, api.ErrorInternalServer(err)
+		}
+		if triggerImport.existing != nil && len(diff) == 0 {
+			triggerImport.result.Action = dto.TriggerImportUnchanged
+		}
+		triggerImport.result.Diff = diff
+		imports = append(imports, triggerImport)
+	}
+	return imports, nil
+}
+
+// checkBundleDependencies checks that no bundle trigger becomes its own ancestor. Bundle versions of triggers
+// are used instead of stored ones, so cycles made by several bundle triggers are found before anything is saved
+func checkBundleDependencies(dataBase moira.Database, imports []*triggerImport) *api.ErrorResponse {
+	dependencies := make(map[string][]string, len(imports))
+	for _, triggerImport := range imports {
+		if triggerImport.trigger.ID != "" {
+			dependencies[triggerImport.trigger.ID] = triggerImport.trigger.Dependencies
+		}
+	}
+	getDependencies := func(triggerID string) ([]string, error) {
+		if triggerDependencies, ok := dependencies[triggerID]; ok {
+			return triggerDependencies, nil
+		}
+		trigger, err := dataBase.GetTrigger(triggerID)
+		if err != nil && err != database.ErrNil {
+			return nil, err
+		}
+		dependencies[triggerID] = trigger.Dependencies
+		return trigger.Dependencies, nil
+	}
+
+	for _, triggerImport := range imports {
+		triggerID := triggerImport.trigger.ID
+		if triggerID == "" {
+			continue
+		}
+		visited := make(map[string]bool)
+		queue := append(make([]string, 0, len(triggerImport.trigger.Dependencies)), triggerImport.trigger.Dependencies...)
+		for len(queue) > 0 {
+			ancestorID := queue[0]
+			queue = queue[1:]
+			if ancestorID == triggerID {
+				return api.ErrorInvalidRequest(fmt.Errorf("trigger dependencies contain a cycle: trigger %s depends on itself", triggerID))
+			}
+			if visited[ancestorID] {
+				continue
+			}
+			visited[ancestorID] = true
+			ancestorDependencies, err := getDependencies(ancestorID)
+			if err != nil {
+				return api.ErrorInternalServer(err)
+			}
+			queue = append(queue, ancestorDependencies...)
+		}
+	}
+	return nil
+}
+
+// revertTriggersImport removes created and restores previous versions of updated triggers and their last checks
+func revertTriggersImport(dataBase moira.Database, applied []*triggerImport) {
+	for _, triggerImport := range applied {
+		if triggerImport.existing == nil {
+			dataBase.RemoveTrigger(triggerImport.result.ID)
+			dataBase.RemoveTriggerLastCheck(triggerImport.result.ID)
+			continue
+		}
+		dataBase.SaveTrigger(triggerImport.result.ID, triggerImport.existing)
+		if triggerImport.existingLastCheck != nil {
+			dataBase.SetTriggerLastCheck(triggerImport.result.ID, triggerImport.existingLastCheck, triggerImport.existing.ClusterKey())
+		} else {
+			dataBase.RemoveTriggerLastCheck(triggerImport.result.ID)
+		}
+	}
+}
+
+// getTaggedTriggerIDs returns sorted IDs of triggers marked with all given tags or all trigger IDs if tags are empty
+func getTaggedTriggerIDs(database moira.Database, tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		triggerIDs, err := database.GetAllTriggerIDs()
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(triggerIDs)
+		return triggerIDs, nil
+	}
+	tagsCount := make(map[string]int)
+	for _, tag := range tags {
+		tagTriggerIDs, err := database.GetTagTriggerIDs(tag)
+		if err != nil {
+			return nil, err
+		}
+		for _, triggerID := range tagTriggerIDs {
+			tagsCount[triggerID]++
+		}
+	}
+	triggerIDs := make([]string, 0, len(tagsCount))
+	for triggerID, count := range tagsCount {
+		if count == len(tags) {
+			triggerIDs = append(triggerIDs, triggerID)
+		}
+	}
+	sort.Strings(triggerIDs)
+	return triggerIDs, nil
+}
@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/database"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExportTriggers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	first := moira.Trigger{ID: "first", Name: "first", Tags: []string{"a", "b"}}
+
+	Convey("Export all triggers", t, func() {
+		dataBase.EXPECT().GetAllTriggerIDs().Return([]string{"second", "first"}, nil)
+		dataBase.EXPECT().GetTriggers([]string{"first", "second"}).Return([]*moira.Trigger{&first, nil}, nil)
+		bundle, err := ExportTriggers(dataBase, nil)
+		So(err, ShouldBeNil)
+		So(bundle, ShouldResemble, &dto.TriggersBundle{Triggers: []dto.TriggerModel{dto.CreateTriggerModel(&first)}})
+	})
+
+	Convey("Export triggers marked with all tags", t, func() {
+		dataBase.EXPECT().GetTagTriggerIDs("a").Return([]string{"first", "second"}, nil)
+		dataBase.EXPECT().GetTagTriggerIDs("b").Return([]string{"first"}, nil)
+		dataBase.EXPECT().GetTriggers([]string{"first"}).Return([]*moira.Trigger{&first}, nil)
+		bundle, err := ExportTriggers(dataBase, []string{"a", "b"})
+		So(err, ShouldBeNil)
+		So(bundle, ShouldResemble, &dto.TriggersBundle{Triggers: []dto.TriggerModel{dto.CreateTriggerModel(&first)}})
+	})
+
+	Convey("Error get tag triggers", t, func() {
+		expected := fmt.Errorf("oooops! Can not get tag triggers")
+		dataBase.EXPECT().GetTagTriggerIDs("a").Return(nil, expected)
+		bundle, err := ExportTriggers(dataBase, []string{"a"})
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(bundle, ShouldBeNil)
+	})
+}
+
+func TestImportTriggers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	userLogin := "user"
+	existing := moira.Trigger{ID: "existing", Name: "existing", Targets: []string{"my.metric"}, Tags: []string{"tag"}, Expression: new(string)}
+	existingModel := dto.CreateTriggerModel(&existing)
+	updatedModel := dto.CreateTriggerModel(&existing)
+	updatedModel.Name = "updated"
+	newModel := dto.TriggerModel{ID: "new", Name: "new", Targets: []string{"my.metric"}, Tags: []string{"tag"}}
+	timeSeriesNames := []map[string]bool{{}, {}}
+
+	Convey("Dry run returns changes without saving", t, func() {
+		bundle := &dto.TriggersBundle{Triggers: []dto.TriggerModel{updatedModel, newModel}}
+		dataBase.EXPECT().GetTrigger("existing").Return(existing, nil)
+		dataBase.EXPECT().GetTrigger("new").Return(moira.Trigger{}, database.ErrNil)
+		result, err := ImportTriggers(dataBase, bundle, timeSeriesNames, true, userLogin)
+		So(err, ShouldBeNil)
+		So(result.DryRun, ShouldBeTrue)
+		So(result.Triggers, ShouldHaveLength, 2)
+		So(result.Triggers[0].Action, ShouldEqual, dto.TriggerImportUpdate)
+		So(result.Triggers[0].Diff, ShouldHaveLength, 1)
+		So(result.Triggers[0].Diff, ShouldContainKey, "name")
+		So(result.Triggers[1].Action, ShouldEqual, dto.TriggerImportCreate)
+	})
+
+	Convey("Unchanged trigger is not saved", t, func() {
+		bundle := &dto.TriggersBundle{Triggers: []dto.TriggerModel{existingModel}}
+		dataBase.EXPECT().GetTrigger("existing").Return(existing, nil)
+		result, err := ImportTriggers(dataBase, bundle, timeSeriesNames[:1], false, userLogin)
+		So(err, ShouldBeNil)
+		So(result.Triggers[0].Action, ShouldEqual, dto.TriggerImportUnchanged)
+		So(result.Triggers[0].Diff, ShouldBeEmpty)
+	})
+
+	Convey("Create trigger without ID", t, func() {
+		model := newModel
+		model.ID = ""
+		bundle := &dto.TriggersBundle{Triggers: []dto.TriggerModel{model}}
+		dataBase.EXPECT().AcquireTriggerCheckLock(gomock.Any(), 10)
+		dataBase.EXPECT().DeleteTriggerCheckLock(gomock.Any())
+		dataBase.EXPECT().GetTriggerLastCheck(gomock.Any()).Return(moira.CheckData{}, database.ErrNil)
+		dataBase.EXPECT().SetTriggerLastCheck(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		dataBase.EXPECT().SaveTrigger(gomock.Any(), gomock.Any()).Return(nil)
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		result, err := ImportTriggers(dataBase, bundle, timeSeriesNames[:1], false, userLogin)
+		So(err, ShouldBeNil)
+		So(result.Triggers[0].Action, ShouldEqual, dto.TriggerImportCreate)
+		So(result.Triggers[0].ID, ShouldNotBeEmpty)
+	})
+
+	Convey("Saved triggers and last checks are reverted on error", t, func() {
+		bundle := &dto.TriggersBundle{Triggers: []dto.TriggerModel{updatedModel, newModel}}
+		expected := fmt.Errorf("oooops! Can not save trigger")
+		existingLastCheck := moira.CheckData{Score: 10, Metrics: map[string]moira.MetricState{}}
+		dataBase.EXPECT().GetTrigger("existing").Return(existing, nil)
+		dataBase.EXPECT().GetTrigger("new").Return(moira.Trigger{}, database.ErrNil)
+		dataBase.EXPECT().AcquireTriggerCheckLock(gomock.Any(), 10).Times(2)
+		dataBase.EXPECT().DeleteTriggerCheckLock(gomock.Any()).Times(2)
+		dataBase.EXPECT().GetTriggerLastCheck("existing").Return(existingLastCheck, nil).Times(2)
+		dataBase.EXPECT().GetTriggerLastCheck("new").Return(moira.CheckData{}, database.ErrNil)
+		dataBase.EXPECT().SetTriggerLastCheck(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		dataBase.EXPECT().SaveTrigger("existing", updatedModel.ToMoiraTrigger()).Return(nil)
+		dataBase.EXPECT().SaveTrigger("new", newModel.ToMoiraTrigger()).Return(expected)
+		dataBase.EXPECT().SaveTrigger("existing", &existing).Return(nil)
+		dataBase.EXPECT().SetTriggerLastCheck("existing", &existingLastCheck, existing.ClusterKey()).Return(nil)
+		result, err := ImportTriggers(dataBase, bundle, timeSeriesNames, false, userLogin)
+		So(err, ShouldResemble, api.ErrorInternalServer(expected))
+		So(result, ShouldBeNil)
+	})
+
+	Convey("Dependency cycle within bundle is rejected before saving", t, func() {
+		first := newModel
+		first.ID = "first"
+		first.Dependencies = []string{"second"}
+		second := newModel
+		second.ID = "second"
+		second.Dependencies = []string{"first"}
+		bundle := &dto.TriggersBundle{Triggers: []dto.TriggerModel{first, second}}
+		dataBase.EXPECT().GetTrigger("first").Return(moira.Trigger{}, database.ErrNil)
+		dataBase.EXPECT().GetTrigger("second").Return(moira.Trigger{}, database.ErrNil)
+		result, err := ImportTriggers(dataBase, bundle, timeSeriesNames, true, userLogin)
+		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("trigger dependencies contain a cycle: trigger first depends on itself")))
+		So(result, ShouldBeNil)
+	})
+
+	Convey("Duplicated trigger ID", t, func() {
+		bundle := &dto.TriggersBundle{Triggers: []dto.TriggerModel{newModel, newModel}}
+		dataBase.EXPECT().GetTrigger("new").Return(moira.Trigger{}, database.ErrNil)
+		result, err := ImportTriggers(dataBase, bundle, timeSeriesNames, false, userLogin)
+		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("trigger with ID = 'new' is found in bundle more than once")))
+		So(result, ShouldBeNil)
+	})
+}
@@ -26,7 +26,8 @@ func TestCreateTrigger(t *testing.T) {
 		dataBase.EXPECT().GetTriggerLastCheck(gomock.Any()).Return(moira.CheckData{}, database.ErrNil)
 		dataBase.EXPECT().SetTriggerLastCheck(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		dataBase.EXPECT().SaveTrigger(gomock.Any(), gomock.Any()).Return(nil)
-		resp, err := CreateTrigger(dataBase, &triggerModel, make(map[string]bool))
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		resp, err := CreateTrigger(dataBase, &triggerModel, make(map[string]bool), "user")
 		So(err, ShouldBeNil)
 		So(resp.Message, ShouldResemble, "trigger created")
 	})
@@ -40,7 +41,8 @@ func TestCreateTrigger(t *testing.T) {
 		dataBase.EXPECT().GetTriggerLastCheck(gomock.Any()).Return(moira.CheckData{}, database.ErrNil)
 		dataBase.EXPECT().SetTriggerLastCheck(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		dataBase.EXPECT().SaveTrigger(gomock.Any(), triggerModel.ToMoiraTrigger()).Return(nil)
-		resp, err := CreateTrigger(dataBase, &triggerModel, make(map[string]bool))
+		dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+		resp, err := CreateTrigger(dataBase, &triggerModel, make(map[string]bool), "user")
 		So(err, ShouldBeNil)
 		So(resp.Message, ShouldResemble, "trigger created")
 		So(resp.ID, ShouldResemble, triggerID)
@@ -50,7 +52,7 @@ func TestCreateTrigger(t *testing.T) {
 		triggerModel := dto.TriggerModel{ID: uuid.Must(uuid.NewV4()).String()}
 		trigger := triggerModel.ToMoiraTrigger()
 		dataBase.EXPECT().GetTrigger(triggerModel.ID).Return(*trigger, nil)
-		resp, err := CreateTrigger(dataBase, &triggerModel, make(map[string]bool))
+		resp, err := CreateTrigger(dataBase, &triggerModel, make(map[string]bool), "user")
 		So(err, ShouldResemble, api.ErrorInvalidRequest(fmt.Errorf("trigger with this ID already exists")))
 		So(resp, ShouldBeNil)
 	})
@@ -59,7 +61,7 @@ func TestCreateTrigger(t *testing.T) {
 		trigger := dto.TriggerModel{ID: uuid.Must(uuid.NewV4()).String()}
 		expected := fmt.Errorf("soo bad trigger")
 		dataBase.EXPECT().GetTrigger(trigger.ID).Return(moira.Trigger{}, expected)
-		resp, err := CreateTrigger(dataBase, &trigger, make(map[string]bool))
+		resp, err := CreateTrigger(dataBase, &trigger, make(map[string]bool), "user")
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 		So(resp, ShouldBeNil)
 	})
@@ -73,7 +75,7 @@ func TestCreateTrigger(t *testing.T) {
 		dataBase.EXPECT().GetTriggerLastCheck(gomock.Any()).Return(moira.CheckData{}, database.ErrNil)
 		dataBase.EXPECT().SetTriggerLastCheck(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		dataBase.EXPECT().SaveTrigger(gomock.Any(), triggerModel.ToMoiraTrigger()).Return(expected)
-		resp, err := CreateTrigger(dataBase, &triggerModel, make(map[string]bool))
+		resp, err := CreateTrigger(dataBase, &triggerModel, make(map[string]bool), "user")
 		So(err, ShouldResemble, api.ErrorInternalServer(expected))
 		So(resp, ShouldBeNil)
 	})
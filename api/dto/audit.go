@@ -0,0 +1,19 @@
+// nolint
+package dto
+
+import (
+	"net/http"
+
+	"github.com/moira-alert/moira"
+)
+
+type AuditRecordsList struct {
+	Page  int64               `json:"page"`
+	Size  int64               `json:"size"`
+	Total int64               `json:"total"`
+	List  []moira.AuditRecord `json:"list"`
+}
+
+func (*AuditRecordsList) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/templating"
 )
 
 type ContactList struct {
@@ -17,10 +18,12 @@ func (*ContactList) Render(w http.ResponseWriter, r *http.Request) error {
 }
 
 type Contact struct {
-	Type  string `json:"type"`
-	Value string `json:"value"`
-	ID    string `json:"id,omitempty"`
-	User  string `json:"user,omitempty"`
+	Type            string `json:"type"`
+	Value           string `json:"value"`
+	ID              string `json:"id,omitempty"`
+	User            string `json:"user,omitempty"`
+	DigestInterval  int64  `json:"digest_interval,omitempty"`
+	MessageTemplate string `json:"message_template,omitempty"`
 }
 
 func (*Contact) Render(w http.ResponseWriter, r *http.Request) error {
@@ -34,5 +37,13 @@ func (contact *Contact) Bind(r *http.Request) error {
 	if contact.Value == "" {
 		return fmt.Errorf("contact value of type %s can not be empty", contact.Type)
 	}
+	if contact.DigestInterval < 0 {
+		return fmt.Errorf("contact digest interval can not be negative")
+	}
+	if contact.MessageTemplate != "" {
+		if _, err := templating.Parse("contact", contact.MessageTemplate); err != nil {
+			return fmt.Errorf("contact message template is invalid: %s", err.Error())
+		}
+	}
 	return nil
 }
@@ -23,3 +23,31 @@ type NotificationDeleteResponse struct {
 func (*NotificationDeleteResponse) Render(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
+
+type FailedNotificationsList struct {
+	Total int64                       `json:"total"`
+	List  []*moira.FailedNotification `json:"list"`
+}
+
+func (*FailedNotificationsList) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type FailedNotificationsRetryResponse struct {
+	Result int64 `json:"result"`
+}
+
+func (*FailedNotificationsRetryResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type DeliveriesList struct {
+	Page  int64                         `json:"page"`
+	Size  int64                         `json:"size"`
+	Total int64                         `json:"total"`
+	List  []*moira.NotificationDelivery `json:"list"`
+}
+
+func (*DeliveriesList) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
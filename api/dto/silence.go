@@ -0,0 +1,44 @@
+// nolint
+package dto
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/moira-alert/moira"
+)
+
+type SilencesList struct {
+	List []*moira.Silence `json:"list"`
+}
+
+func (*SilencesList) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type Silence moira.Silence
+
+func (*Silence) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (silence *Silence) Bind(request *http.Request) error {
+	silence.Tags = normalizeTags(silence.Tags)
+	if len(silence.Tags) == 0 && silence.Metric == "" {
+		return fmt.Errorf("silence must have tags or metric pattern")
+	}
+	if silence.Metric != "" {
+		if _, err := path.Match(silence.Metric, ""); err != nil {
+			return fmt.Errorf("invalid metric pattern '%s': %s", silence.Metric, err.Error())
+		}
+	}
+	if silence.StartTime == 0 {
+		silence.StartTime = time.Now().Unix()
+	}
+	if silence.EndTime <= silence.StartTime {
+		return fmt.Errorf("silence end time must be greater than start time")
+	}
+	return nil
+}
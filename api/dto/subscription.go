@@ -8,6 +8,7 @@ import (
 
 	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/api/middleware"
+	"github.com/moira-alert/moira/database"
 )
 
 // ErrProvidedContactsForbidden used when user try to save subscription with another users contacts
@@ -55,30 +56,96 @@ func (subscription *Subscription) Bind(request *http.Request) error {
 	if len(subscription.Contacts) == 0 {
 		return fmt.Errorf("subscription must have contacts")
 	}
-	return subscription.checkContacts(request)
+	if err := validateEscalations(subscription.Escalations); err != nil {
+		return err
+	}
+	if err := moira.ValidateThrottlingLevels(subscription.ThrottlingLevels); err != nil {
+		return err
+	}
+	return checkContacts(request, subscription.TeamID, append(getEscalationsContacts(subscription.Escalations), subscription.Contacts...))
+}
+
+type SubscriptionEscalations struct {
+	Escalations []moira.EscalationData `json:"escalations"`
+}
+
+func (*SubscriptionEscalations) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
 }
 
-func (subscription *Subscription) checkContacts(request *http.Request) error {
-	database := middleware.GetDatabase(request)
-	userLogin := middleware.GetLogin(request)
-	contactIDs, err := database.GetUserContactIDs(userLogin)
+func (escalations *SubscriptionEscalations) Bind(request *http.Request) error {
+	if escalations.Escalations == nil {
+		escalations.Escalations = make([]moira.EscalationData, 0)
+	}
+	if err := validateEscalations(escalations.Escalations); err != nil {
+		return err
+	}
+	subscription, err := middleware.GetDatabase(request).GetSubscription(middleware.GetSubscriptionID(request))
 	if err != nil {
 		return err
 	}
+	return checkContacts(request, subscription.TeamID, getEscalationsContacts(escalations.Escalations))
+}
+
+// validateEscalations checks that every escalation has contacts and escalations offsets are increasing
+func validateEscalations(escalations []moira.EscalationData) error {
+	var previousOffset int64
+	for i, escalation := range escalations {
+		if len(escalation.Contacts) == 0 {
+			return fmt.Errorf("escalation #%d must have contacts", i+1)
+		}
+		if escalation.OffsetInMinutes <= previousOffset {
+			return fmt.Errorf("escalation #%d offset must be greater than %d minutes", i+1, previousOffset)
+		}
+		previousOffset = escalation.OffsetInMinutes
+	}
+	return nil
+}
+
+func getEscalationsContacts(escalations []moira.EscalationData) []string {
+	contactIDs := make([]string, 0)
+	for _, escalation := range escalations {
+		contactIDs = append(contactIDs, escalation.Contacts...)
+	}
+	return contactIDs
+}
+
+// checkContacts checks that all given contacts belong to current user, contacts of team subscription can belong to any team member
+func checkContacts(request *http.Request, teamID string, contacts []string) error {
+	dataBase := middleware.GetDatabase(request)
+	logins := []string{middleware.GetLogin(request)}
+	if teamID != "" {
+		team, err := dataBase.GetTeam(teamID)
+		if err == database.ErrNil {
+			return fmt.Errorf("team with ID '%s' does not exists", teamID)
+		}
+		if err != nil {
+			return err
+		}
+		for login := range team.Members {
+			logins = append(logins, login)
+		}
+	}
 
 	userContactIdsHash := make(map[string]interface{})
-	for _, contactId := range contactIDs {
-		userContactIdsHash[contactId] = true
+	for _, login := range logins {
+		contactIDs, err := dataBase.GetUserContactIDs(login)
+		if err != nil {
+			return err
+		}
+		for _, contactId := range contactIDs {
+			userContactIdsHash[contactId] = true
+		}
 	}
 
 	anotherUserContactIds := make([]string, 0)
-	for _, subContactId := range subscription.Contacts {
+	for _, subContactId := range contacts {
 		if _, ok := userContactIdsHash[subContactId]; !ok {
 			anotherUserContactIds = append(anotherUserContactIds, subContactId)
 		}
 	}
 	if len(anotherUserContactIds) > 0 {
-		contacts, err := database.GetContacts(anotherUserContactIds)
+		contacts, err := dataBase.GetContacts(anotherUserContactIds)
 		if err != nil {
 			return ErrProvidedContactsForbidden{contactIds: anotherUserContactIds}
 		}
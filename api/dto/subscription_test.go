@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/moira-alert/moira"
+)
+
+func TestValidateEscalations(t *testing.T) {
+	Convey("Valid escalations", t, func() {
+		escalations := []moira.EscalationData{
+			{Contacts: []string{"first"}, OffsetInMinutes: 10},
+			{Contacts: []string{"second", "third"}, OffsetInMinutes: 30},
+		}
+		So(validateEscalations(escalations), ShouldBeNil)
+		So(validateEscalations(nil), ShouldBeNil)
+		So(getEscalationsContacts(escalations), ShouldResemble, []string{"first", "second", "third"})
+	})
+
+	Convey("Escalation without contacts", t, func() {
+		escalations := []moira.EscalationData{{OffsetInMinutes: 10}}
+		So(validateEscalations(escalations), ShouldResemble, fmt.Errorf("escalation #1 must have contacts"))
+	})
+
+	Convey("Escalation without offset", t, func() {
+		escalations := []moira.EscalationData{{Contacts: []string{"first"}}}
+		So(validateEscalations(escalations), ShouldResemble, fmt.Errorf("escalation #1 offset must be greater than 0 minutes"))
+	})
+
+	Convey("Escalations offsets are not increasing", t, func() {
+		escalations := []moira.EscalationData{
+			{Contacts: []string{"first"}, OffsetInMinutes: 30},
+			{Contacts: []string{"second"}, OffsetInMinutes: 10},
+		}
+		So(validateEscalations(escalations), ShouldResemble, fmt.Errorf("escalation #2 offset must be greater than 30 minutes"))
+	})
+}
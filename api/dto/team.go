@@ -0,0 +1,41 @@
+// nolint
+package dto
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/moira-alert/moira"
+)
+
+type TeamsList struct {
+	List []moira.Team `json:"list"`
+}
+
+func (*TeamsList) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type Team moira.Team
+
+func (*Team) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (team *Team) Bind(request *http.Request) error {
+	if team.Name == "" {
+		return fmt.Errorf("team name can not be empty")
+	}
+	if team.Members == nil {
+		team.Members = make(map[string]moira.TeamRole)
+	}
+	for login, role := range team.Members {
+		if login == "" {
+			return fmt.Errorf("team member login can not be empty")
+		}
+		if !role.IsValid() {
+			return fmt.Errorf("invalid role '%s' of team member %s", role, login)
+		}
+	}
+	return nil
+}
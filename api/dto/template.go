@@ -0,0 +1,36 @@
+// nolint
+package dto
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/templating"
+)
+
+type TemplatePreviewRequest struct {
+	Template  string                    `json:"template"`
+	Contact   moira.ContactData         `json:"contact"`
+	Trigger   *moira.TriggerData        `json:"trigger,omitempty"`
+	Events    []moira.NotificationEvent `json:"events,omitempty"`
+	Throttled bool                      `json:"throttled"`
+}
+
+func (preview *TemplatePreviewRequest) Bind(r *http.Request) error {
+	if preview.Template == "" {
+		return fmt.Errorf("template can not be empty")
+	}
+	if _, err := templating.Parse("preview", preview.Template); err != nil {
+		return fmt.Errorf("template is invalid: %s", err.Error())
+	}
+	return nil
+}
+
+type TemplatePreview struct {
+	Message string `json:"message"`
+}
+
+func (*TemplatePreview) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
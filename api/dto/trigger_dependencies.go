@@ -0,0 +1,22 @@
+// nolint
+package dto
+
+import "net/http"
+
+// TriggerDependencyNode is a trigger in dependency graph, name is empty if trigger does not exist
+type TriggerDependencyNode struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Dependencies []string `json:"dependencies"`
+}
+
+// TriggerDependencies is a dependency graph of trigger which contains all its ancestors and descendants
+type TriggerDependencies struct {
+	TriggerID string                  `json:"trigger_id"`
+	Parents   []TriggerDependencyNode `json:"parents"`
+	Children  []TriggerDependencyNode `json:"children"`
+}
+
+func (*TriggerDependencies) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
@@ -13,6 +13,11 @@ import (
 	metricSource "github.com/moira-alert/moira/metric_source"
 )
 
+const (
+	maxAnomalySeasons            = 30
+	minAnomalySeasonLength int64 = 60
+)
+
 type TriggersList struct {
 	Page  *int64               `json:"page,omitempty"`
 	Size  *int64               `json:"size,omitempty"`
@@ -44,7 +49,13 @@ type TriggerModel struct {
 	WarnValue *float64 `json:"warn_value"`
 	// ERROR threshold
 	ErrorValue *float64 `json:"error_value"`
-	// Could be: rising, falling, expression
+	// Metric in WARN or ERROR state recovers from WARN only after its value crosses this threshold
+	WarnRecoveryValue *float64 `json:"warn_recovery_value,omitempty"`
+	// Metric in ERROR state recovers from ERROR only after its value crosses this threshold
+	ErrorRecoveryValue *float64 `json:"error_recovery_value,omitempty"`
+	// Minimum duration in seconds metric should stay in new state before event is sent
+	PendingInterval int64 `json:"pending_interval,omitempty"`
+	// Could be: rising, falling, expression, anomaly
 	TriggerType string `json:"trigger_type"`
 	// Set of tags to manipulate subscriptions
 	Tags []string `json:"tags"`
@@ -59,50 +70,82 @@ type TriggerModel struct {
 	// Graphite patterns for trigger
 	Patterns []string `json:"patterns"`
 	// Shows if trigger is remote (graphite-backend) based or stored inside Moira-Redis DB
+	//
+	// Deprecated: use TriggerSource field instead
 	IsRemote bool `json:"is_remote"`
+	// Shows the source of trigger metrics: graphite_local, graphite_remote or prometheus_remote
+	TriggerSource moira.TriggerSource `json:"trigger_source,omitempty"`
+	// Name of remote cluster to fetch trigger metrics from, default cluster is used if empty
+	ClusterID moira.ClusterID `json:"cluster_id,omitempty"`
 	// If true, first event NODATA → OK will be omitted
 	MuteNewMetrics bool `json:"mute_new_metrics"`
+	// Settings of baseline calculation used by anomaly triggers
+	Anomaly *moira.AnomalySettings `json:"anomaly,omitempty"`
+	// IDs of parent triggers, notifications about trigger are suppressed while any parent is in bad state
+	Dependencies []string `json:"dependencies,omitempty"`
+	// Intervals in seconds between reminders about trigger or metric staying in ERROR, NODATA, WARN or EXCEPTION state.
+	// Override intervals from checker config state by state, zero interval disables reminders about the state
+	Reminders map[moira.State]int64 `json:"reminders,omitempty"`
+	// ID of team owning trigger, only team editors and admins can change owned trigger
+	TeamID string `json:"team_id,omitempty"`
 }
 
 // ToMoiraTrigger transforms TriggerModel to moira.Trigger
 func (model *TriggerModel) ToMoiraTrigger() *moira.Trigger {
 	return &moira.Trigger{
-		ID:             model.ID,
-		Name:           model.Name,
-		Desc:           model.Desc,
-		Targets:        model.Targets,
-		WarnValue:      model.WarnValue,
-		ErrorValue:     model.ErrorValue,
-		TriggerType:    model.TriggerType,
-		Tags:           model.Tags,
-		TTLState:       model.TTLState,
-		TTL:            model.TTL,
-		Schedule:       model.Schedule,
-		Expression:     &model.Expression,
-		Patterns:       model.Patterns,
-		IsRemote:       model.IsRemote,
-		MuteNewMetrics: model.MuteNewMetrics,
+		ID:                 model.ID,
+		Name:               model.Name,
+		Desc:               model.Desc,
+		Targets:            model.Targets,
+		WarnValue:          model.WarnValue,
+		ErrorValue:         model.ErrorValue,
+		WarnRecoveryValue:  model.WarnRecoveryValue,
+		ErrorRecoveryValue: model.ErrorRecoveryValue,
+		PendingInterval:    model.PendingInterval,
+		TriggerType:        model.TriggerType,
+		Tags:               model.Tags,
+		TTLState:           model.TTLState,
+		TTL:                model.TTL,
+		Schedule:           model.Schedule,
+		Expression:         &model.Expression,
+		Patterns:           model.Patterns,
+		TriggerSource:      model.TriggerSource,
+		ClusterID:          model.ClusterID,
+		MuteNewMetrics:     model.MuteNewMetrics,
+		Anomaly:            model.Anomaly,
+		Dependencies:       model.Dependencies,
+		Reminders:          model.Reminders,
+		TeamID:             model.TeamID,
 	}
 }
 
 // CreateTriggerModel transforms moira.Trigger to TriggerModel
 func CreateTriggerModel(trigger *moira.Trigger) TriggerModel {
 	return TriggerModel{
-		ID:             trigger.ID,
-		Name:           trigger.Name,
-		Desc:           trigger.Desc,
-		Targets:        trigger.Targets,
-		WarnValue:      trigger.WarnValue,
-		ErrorValue:     trigger.ErrorValue,
-		TriggerType:    trigger.TriggerType,
-		Tags:           trigger.Tags,
-		TTLState:       trigger.TTLState,
-		TTL:            trigger.TTL,
-		Schedule:       trigger.Schedule,
-		Expression:     moira.UseString(trigger.Expression),
-		Patterns:       trigger.Patterns,
-		IsRemote:       trigger.IsRemote,
-		MuteNewMetrics: trigger.MuteNewMetrics,
+		ID:                 trigger.ID,
+		Name:               trigger.Name,
+		Desc:               trigger.Desc,
+		Targets:            trigger.Targets,
+		WarnValue:          trigger.WarnValue,
+		ErrorValue:         trigger.ErrorValue,
+		WarnRecoveryValue:  trigger.WarnRecoveryValue,
+		ErrorRecoveryValue: trigger.ErrorRecoveryValue,
+		PendingInterval:    trigger.PendingInterval,
+		TriggerType:        trigger.TriggerType,
+		Tags:               trigger.Tags,
+		TTLState:           trigger.TTLState,
+		TTL:                trigger.TTL,
+		Schedule:           trigger.Schedule,
+		Expression:         moira.UseString(trigger.Expression),
+		Patterns:           trigger.Patterns,
+		IsRemote:           trigger.TriggerSource == moira.GraphiteRemote,
+		TriggerSource:      trigger.TriggerSource,
+		ClusterID:          trigger.ClusterID,
+		MuteNewMetrics:     trigger.MuteNewMetrics,
+		Anomaly:            trigger.Anomaly,
+		Dependencies:       trigger.Dependencies,
+		Reminders:          trigger.Reminders,
+		TeamID:             trigger.TeamID,
 	}
 }
 
@@ -117,9 +160,29 @@ func (trigger *Trigger) Bind(request *http.Request) error {
 	if trigger.Name == "" {
 		return api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("trigger name is required")}
 	}
+	trigger.TriggerSource = trigger.TriggerSource.FillInIfNotSet(trigger.IsRemote)
+	if err := checkTriggerSource(trigger.TriggerSource, trigger.ClusterID); err != nil {
+		return api.ErrInvalidRequestContent{ValidationError: err}
+	}
+	trigger.IsRemote = trigger.TriggerSource == moira.GraphiteRemote
 	if err := checkWarnErrorExpression(trigger); err != nil {
 		return api.ErrInvalidRequestContent{ValidationError: err}
 	}
+	if err := checkRecoveryValues(trigger); err != nil {
+		return api.ErrInvalidRequestContent{ValidationError: err}
+	}
+	if err := checkAnomalySettings(trigger, middleware.GetLocalMetricsTTL(request)); err != nil {
+		return api.ErrInvalidRequestContent{ValidationError: err}
+	}
+	if err := checkDependencies(trigger); err != nil {
+		return api.ErrInvalidRequestContent{ValidationError: err}
+	}
+	if err := moira.ValidateReminders(trigger.Reminders); err != nil {
+		return api.ErrInvalidRequestContent{ValidationError: err}
+	}
+	if trigger.PendingInterval < 0 {
+		return api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("pending_interval can't be negative")}
+	}
 
 	triggerExpression := expression.TriggerExpression{
 		AdditionalTargetsValues: make(map[string]float64),
@@ -131,7 +194,7 @@ func (trigger *Trigger) Bind(request *http.Request) error {
 	}
 
 	metricsSourceProvider := middleware.GetTriggerTargetsSourceProvider(request)
-	metricsSource, err := metricsSourceProvider.GetMetricSource(trigger.IsRemote)
+	metricsSource, err := metricsSourceProvider.GetMetricSource(moira.MakeClusterKey(trigger.TriggerSource, trigger.ClusterID))
 	if err != nil {
 		return err
 	}
@@ -243,11 +306,121 @@ func checkWarnErrorExpression(trigger *Trigger) error {
 			return fmt.Errorf("can't use 'error_value' on trigger_type: '%v'", moira.ExpressionTrigger)
 		}
 
+	case moira.AnomalyTrigger:
+		if trigger.WarnValue != nil && *trigger.WarnValue <= 0 {
+			return fmt.Errorf("warn_value should be positive number of standard deviations for trigger_type: '%v'", moira.AnomalyTrigger)
+		}
+		if trigger.ErrorValue != nil && *trigger.ErrorValue <= 0 {
+			return fmt.Errorf("error_value should be positive number of standard deviations for trigger_type: '%v'", moira.AnomalyTrigger)
+		}
+		if trigger.WarnValue != nil && trigger.ErrorValue != nil && *trigger.WarnValue > *trigger.ErrorValue {
+			return fmt.Errorf("error_value should be greater than warn_value")
+		}
+		if err := checkSimpleModeFields(trigger); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("wrong trigger_type: %v, allowable values: '%v', '%v', '%v', '%v'",
+			trigger.TriggerType, moira.RisingTrigger, moira.FallingTrigger, moira.ExpressionTrigger, moira.AnomalyTrigger)
+	}
+
+	return nil
+}
+
+// checkAnomalySettings fills default anomaly settings and checks them, local trigger must not need history
+// older than local metrics are kept for, otherwise its baseline can never be calculated
+func checkAnomalySettings(trigger *Trigger, localMetricsTTL int64) error {
+	if trigger.TriggerType != moira.AnomalyTrigger {
+		if trigger.Anomaly != nil {
+			return fmt.Errorf("can't use anomaly settings with trigger_type: '%v'", trigger.TriggerType)
+		}
+		return nil
+	}
+	if trigger.Anomaly == nil {
+		trigger.Anomaly = moira.GetDefaultAnomalySettings()
+	}
+	if trigger.Anomaly.Seasons < 1 || trigger.Anomaly.Seasons > maxAnomalySeasons {
+		return fmt.Errorf("anomaly seasons should be from 1 to %d", maxAnomalySeasons)
+	}
+	if trigger.Anomaly.SeasonLength < minAnomalySeasonLength {
+		return fmt.Errorf("anomaly season_length should be at least %d seconds", minAnomalySeasonLength)
+	}
+	if trigger.Anomaly.Window < 0 || trigger.Anomaly.Window*2 >= trigger.Anomaly.SeasonLength {
+		return fmt.Errorf("anomaly window can't be negative or exceed half of season_length")
+	}
+	historyLength := int64(trigger.Anomaly.Seasons)*trigger.Anomaly.SeasonLength + trigger.Anomaly.Window
+	if trigger.TriggerSource == moira.GraphiteLocal && localMetricsTTL > 0 && historyLength > localMetricsTTL {
+		return fmt.Errorf("anomaly baseline needs %d seconds of metrics history, but local metrics are kept for %d seconds only: "+
+			"decrease seasons or season_length", historyLength, localMetricsTTL)
+	}
+	return nil
+}
+
+// checkDependencies checks parent trigger IDs and removes duplicates, cycles are checked on trigger save
+func checkDependencies(trigger *Trigger) error {
+	if len(trigger.Dependencies) == 0 {
+		return nil
+	}
+	dependencies := make([]string, 0, len(trigger.Dependencies))
+	seen := make(map[string]bool, len(trigger.Dependencies))
+	for _, parentTriggerID := range trigger.Dependencies {
+		if parentTriggerID == "" {
+			return fmt.Errorf("dependencies can't contain empty trigger id")
+		}
+		if parentTriggerID == trigger.ID {
+			return fmt.Errorf("trigger can't depend on itself")
+		}
+		if !seen[parentTriggerID] {
+			seen[parentTriggerID] = true
+			dependencies = append(dependencies, parentTriggerID)
+		}
+	}
+	trigger.Dependencies = dependencies
+	return nil
+}
+
+func checkTriggerSource(triggerSource moira.TriggerSource, clusterID moira.ClusterID) error {
+	switch triggerSource {
+	case moira.GraphiteRemote:
+		return nil
+	case moira.GraphiteLocal, moira.PrometheusRemote:
+		if clusterID != "" && clusterID != moira.DefaultCluster {
+			return fmt.Errorf("can't use cluster_id '%v' with trigger_source '%v'", clusterID, triggerSource)
+		}
+		return nil
 	default:
-		return fmt.Errorf("wrong trigger_type: %v, allowable values: '%v', '%v', '%v'",
-			trigger.TriggerType, moira.RisingTrigger, moira.FallingTrigger, moira.ExpressionTrigger)
+		return fmt.Errorf("wrong trigger_source: %v, allowable values: '%v', '%v', '%v'",
+			triggerSource, moira.GraphiteLocal, moira.GraphiteRemote, moira.PrometheusRemote)
 	}
+}
 
+func checkRecoveryValues(trigger *Trigger) error {
+	if trigger.WarnRecoveryValue == nil && trigger.ErrorRecoveryValue == nil {
+		return nil
+	}
+	if trigger.TriggerType != moira.RisingTrigger && trigger.TriggerType != moira.FallingTrigger {
+		return fmt.Errorf("can't use recovery values with trigger_type: '%v'", trigger.TriggerType)
+	}
+	if err := checkRecoveryValue("warn", trigger.WarnValue, trigger.WarnRecoveryValue, trigger.TriggerType); err != nil {
+		return err
+	}
+	return checkRecoveryValue("error", trigger.ErrorValue, trigger.ErrorRecoveryValue, trigger.TriggerType)
+}
+
+func checkRecoveryValue(name string, value, recoveryValue *float64, triggerType string) error {
+	if recoveryValue == nil {
+		return nil
+	}
+	if value == nil {
+		return fmt.Errorf("%s_recovery_value can't be used without %s_value", name, name)
+	}
+	if triggerType == moira.RisingTrigger && *recoveryValue >= *value {
+		return fmt.Errorf("%s_recovery_value should be less than %s_value for trigger_type: '%v'", name, name, triggerType)
+	}
+	if triggerType == moira.FallingTrigger && *recoveryValue <= *value {
+		return fmt.Errorf("%s_recovery_value should be greater than %s_value for trigger_type: '%v'", name, name, triggerType)
+	}
 	return nil
 }
 
@@ -289,6 +462,28 @@ func (*TriggerMaintenance) Bind(r *http.Request) error {
 	return nil
 }
 
+type TriggerAcknowledgement struct {
+	Metrics []string `json:"metrics,omitempty"`
+	Expiry  int64    `json:"expiry,omitempty"`
+}
+
+func (ack *TriggerAcknowledgement) Bind(r *http.Request) error {
+	if ack.Expiry != 0 && ack.Expiry <= time.Now().Unix() {
+		return fmt.Errorf("acknowledgement expiry must be in the future")
+	}
+	return nil
+}
+
+type SlackAcknowledgeResponse struct {
+	ResponseType    string `json:"response_type"`
+	ReplaceOriginal bool   `json:"replace_original"`
+	Text            string `json:"text"`
+}
+
+func (*SlackAcknowledgeResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
 type ThrottlingResponse struct {
 	Throttling int64 `json:"throttling"`
 }
@@ -314,3 +509,14 @@ type TriggerMetrics struct {
 func (*TriggerMetrics) Render(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
+
+type TriggerBacktest struct {
+	From      int64                      `json:"from"`
+	To        int64                      `json:"to"`
+	Events    []*moira.NotificationEvent `json:"events"`
+	LastCheck *moira.CheckData           `json:"last_check"`
+}
+
+func (*TriggerBacktest) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
@@ -0,0 +1,141 @@
+// nolint
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api/middleware"
+)
+
+// Formats of triggers bundle
+const (
+	TriggersBundleJSON = "json"
+	TriggersBundleYAML = "yaml"
+)
+
+// Actions made with bundle trigger on import
+const (
+	TriggerImportCreate    = "create"
+	TriggerImportUpdate    = "update"
+	TriggerImportUnchanged = "unchanged"
+)
+
+type TriggersBundle struct {
+	Triggers []TriggerModel `json:"triggers"`
+}
+
+func (*TriggersBundle) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// Validate checks every bundle trigger same way as single trigger is checked on create or update
+// and returns time series names of every trigger
+func (bundle *TriggersBundle) Validate(request *http.Request) ([]map[string]bool, error) {
+	timeSeriesNames := make([]map[string]bool, len(bundle.Triggers))
+	for i := range bundle.Triggers {
+		trigger := &Trigger{TriggerModel: bundle.Triggers[i]}
+		if err := trigger.Bind(request); err != nil {
+			return nil, ErrInvalidBundleTrigger{Index: i, Name: trigger.Name, Err: err}
+		}
+		bundle.Triggers[i] = trigger.TriggerModel
+		timeSeriesNames[i] = middleware.GetTimeSeriesNames(request)
+	}
+	return timeSeriesNames, nil
+}
+
+// ErrInvalidBundleTrigger is used when one of bundle triggers does not pass validation
+type ErrInvalidBundleTrigger struct {
+	Index int
+	Name  string
+	Err   error
+}
+
+// Error is a representation of Error interface method
+func (err ErrInvalidBundleTrigger) Error() string {
+	return fmt.Sprintf("trigger #%d '%s': %s", err.Index, err.Name, err.Err.Error())
+}
+
+// MarshalTriggersBundle encodes bundle to given format, keys of YAML bundle are the same as JSON ones
+func MarshalTriggersBundle(bundle *TriggersBundle, format string) ([]byte, error) {
+	jsonBytes, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case TriggersBundleJSON:
+		return jsonBytes, nil
+	case TriggersBundleYAML:
+		var content yaml.MapSlice
+		if err = yaml.Unmarshal(jsonBytes, &content); err != nil {
+			return nil, err
+		}
+		return yaml.Marshal(content)
+	default:
+		return nil, fmt.Errorf("unknown triggers bundle format '%s'", format)
+	}
+}
+
+// UnmarshalTriggersBundle decodes bundle in given format
+func UnmarshalTriggersBundle(data []byte, format string) (*TriggersBundle, error) {
+	bundle := &TriggersBundle{}
+	switch format {
+	case TriggersBundleJSON:
+	case TriggersBundleYAML:
+		var content interface{}
+		if err := yaml.Unmarshal(data, &content); err != nil {
+			return nil, err
+		}
+		jsonBytes, err := json.Marshal(yamlToJSONValue(content))
+		if err != nil {
+			return nil, err
+		}
+		data = jsonBytes
+	default:
+		return nil, fmt.Errorf("unknown triggers bundle format '%s'", format)
+	}
+	if err := json.Unmarshal(data, bundle); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// yamlToJSONValue converts YAML maps with interface keys to maps which can be encoded to JSON
+func yamlToJSONValue(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(typedValue))
+		for key, item := range typedValue {
+			result[fmt.Sprintf("%v", key)] = yamlToJSONValue(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(typedValue))
+		for i, item := range typedValue {
+			result[i] = yamlToJSONValue(item)
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+type TriggersImportResult struct {
+	DryRun   bool                  `json:"dry_run"`
+	Triggers []TriggerImportResult `json:"triggers"`
+}
+
+func (*TriggersImportResult) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type TriggerImportResult struct {
+	ID     string                          `json:"id"`
+	Name   string                          `json:"name"`
+	Action string                          `json:"action"`
+	Diff   map[string]moira.AuditFieldDiff `json:"diff,omitempty"`
+}
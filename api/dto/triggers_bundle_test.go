@@ -0,0 +1,116 @@
+package dto
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/middleware"
+	metricSource "github.com/moira-alert/moira/metric_source"
+	mock_metric_source "github.com/moira-alert/moira/mock/metric_source"
+)
+
+func TestTriggersBundleFormats(t *testing.T) {
+	desc := "description"
+	warnValue := 10.0
+	bundle := &TriggersBundle{
+		Triggers: []TriggerModel{
+			{
+				ID:          "trigger-id",
+				Name:        "trigger",
+				Desc:        &desc,
+				Targets:     []string{"my.metric"},
+				WarnValue:   &warnValue,
+				TriggerType: moira.RisingTrigger,
+				Tags:        []string{"tag"},
+				TTLState:    &moira.TTLStateNODATA,
+				TTL:         600,
+				Schedule: &moira.ScheduleData{
+					Days:           []moira.ScheduleDataDay{{Enabled: true, Name: "Mon"}},
+					TimezoneOffset: -180,
+					EndOffset:      1439,
+				},
+				Patterns:      []string{"my.metric"},
+				TriggerSource: moira.GraphiteLocal,
+			},
+		},
+	}
+
+	Convey("JSON bundle", t, func() {
+		content, err := MarshalTriggersBundle(bundle, TriggersBundleJSON)
+		So(err, ShouldBeNil)
+		actual, err := UnmarshalTriggersBundle(content, TriggersBundleJSON)
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, bundle)
+	})
+
+	Convey("YAML bundle has same keys as JSON one", t, func() {
+		content, err := MarshalTriggersBundle(bundle, TriggersBundleYAML)
+		So(err, ShouldBeNil)
+		So(string(content), ShouldContainSubstring, "trigger_type: rising")
+		So(string(content), ShouldContainSubstring, "tzOffset: -180")
+		actual, err := UnmarshalTriggersBundle(content, TriggersBundleYAML)
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, bundle)
+	})
+
+	Convey("Unknown format", t, func() {
+		_, err := MarshalTriggersBundle(bundle, "xml")
+		So(err, ShouldResemble, fmt.Errorf("unknown triggers bundle format 'xml'"))
+		_, err = UnmarshalTriggersBundle([]byte{}, "xml")
+		So(err, ShouldResemble, fmt.Errorf("unknown triggers bundle format 'xml'"))
+	})
+}
+
+func TestTriggersBundleValidate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	localSource := mock_metric_source.NewMockMetricSource(mockCtrl)
+	fetchResult := mock_metric_source.NewMockFetchResult(mockCtrl)
+	sourceProvider := metricSource.CreateMetricSourceProvider(localSource, nil, nil)
+	localSource.EXPECT().IsConfigured().Return(true, nil).AnyTimes()
+	localSource.EXPECT().Fetch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fetchResult, nil).AnyTimes()
+	fetchResult.EXPECT().GetPatterns().Return([]string{"my.metric"}, nil).AnyTimes()
+	fetchResult.EXPECT().GetMetricsData().Return([]*metricSource.MetricData{metricSource.MakeMetricData("my.metric", []float64{}, 0, 0)}).AnyTimes()
+
+	request, _ := http.NewRequest("POST", "/api/trigger/import", nil)
+	middleware.SetTriggerTargetsSourceProvider(request, sourceProvider)
+	warnValue := 10.0
+
+	Convey("Valid triggers", t, func() {
+		bundle := &TriggersBundle{
+			Triggers: []TriggerModel{
+				{Name: "first", Targets: []string{"my.metric"}, Tags: []string{"tag"}, WarnValue: &warnValue, TriggerType: moira.RisingTrigger},
+				{Name: "second", Targets: []string{"my.metric"}, Tags: []string{"tag"}, WarnValue: &warnValue, TriggerType: moira.FallingTrigger},
+			},
+		}
+		timeSeriesNames, err := bundle.Validate(request)
+		So(err, ShouldBeNil)
+		So(timeSeriesNames, ShouldResemble, []map[string]bool{{"my.metric": true}, {"my.metric": true}})
+		So(bundle.Triggers[0].Patterns, ShouldResemble, []string{"my.metric"})
+		So(bundle.Triggers[0].TriggerSource, ShouldEqual, moira.GraphiteLocal)
+	})
+
+	Convey("Invalid trigger", t, func() {
+		bundle := &TriggersBundle{
+			Triggers: []TriggerModel{
+				{Name: "first", Targets: []string{"my.metric"}, Tags: []string{"tag"}, WarnValue: &warnValue, TriggerType: moira.RisingTrigger},
+				{Name: "second", Targets: []string{"my.metric"}, WarnValue: &warnValue, TriggerType: moira.RisingTrigger},
+			},
+		}
+		timeSeriesNames, err := bundle.Validate(request)
+		So(err, ShouldResemble, ErrInvalidBundleTrigger{
+			Index: 1,
+			Name:  "second",
+			Err:   api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("tags is required")},
+		})
+		So(err.Error(), ShouldEqual, "trigger #1 'second': tags is required")
+		So(timeSeriesNames, ShouldBeNil)
+	})
+}
@@ -25,7 +25,7 @@ func TestExpressionModeMultipleTargetsWarnValue(t *testing.T) {
 		localSource := mock_metric_source.NewMockMetricSource(mockCtrl)
 		remoteSource := mock_metric_source.NewMockMetricSource(mockCtrl)
 		fetchResult := mock_metric_source.NewMockFetchResult(mockCtrl)
-		sourceProvider := metricSource.CreateMetricSourceProvider(localSource, remoteSource)
+		sourceProvider := metricSource.CreateMetricSourceProvider(localSource, map[moira.ClusterID]metricSource.MetricSource{moira.DefaultCluster: remoteSource}, nil)
 
 		localSource.EXPECT().IsConfigured().Return(true, nil).AnyTimes()
 		localSource.EXPECT().Fetch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fetchResult, nil).AnyTimes()
@@ -51,10 +51,54 @@ func TestExpressionModeMultipleTargetsWarnValue(t *testing.T) {
 			Tags:           tags,
 			TTLState:       &moira.TTLStateNODATA,
 			TTL:            600,
-			IsRemote:       false,
+			TriggerSource:  moira.GraphiteLocal,
 			MuteNewMetrics: false,
 		}
 
+		Convey("Test trigger source", func() {
+			trigger.TriggerType = moira.RisingTrigger
+			trigger.Targets = []string{"DevOps.system.graphite01.disk._mnt_data.gigabyte_percentfree"}
+			trigger.WarnValue = &errorValue
+			trigger.ErrorValue = &warnValue
+
+			Convey("is not set and is_remote is false", func() {
+				trigger.TriggerSource = moira.TriggerSourceNotSet
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldBeNil)
+				So(tr.TriggerSource, ShouldEqual, moira.GraphiteLocal)
+			})
+
+			Convey("is unknown", func() {
+				trigger.TriggerSource = "influxdb"
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("wrong trigger_source: influxdb, allowable values: 'graphite_local', 'graphite_remote', 'prometheus_remote'")})
+			})
+
+			Convey("is prometheus_remote and prometheus is not configured", func() {
+				trigger.TriggerSource = moira.PrometheusRemote
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, metricSource.ErrMetricSourceIsNotConfigured)
+			})
+
+			Convey("is graphite_local with not default cluster_id", func() {
+				trigger.ClusterID = "staging"
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("can't use cluster_id 'staging' with trigger_source 'graphite_local'")})
+			})
+
+			Convey("is graphite_remote with unknown cluster_id", func() {
+				trigger.TriggerSource = moira.GraphiteRemote
+				trigger.ClusterID = "staging"
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, metricSource.ErrRemoteClusterIsNotConfigured{ClusterID: "staging"})
+			})
+		})
+
 		Convey("Test FallingTrigger", func() {
 			trigger.TriggerType = moira.FallingTrigger
 
@@ -160,5 +204,209 @@ func TestExpressionModeMultipleTargetsWarnValue(t *testing.T) {
 				So(err, ShouldBeNil)
 			})
 		})
+
+		Convey("Test recovery values and pending interval", func() {
+			trigger.TriggerType = moira.RisingTrigger
+			trigger.Targets = []string{"DevOps.system.graphite01.disk._mnt_data.gigabyte_percentfree"}
+			warnValue, errorValue := float64(5), float64(10)
+			trigger.WarnValue = &warnValue
+			trigger.ErrorValue = &errorValue
+
+			Convey("valid for rising trigger", func() {
+				warnRecoveryValue, errorRecoveryValue := float64(4), float64(8)
+				trigger.WarnRecoveryValue = &warnRecoveryValue
+				trigger.ErrorRecoveryValue = &errorRecoveryValue
+				trigger.PendingInterval = 300
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldBeNil)
+			})
+
+			Convey("recovery value is not less than value of rising trigger", func() {
+				errorRecoveryValue := float64(10)
+				trigger.ErrorRecoveryValue = &errorRecoveryValue
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("error_recovery_value should be less than error_value for trigger_type: 'rising'")})
+			})
+
+			Convey("recovery value is not greater than value of falling trigger", func() {
+				trigger.TriggerType = moira.FallingTrigger
+				trigger.WarnValue, trigger.ErrorValue = &errorValue, &warnValue
+				warnRecoveryValue := float64(9)
+				trigger.WarnRecoveryValue = &warnRecoveryValue
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("warn_recovery_value should be greater than warn_value for trigger_type: 'falling'")})
+			})
+
+			Convey("recovery value without value", func() {
+				trigger.WarnValue = nil
+				warnRecoveryValue := float64(4)
+				trigger.WarnRecoveryValue = &warnRecoveryValue
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("warn_recovery_value can't be used without warn_value")})
+			})
+
+			Convey("recovery value with expression trigger", func() {
+				trigger.TriggerType = moira.ExpressionTrigger
+				trigger.WarnValue, trigger.ErrorValue = nil, nil
+				trigger.Expression = "t1 > 10 ? ERROR : OK"
+				errorRecoveryValue := float64(8)
+				trigger.ErrorRecoveryValue = &errorRecoveryValue
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("can't use recovery values with trigger_type: 'expression'")})
+			})
+
+			Convey("negative pending interval", func() {
+				trigger.PendingInterval = -1
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("pending_interval can't be negative")})
+			})
+		})
+
+		Convey("Test reminders", func() {
+			trigger.TriggerType = moira.RisingTrigger
+			trigger.Targets = []string{"DevOps.system.graphite01.disk._mnt_data.gigabyte_percentfree"}
+			trigger.WarnValue = &errorValue
+			trigger.ErrorValue = &warnValue
+
+			Convey("valid reminders", func() {
+				trigger.Reminders = map[moira.State]int64{moira.StateERROR: 7200, moira.StateWARN: 7200, moira.StateNODATA: 0}
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldBeNil)
+			})
+
+			Convey("reminder for OK state", func() {
+				trigger.Reminders = map[moira.State]int64{moira.StateOK: 7200}
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("reminders can't be set for state 'OK', allowable states: 'WARN', 'ERROR', 'NODATA', 'EXCEPTION'")})
+			})
+
+			Convey("too short reminder interval", func() {
+				trigger.Reminders = map[moira.State]int64{moira.StateERROR: 60}
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("reminder interval for state 'ERROR' should be 0 or at least 3600 seconds")})
+			})
+
+			Convey("negative reminder interval", func() {
+				trigger.Reminders = map[moira.State]int64{moira.StateNODATA: -1}
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("reminder interval for state 'NODATA' should be 0 or at least 3600 seconds")})
+			})
+		})
+
+		Convey("Test dependencies", func() {
+			trigger.TriggerType = moira.RisingTrigger
+			trigger.Targets = []string{"DevOps.system.graphite01.disk._mnt_data.gigabyte_percentfree"}
+			trigger.WarnValue = &errorValue
+			trigger.ErrorValue = &warnValue
+
+			Convey("duplicates are removed", func() {
+				trigger.Dependencies = []string{"first", "second", "first"}
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldBeNil)
+				So(tr.Dependencies, ShouldResemble, []string{"first", "second"})
+			})
+
+			Convey("empty trigger id", func() {
+				trigger.Dependencies = []string{"first", ""}
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("dependencies can't contain empty trigger id")})
+			})
+
+			Convey("trigger depends on itself", func() {
+				trigger.Dependencies = []string{trigger.ID}
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("trigger can't depend on itself")})
+			})
+		})
+
+		Convey("Test AnomalyTrigger", func() {
+			trigger.TriggerType = moira.AnomalyTrigger
+			trigger.Targets = []string{"DevOps.system.graphite01.disk._mnt_data.gigabyte_percentfree"}
+			warnValue, errorValue := float64(2), float64(3)
+			trigger.WarnValue = &warnValue
+			trigger.ErrorValue = &errorValue
+
+			Convey("default anomaly settings are used", func() {
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldBeNil)
+				So(tr.Anomaly, ShouldResemble, moira.GetDefaultAnomalySettings())
+			})
+
+			Convey("valid anomaly settings", func() {
+				trigger.Anomaly = &moira.AnomalySettings{Seasons: 4, SeasonLength: 3600, Window: 300}
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldBeNil)
+			})
+
+			Convey("not positive deviations", func() {
+				trigger.WarnValue = &errorValue
+				trigger.ErrorValue = new(float64)
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("error_value should be positive number of standard deviations for trigger_type: 'anomaly'")})
+			})
+
+			Convey("warn_value is greater than error_value", func() {
+				trigger.WarnValue, trigger.ErrorValue = &errorValue, &warnValue
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("error_value should be greater than warn_value")})
+			})
+
+			Convey("and expression", func() {
+				trigger.Expression = "t1 > 10 ? ERROR : OK"
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("can't use 'expression' to trigger_type: 'anomaly'")})
+			})
+
+			Convey("invalid anomaly settings", func() {
+				trigger.Anomaly = &moira.AnomalySettings{Seasons: 0, SeasonLength: 3600}
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("anomaly seasons should be from 1 to 30")})
+
+				trigger.Anomaly = &moira.AnomalySettings{Seasons: 1, SeasonLength: 30}
+				tr = Trigger{trigger, throttling}
+				err = tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("anomaly season_length should be at least 60 seconds")})
+
+				trigger.Anomaly = &moira.AnomalySettings{Seasons: 1, SeasonLength: 3600, Window: 1800}
+				tr = Trigger{trigger, throttling}
+				err = tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("anomaly window can't be negative or exceed half of season_length")})
+			})
+
+			Convey("local metrics are not kept long enough for baseline", func() {
+				trigger.Anomaly = &moira.AnomalySettings{Seasons: 4, SeasonLength: 3600, Window: 300}
+				tr := Trigger{trigger, throttling}
+				ttlRequest := request.WithContext(context.WithValue(request.Context(), middleware.ContextKey("localMetricsTTL"), int64(3*3600)))
+				err := tr.Bind(ttlRequest)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("anomaly baseline needs 14700 seconds of metrics history, but local metrics are kept for 10800 seconds only: decrease seasons or season_length")})
+			})
+
+			Convey("anomaly settings with rising trigger", func() {
+				trigger.TriggerType = moira.RisingTrigger
+				trigger.Anomaly = moira.GetDefaultAnomalySettings()
+				tr := Trigger{trigger, throttling}
+				err := tr.Bind(request)
+				So(err, ShouldResemble, api.ErrInvalidRequestContent{ValidationError: fmt.Errorf("can't use anomaly settings with trigger_type: 'rising'")})
+			})
+		})
 	})
 }
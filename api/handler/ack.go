@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/nlopes/slack"
+
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/controller"
+	"github.com/moira-alert/moira/api/dto"
+	slackSender "github.com/moira-alert/moira/senders/slack"
+)
+
+func ack(slackSigningSecret string) func(chi.Router) {
+	return func(router chi.Router) {
+		router.Post("/slack", slackAcknowledge(slackSigningSecret))
+	}
+}
+
+// slackAcknowledge handles slack interactive acknowledge button callbacks
+func slackAcknowledge(signingSecret string) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		callback, err := parseSlackCallback(request, signingSecret)
+		if err != nil {
+			render.Render(writer, request, api.ErrorForbidden(err.Error()))
+			return
+		}
+		if callback.CallbackID != slackSender.AckCallbackID || len(callback.Actions) == 0 {
+			render.Render(writer, request, api.ErrorInvalidRequest(fmt.Errorf("unknown slack callback %s", callback.CallbackID)))
+			return
+		}
+		triggerID := callback.Actions[0].Value
+		userLogin := callback.User.Name
+		if err := controller.AcknowledgeTrigger(database, triggerID, dto.TriggerAcknowledgement{}, userLogin, time.Now().Unix()); err != nil {
+			render.Render(writer, request, err)
+			return
+		}
+		response := &dto.SlackAcknowledgeResponse{
+			ResponseType: "in_channel",
+			Text:         fmt.Sprintf("Acknowledged by @%s", userLogin),
+		}
+		if err := render.Render(writer, request, response); err != nil {
+			render.Render(writer, request, api.ErrorRender(err))
+		}
+	}
+}
+
+// parseSlackCallback verifies slack request signature and parses interaction callback from request payload
+func parseSlackCallback(request *http.Request, signingSecret string) (*slack.InteractionCallback, error) {
+	if signingSecret == "" {
+		return nil, fmt.Errorf("slack signing secret is not configured")
+	}
+	verifier, err := slack.NewSecretsVerifier(request.Header, signingSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify slack request: %s", err.Error())
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read slack request: %s", err.Error())
+	}
+	verifier.Write(body)
+	if err := verifier.Ensure(); err != nil {
+		return nil, fmt.Errorf("failed to verify slack request: %s", err.Error())
+	}
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse slack request: %s", err.Error())
+	}
+	callback := &slack.InteractionCallback{}
+	if err := json.Unmarshal([]byte(values.Get("payload")), callback); err != nil {
+		return nil, fmt.Errorf("failed to parse slack callback: %s", err.Error())
+	}
+	return callback, nil
+}
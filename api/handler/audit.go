@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/controller"
+	"github.com/moira-alert/moira/api/middleware"
+)
+
+func audit(router chi.Router) {
+	router.With(middleware.Paginate(0, 100)).Get("/", getAuditLog)
+}
+
+func getAuditLog(writer http.ResponseWriter, request *http.Request) {
+	size := middleware.GetSize(request)
+	page := middleware.GetPage(request)
+	auditLog, err := controller.GetAuditLog(database, page, size)
+	if err != nil {
+		render.Render(writer, request, err)
+		return
+	}
+	if err := render.Render(writer, request, auditLog); err != nil {
+		render.Render(writer, request, api.ErrorRender(err))
+	}
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/go-chi/chi"
@@ -17,12 +18,14 @@ import (
 func contact(router chi.Router) {
 	router.Get("/", getAllContacts)
 	router.Put("/", createNewContact)
+	router.Post("/preview", previewTemplate)
 	router.Route("/{contactId}", func(router chi.Router) {
 		router.Use(middleware.ContactContext)
 		router.Use(contactFilter)
 		router.Put("/", updateContact)
 		router.Delete("/", removeContact)
 		router.Post("/test", sendTestContactNotification)
+		router.With(middleware.Paginate(0, 100)).Get("/history", getContactDeliveries)
 	})
 }
 
@@ -58,6 +61,34 @@ func createNewContact(writer http.ResponseWriter, request *http.Request) {
 	}
 }
 
+func previewTemplate(writer http.ResponseWriter, request *http.Request) {
+	previewRequest := &dto.TemplatePreviewRequest{}
+	if err := render.Bind(request, previewRequest); err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err))
+		return
+	}
+
+	preview, err := controller.PreviewTemplate(previewRequest, getFrontURI(request))
+	if err != nil {
+		render.Render(writer, request, err)
+		return
+	}
+
+	if err := render.Render(writer, request, preview); err != nil {
+		render.Render(writer, request, api.ErrorRender(err))
+		return
+	}
+}
+
+// getFrontURI returns web UI address assuming it is served on the same host as api
+func getFrontURI(request *http.Request) string {
+	scheme := "http"
+	if request.TLS != nil {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, request.Host)
+}
+
 // contactFilter is middleware for check contact existence and user permissions
 func contactFilter(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
@@ -80,8 +111,9 @@ func updateContact(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 	contactData := request.Context().Value(contactKey).(moira.ContactData)
+	userLogin := middleware.GetLogin(request)
 
-	contactDTO, err := controller.UpdateContact(database, contactDTO, contactData)
+	contactDTO, err := controller.UpdateContact(database, contactDTO, contactData, userLogin)
 	if err != nil {
 		render.Render(writer, request, err)
 		return
@@ -106,3 +138,17 @@ func sendTestContactNotification(writer http.ResponseWriter, request *http.Reque
 		render.Render(writer, request, err)
 	}
 }
+
+func getContactDeliveries(writer http.ResponseWriter, request *http.Request) {
+	contactID := middleware.GetContactID(request)
+	size := middleware.GetSize(request)
+	page := middleware.GetPage(request)
+	deliveries, err := controller.GetContactDeliveries(database, contactID, page, size)
+	if err != nil {
+		render.Render(writer, request, err)
+		return
+	}
+	if err := render.Render(writer, request, deliveries); err != nil {
+		render.Render(writer, request, api.ErrorRender(err))
+	}
+}
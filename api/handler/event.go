@@ -12,7 +12,7 @@ import (
 
 func event(router chi.Router) {
 	router.With(middleware.TriggerContext, middleware.Paginate(0, 100)).Get("/{triggerId}", getEventsList)
-	router.Delete("/all", deleteAllEvents)
+	router.With(middleware.AdminOnly).Delete("/all", deleteAllEvents)
 }
 
 func getEventsList(writer http.ResponseWriter, request *http.Request) {
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/middleware"
+	"github.com/moira-alert/moira/api/stream"
+)
+
+const (
+	eventsStreamHeartbeatInterval = 30 * time.Second
+	eventsStreamRetryInterval     = 3 * time.Second
+)
+
+func eventsStream(notificationEventsStream *stream.EventsStream) func(router chi.Router) {
+	return func(router chi.Router) {
+		router.Get("/stream", getEventsStream(notificationEventsStream))
+	}
+}
+
+// getEventsStream streams notification events as Server-Sent Events, client resumes stream after
+// reconnect by sending ID of the last received event in Last-Event-ID header or lastEventId query parameter
+func getEventsStream(notificationEventsStream *stream.EventsStream) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		flusher, ok := writer.(http.Flusher)
+		if !ok {
+			render.Render(writer, request, api.ErrorInternalServer(fmt.Errorf("streaming is not supported")))
+			return
+		}
+		filter, err := getEventsStreamFilter(request)
+		if err != nil {
+			render.Render(writer, request, api.ErrorInvalidRequest(err))
+			return
+		}
+		lastEventID, resume, err := getLastEventID(request)
+		if err != nil {
+			render.Render(writer, request, api.ErrorInvalidRequest(err))
+			return
+		}
+
+		// subscribe before reading missed events to not lose events published in between
+		subscriber := notificationEventsStream.Subscribe(filter)
+		defer notificationEventsStream.Unsubscribe(subscriber)
+
+		writer.Header().Set("Content-Type", "text/event-stream")
+		writer.Header().Set("Cache-Control", "no-cache")
+		writer.Header().Set("Connection", "keep-alive")
+		writer.Header().Set("X-Accel-Buffering", "no")
+		writer.WriteHeader(http.StatusOK)
+		fmt.Fprintf(writer, "retry: %d\n\n", eventsStreamRetryInterval.Milliseconds())
+
+		if resume {
+			events, err := notificationEventsStream.GetEvents(lastEventID, filter)
+			if err != nil {
+				middleware.GetLoggerEntry(request).Errorf("Failed to get events stream after %d: %s", lastEventID, err.Error())
+			}
+			for _, event := range events {
+				if err := writeStreamedEvent(writer, event); err != nil {
+					return
+				}
+				lastEventID = event.ID
+			}
+		}
+		flusher.Flush()
+
+		heartbeat := time.NewTicker(eventsStreamHeartbeatInterval)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case <-request.Context().Done():
+				return
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(writer, ": heartbeat\n\n"); err != nil {
+					return
+				}
+			case event, ok := <-subscriber.Events():
+				if !ok {
+					return
+				}
+				if event.ID <= lastEventID {
+					continue
+				}
+				if err := writeStreamedEvent(writer, event); err != nil {
+					return
+				}
+				lastEventID = event.ID
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeStreamedEvent(writer http.ResponseWriter, event *moira.StreamedNotificationEvent) error {
+	data, err := json.Marshal(event.Event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(writer, "id: %d\ndata: %s\n\n", event.ID, data)
+	return err
+}
+
+func getEventsStreamFilter(request *http.Request) (stream.Filter, error) {
+	filter := stream.Filter{
+		Tags:       getRequestTags(request),
+		TriggerIDs: getRequestValues(request, "triggers"),
+	}
+	for _, value := range getRequestValues(request, "states") {
+		switch state := moira.State(value); state {
+		case moira.StateOK, moira.StateWARN, moira.StateERROR, moira.StateNODATA, moira.StateEXCEPTION:
+			filter.States = append(filter.States, state)
+		default:
+			return filter, fmt.Errorf("invalid state '%s'", value)
+		}
+	}
+	return filter, nil
+}
+
+func getLastEventID(request *http.Request) (int64, bool, error) {
+	value := request.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = request.URL.Query().Get("lastEventId")
+	}
+	if value == "" {
+		return 0, false, nil
+	}
+	lastEventID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid last event id '%s'", value)
+	}
+	return lastEventID, true, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/api"
 	moiramiddle "github.com/moira-alert/moira/api/middleware"
+	"github.com/moira-alert/moira/api/stream"
 )
 
 var database moira.Database
@@ -19,14 +20,16 @@ var searchIndex moira.Searcher
 
 const contactKey moiramiddle.ContextKey = "contact"
 const subscriptionKey moiramiddle.ContextKey = "subscription"
+const teamKey moiramiddle.ContextKey = "team"
 
 // NewHandler creates new api handler request uris based on github.com/go-chi/chi
-func NewHandler(db moira.Database, log moira.Logger, index moira.Searcher, config *api.Config, metricSourceProvider *metricSource.SourceProvider, webConfigContent []byte) http.Handler {
+func NewHandler(db moira.Database, log moira.Logger, index moira.Searcher, config *api.Config, metricSourceProvider *metricSource.SourceProvider, webConfigContent []byte, notificationEventsStream *stream.EventsStream) http.Handler {
 	database = db
 	searchIndex = index
 	router := chi.NewRouter()
 	router.Use(render.SetContentType(render.ContentTypeJSON))
 	router.Use(moiramiddle.UserContext)
+	router.Use(moiramiddle.AdminContext(config.Admins))
 	router.Use(moiramiddle.RequestLogger(log))
 	router.Use(middleware.NoCache)
 
@@ -37,12 +40,17 @@ func NewHandler(db moira.Database, log moira.Logger, index moira.Searcher, confi
 		router.Use(moiramiddle.DatabaseContext(database))
 		router.Get("/config", getWebConfig(webConfigContent))
 		router.Route("/user", user)
-		router.Route("/trigger", triggers(metricSourceProvider, searchIndex))
+		router.Route("/trigger", triggers(metricSourceProvider, searchIndex, config.LocalMetricsTTL, config.MaxBacktestWindow))
 		router.Route("/tag", tag)
 		router.Route("/pattern", pattern)
 		router.Route("/event", event)
+		router.Route("/events", eventsStream(notificationEventsStream))
+		router.Route("/audit", audit)
 		router.Route("/contact", contact)
 		router.Route("/subscription", subscription)
+		router.Route("/teams", team)
+		router.Route("/silence", silence)
+		router.Route("/ack", ack(config.SlackSigningSecret))
 		router.Route("/notification", notification)
 		router.Route("/health", health)
 	})
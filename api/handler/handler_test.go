@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/logging/go-logging"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+)
+
+func TestPermissions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	logger, _ := logging.ConfigureLog("stdout", "error", "test")
+	handler := NewHandler(dataBase, logger, nil, &api.Config{Admins: []string{"admin"}}, nil, nil, nil)
+
+	team := moira.Team{ID: "teamID", Name: "Team", Members: map[string]moira.TeamRole{"editor": moira.TeamRoleEditor}}
+	teamTrigger := moira.Trigger{ID: "triggerID", Tags: []string{"tag"}, TeamID: team.ID}
+
+	serve := func(method, target, body, login string) int {
+		request := httptest.NewRequest(method, target, strings.NewReader(body))
+		request.Header.Set("x-webauth-user", login)
+		request.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	Convey("Team triggers", t, func() {
+		Convey("Non-member can not remove team trigger", func() {
+			dataBase.EXPECT().GetTrigger(teamTrigger.ID).Return(teamTrigger, nil)
+			dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+			So(serve("DELETE", "/api/trigger/triggerID", "", "user"), ShouldEqual, http.StatusForbidden)
+		})
+
+		Convey("Non-member can not acknowledge team trigger", func() {
+			dataBase.EXPECT().GetTrigger(teamTrigger.ID).Return(teamTrigger, nil)
+			dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+			So(serve("PUT", "/api/trigger/triggerID/ack", "{}", "user"), ShouldEqual, http.StatusForbidden)
+		})
+	})
+
+	Convey("Silences", t, func() {
+		Convey("Non-member can not mute team triggers", func() {
+			body := `{"tags": ["tag"], "end_time": ` + strconv.FormatInt(time.Now().Unix()+3600, 10) + `}`
+			dataBase.EXPECT().GetTagTriggerIDs("tag").Return([]string{teamTrigger.ID}, nil)
+			dataBase.EXPECT().GetTriggers([]string{teamTrigger.ID}).Return([]*moira.Trigger{&teamTrigger}, nil)
+			dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+			So(serve("PUT", "/api/silence", body, "user"), ShouldEqual, http.StatusForbidden)
+		})
+
+		Convey("Non-author can not remove silence", func() {
+			dataBase.EXPECT().GetSilence("silenceID").Return(moira.Silence{ID: "silenceID", Tags: []string{"tag"}, User: "editor"}, nil)
+			So(serve("DELETE", "/api/silence/silenceID", "", "user"), ShouldEqual, http.StatusForbidden)
+		})
+	})
+
+	Convey("Teams", t, func() {
+		Convey("Non-member can not get team", func() {
+			dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+			So(serve("GET", "/api/teams/teamID", "", "user"), ShouldEqual, http.StatusForbidden)
+		})
+
+		Convey("Editor can not change team", func() {
+			dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+			So(serve("PUT", "/api/teams/teamID", `{"name": "Team"}`, "editor"), ShouldEqual, http.StatusForbidden)
+		})
+	})
+
+	Convey("Administrative methods", t, func() {
+		Convey("Non-admin can not remove all events", func() {
+			So(serve("DELETE", "/api/event/all", "", "user"), ShouldEqual, http.StatusForbidden)
+		})
+
+		Convey("Admin can remove all events", func() {
+			dataBase.EXPECT().RemoveAllNotificationEvents().Return(nil)
+			So(serve("DELETE", "/api/event/all", "", "admin"), ShouldEqual, http.StatusOK)
+		})
+
+		Convey("Non-admin can not remove tag", func() {
+			So(serve("DELETE", "/api/tag/tag", "", "user"), ShouldEqual, http.StatusForbidden)
+		})
+
+		Convey("Non-admin can not remove pattern", func() {
+			So(serve("DELETE", "/api/pattern/my.metric", "", "user"), ShouldEqual, http.StatusForbidden)
+		})
+
+		Convey("Non-admin can not get notifications", func() {
+			So(serve("GET", "/api/notification", "", "user"), ShouldEqual, http.StatusForbidden)
+		})
+
+		Convey("Non-admin can not change notifier state", func() {
+			So(serve("PUT", "/api/health/notifier", `{"state": "OK"}`, "user"), ShouldEqual, http.StatusForbidden)
+		})
+	})
+}
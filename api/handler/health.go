@@ -8,9 +8,11 @@ import (
 	"github.com/moira-alert/moira/api"
 	"github.com/moira-alert/moira/api/controller"
 	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/api/middleware"
 )
 
 func health(router chi.Router) {
+	router.Use(middleware.AdminOnly)
 	router.Get("/notifier", getNotifierState)
 	router.Put("/notifier", setNotifierState)
 }
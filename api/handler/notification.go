@@ -9,12 +9,19 @@ import (
 	"github.com/go-chi/render"
 	"github.com/moira-alert/moira/api"
 	"github.com/moira-alert/moira/api/controller"
+	"github.com/moira-alert/moira/api/middleware"
 )
 
 func notification(router chi.Router) {
+	router.Use(middleware.AdminOnly)
 	router.Get("/", getNotification)
 	router.Delete("/", deleteNotification)
 	router.Delete("/all", deleteAllNotifications)
+	router.Route("/failed", func(router chi.Router) {
+		router.Get("/", getFailedNotifications)
+		router.Post("/retry", retryFailedNotification)
+		router.Post("/retry/all", retryAllFailedNotifications)
+	})
 }
 
 func getNotification(writer http.ResponseWriter, request *http.Request) {
@@ -59,3 +66,51 @@ func deleteAllNotifications(writer http.ResponseWriter, request *http.Request) {
 		render.Render(writer, request, errorResponse)
 	}
 }
+
+func getFailedNotifications(writer http.ResponseWriter, request *http.Request) {
+	start, err := strconv.ParseInt(request.URL.Query().Get("start"), 10, 64)
+	if err != nil {
+		start = 0
+	}
+	end, err := strconv.ParseInt(request.URL.Query().Get("end"), 10, 64)
+	if err != nil {
+		end = -1
+	}
+
+	notifications, errorResponse := controller.GetFailedNotifications(database, start, end)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse)
+		return
+	}
+	if err := render.Render(writer, request, notifications); err != nil {
+		render.Render(writer, request, api.ErrorRender(err))
+	}
+}
+
+func retryFailedNotification(writer http.ResponseWriter, request *http.Request) {
+	id := request.URL.Query().Get("id")
+	if id == "" {
+		render.Render(writer, request, api.ErrorInvalidRequest(fmt.Errorf("failed notification id can not be empty")))
+		return
+	}
+
+	result, errorResponse := controller.RetryFailedNotification(database, id)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse)
+		return
+	}
+	if err := render.Render(writer, request, result); err != nil {
+		render.Render(writer, request, api.ErrorRender(err))
+	}
+}
+
+func retryAllFailedNotifications(writer http.ResponseWriter, request *http.Request) {
+	result, errorResponse := controller.RetryAllFailedNotifications(database)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse)
+		return
+	}
+	if err := render.Render(writer, request, result); err != nil {
+		render.Render(writer, request, api.ErrorRender(err))
+	}
+}
@@ -13,7 +13,7 @@ import (
 
 func pattern(router chi.Router) {
 	router.Get("/", getAllPatterns)
-	router.Delete("/{pattern}", deletePattern)
+	router.With(middleware.AdminOnly).Delete("/{pattern}", deletePattern)
 }
 
 func getAllPatterns(writer http.ResponseWriter, request *http.Request) {
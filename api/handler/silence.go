@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/controller"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/api/middleware"
+)
+
+func silence(router chi.Router) {
+	router.Get("/", getAllSilences)
+	router.Put("/", createSilence)
+	router.Route("/{silenceId}", func(router chi.Router) {
+		router.Use(middleware.SilenceContext)
+		router.Use(silenceFilter)
+		router.Get("/", getSilence)
+		router.Put("/", updateSilence)
+		router.Delete("/", removeSilence)
+	})
+}
+
+func getAllSilences(writer http.ResponseWriter, request *http.Request) {
+	silences, err := controller.GetAllSilences(database)
+	if err != nil {
+		render.Render(writer, request, err)
+		return
+	}
+	if err := render.Render(writer, request, silences); err != nil {
+		render.Render(writer, request, api.ErrorRender(err))
+	}
+}
+
+func createSilence(writer http.ResponseWriter, request *http.Request) {
+	silence := &dto.Silence{}
+	if err := render.Bind(request, silence); err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err))
+		return
+	}
+	userLogin := middleware.GetLogin(request)
+	if err := controller.CheckUserPermissionsForSilencedTriggers(database, silence, userLogin, middleware.IsAdmin(request)); err != nil {
+		render.Render(writer, request, err)
+		return
+	}
+
+	if err := controller.CreateSilence(database, silence, userLogin); err != nil {
+		render.Render(writer, request, err)
+		return
+	}
+	if err := render.Render(writer, request, silence); err != nil {
+		render.Render(writer, request, api.ErrorRender(err))
+	}
+}
+
+// silenceFilter is middleware for check silence existence and user permissions to change it, reading silence is permitted to all users
+func silenceFilter(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodGet {
+			silenceID := middleware.GetSilenceID(request)
+			userLogin := middleware.GetLogin(request)
+			if _, err := controller.CheckUserPermissionsForSilence(database, silenceID, userLogin, middleware.IsAdmin(request)); err != nil {
+				render.Render(writer, request, err)
+				return
+			}
+		}
+		next.ServeHTTP(writer, request)
+	})
+}
+
+func getSilence(writer http.ResponseWriter, request *http.Request) {
+	silenceID := middleware.GetSilenceID(request)
+	silence, err := controller.GetSilence(database, silenceID)
+	if err != nil {
+		render.Render(writer, request, err)
+		return
+	}
+	if err := render.Render(writer, request, silence); err != nil {
+		render.Render(writer, request, api.ErrorRender(err))
+	}
+}
+
+func updateSilence(writer http.ResponseWriter, request *http.Request) {
+	silence := &dto.Silence{}
+	if err := render.Bind(request, silence); err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err))
+		return
+	}
+	silenceID := middleware.GetSilenceID(request)
+	userLogin := middleware.GetLogin(request)
+	if err := controller.CheckUserPermissionsForSilencedTriggers(database, silence, userLogin, middleware.IsAdmin(request)); err != nil {
+		render.Render(writer, request, err)
+		return
+	}
+
+	if err := controller.UpdateSilence(database, silence, silenceID, userLogin); err != nil {
+		render.Render(writer, request, err)
+		return
+	}
+	if err := render.Render(writer, request, silence); err != nil {
+		render.Render(writer, request, api.ErrorRender(err))
+	}
+}
+
+func removeSilence(writer http.ResponseWriter, request *http.Request) {
+	silenceID := middleware.GetSilenceID(request)
+	userLogin := middleware.GetLogin(request)
+	if err := controller.RemoveSilence(database, silenceID, userLogin); err != nil {
+		render.Render(writer, request, err)
+	}
+}
@@ -23,6 +23,8 @@ func subscription(router chi.Router) {
 		router.Put("/", updateSubscription)
 		router.Delete("/", removeSubscription)
 		router.Put("/test", sendTestNotification)
+		router.Get("/escalations", getSubscriptionEscalations)
+		router.Put("/escalations", updateSubscriptionEscalations)
 	})
 }
 
@@ -53,6 +55,10 @@ func createSubscription(writer http.ResponseWriter, request *http.Request) {
 			errors.New("if any_tags is true, then the tags must be empty")))
 		return
 	}
+	if err := controller.CheckTeamAssignment(database, subscription.TeamID, userLogin, middleware.IsAdmin(request)); err != nil {
+		render.Render(writer, request, err)
+		return
+	}
 	if err := controller.CreateSubscription(database, userLogin, subscription); err != nil {
 		render.Render(writer, request, err)
 		return
@@ -68,7 +74,7 @@ func subscriptionFilter(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 		contactID := middleware.GetSubscriptionID(request)
 		userLogin := middleware.GetLogin(request)
-		subscriptionData, err := controller.CheckUserPermissionsForSubscription(database, contactID, userLogin)
+		subscriptionData, err := controller.CheckUserPermissionsForSubscription(database, contactID, userLogin, middleware.IsAdmin(request))
 		if err != nil {
 			render.Render(writer, request, err)
 			return
@@ -98,6 +104,11 @@ func updateSubscription(writer http.ResponseWriter, request *http.Request) {
 	}
 
 	subscriptionData := request.Context().Value(subscriptionKey).(moira.SubscriptionData)
+	userLogin := middleware.GetLogin(request)
+	if err := controller.CheckTeamAssignment(database, subscription.TeamID, userLogin, middleware.IsAdmin(request)); err != nil {
+		render.Render(writer, request, err)
+		return
+	}
 
 	if err := controller.UpdateSubscription(database, subscriptionData.ID, subscriptionData.User, subscription); err != nil {
 		render.Render(writer, request, err)
@@ -111,7 +122,8 @@ func updateSubscription(writer http.ResponseWriter, request *http.Request) {
 
 func removeSubscription(writer http.ResponseWriter, request *http.Request) {
 	subscriptionID := middleware.GetSubscriptionID(request)
-	if err := controller.RemoveSubscription(database, subscriptionID); err != nil {
+	userLogin := middleware.GetLogin(request)
+	if err := controller.RemoveSubscription(database, subscriptionID, userLogin); err != nil {
 		render.Render(writer, request, err)
 	}
 }
@@ -122,3 +134,34 @@ func sendTestNotification(writer http.ResponseWriter, request *http.Request) {
 		render.Render(writer, request, err)
 	}
 }
+
+func getSubscriptionEscalations(writer http.ResponseWriter, request *http.Request) {
+	subscriptionData := request.Context().Value(subscriptionKey).(moira.SubscriptionData)
+	escalations := controller.GetSubscriptionEscalations(subscriptionData)
+	if err := render.Render(writer, request, escalations); err != nil {
+		render.Render(writer, request, api.ErrorRender(err))
+	}
+}
+
+func updateSubscriptionEscalations(writer http.ResponseWriter, request *http.Request) {
+	escalations := &dto.SubscriptionEscalations{}
+	if err := render.Bind(request, escalations); err != nil {
+		switch err.(type) {
+		case dto.ErrProvidedContactsForbidden:
+			render.Render(writer, request, api.ErrorForbidden(err.Error()))
+		default:
+			render.Render(writer, request, api.ErrorInvalidRequest(err))
+		}
+		return
+	}
+	subscriptionData := request.Context().Value(subscriptionKey).(moira.SubscriptionData)
+	userLogin := middleware.GetLogin(request)
+
+	if err := controller.UpdateSubscriptionEscalations(database, subscriptionData, escalations, userLogin); err != nil {
+		render.Render(writer, request, err)
+		return
+	}
+	if err := render.Render(writer, request, escalations); err != nil {
+		render.Render(writer, request, api.ErrorRender(err))
+	}
+}
@@ -15,7 +15,7 @@ func tag(router chi.Router) {
 	router.Get("/stats", getAllTagsAndSubscriptions)
 	router.Route("/{tag}", func(router chi.Router) {
 		router.Use(middleware.TagContext)
-		router.Delete("/", removeTag)
+		router.With(middleware.AdminOnly).Delete("/", removeTag)
 	})
 }
 
@@ -7,7 +7,9 @@ import (
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/render"
+	metricSource "github.com/moira-alert/moira/metric_source"
 	"github.com/moira-alert/moira/metric_source/local"
+	"github.com/moira-alert/moira/metric_source/prometheus"
 	"github.com/moira-alert/moira/metric_source/remote"
 
 	"github.com/moira-alert/moira/api"
@@ -19,6 +21,7 @@ import (
 
 func trigger(router chi.Router) {
 	router.Use(middleware.TriggerContext)
+	router.Use(triggerFilter)
 	router.Put("/", updateTrigger)
 	router.Get("/", getTrigger)
 	router.Delete("/", removeTrigger)
@@ -29,9 +32,32 @@ func trigger(router chi.Router) {
 	})
 	router.Route("/metrics", triggerMetrics)
 	router.Put("/setMaintenance", setTriggerMaintenance)
+	router.Route("/ack", func(router chi.Router) {
+		router.Put("/", acknowledgeTrigger)
+		router.Delete("/", removeTriggerAcknowledgement)
+	})
+	router.Get("/dependencies", getTriggerDependencies)
+	router.With(middleware.Paginate(0, 100)).Get("/history", getTriggerHistory)
+	router.Put("/history/{auditRecordId}/restore", restoreTrigger)
+	router.With(middleware.Paginate(0, 100)).Get("/notifications", getTriggerDeliveries)
 	router.With(middleware.DateRange("-1hour", "now")).Get("/render", renderTrigger)
 }
 
+// triggerFilter is middleware for check user permissions to change trigger, reading trigger is permitted to all users
+func triggerFilter(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodGet {
+			triggerID := middleware.GetTriggerID(request)
+			userLogin := middleware.GetLogin(request)
+			if err := controller.CheckUserPermissionsForTrigger(database, triggerID, userLogin, middleware.IsAdmin(request)); err != nil {
+				render.Render(writer, request, err)
+				return
+			}
+		}
+		next.ServeHTTP(writer, request)
+	})
+}
+
 func updateTrigger(writer http.ResponseWriter, request *http.Request) {
 	triggerID := middleware.GetTriggerID(request)
 	trigger := &dto.Trigger{}
@@ -41,12 +67,18 @@ func updateTrigger(writer http.ResponseWriter, request *http.Request) {
 			render.Render(writer, request, api.ErrorInvalidRequest(fmt.Errorf("invalid graphite targets: %s", err.Error())))
 		case expression.ErrInvalidExpression:
 			render.Render(writer, request, api.ErrorInvalidRequest(fmt.Errorf("invalid expression: %s", err.Error())))
-		case api.ErrInvalidRequestContent:
+		case api.ErrInvalidRequestContent, metricSource.ErrRemoteClusterIsNotConfigured:
 			render.Render(writer, request, api.ErrorInvalidRequest(err))
 		case remote.ErrRemoteTriggerResponse:
 			response := api.ErrorRemoteServerUnavailable(err)
 			middleware.GetLoggerEntry(request).Error("%s : %s : %s", response.StatusText, response.ErrorText, err.Target)
 			render.Render(writer, request, response)
+		case prometheus.ErrInvalidQuery:
+			render.Render(writer, request, api.ErrorInvalidRequest(fmt.Errorf("invalid prometheus targets: %s", err.Error())))
+		case prometheus.ErrPrometheusTriggerResponse:
+			response := api.ErrorRemoteServerUnavailable(err)
+			middleware.GetLoggerEntry(request).Error("%s : %s : %s", response.StatusText, response.ErrorText, err.Target)
+			render.Render(writer, request, response)
 		default:
 			render.Render(writer, request, api.ErrorInternalServer(err))
 		}
@@ -54,7 +86,12 @@ func updateTrigger(writer http.ResponseWriter, request *http.Request) {
 	}
 
 	timeSeriesNames := middleware.GetTimeSeriesNames(request)
-	response, err := controller.UpdateTrigger(database, &trigger.TriggerModel, triggerID, timeSeriesNames)
+	userLogin := middleware.GetLogin(request)
+	if err := controller.CheckTeamAssignment(database, trigger.TeamID, userLogin, middleware.IsAdmin(request)); err != nil {
+		render.Render(writer, request, err)
+		return
+	}
+	response, err := controller.UpdateTrigger(database, &trigger.TriggerModel, triggerID, timeSeriesNames, userLogin)
 	if err != nil {
 		render.Render(writer, request, err)
 		return
@@ -68,7 +105,8 @@ func updateTrigger(writer http.ResponseWriter, request *http.Request) {
 
 func removeTrigger(writer http.ResponseWriter, request *http.Request) {
 	triggerID := middleware.GetTriggerID(request)
-	err := controller.RemoveTrigger(database, triggerID)
+	userLogin := middleware.GetLogin(request)
+	err := controller.RemoveTrigger(database, triggerID, userLogin)
 	if err != nil {
 		render.Render(writer, request, err)
 	}
@@ -115,7 +153,8 @@ func getTriggerThrottling(writer http.ResponseWriter, request *http.Request) {
 
 func deleteThrottling(writer http.ResponseWriter, request *http.Request) {
 	triggerID := middleware.GetTriggerID(request)
-	err := controller.DeleteTriggerThrottling(database, triggerID)
+	userLogin := middleware.GetLogin(request)
+	err := controller.DeleteTriggerThrottling(database, triggerID, userLogin)
 	if err != nil {
 		render.Render(writer, request, err)
 	}
@@ -136,3 +175,91 @@ func setTriggerMaintenance(writer http.ResponseWriter, request *http.Request) {
 		render.Render(writer, request, err)
 	}
 }
+
+func acknowledgeTrigger(writer http.ResponseWriter, request *http.Request) {
+	triggerID := middleware.GetTriggerID(request)
+	triggerAck := dto.TriggerAcknowledgement{}
+	if err := render.Bind(request, &triggerAck); err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err))
+		return
+	}
+	userLogin := middleware.GetLogin(request)
+	timeCallAck := time.Now().Unix()
+
+	err := controller.AcknowledgeTrigger(database, triggerID, triggerAck, userLogin, timeCallAck)
+	if err != nil {
+		render.Render(writer, request, err)
+	}
+}
+
+func removeTriggerAcknowledgement(writer http.ResponseWriter, request *http.Request) {
+	triggerID := middleware.GetTriggerID(request)
+	err := controller.RemoveTriggerAcknowledgement(database, triggerID)
+	if err != nil {
+		render.Render(writer, request, err)
+	}
+}
+
+func getTriggerDependencies(writer http.ResponseWriter, request *http.Request) {
+	triggerID := middleware.GetTriggerID(request)
+	dependencies, err := controller.GetTriggerDependencies(database, triggerID)
+	if err != nil {
+		render.Render(writer, request, err)
+		return
+	}
+	if err := render.Render(writer, request, dependencies); err != nil {
+		render.Render(writer, request, api.ErrorRender(err))
+	}
+}
+
+func getTriggerHistory(writer http.ResponseWriter, request *http.Request) {
+	triggerID := middleware.GetTriggerID(request)
+	size := middleware.GetSize(request)
+	page := middleware.GetPage(request)
+	history, err := controller.GetTriggerHistory(database, triggerID, page, size)
+	if err != nil {
+		render.Render(writer, request, err)
+		return
+	}
+	if err := render.Render(writer, request, history); err != nil {
+		render.Render(writer, request, api.ErrorRender(err))
+	}
+}
+
+func getTriggerDeliveries(writer http.ResponseWriter, request *http.Request) {
+	triggerID := middleware.GetTriggerID(request)
+	size := middleware.GetSize(request)
+	page := middleware.GetPage(request)
+	deliveries, err := controller.GetTriggerDeliveries(database, triggerID, page, size)
+	if err != nil {
+		render.Render(writer, request, err)
+		return
+	}
+	if err := render.Render(writer, request, deliveries); err != nil {
+		render.Render(writer, request, api.ErrorRender(err))
+	}
+}
+
+func restoreTrigger(writer http.ResponseWriter, request *http.Request) {
+	triggerID := middleware.GetTriggerID(request)
+	auditRecordID := chi.URLParam(request, "auditRecordId")
+	version, err := controller.GetTriggerVersion(database, triggerID, auditRecordID)
+	if err != nil {
+		render.Render(writer, request, err)
+		return
+	}
+	if err := version.Bind(request); err != nil {
+		render.Render(writer, request, getTriggerBindErrorResponse(err))
+		return
+	}
+	timeSeriesNames := middleware.GetTimeSeriesNames(request)
+	userLogin := middleware.GetLogin(request)
+	response, err := controller.RestoreTrigger(database, &version.TriggerModel, timeSeriesNames, userLogin, middleware.IsAdmin(request))
+	if err != nil {
+		render.Render(writer, request, err)
+		return
+	}
+	if err := render.Render(writer, request, response); err != nil {
+		render.Render(writer, request, api.ErrorRender(err))
+	}
+}
@@ -48,14 +48,16 @@ func getTriggerMetrics(writer http.ResponseWriter, request *http.Request) {
 func deleteTriggerMetric(writer http.ResponseWriter, request *http.Request) {
 	triggerID := middleware.GetTriggerID(request)
 	metricName := request.URL.Query().Get("name")
-	if err := controller.DeleteTriggerMetric(database, metricName, triggerID); err != nil {
+	userLogin := middleware.GetLogin(request)
+	if err := controller.DeleteTriggerMetric(database, metricName, triggerID, userLogin); err != nil {
 		render.Render(writer, request, err)
 	}
 }
 
 func deleteTriggerNodataMetrics(writer http.ResponseWriter, request *http.Request) {
 	triggerID := middleware.GetTriggerID(request)
-	if err := controller.DeleteTriggerNodataMetrics(database, triggerID); err != nil {
+	userLogin := middleware.GetLogin(request)
+	if err := controller.DeleteTriggerNodataMetrics(database, triggerID, userLogin); err != nil {
 		render.Render(writer, request, err)
 	}
 }
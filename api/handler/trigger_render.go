@@ -28,7 +28,12 @@ func renderTrigger(writer http.ResponseWriter, request *http.Request) {
 		render.Render(writer, request, api.ErrorInternalServer(err))
 		return
 	}
-	renderable, err := buildRenderable(request, trigger, metricsData)
+	history, err := metricSource.FetchTriggerHistory(sourceProvider, trigger, from, to)
+	if err != nil {
+		render.Render(writer, request, api.ErrorInternalServer(err))
+		return
+	}
+	renderable, err := buildRenderable(request, trigger, metricsData, history)
 	if err != nil {
 		render.Render(writer, request, api.ErrorInternalServer(err))
 		return
@@ -43,16 +48,9 @@ func renderTrigger(writer http.ResponseWriter, request *http.Request) {
 func getEvaluationParameters(request *http.Request) (sourceProvider *metricSource.SourceProvider, from int64, to int64, triggerID string, fetchRealtimeData bool, err error) {
 	sourceProvider = middleware.GetTriggerTargetsSourceProvider(request)
 	triggerID = middleware.GetTriggerID(request)
-	fromStr := middleware.GetFromStr(request)
-	toStr := middleware.GetToStr(request)
-	from = date.DateParamToEpoch(fromStr, "UTC", 0, time.UTC)
-	if from == 0 {
-		return sourceProvider, 0, 0, "", false, fmt.Errorf("can not parse from: %s", fromStr)
-	}
-	from -= from % 60
-	to = date.DateParamToEpoch(toStr, "UTC", 0, time.UTC)
-	if to == 0 {
-		return sourceProvider, 0, 0, "", false, fmt.Errorf("can not parse to: %s", fromStr)
+	from, to, err = getDateRangeParameters(request)
+	if err != nil {
+		return sourceProvider, 0, 0, "", false, err
 	}
 	realtime := request.URL.Query().Get("realtime")
 	if realtime == "" {
@@ -65,6 +63,21 @@ func getEvaluationParameters(request *http.Request) (sourceProvider *metricSourc
 	return
 }
 
+func getDateRangeParameters(request *http.Request) (from int64, to int64, err error) {
+	fromStr := middleware.GetFromStr(request)
+	toStr := middleware.GetToStr(request)
+	from = date.DateParamToEpoch(fromStr, "UTC", 0, time.UTC)
+	if from == 0 {
+		return 0, 0, fmt.Errorf("can not parse from: %s", fromStr)
+	}
+	from -= from % 60
+	to = date.DateParamToEpoch(toStr, "UTC", 0, time.UTC)
+	if to == 0 {
+		return 0, 0, fmt.Errorf("can not parse to: %s", toStr)
+	}
+	return from, to, nil
+}
+
 func evaluateTriggerMetrics(metricSourceProvider *metricSource.SourceProvider, from, to int64, triggerID string, fetchRealtimeData bool) ([]*metricSource.MetricData, *moira.Trigger, error) {
 	tts, trigger, err := controller.GetTriggerEvaluationResult(database, metricSourceProvider, from, to, triggerID, fetchRealtimeData)
 	if err != nil {
@@ -76,7 +89,7 @@ func evaluateTriggerMetrics(metricSourceProvider *metricSource.SourceProvider, f
 	return metricsData, trigger, err
 }
 
-func buildRenderable(request *http.Request, trigger *moira.Trigger, metricsData []*metricSource.MetricData) (*chart.Chart, error) {
+func buildRenderable(request *http.Request, trigger *moira.Trigger, metricsData []*metricSource.MetricData, history map[string]*metricSource.MetricData) (*chart.Chart, error) {
 	timezone := request.URL.Query().Get("timezone")
 	location, err := time.LoadLocation(timezone)
 	if err != nil {
@@ -87,7 +100,7 @@ func buildRenderable(request *http.Request, trigger *moira.Trigger, metricsData
 	if err != nil {
 		return nil, fmt.Errorf("can not initialize plot theme %s", err.Error())
 	}
-	renderable, err := plotTemplate.GetRenderable(trigger, metricsData)
+	renderable, err := plotTemplate.GetRenderable(trigger, metricsData, history)
 	if err != nil {
 		return nil, err
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/moira-alert/moira"
 	metricSource "github.com/moira-alert/moira/metric_source"
 	"github.com/moira-alert/moira/metric_source/local"
+	"github.com/moira-alert/moira/metric_source/prometheus"
 	"github.com/moira-alert/moira/metric_source/remote"
 
 	"github.com/moira-alert/moira/api"
@@ -20,12 +21,16 @@ import (
 	"github.com/moira-alert/moira/expression"
 )
 
-func triggers(metricSourceProvider *metricSource.SourceProvider, searcher moira.Searcher) func(chi.Router) {
+func triggers(metricSourceProvider *metricSource.SourceProvider, searcher moira.Searcher, localMetricsTTL, maxBacktestWindow int64) func(chi.Router) {
 	return func(router chi.Router) {
 		router.Use(middleware.MetricSourceProvider(metricSourceProvider))
+		router.Use(middleware.LocalMetricsTTL(localMetricsTTL))
 		router.Use(middleware.SearchIndexContext(searcher))
 		router.Get("/", getAllTriggers)
 		router.Put("/", createTrigger)
+		router.With(middleware.DateRange("-1hour", "now")).Post("/check", backtestTrigger(maxBacktestWindow))
+		router.Get("/export", exportTriggers)
+		router.Post("/import", importTriggers)
 		router.Route("/{triggerId}", trigger)
 		router.With(middleware.Paginate(0, 10)).With(middleware.Pager(false, "")).Get("/search", searchTriggers)
 		// ToDo: DEPRECATED method. Remove in Moira 2.6
@@ -49,22 +54,16 @@ func getAllTriggers(writer http.ResponseWriter, request *http.Request) {
 func createTrigger(writer http.ResponseWriter, request *http.Request) {
 	trigger := &dto.Trigger{}
 	if err := render.Bind(request, trigger); err != nil {
-		switch err.(type) {
-		case local.ErrParseExpr, local.ErrEvalExpr, local.ErrUnknownFunction:
-			render.Render(writer, request, api.ErrorInvalidRequest(fmt.Errorf("invalid graphite targets: %s", err.Error())))
-		case expression.ErrInvalidExpression:
-			render.Render(writer, request, api.ErrorInvalidRequest(fmt.Errorf("invalid expression: %s", err.Error())))
-		case api.ErrInvalidRequestContent:
-			render.Render(writer, request, api.ErrorInvalidRequest(err))
-		case remote.ErrRemoteTriggerResponse:
-			render.Render(writer, request, api.ErrorRemoteServerUnavailable(err))
-		default:
-			render.Render(writer, request, api.ErrorInternalServer(err))
-		}
+		render.Render(writer, request, getTriggerBindErrorResponse(err))
 		return
 	}
 	timeSeriesNames := middleware.GetTimeSeriesNames(request)
-	response, err := controller.CreateTrigger(database, &trigger.TriggerModel, timeSeriesNames)
+	userLogin := middleware.GetLogin(request)
+	if err := controller.CheckTeamAssignment(database, trigger.TeamID, userLogin, middleware.IsAdmin(request)); err != nil {
+		render.Render(writer, request, err)
+		return
+	}
+	response, err := controller.CreateTrigger(database, &trigger.TriggerModel, timeSeriesNames, userLogin)
 	if err != nil {
 		render.Render(writer, request, err)
 		return
@@ -76,6 +75,50 @@ func createTrigger(writer http.ResponseWriter, request *http.Request) {
 	}
 }
 
+func getTriggerBindErrorResponse(err error) *api.ErrorResponse {
+	switch err.(type) {
+	case local.ErrParseExpr, local.ErrEvalExpr, local.ErrUnknownFunction:
+		return api.ErrorInvalidRequest(fmt.Errorf("invalid graphite targets: %s", err.Error()))
+	case expression.ErrInvalidExpression:
+		return api.ErrorInvalidRequest(fmt.Errorf("invalid expression: %s", err.Error()))
+	case api.ErrInvalidRequestContent, metricSource.ErrRemoteClusterIsNotConfigured:
+		return api.ErrorInvalidRequest(err)
+	case prometheus.ErrInvalidQuery:
+		return api.ErrorInvalidRequest(fmt.Errorf("invalid prometheus targets: %s", err.Error()))
+	case remote.ErrRemoteTriggerResponse, prometheus.ErrPrometheusTriggerResponse:
+		return api.ErrorRemoteServerUnavailable(err)
+	default:
+		return api.ErrorInternalServer(err)
+	}
+}
+
+func backtestTrigger(maxWindow int64) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		trigger := &dto.Trigger{}
+		if err := render.Bind(request, trigger); err != nil {
+			render.Render(writer, request, getTriggerBindErrorResponse(err))
+			return
+		}
+		from, to, err := getDateRangeParameters(request)
+		if err != nil {
+			render.Render(writer, request, api.ErrorInvalidRequest(err))
+			return
+		}
+		sourceProvider := middleware.GetTriggerTargetsSourceProvider(request)
+		logger := middleware.GetLoggerEntry(request)
+		response, errorResponse := controller.BacktestTrigger(sourceProvider, logger, &trigger.TriggerModel, from, to, maxWindow)
+		if errorResponse != nil {
+			render.Render(writer, request, errorResponse)
+			return
+		}
+
+		if err := render.Render(writer, request, response); err != nil {
+			render.Render(writer, request, api.ErrorRender(err))
+			return
+		}
+	}
+}
+
 func searchTriggers(writer http.ResponseWriter, request *http.Request) {
 	request.ParseForm()
 	onlyErrors := getOnlyProblemsFlag(request)
@@ -101,17 +144,22 @@ func searchTriggers(writer http.ResponseWriter, request *http.Request) {
 }
 
 func getRequestTags(request *http.Request) []string {
-	var filterTags []string
+	return getRequestValues(request, "tags")
+}
+
+// getRequestValues gets list passed in request as indexed parameters, e.g. tags[0]=first&tags[1]=second
+func getRequestValues(request *http.Request, name string) []string {
+	var values []string
 	i := 0
 	for {
-		tag := request.FormValue(fmt.Sprintf("tags[%v]", i))
-		if tag == "" {
+		value := request.FormValue(fmt.Sprintf("%s[%v]", name, i))
+		if value == "" {
 			break
 		}
-		filterTags = append(filterTags, tag)
+		values = append(values, value)
 		i++
 	}
-	return filterTags
+	return values
 }
 
 func getOnlyProblemsFlag(request *http.Request) bool {
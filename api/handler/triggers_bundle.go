@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/render"
+
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/controller"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/api/middleware"
+)
+
+func exportTriggers(writer http.ResponseWriter, request *http.Request) {
+	format, err := getTriggersBundleFormat(request)
+	if err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err))
+		return
+	}
+	bundle, errorResponse := controller.ExportTriggers(database, getRequestTags(request))
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse)
+		return
+	}
+	if format == dto.TriggersBundleJSON {
+		if err := render.Render(writer, request, bundle); err != nil {
+			render.Render(writer, request, api.ErrorRender(err))
+		}
+		return
+	}
+	content, err := dto.MarshalTriggersBundle(bundle, format)
+	if err != nil {
+		render.Render(writer, request, api.ErrorRender(err))
+		return
+	}
+	writer.Header().Set("Content-Type", "application/x-yaml")
+	writer.Write(content)
+}
+
+func importTriggers(writer http.ResponseWriter, request *http.Request) {
+	format, err := getTriggersBundleFormat(request)
+	if err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err))
+		return
+	}
+	dryRun, err := getDryRunFlag(request)
+	if err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err))
+		return
+	}
+	content, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err))
+		return
+	}
+	bundle, err := dto.UnmarshalTriggersBundle(content, format)
+	if err != nil {
+		render.Render(writer, request, api.ErrorInvalidRequest(err))
+		return
+	}
+	timeSeriesNames, err := bundle.Validate(request)
+	if err != nil {
+		render.Render(writer, request, getTriggersBundleValidateErrorResponse(err))
+		return
+	}
+
+	userLogin := middleware.GetLogin(request)
+	if errorResponse := controller.CheckUserPermissionsForTriggersBundle(database, bundle, userLogin, middleware.IsAdmin(request)); errorResponse != nil {
+		render.Render(writer, request, errorResponse)
+		return
+	}
+	result, errorResponse := controller.ImportTriggers(database, bundle, timeSeriesNames, dryRun, userLogin)
+	if errorResponse != nil {
+		render.Render(writer, request, errorResponse)
+		return
+	}
+	if err := render.Render(writer, request, result); err != nil {
+		render.Render(writer, request, api.ErrorRender(err))
+	}
+}
+
+func getTriggersBundleValidateErrorResponse(err error) *api.ErrorResponse {
+	bundleErr, ok := err.(dto.ErrInvalidBundleTrigger)
+	if !ok {
+		return api.ErrorInternalServer(err)
+	}
+	response := getTriggerBindErrorResponse(bundleErr.Err)
+	response.ErrorText = fmt.Sprintf("trigger #%d '%s': %s", bundleErr.Index, bundleErr.Name, response.ErrorText)
+	return response
+}
+
+func getTriggersBundleFormat(request *http.Request) (string, error) {
+	format := request.FormValue("format")
+	switch format {
+	case "", dto.TriggersBundleJSON:
+		return dto.TriggersBundleJSON, nil
+	case dto.TriggersBundleYAML:
+		return dto.TriggersBundleYAML, nil
+	default:
+		return "", fmt.Errorf("unknown format '%s', valid formats are '%s' and '%s'", format, dto.TriggersBundleJSON, dto.TriggersBundleYAML)
+	}
+}
+
+func getDryRunFlag(request *http.Request) (bool, error) {
+	dryRunStr := request.FormValue("dry_run")
+	if dryRunStr == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(dryRunStr)
+}
@@ -42,6 +42,31 @@ func UserContext(next http.Handler) http.Handler {
 	})
 }
 
+// AdminContext checks that user from request context is one of given administrators and sets result to request context
+func AdminContext(admins []string) func(next http.Handler) http.Handler {
+	adminsMap := make(map[string]bool, len(admins))
+	for _, admin := range admins {
+		adminsMap[admin] = true
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			ctx := context.WithValue(request.Context(), isAdminKey, adminsMap[GetLogin(request)])
+			next.ServeHTTP(writer, request.WithContext(ctx))
+		})
+	}
+}
+
+// AdminOnly forbids request if user is not an administrator, should be used after AdminContext middleware
+func AdminOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if !IsAdmin(request) {
+			render.Render(writer, request, api.ErrorForbidden("only administrators are permitted"))
+			return
+		}
+		next.ServeHTTP(writer, request)
+	})
+}
+
 // TriggerContext gets triggerId from parsed URI corresponding to trigger routes and set it to request context
 func TriggerContext(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
@@ -68,6 +93,19 @@ func ContactContext(next http.Handler) http.Handler {
 	})
 }
 
+// TeamContext gets teamId from parsed URI corresponding to team routes and set it to request context
+func TeamContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		teamID := chi.URLParam(request, "teamId")
+		if teamID == "" {
+			render.Render(writer, request, api.ErrorInvalidRequest(fmt.Errorf("teamId must be set")))
+			return
+		}
+		ctx := context.WithValue(request.Context(), teamIDKey, teamID)
+		next.ServeHTTP(writer, request.WithContext(ctx))
+	})
+}
+
 // TagContext gets tagName from parsed URI corresponding to tag routes and set it to request context
 func TagContext(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
@@ -94,6 +132,19 @@ func SubscriptionContext(next http.Handler) http.Handler {
 	})
 }
 
+// SilenceContext gets silenceId from parsed URI corresponding to silence routes and set it to request context
+func SilenceContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		silenceID := chi.URLParam(request, "silenceId")
+		if silenceID == "" {
+			render.Render(writer, request, api.ErrorInvalidRequest(fmt.Errorf("silenceId must be set")))
+			return
+		}
+		ctx := context.WithValue(request.Context(), silenceIDKey, silenceID)
+		next.ServeHTTP(writer, request.WithContext(ctx))
+	})
+}
+
 // MetricSourceProvider adds metrics source provider to context
 func MetricSourceProvider(sourceProvider *metricSource.SourceProvider) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -104,6 +155,16 @@ func MetricSourceProvider(sourceProvider *metricSource.SourceProvider) func(next
 	}
 }
 
+// LocalMetricsTTL sets to requests context local metrics retention in seconds
+func LocalMetricsTTL(localMetricsTTL int64) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			ctx := context.WithValue(request.Context(), localMetricsTTLKey, localMetricsTTL)
+			next.ServeHTTP(writer, request.WithContext(ctx))
+		})
+	}
+}
+
 // Paginate gets page and size values from URI query and set it to request context. If query has not values sets given values
 func Paginate(defaultPage, defaultSize int64) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -125,9 +125,20 @@ type responseWriterWithBody struct {
 
 func (w *responseWriterWithBody) Write(buf []byte) (int, error) {
 	n, err := w.ResponseWriter.Write(buf)
+	// event streams are long-living and never contain error response, so they are not kept
+	if w.Header().Get("Content-Type") == "text/event-stream" {
+		return n, err
+	}
 	_, err2 := w.body.Write(buf[:n])
 	if err == nil {
 		err = err2
 	}
 	return n, err
 }
+
+// Flush implements http.Flusher to allow streaming responses
+func (w *responseWriterWithBody) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
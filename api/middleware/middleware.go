@@ -22,6 +22,7 @@ var (
 	contactIDKey         ContextKey = "contactID"
 	tagKey               ContextKey = "tag"
 	subscriptionIDKey    ContextKey = "subscriptionID"
+	silenceIDKey         ContextKey = "silenceID"
 	pageKey              ContextKey = "page"
 	sizeKey              ContextKey = "size"
 	pagerIDKey           ContextKey = "pagerID"
@@ -29,8 +30,11 @@ var (
 	fromKey              ContextKey = "from"
 	toKey                ContextKey = "to"
 	loginKey             ContextKey = "login"
+	isAdminKey           ContextKey = "isAdmin"
+	teamIDKey            ContextKey = "teamID"
 	timeSeriesNamesKey   ContextKey = "timeSeriesNames"
 	metricSourceProvider ContextKey = "metricSourceProvider"
+	localMetricsTTLKey   ContextKey = "localMetricsTTL"
 )
 
 // GetDatabase gets moira.Database realization from request context
@@ -43,6 +47,17 @@ func GetLogin(request *http.Request) string {
 	return request.Context().Value(loginKey).(string)
 }
 
+// IsAdmin returns true if user is an administrator, it is set in AdminContext middleware
+func IsAdmin(request *http.Request) bool {
+	isAdmin, _ := request.Context().Value(isAdminKey).(bool)
+	return isAdmin
+}
+
+// GetTeamID gets teamId string from request context, which was sets in TeamContext middleware
+func GetTeamID(request *http.Request) string {
+	return request.Context().Value(teamIDKey).(string)
+}
+
 // GetTriggerID gets TriggerID string from request context, which was sets in TriggerContext middleware
 func GetTriggerID(request *http.Request) string {
 	return request.Context().Value(triggerIDKey).(string)
@@ -58,6 +73,11 @@ func GetSubscriptionID(request *http.Request) string {
 	return request.Context().Value(subscriptionIDKey).(string)
 }
 
+// GetSilenceID gets silenceId string from request context, which was sets in SilenceContext middleware
+func GetSilenceID(request *http.Request) string {
+	return request.Context().Value(silenceIDKey).(string)
+}
+
 // GetContactID gets ContactID string from request context, which was sets in TriggerContext middleware
 func GetContactID(request *http.Request) string {
 	return request.Context().Value(contactIDKey).(string)
@@ -104,6 +124,24 @@ func GetTimeSeriesNames(request *http.Request) map[string]bool {
 	return request.Context().Value(timeSeriesNamesKey).(map[string]bool)
 }
 
+// SetTriggerTargetsSourceProvider sets to requests context trigger targets source provider, used to check triggers outside of api router
+func SetTriggerTargetsSourceProvider(request *http.Request, sourceProvider *metricSource.SourceProvider) {
+	ctx := context.WithValue(request.Context(), metricSourceProvider, sourceProvider)
+	*request = *request.WithContext(ctx)
+}
+
+// SetLocalMetricsTTL sets to requests context local metrics retention in seconds, used to check triggers outside of api router
+func SetLocalMetricsTTL(request *http.Request, localMetricsTTL int64) {
+	ctx := context.WithValue(request.Context(), localMetricsTTLKey, localMetricsTTL)
+	*request = *request.WithContext(ctx)
+}
+
+// GetLocalMetricsTTL gets local metrics retention in seconds, zero is returned if retention is unknown
+func GetLocalMetricsTTL(request *http.Request) int64 {
+	localMetricsTTL, _ := request.Context().Value(localMetricsTTLKey).(int64)
+	return localMetricsTTL
+}
+
 // GetTriggerTargetsSourceProvider gets trigger targets source provider
 func GetTriggerTargetsSourceProvider(request *http.Request) *metricSource.SourceProvider {
 	return request.Context().Value(metricSourceProvider).(*metricSource.SourceProvider)
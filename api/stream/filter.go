@@ -0,0 +1,45 @@
+package stream
+
+import "github.com/moira-alert/moira"
+
+// Filter describes streamed events subscriber is interested in, empty filter matches all events
+type Filter struct {
+	// Tags are tags event trigger must have all of
+	Tags []string
+	// TriggerIDs are triggers event must belong to one of
+	TriggerIDs []string
+	// States are states event must have one of
+	States []moira.State
+}
+
+// Match checks that event satisfies filter, getTags is called to get event trigger tags only if filter has tags
+func (filter *Filter) Match(event *moira.NotificationEvent, getTags func() []string) bool {
+	if len(filter.TriggerIDs) > 0 && !contains(filter.TriggerIDs, event.TriggerID) {
+		return false
+	}
+	if len(filter.States) > 0 {
+		matched := false
+		for _, state := range filter.States {
+			if event.State == state {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(filter.Tags) > 0 && !moira.Subset(filter.Tags, getTags()) {
+		return false
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package stream
+
+import (
+	"sync"
+
+	"github.com/moira-alert/moira"
+	"gopkg.in/tomb.v2"
+)
+
+// subscriberBufferSize is a count of events kept for subscriber which reads events slower than they are published.
+// Subscriber is unsubscribed on buffer overflow and should reconnect with last received event ID
+const subscriberBufferSize = 1024
+
+// EventsStream receives notification events published to database events stream
+// and distributes them between api subscribers
+type EventsStream struct {
+	database    moira.Database
+	logger      moira.Logger
+	tomb        tomb.Tomb
+	mutex       sync.Mutex
+	subscribers map[*Subscriber]bool
+}
+
+// Subscriber receives streamed events matching its filter
+type Subscriber struct {
+	filter Filter
+	events chan *moira.StreamedNotificationEvent
+}
+
+// NewEventsStream returns new EventsStream object
+func NewEventsStream(database moira.Database, logger moira.Logger) *EventsStream {
+	return &EventsStream{
+		database:    database,
+		logger:      logger,
+		subscribers: make(map[*Subscriber]bool),
+	}
+}
+
+// Start subscribes to database events stream and starts to distribute events between subscribers
+func (stream *EventsStream) Start() error {
+	events, err := stream.database.SubscribeNotificationEvents(&stream.tomb)
+	if err != nil {
+		return err
+	}
+	stream.tomb.Go(func() error {
+		stream.distributeEvents(events)
+		return nil
+	})
+	stream.logger.Info("Events stream started")
+	return nil
+}
+
+// Stop stops events distribution and unsubscribes all subscribers
+func (stream *EventsStream) Stop() error {
+	stream.tomb.Kill(nil)
+	err := stream.tomb.Wait()
+	stream.logger.Info("Events stream stopped")
+	return err
+}
+
+// Subscribe creates subscriber for events matching given filter
+func (stream *EventsStream) Subscribe(filter Filter) *Subscriber {
+	subscriber := &Subscriber{
+		filter: filter,
+		events: make(chan *moira.StreamedNotificationEvent, subscriberBufferSize),
+	}
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+	if !stream.tomb.Alive() {
+		close(subscriber.events)
+		return subscriber
+	}
+	stream.subscribers[subscriber] = true
+	return subscriber
+}
+
+// Unsubscribe stops sending events to given subscriber
+func (stream *EventsStream) Unsubscribe(subscriber *Subscriber) {
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+	stream.removeSubscriber(subscriber)
+}
+
+// GetEvents gets the latest streamed events published after event with given ID and matching given filter
+func (stream *EventsStream) GetEvents(afterID int64, filter Filter) ([]*moira.StreamedNotificationEvent, error) {
+	events, err := stream.database.GetStreamedNotificationEvents(afterID)
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string][]string)
+	filtered := make([]*moira.StreamedNotificationEvent, 0, len(events))
+	for _, event := range events {
+		triggerID := event.Event.TriggerID
+		matched := filter.Match(&event.Event, func() []string {
+			if _, ok := tags[triggerID]; !ok {
+				tags[triggerID] = stream.getTriggerTags(triggerID)
+			}
+			return tags[triggerID]
+		})
+		if matched {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered, nil
+}
+
+// Events returns channel of events matching subscriber filter, channel is closed when subscriber is unsubscribed
+func (subscriber *Subscriber) Events() <-chan *moira.StreamedNotificationEvent {
+	return subscriber.events
+}
+
+func (stream *EventsStream) distributeEvents(events <-chan *moira.StreamedNotificationEvent) {
+	defer stream.removeAllSubscribers()
+	for {
+		select {
+		case <-stream.tomb.Dying():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			stream.sendEvent(event)
+		}
+	}
+}
+
+func (stream *EventsStream) sendEvent(event *moira.StreamedNotificationEvent) {
+	var tags []string
+	tagsFetched := false
+	getTags := func() []string {
+		if !tagsFetched {
+			tags = stream.getTriggerTags(event.Event.TriggerID)
+			tagsFetched = true
+		}
+		return tags
+	}
+
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+	for subscriber := range stream.subscribers {
+		if !subscriber.filter.Match(&event.Event, getTags) {
+			continue
+		}
+		select {
+		case subscriber.events <- event:
+		default:
+			stream.logger.Warningf("Events stream subscriber buffer is full, unsubscribe it")
+			stream.removeSubscriber(subscriber)
+		}
+	}
+}
+
+func (stream *EventsStream) getTriggerTags(triggerID string) []string {
+	if triggerID == "" {
+		return nil
+	}
+	trigger, err := stream.database.GetTrigger(triggerID)
+	if err != nil {
+		stream.logger.Warningf("Failed to get trigger %s tags for events stream: %s", triggerID, err.Error())
+		return nil
+	}
+	return trigger.Tags
+}
+
+func (stream *EventsStream) removeSubscriber(subscriber *Subscriber) {
+	if stream.subscribers[subscriber] {
+		delete(stream.subscribers, subscriber)
+		close(subscriber.events)
+	}
+}
+
+func (stream *EventsStream) removeAllSubscribers() {
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+	for subscriber := range stream.subscribers {
+		stream.removeSubscriber(subscriber)
+	}
+}
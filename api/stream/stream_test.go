@@ -0,0 +1,170 @@
+package stream
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/logging/go-logging"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFilterMatch(t *testing.T) {
+	event := &moira.NotificationEvent{TriggerID: "triggerID", State: moira.StateERROR}
+	tagsRequested := 0
+	getTags := func() []string {
+		tagsRequested++
+		return []string{"first", "second"}
+	}
+
+	Convey("Match events", t, func() {
+		tagsRequested = 0
+
+		Convey("Empty filter matches all events", func() {
+			filter := Filter{}
+			So(filter.Match(event, getTags), ShouldBeTrue)
+			So(tagsRequested, ShouldEqual, 0)
+		})
+
+		Convey("Trigger IDs", func() {
+			filter := Filter{TriggerIDs: []string{"otherTriggerID", "triggerID"}}
+			So(filter.Match(event, getTags), ShouldBeTrue)
+			filter = Filter{TriggerIDs: []string{"otherTriggerID"}}
+			So(filter.Match(event, getTags), ShouldBeFalse)
+		})
+
+		Convey("States", func() {
+			filter := Filter{States: []moira.State{moira.StateWARN, moira.StateERROR}}
+			So(filter.Match(event, getTags), ShouldBeTrue)
+			filter = Filter{States: []moira.State{moira.StateOK}}
+			So(filter.Match(event, getTags), ShouldBeFalse)
+		})
+
+		Convey("Tags", func() {
+			filter := Filter{Tags: []string{"second"}}
+			So(filter.Match(event, getTags), ShouldBeTrue)
+			filter = Filter{Tags: []string{"second", "third"}}
+			So(filter.Match(event, getTags), ShouldBeFalse)
+			So(tagsRequested, ShouldEqual, 2)
+		})
+
+		Convey("Tags are not requested if event does not match other conditions", func() {
+			filter := Filter{Tags: []string{"first"}, States: []moira.State{moira.StateOK}}
+			So(filter.Match(event, getTags), ShouldBeFalse)
+			So(tagsRequested, ShouldEqual, 0)
+		})
+	})
+}
+
+func TestEventsStream(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	logger, _ := logging.ConfigureLog("stdout", "error", "test")
+
+	errorEvent := &moira.StreamedNotificationEvent{ID: 1, Event: moira.NotificationEvent{TriggerID: "triggerID1", State: moira.StateERROR}}
+	okEvent := &moira.StreamedNotificationEvent{ID: 2, Event: moira.NotificationEvent{TriggerID: "triggerID2", State: moira.StateOK}}
+
+	Convey("Events are distributed between subscribers", t, func() {
+		events := make(chan *moira.StreamedNotificationEvent)
+		dataBase.EXPECT().SubscribeNotificationEvents(gomock.Any()).Return((<-chan *moira.StreamedNotificationEvent)(events), nil)
+		stream := NewEventsStream(dataBase, logger)
+		So(stream.Start(), ShouldBeNil)
+
+		all := stream.Subscribe(Filter{})
+		errors := stream.Subscribe(Filter{States: []moira.State{moira.StateERROR}})
+		tagged := stream.Subscribe(Filter{Tags: []string{"tag"}})
+
+		dataBase.EXPECT().GetTrigger("triggerID1").Return(moira.Trigger{ID: "triggerID1", Tags: []string{"tag"}}, nil)
+		events <- errorEvent
+		dataBase.EXPECT().GetTrigger("triggerID2").Return(moira.Trigger{ID: "triggerID2", Tags: []string{"other"}}, nil)
+		events <- okEvent
+
+		So(<-all.Events(), ShouldEqual, errorEvent)
+		So(<-all.Events(), ShouldEqual, okEvent)
+		So(<-errors.Events(), ShouldEqual, errorEvent)
+		So(<-tagged.Events(), ShouldEqual, errorEvent)
+
+		stream.Unsubscribe(errors)
+		_, ok := <-errors.Events()
+		So(ok, ShouldBeFalse)
+
+		So(stream.Stop(), ShouldBeNil)
+		_, ok = <-all.Events()
+		So(ok, ShouldBeFalse)
+		_, ok = <-tagged.Events()
+		So(ok, ShouldBeFalse)
+
+		Convey("Subscriber of stopped stream gets closed channel", func() {
+			subscriber := stream.Subscribe(Filter{})
+			_, ok := <-subscriber.Events()
+			So(ok, ShouldBeFalse)
+			stream.Unsubscribe(subscriber)
+		})
+	})
+
+	Convey("Slow subscriber is unsubscribed", t, func() {
+		events := make(chan *moira.StreamedNotificationEvent)
+		dataBase.EXPECT().SubscribeNotificationEvents(gomock.Any()).Return((<-chan *moira.StreamedNotificationEvent)(events), nil)
+		stream := NewEventsStream(dataBase, logger)
+		So(stream.Start(), ShouldBeNil)
+		defer stream.Stop()
+
+		subscriber := stream.Subscribe(Filter{})
+		for i := 0; i <= subscriberBufferSize; i++ {
+			events <- okEvent
+		}
+		received := 0
+		for range subscriber.Events() {
+			received++
+		}
+		So(received, ShouldEqual, subscriberBufferSize)
+	})
+
+	Convey("Database subscription error", t, func() {
+		dataBase.EXPECT().SubscribeNotificationEvents(gomock.Any()).Return(nil, fmt.Errorf("oops"))
+		stream := NewEventsStream(dataBase, logger)
+		So(stream.Start(), ShouldResemble, fmt.Errorf("oops"))
+	})
+}
+
+func TestGetEvents(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	logger, _ := logging.ConfigureLog("stdout", "error", "test")
+	stream := NewEventsStream(dataBase, logger)
+
+	events := []*moira.StreamedNotificationEvent{
+		{ID: 11, Event: moira.NotificationEvent{TriggerID: "triggerID1", State: moira.StateERROR}},
+		{ID: 12, Event: moira.NotificationEvent{TriggerID: "triggerID2", State: moira.StateERROR}},
+		{ID: 13, Event: moira.NotificationEvent{TriggerID: "triggerID1", State: moira.StateOK}},
+	}
+
+	Convey("Get missed events", t, func() {
+		Convey("Without filter", func() {
+			dataBase.EXPECT().GetStreamedNotificationEvents(int64(10)).Return(events, nil)
+			actual, err := stream.GetEvents(10, Filter{})
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, events)
+		})
+
+		Convey("With tags filter trigger tags are fetched once", func() {
+			dataBase.EXPECT().GetStreamedNotificationEvents(int64(10)).Return(events, nil)
+			dataBase.EXPECT().GetTrigger("triggerID1").Return(moira.Trigger{ID: "triggerID1", Tags: []string{"tag"}}, nil)
+			dataBase.EXPECT().GetTrigger("triggerID2").Return(moira.Trigger{}, fmt.Errorf("oops"))
+			actual, err := stream.GetEvents(10, Filter{Tags: []string{"tag"}})
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, []*moira.StreamedNotificationEvent{events[0], events[2]})
+		})
+
+		Convey("Database error", func() {
+			dataBase.EXPECT().GetStreamedNotificationEvents(int64(10)).Return(nil, fmt.Errorf("oops"))
+			actual, err := stream.GetEvents(10, Filter{})
+			So(err, ShouldResemble, fmt.Errorf("oops"))
+			So(actual, ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,146 @@
+package moira
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// AuditEntityType represents type of entity which changes are recorded in audit log
+type AuditEntityType string
+
+// Entity types recorded in audit log
+const (
+	AuditEntityTrigger      AuditEntityType = "trigger"
+	AuditEntityContact      AuditEntityType = "contact"
+	AuditEntitySubscription AuditEntityType = "subscription"
+	AuditEntitySilence      AuditEntityType = "silence"
+	AuditEntityTeam         AuditEntityType = "team"
+)
+
+// AuditAction represents kind of entity change recorded in audit log
+type AuditAction string
+
+// Actions recorded in audit log
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionRemove  AuditAction = "remove"
+	AuditActionRestore AuditAction = "restore"
+	// Actions which do not change entity itself, related data is recorded instead of entity versions
+	AuditActionMaintenance      AuditAction = "maintenance"
+	AuditActionRemoveThrottling AuditAction = "remove_throttling"
+	AuditActionRemoveMetrics    AuditAction = "remove_metrics"
+)
+
+// HasEntityVersion returns true if records of action store entity versions
+func (action AuditAction) HasEntityVersion() bool {
+	switch action {
+	case AuditActionCreate, AuditActionUpdate, AuditActionRemove, AuditActionRestore:
+		return true
+	}
+	return false
+}
+
+// AuditRecord represents single change of entity made by user
+type AuditRecord struct {
+	ID         string                    `json:"id"`
+	Timestamp  int64                     `json:"timestamp"`
+	User       string                    `json:"user"`
+	EntityType AuditEntityType           `json:"entity_type"`
+	EntityID   string                    `json:"entity_id"`
+	Action     AuditAction               `json:"action"`
+	Before     json.RawMessage           `json:"before,omitempty"`
+	After      json.RawMessage           `json:"after,omitempty"`
+	Diff       map[string]AuditFieldDiff `json:"diff,omitempty"`
+}
+
+// AuditFieldDiff represents values of entity field before and after change
+type AuditFieldDiff struct {
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// NewAuditRecord creates audit record with JSON representations of entity before and after change
+// and difference between their top level fields. Nil before or after means that entity does not exist
+func NewAuditRecord(id string, timestamp int64, user string, entityType AuditEntityType, entityID string, action AuditAction, before, after interface{}) (*AuditRecord, error) {
+	record := &AuditRecord{
+		ID:         id,
+		Timestamp:  timestamp,
+		User:       user,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+	}
+	var err error
+	if record.Before, err = marshalAuditEntity(before); err != nil {
+		return nil, err
+	}
+	if record.After, err = marshalAuditEntity(after); err != nil {
+		return nil, err
+	}
+	if record.Diff, err = getAuditDiff(record.Before, record.After); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// GetEntitiesDiff returns difference between top level fields of entities JSON representations
+func GetEntitiesDiff(before, after interface{}) (map[string]AuditFieldDiff, error) {
+	beforeBytes, err := marshalAuditEntity(before)
+	if err != nil {
+		return nil, err
+	}
+	afterBytes, err := marshalAuditEntity(after)
+	if err != nil {
+		return nil, err
+	}
+	return getAuditDiff(beforeBytes, afterBytes)
+}
+
+func marshalAuditEntity(entity interface{}) (json.RawMessage, error) {
+	if entity == nil {
+		return nil, nil
+	}
+	bytes, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	if string(bytes) == "null" {
+		return nil, nil
+	}
+	return bytes, nil
+}
+
+func getAuditDiff(before, after json.RawMessage) (map[string]AuditFieldDiff, error) {
+	beforeFields, err := unmarshalAuditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := unmarshalAuditFields(after)
+	if err != nil {
+		return nil, err
+	}
+	diff := make(map[string]AuditFieldDiff)
+	for field, beforeValue := range beforeFields {
+		if afterValue, ok := afterFields[field]; !ok || !bytes.Equal(beforeValue, afterValue) {
+			diff[field] = AuditFieldDiff{Before: beforeValue, After: afterValue}
+		}
+	}
+	for field, afterValue := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			diff[field] = AuditFieldDiff{After: afterValue}
+		}
+	}
+	return diff, nil
+}
+
+func unmarshalAuditFields(entity json.RawMessage) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if entity == nil {
+		return fields, nil
+	}
+	if err := json.Unmarshal(entity, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
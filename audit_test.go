@@ -0,0 +1,50 @@
+package moira
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewAuditRecord(t *testing.T) {
+	type entity struct {
+		Name  string   `json:"name"`
+		Value *float64 `json:"value,omitempty"`
+		Tags  []string `json:"tags"`
+	}
+	value := 10.0
+
+	Convey("Create record", t, func() {
+		record, err := NewAuditRecord("id", 100, "user", AuditEntityTrigger, "trigger", AuditActionCreate, nil, &entity{Name: "name", Tags: []string{"tag"}})
+		So(err, ShouldBeNil)
+		So(record.Before, ShouldBeNil)
+		So(string(record.After), ShouldEqual, `{"name":"name","tags":["tag"]}`)
+		So(record.Diff, ShouldResemble, map[string]AuditFieldDiff{
+			"name": {After: json.RawMessage(`"name"`)},
+			"tags": {After: json.RawMessage(`["tag"]`)},
+		})
+	})
+
+	Convey("Update record contains only changed fields", t, func() {
+		before := &entity{Name: "name", Tags: []string{"tag"}}
+		after := &entity{Name: "name", Value: &value, Tags: []string{"tag", "other"}}
+		record, err := NewAuditRecord("id", 100, "user", AuditEntityTrigger, "trigger", AuditActionUpdate, before, after)
+		So(err, ShouldBeNil)
+		So(record.Diff, ShouldResemble, map[string]AuditFieldDiff{
+			"value": {After: json.RawMessage(`10`)},
+			"tags":  {Before: json.RawMessage(`["tag"]`), After: json.RawMessage(`["tag","other"]`)},
+		})
+	})
+
+	Convey("Remove record with nil pointer after", t, func() {
+		var after *entity
+		record, err := NewAuditRecord("id", 100, "user", AuditEntityContact, "contact", AuditActionRemove, &entity{Name: "name"}, after)
+		So(err, ShouldBeNil)
+		So(record.After, ShouldBeNil)
+		So(record.Diff, ShouldResemble, map[string]AuditFieldDiff{
+			"name": {Before: json.RawMessage(`"name"`)},
+			"tags": {Before: json.RawMessage(`null`)},
+		})
+	})
+}
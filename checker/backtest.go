@@ -0,0 +1,181 @@
+package checker
+
+import (
+	"github.com/moira-alert/moira"
+	metricSource "github.com/moira-alert/moira/metric_source"
+	"github.com/moira-alert/moira/metrics"
+)
+
+// DefaultBacktestCheckInterval is a default interval between replayed trigger checks
+const DefaultBacktestCheckInterval int64 = 60
+
+// MaxBacktestChecks is a maximum number of trigger checks replayed by single backtest, week at default interval
+const MaxBacktestChecks int64 = 7 * 24 * 60
+
+// BacktestResult represents result of trigger checks replayed over historical window
+type BacktestResult struct {
+	LastCheck *moira.CheckData
+	Events    []*moira.NotificationEvent
+}
+
+// Backtest replays trigger checks step by step over given time window on once fetched metrics
+// and returns notification events which would have been fired. Nothing is written to database.
+func Backtest(trigger *moira.Trigger, source metricSource.MetricSource, logger moira.Logger, from, until, checkInterval int64) (*BacktestResult, error) {
+	if checkInterval <= 0 {
+		checkInterval = DefaultBacktestCheckInterval
+	}
+	if (until-from)/checkInterval > MaxBacktestChecks {
+		return nil, ErrBacktestTooManyChecks
+	}
+	recorder := &eventsRecorder{
+		events:     make([]*moira.NotificationEvent, 0),
+		lastChecks: make(map[string]*moira.CheckData),
+	}
+	triggerChecker := &TriggerChecker{
+		database: recorder,
+		logger:   logger,
+		config:   &Config{},
+		metrics:  metrics.ConfigureCheckerMetrics(metrics.NewDummyRegistry(), nil, false).LocalMetrics,
+		source:   source,
+
+		from:  calculateFrom(from, trigger.TTL),
+		until: until,
+
+		triggerID: trigger.ID,
+		trigger:   trigger,
+		lastCheck: &moira.CheckData{
+			Metrics:   make(map[string]moira.MetricState),
+			State:     moira.StateOK,
+			Timestamp: from,
+		},
+
+		ttl:      trigger.TTL,
+		ttlState: getTTLState(trigger.TTLState),
+	}
+
+	triggerMetricsData, _, err := triggerChecker.fetch()
+	if err != nil {
+		return nil, err
+	}
+	if trigger.MuteNewMetrics {
+		// Muted new metrics start from current time in regular check, so they have to start from the beginning of the window here
+		for _, metricData := range triggerMetricsData.Main {
+			if !metricData.Wildcard {
+				triggerChecker.lastCheck.Metrics[metricData.Name] = moira.MetricState{
+					State:          moira.StateOK,
+					Timestamp:      from,
+					EventTimestamp: from,
+				}
+			}
+		}
+	}
+
+	for checkTimestamp := from + checkInterval; checkTimestamp <= until; checkTimestamp += checkInterval {
+		triggerChecker.from = calculateFrom(triggerChecker.lastCheck.Timestamp, triggerChecker.ttl)
+		triggerChecker.until = checkTimestamp
+		checkData, err := triggerChecker.replayCheck(triggerMetricsData)
+		if err != nil {
+			return nil, err
+		}
+		checkData.UpdateScore()
+		triggerChecker.lastCheck = &checkData
+	}
+
+	return &BacktestResult{
+		LastCheck: triggerChecker.lastCheck,
+		Events:    recorder.events,
+	}, nil
+}
+
+// replayCheck performs single trigger check on metrics cut to current check window.
+// Errors which change trigger state are handled as regular check does, others are returned
+func (triggerChecker *TriggerChecker) replayCheck(triggerMetricsData *metricSource.TriggerMetricsData) (moira.CheckData, error) {
+	checkData := newCheckData(triggerChecker.lastCheck, triggerChecker.until)
+	windowMetricsData := cutTriggerMetricsData(triggerMetricsData, triggerChecker.from, triggerChecker.until, triggerChecker.trigger.GetAnomalySettings())
+	err := triggerChecker.checkTriggerHasMetrics(windowMetricsData)
+	if err == nil {
+		checkData, err = triggerChecker.checkTriggerMetrics(windowMetricsData, checkData)
+	}
+	switch err.(type) {
+	case nil, ErrTriggerHasNoMetrics, ErrTriggerHasOnlyWildcards, ErrTriggerHasSameMetricNames:
+		return triggerChecker.handleCheckResult(checkData, err)
+	default:
+		return checkData, err
+	}
+}
+
+// cutTriggerMetricsData returns copy of trigger metrics data cut to given check window,
+// history of anomaly trigger is cut to the range required to calculate baselines of window values
+func cutTriggerMetricsData(triggerMetricsData *metricSource.TriggerMetricsData, from, until int64, settings *moira.AnomalySettings) *metricSource.TriggerMetricsData {
+	main := make([]*metricSource.MetricData, 0, len(triggerMetricsData.Main))
+	for _, metricData := range triggerMetricsData.Main {
+		if windowMetricData := cutMetricData(metricData, from, until); len(windowMetricData.Values) > 0 {
+			main = append(main, windowMetricData)
+		}
+	}
+	additional := make([]*metricSource.MetricData, 0, len(triggerMetricsData.Additional))
+	for _, metricData := range triggerMetricsData.Additional {
+		additional = append(additional, cutMetricData(metricData, from, until))
+	}
+	windowMetricsData := metricSource.MakeTriggerMetricsData(main, additional)
+	if triggerMetricsData.History != nil {
+		historyFrom, historyUntil := settings.GetHistoryRange(from, until)
+		windowMetricsData.History = make(map[string]*metricSource.MetricData, len(triggerMetricsData.History))
+		for metricName, metricData := range triggerMetricsData.History {
+			windowMetricsData.History[metricName] = cutMetricData(metricData, historyFrom, historyUntil)
+		}
+	}
+	return windowMetricsData
+}
+
+// cutMetricData returns copy of metric data which contains only values with timestamps in [from, until]
+func cutMetricData(metricData *metricSource.MetricData, from, until int64) *metricSource.MetricData {
+	if metricData == nil || metricData.StepTime <= 0 {
+		return metricData
+	}
+	startIndex := int64(0)
+	if from > metricData.StartTime {
+		startIndex = (from - metricData.StartTime + metricData.StepTime - 1) / metricData.StepTime
+	}
+	stopIndex := int64(0)
+	if until >= metricData.StartTime {
+		stopIndex = (until-metricData.StartTime)/metricData.StepTime + 1
+	}
+	valuesCount := int64(len(metricData.Values))
+	if stopIndex > valuesCount {
+		stopIndex = valuesCount
+	}
+	if startIndex > stopIndex {
+		startIndex = stopIndex
+	}
+	windowMetricData := *metricData
+	windowMetricData.Values = metricData.Values[startIndex:stopIndex]
+	windowMetricData.StartTime = metricData.StartTime + startIndex*metricData.StepTime
+	windowMetricData.StopTime = metricData.StartTime + stopIndex*metricData.StepTime
+	return &windowMetricData
+}
+
+// eventsRecorder keeps trigger check results in memory instead of database: notification events are collected
+// instead of pushing them, last checks are stored in memory and metrics are never removed
+type eventsRecorder struct {
+	events     []*moira.NotificationEvent
+	lastChecks map[string]*moira.CheckData
+}
+
+func (recorder *eventsRecorder) SetTriggerLastCheck(triggerID string, checkData *moira.CheckData, clusterKey moira.ClusterKey) error {
+	recorder.lastChecks[triggerID] = checkData
+	return nil
+}
+
+func (recorder *eventsRecorder) PushNotificationEvent(event *moira.NotificationEvent, ui bool) error {
+	recorder.events = append(recorder.events, event)
+	return nil
+}
+
+func (*eventsRecorder) RemovePatternsMetrics(pattern []string) error {
+	return nil
+}
+
+func (*eventsRecorder) RemoveMetricsValues(metrics []string, toTime int64) error {
+	return nil
+}
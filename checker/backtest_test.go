@@ -0,0 +1,178 @@
+package checker
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/moira-alert/moira"
+	metricSource "github.com/moira-alert/moira/metric_source"
+	mock_metric_source "github.com/moira-alert/moira/mock/metric_source"
+	"github.com/op/go-logging"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBacktest(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	logger, _ := logging.GetLogger("Test")
+	source := mock_metric_source.NewMockMetricSource(mockCtrl)
+	fetchResult := mock_metric_source.NewMockFetchResult(mockCtrl)
+
+	warnValue := float64(10)
+	errorValue := float64(20)
+	trigger := &moira.Trigger{
+		ID:          "SuperId",
+		Targets:     []string{"super.puper.pattern"},
+		WarnValue:   &warnValue,
+		ErrorValue:  &errorValue,
+		TriggerType: moira.RisingTrigger,
+		TTL:         600,
+		TTLState:    &moira.TTLStateNODATA,
+	}
+
+	var from int64 = 3600
+	var until int64 = 4200
+	values := make([]float64, 0)
+	for timestamp := int64(3000); timestamp <= until; timestamp += 60 {
+		switch {
+		case timestamp >= 4080:
+			values = append(values, 25)
+		case timestamp >= 3900:
+			values = append(values, 15)
+		default:
+			values = append(values, 0)
+		}
+	}
+	metricData := metricSource.MakeMetricData("metric", values, 60, 3000)
+
+	Convey("Backtest should return events which would have been fired", t, func() {
+		source.EXPECT().Fetch(trigger.Targets[0], int64(3000), until, true).Return(fetchResult, nil)
+		fetchResult.EXPECT().GetMetricsData().Return([]*metricSource.MetricData{metricData})
+		fetchResult.EXPECT().GetPatternMetrics().Return([]string{"metric"}, nil)
+
+		result, err := Backtest(trigger, source, logger, from, until, 0)
+		So(err, ShouldBeNil)
+
+		okValue, warningValue, errorStateValue := float64(0), float64(15), float64(25)
+		So(result.Events, ShouldResemble, []*moira.NotificationEvent{
+			{TriggerID: trigger.ID, Metric: "metric", State: moira.StateOK, OldState: moira.StateNODATA, Timestamp: 3000, Value: &okValue},
+			{TriggerID: trigger.ID, Metric: "metric", State: moira.StateWARN, OldState: moira.StateOK, Timestamp: 3900, Value: &warningValue},
+			{TriggerID: trigger.ID, Metric: "metric", State: moira.StateERROR, OldState: moira.StateWARN, Timestamp: 4080, Value: &errorStateValue},
+		})
+		So(result.LastCheck.Timestamp, ShouldEqual, until)
+		So(result.LastCheck.Metrics["metric"].State, ShouldEqual, moira.StateERROR)
+	})
+
+	Convey("Backtest should return fetch error", t, func() {
+		source.EXPECT().Fetch(trigger.Targets[0], int64(3000), until, true).Return(nil, ErrTriggerNotExists)
+		result, err := Backtest(trigger, source, logger, from, until, 60)
+		So(err, ShouldResemble, ErrTriggerNotExists)
+		So(result, ShouldBeNil)
+	})
+
+	Convey("Backtest should not fetch metrics if window requires too many checks", t, func() {
+		result, err := Backtest(trigger, source, logger, from, from+(MaxBacktestChecks+1)*60, 60)
+		So(err, ShouldResemble, ErrBacktestTooManyChecks)
+		So(result, ShouldBeNil)
+	})
+}
+
+func TestBacktestAnomaly(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	logger, _ := logging.GetLogger("Test")
+	source := mock_metric_source.NewMockMetricSource(mockCtrl)
+	fetchResult := mock_metric_source.NewMockFetchResult(mockCtrl)
+	historyFetchResult := mock_metric_source.NewMockFetchResult(mockCtrl)
+
+	warnValue := float64(2)
+	errorValue := float64(3)
+	trigger := &moira.Trigger{
+		ID:          "SuperId",
+		Targets:     []string{"super.puper.pattern"},
+		WarnValue:   &warnValue,
+		ErrorValue:  &errorValue,
+		TriggerType: moira.AnomalyTrigger,
+		Anomaly:     &moira.AnomalySettings{Seasons: 2, SeasonLength: 600, Window: 60},
+		TTL:         600,
+		TTLState:    &moira.TTLStateNODATA,
+	}
+
+	var from int64 = 3600
+	var until int64 = 4200
+	values := make([]float64, 0)
+	for timestamp := int64(3000); timestamp <= until; timestamp += 60 {
+		if timestamp >= 3900 {
+			values = append(values, 20)
+		} else {
+			values = append(values, 10)
+		}
+	}
+	history := make([]float64, 0)
+	for timestamp := int64(1740); timestamp <= 3660; timestamp += 60 {
+		history = append(history, float64(9+2*(timestamp/60%2)))
+	}
+
+	Convey("Backtest should use history to calculate anomaly trigger baselines", t, func() {
+		source.EXPECT().Fetch(trigger.Targets[0], int64(3000), until, true).Return(fetchResult, nil)
+		fetchResult.EXPECT().GetMetricsData().Return([]*metricSource.MetricData{metricSource.MakeMetricData("metric", values, 60, 3000)})
+		fetchResult.EXPECT().GetPatternMetrics().Return([]string{"metric"}, nil)
+		source.EXPECT().Fetch(trigger.Targets[0], int64(1740), int64(3660), false).Return(historyFetchResult, nil)
+		historyFetchResult.EXPECT().GetMetricsData().Return([]*metricSource.MetricData{metricSource.MakeMetricData("metric", history, 60, 1740)})
+
+		result, err := Backtest(trigger, source, logger, from, until, 0)
+		So(err, ShouldBeNil)
+
+		okValue, errorStateValue := float64(10), float64(20)
+		So(result.Events, ShouldResemble, []*moira.NotificationEvent{
+			{TriggerID: trigger.ID, Metric: "metric", State: moira.StateOK, OldState: moira.StateNODATA, Timestamp: 3000, Value: &okValue},
+			{TriggerID: trigger.ID, Metric: "metric", State: moira.StateERROR, OldState: moira.StateOK, Timestamp: 3900, Value: &errorStateValue},
+		})
+	})
+}
+
+func TestCutTriggerMetricsData(t *testing.T) {
+	Convey("Cut trigger metrics data keeps history required for baselines", t, func() {
+		settings := &moira.AnomalySettings{Seasons: 1, SeasonLength: 100, Window: 10}
+		triggerMetricsData := metricSource.MakeTriggerMetricsData(
+			[]*metricSource.MetricData{metricSource.MakeMetricData("metric", []float64{0, 1, 2, 3}, 60, 200)},
+			[]*metricSource.MetricData{})
+		triggerMetricsData.History = map[string]*metricSource.MetricData{
+			"metric": metricSource.MakeMetricData("metric", []float64{0, 1, 2, 3, 4, 5, 6}, 60, 0),
+		}
+
+		actual := cutTriggerMetricsData(triggerMetricsData, 260, 320, settings)
+		So(actual.Main, ShouldResemble, []*metricSource.MetricData{metricSource.MakeMetricData("metric", []float64{1, 2}, 60, 260)})
+		So(actual.History, ShouldResemble, map[string]*metricSource.MetricData{
+			"metric": metricSource.MakeMetricData("metric", []float64{3}, 60, 180),
+		})
+	})
+}
+
+func TestCutMetricData(t *testing.T) {
+	Convey("Cut metric data", t, func() {
+		metricData := metricSource.MakeMetricData("metric", []float64{0, 1, 2, 3, 4}, 60, 60)
+
+		Convey("to window inside of data", func() {
+			actual := cutMetricData(metricData, 100, 250)
+			So(actual, ShouldResemble, metricSource.MakeMetricData("metric", []float64{1, 2, 3}, 60, 120))
+		})
+
+		Convey("to window wider than data", func() {
+			actual := cutMetricData(metricData, 0, 1000)
+			So(actual, ShouldResemble, metricData)
+		})
+
+		Convey("to window before data", func() {
+			actual := cutMetricData(metricData, 0, 30)
+			So(actual.Values, ShouldBeEmpty)
+		})
+
+		Convey("with NaN values", func() {
+			metricData := metricSource.MakeMetricData("metric", []float64{math.NaN(), 1}, 60, 60)
+			actual := cutMetricData(metricData, 100, 120)
+			So(actual, ShouldResemble, metricSource.MakeMetricData("metric", []float64{1}, 60, 120))
+		})
+	})
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/anomaly"
 	"github.com/moira-alert/moira/expression"
 	metricSource "github.com/moira-alert/moira/metric_source"
 	"github.com/moira-alert/moira/metric_source/local"
+	"github.com/moira-alert/moira/metric_source/prometheus"
 	"github.com/moira-alert/moira/metric_source/remote"
 )
 
@@ -25,7 +27,7 @@ func (triggerChecker *TriggerChecker) Check() error {
 	}
 
 	checkData.UpdateScore()
-	return triggerChecker.database.SetTriggerLastCheck(triggerChecker.triggerID, &checkData, triggerChecker.trigger.IsRemote)
+	return triggerChecker.database.SetTriggerLastCheck(triggerChecker.triggerID, &checkData, triggerChecker.trigger.ClusterKey())
 }
 
 func (triggerChecker *TriggerChecker) checkTrigger() (moira.CheckData, error) {
@@ -180,14 +182,14 @@ func (triggerChecker *TriggerChecker) handleCheckResult(checkData moira.CheckDat
 	case ErrWrongTriggerTargets, ErrTriggerHasSameMetricNames:
 		checkData.State = moira.StateERROR
 		checkData.Message = checkingError.Error()
-	case remote.ErrRemoteTriggerResponse:
+	case remote.ErrRemoteTriggerResponse, prometheus.ErrPrometheusTriggerResponse:
 		timeSinceLastSuccessfulCheck := checkData.Timestamp - checkData.LastSuccessfulCheckTimestamp
 		if timeSinceLastSuccessfulCheck >= triggerChecker.ttl {
 			checkData.State = moira.StateEXCEPTION
 			checkData.Message = fmt.Sprintf("Remote server unavailable. Trigger is not checked for %d seconds", timeSinceLastSuccessfulCheck)
 		}
 		triggerChecker.logger.Errorf("Trigger %s: %s", triggerChecker.triggerID, checkingError.Error())
-	case local.ErrUnknownFunction, local.ErrEvalExpr:
+	case local.ErrUnknownFunction, local.ErrEvalExpr, prometheus.ErrInvalidQuery:
 		checkData.State = moira.StateEXCEPTION
 		checkData.Message = checkingError.Error()
 		triggerChecker.logger.Warningf("Trigger %s: %s", triggerChecker.triggerID, checkingError.Error())
@@ -254,9 +256,15 @@ func (triggerChecker *TriggerChecker) getMetricDataState(triggerMetricsData *met
 
 	triggerExpression.WarnValue = triggerChecker.trigger.WarnValue
 	triggerExpression.ErrorValue = triggerChecker.trigger.ErrorValue
+	triggerExpression.WarnRecoveryValue = triggerChecker.trigger.WarnRecoveryValue
+	triggerExpression.ErrorRecoveryValue = triggerChecker.trigger.ErrorRecoveryValue
 	triggerExpression.TriggerType = triggerChecker.trigger.TriggerType
 	triggerExpression.PreviousState = lastState.State
 	triggerExpression.Expression = triggerChecker.trigger.Expression
+	if triggerChecker.trigger.TriggerType == moira.AnomalyTrigger {
+		triggerExpression.Deviation = getAnomalyDeviation(triggerMetricsData, metricData.Name, triggerExpression.MainTargetValue,
+			valueTimestamp, triggerChecker.trigger.GetAnomalySettings())
+	}
 
 	expressionState, err := triggerExpression.Evaluate()
 	if err != nil {
@@ -271,6 +279,16 @@ func (triggerChecker *TriggerChecker) getMetricDataState(triggerMetricsData *met
 	), nil
 }
 
+// getAnomalyDeviation returns number of standard deviations metric value deviates from its baseline,
+// value is considered as not deviating if there is not enough history to calculate baseline
+func getAnomalyDeviation(triggerMetricsData *metricSource.TriggerMetricsData, metricName string, value float64, valueTimestamp int64, settings *moira.AnomalySettings) float64 {
+	baseline, ok := anomaly.GetBaseline(triggerMetricsData.History[metricName], valueTimestamp, settings)
+	if !ok {
+		return 0
+	}
+	return baseline.GetDeviation(value)
+}
+
 func getExpressionValues(triggerMetricsData *metricSource.TriggerMetricsData, firstTargetMetricData *metricSource.MetricData, valueTimestamp int64) (*expression.TriggerExpression, bool) {
 	expressionValues := &expression.TriggerExpression{
 		AdditionalTargetsValues: make(map[string]float64, len(triggerMetricsData.Additional)),
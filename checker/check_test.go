@@ -23,7 +23,7 @@ func TestGetMetricDataState(t *testing.T) {
 	logger, _ := logging.GetLogger("Test")
 	var warnValue float64 = 10
 	var errValue float64 = 20
-	checkerMetrics := metrics.ConfigureCheckerMetrics(metrics.NewDummyRegistry(), false)
+	checkerMetrics := metrics.ConfigureCheckerMetrics(metrics.NewDummyRegistry(), []moira.ClusterID{}, false)
 	triggerChecker := TriggerChecker{
 		logger:  logger,
 		metrics: checkerMetrics.LocalMetrics,
@@ -107,6 +107,46 @@ func TestGetMetricDataState(t *testing.T) {
 	})
 }
 
+func TestGetMetricDataStateAnomaly(t *testing.T) {
+	logger, _ := logging.GetLogger("Test")
+	var warnValue float64 = 2
+	var errValue float64 = 3
+	triggerChecker := TriggerChecker{
+		logger: logger,
+		trigger: &moira.Trigger{
+			WarnValue:   &warnValue,
+			ErrorValue:  &errValue,
+			TriggerType: moira.AnomalyTrigger,
+			Anomaly:     &moira.AnomalySettings{Seasons: 2, SeasonLength: 100, Window: 10},
+		},
+	}
+	metricData := metricSource.MakeMetricData("main.metric", []float64{10, 11, 20, 40}, 10, 200)
+	history := metricSource.MakeMetricData("main.metric", []float64{
+		9, 10, 11, 10, 10, 10, 10, 10, 10, 10,
+		9, 10, 11, 10, 10, 10, 10, 10, 10, 10,
+	}, 10, 0)
+	tts := metricSource.MakeTriggerMetricsData([]*metricSource.MetricData{metricData}, []*metricSource.MetricData{})
+	tts.History = map[string]*metricSource.MetricData{"main.metric": history}
+
+	Convey("Metric states depend on deviation from baseline", t, func() {
+		metricState, err := triggerChecker.getMetricDataState(tts, metricData, moira.MetricState{}, 210, 0)
+		So(err, ShouldBeNil)
+		So(metricState.State, ShouldEqual, moira.StateOK)
+		So(*metricState.Value, ShouldEqual, 11)
+
+		metricState, err = triggerChecker.getMetricDataState(tts, metricData, moira.MetricState{}, 220, 0)
+		So(err, ShouldBeNil)
+		So(metricState.State, ShouldEqual, moira.StateERROR)
+	})
+
+	Convey("Metric without history is OK", t, func() {
+		withoutHistory := metricSource.MakeTriggerMetricsData([]*metricSource.MetricData{metricData}, []*metricSource.MetricData{})
+		metricState, err := triggerChecker.getMetricDataState(withoutHistory, metricData, moira.MetricState{}, 230, 0)
+		So(err, ShouldBeNil)
+		So(metricState.State, ShouldEqual, moira.StateOK)
+	})
+}
+
 func TestGetMetricsDataToCheck(t *testing.T) {
 	logger, _ := logging.GetLogger("Test")
 	Convey("Get metrics data to check:", t, func() {
@@ -388,7 +428,7 @@ func TestCheckForNODATA(t *testing.T) {
 
 	var ttl int64 = 600
 
-	checkerMetrics := metrics.ConfigureCheckerMetrics(metrics.NewDummyRegistry(), false)
+	checkerMetrics := metrics.ConfigureCheckerMetrics(metrics.NewDummyRegistry(), []moira.ClusterID{}, false)
 	triggerChecker := TriggerChecker{
 		metrics: checkerMetrics.LocalMetrics,
 		logger:  logger,
@@ -485,7 +525,7 @@ func TestCheckErrors(t *testing.T) {
 
 	var ttl int64 = 30
 
-	checkerMetrics := metrics.ConfigureCheckerMetrics(metrics.NewDummyRegistry(), false)
+	checkerMetrics := metrics.ConfigureCheckerMetrics(metrics.NewDummyRegistry(), []moira.ClusterID{}, false)
 	triggerChecker := TriggerChecker{
 		triggerID: "SuperId",
 		database:  dataBase,
@@ -530,7 +570,7 @@ func TestCheckErrors(t *testing.T) {
 		}
 
 		source.EXPECT().Fetch(pattern, triggerChecker.from, triggerChecker.until, true).Return(nil, metricErr)
-		dataBase.EXPECT().SetTriggerLastCheck(triggerChecker.triggerID, &lastCheck, triggerChecker.trigger.IsRemote).Return(nil)
+		dataBase.EXPECT().SetTriggerLastCheck(triggerChecker.triggerID, &lastCheck, triggerChecker.trigger.ClusterKey()).Return(nil)
 		err := triggerChecker.Check()
 		So(err, ShouldBeNil)
 	})
@@ -558,7 +598,7 @@ func TestCheckErrors(t *testing.T) {
 
 			source.EXPECT().Fetch(pattern, triggerChecker.from, triggerChecker.until, true).Return(nil, unknownFunctionExc)
 			dataBase.EXPECT().PushNotificationEvent(&event, true).Return(nil)
-			dataBase.EXPECT().SetTriggerLastCheck(triggerChecker.triggerID, &lastCheck, triggerChecker.trigger.IsRemote).Return(nil)
+			dataBase.EXPECT().SetTriggerLastCheck(triggerChecker.triggerID, &lastCheck, triggerChecker.trigger.ClusterKey()).Return(nil)
 			err := triggerChecker.Check()
 			So(err, ShouldBeNil)
 		})
@@ -602,7 +642,7 @@ func TestCheckErrors(t *testing.T) {
 			fetchResult.EXPECT().GetMetricsData().Return([]*metricSource.MetricData{metricSource.MakeMetricData(metric, []float64{0, 1, 2, 3, 4}, retention, triggerChecker.from)})
 			fetchResult.EXPECT().GetPatternMetrics().Return([]string{metric}, nil)
 			dataBase.EXPECT().PushNotificationEvent(&event, true).Return(nil)
-			dataBase.EXPECT().SetTriggerLastCheck(triggerChecker.triggerID, &lastCheck, triggerChecker.trigger.IsRemote).Return(nil)
+			dataBase.EXPECT().SetTriggerLastCheck(triggerChecker.triggerID, &lastCheck, triggerChecker.trigger.ClusterKey()).Return(nil)
 			err := triggerChecker.Check()
 			So(err, ShouldBeNil)
 		})
@@ -978,6 +1018,7 @@ func TestHandleTriggerCheck(t *testing.T) {
 				},
 			}
 			var interval int64 = 24
+			var reminderCount int64 = 1
 			checkData := moira.CheckData{
 				State:     moira.StateOK,
 				Timestamp: time.Now().Unix(),
@@ -988,7 +1029,7 @@ func TestHandleTriggerCheck(t *testing.T) {
 				TriggerID:        triggerChecker.triggerID,
 				OldState:         moira.StateNODATA,
 				State:            moira.StateNODATA,
-				MessageEventInfo: &moira.EventInfo{Interval: &interval},
+				MessageEventInfo: &moira.EventInfo{Interval: &interval, ReminderCount: &reminderCount},
 			}
 
 			dataBase.EXPECT().PushNotificationEvent(event, true).Return(nil)
@@ -999,6 +1040,7 @@ func TestHandleTriggerCheck(t *testing.T) {
 				EventTimestamp:               checkData.Timestamp,
 				Message:                      "Trigger has no metrics, check your target",
 				LastSuccessfulCheckTimestamp: 0,
+				ReminderCount:                reminderCount,
 			}
 			So(err, ShouldBeNil)
 			So(actual, ShouldResemble, expected)
@@ -2,6 +2,8 @@ package checker
 
 import (
 	"time"
+
+	"github.com/moira-alert/moira"
 )
 
 // Config represent checker config
@@ -14,6 +16,8 @@ type Config struct {
 	StopCheckingIntervalSeconds int64
 	MaxParallelChecks           int
 	MaxParallelRemoteChecks     int
+	MaxParallelPrometheusChecks int
+	Reminders                   map[moira.State]int64
 	LogFile                     string
 	LogLevel                    string
 }
@@ -10,6 +10,9 @@ import (
 // ErrTriggerNotExists used if trigger to check does not exists
 var ErrTriggerNotExists = fmt.Errorf("trigger does not exists")
 
+// ErrBacktestTooManyChecks used if backtest window requires more checks than MaxBacktestChecks
+var ErrBacktestTooManyChecks = fmt.Errorf("backtest window is too long, at most %d checks can be replayed", MaxBacktestChecks)
+
 // ErrTriggerHasNoMetrics used if trigger has no metrics
 type ErrTriggerHasNoMetrics struct{}
 
@@ -4,11 +4,6 @@ import (
 	"github.com/moira-alert/moira"
 )
 
-var badStateReminder = map[moira.State]int64{
-	moira.StateERROR:  86400,
-	moira.StateNODATA: 86400,
-}
-
 func (triggerChecker *TriggerChecker) compareTriggerStates(currentCheck moira.CheckData) (moira.CheckData, error) {
 	lastCheck := triggerChecker.lastCheck
 
@@ -30,13 +25,16 @@ func (triggerChecker *TriggerChecker) compareTriggerStates(currentCheck moira.Ch
 		lastStateSuppressedValue = lastStateValue
 	}
 	currentCheck.SuppressedState = lastStateSuppressedValue
+	currentCheck.Acknowledgement = getActualAcknowledgement(currentCheck.Acknowledgement, currentStateValue, lastStateValue, currentCheckTimestamp)
 
 	maintenanceInfo, maintenanceTimestamp := getMaintenanceInfo(lastCheck, nil)
-	eventInfo, needSend := isStateChanged(currentStateValue, lastStateValue, currentCheckTimestamp, lastCheck.GetEventTimestamp(), lastStateSuppressed, lastStateSuppressedValue, maintenanceInfo)
-	if !needSend {
+	remindInterval := triggerChecker.getReminderInterval(currentStateValue)
+	eventInfo, needSend := isStateChanged(currentStateValue, lastStateValue, currentCheckTimestamp, lastCheck.GetEventTimestamp(), lastStateSuppressed, lastStateSuppressedValue, maintenanceInfo, lastCheck.SuppressedDependency, remindInterval)
+	if !needSend || isReminderAcknowledged(eventInfo, currentCheck.Acknowledgement, currentCheckTimestamp) {
 		if maintenanceTimestamp < currentCheckTimestamp {
 			currentCheck.Suppressed = false
 			currentCheck.SuppressedState = ""
+			currentCheck.SuppressedDependency = ""
 		}
 		return currentCheck, nil
 	}
@@ -48,11 +46,23 @@ func (triggerChecker *TriggerChecker) compareTriggerStates(currentCheck moira.Ch
 		if !lastStateSuppressed {
 			currentCheck.SuppressedState = lastStateValue
 		}
+		currentCheck.SuppressedDependency = ""
+		return currentCheck, nil
+	}
+
+	if triggerChecker.badDependency != "" {
+		currentCheck.Suppressed = true
+		if !lastStateSuppressed {
+			currentCheck.SuppressedState = lastStateValue
+		}
+		currentCheck.SuppressedDependency = triggerChecker.badDependency
 		return currentCheck, nil
 	}
 
 	currentCheck.Suppressed = false
 	currentCheck.SuppressedState = ""
+	currentCheck.SuppressedDependency = ""
+	currentCheck.ReminderCount = getReminderCount(eventInfo, lastCheck.ReminderCount)
 
 	err := triggerChecker.database.PushNotificationEvent(&moira.NotificationEvent{
 		IsTriggerEvent:   true,
@@ -62,6 +72,7 @@ func (triggerChecker *TriggerChecker) compareTriggerStates(currentCheck moira.Ch
 		Timestamp:        currentCheckTimestamp,
 		Metric:           triggerChecker.trigger.Name,
 		MessageEventInfo: eventInfo,
+		SilenceID:        triggerChecker.getSilenceID("", currentCheckTimestamp),
 	}, true)
 	return currentCheck, err
 }
@@ -81,12 +92,19 @@ func (triggerChecker *TriggerChecker) compareMetricStates(metric string, current
 	}
 	currentState.SuppressedState = lastState.SuppressedState
 
+	if triggerChecker.isStateChangePending(&currentState, lastState) {
+		return currentState, nil
+	}
+	currentState.Acknowledgement = getActualAcknowledgement(currentState.Acknowledgement, currentState.State, lastState.State, currentState.Timestamp)
+
 	maintenanceInfo, maintenanceTimestamp := getMaintenanceInfo(triggerChecker.lastCheck, &currentState)
-	eventInfo, needSend := isStateChanged(currentState.State, lastState.State, currentState.Timestamp, lastState.GetEventTimestamp(), lastState.Suppressed, lastState.SuppressedState, maintenanceInfo)
-	if !needSend {
+	remindInterval := triggerChecker.getReminderInterval(currentState.State)
+	eventInfo, needSend := isStateChanged(currentState.State, lastState.State, currentState.Timestamp, lastState.GetEventTimestamp(), lastState.Suppressed, lastState.SuppressedState, maintenanceInfo, lastState.SuppressedDependency, remindInterval)
+	if !needSend || isReminderAcknowledged(eventInfo, currentState.Acknowledgement, currentState.Timestamp) {
 		if maintenanceTimestamp < currentState.Timestamp {
 			currentState.Suppressed = false
 			currentState.SuppressedState = ""
+			currentState.SuppressedDependency = ""
 		}
 		return currentState, nil
 	}
@@ -99,11 +117,25 @@ func (triggerChecker *TriggerChecker) compareMetricStates(metric string, current
 		if !lastState.Suppressed {
 			currentState.SuppressedState = lastState.State
 		}
+		currentState.SuppressedDependency = ""
+		return currentState, nil
+	}
+
+	// Event is not sent while one of parent triggers is in bad state, it will be sent after parent recovery
+	// if metric state still differs from the state it had before suppression
+	if triggerChecker.badDependency != "" {
+		currentState.Suppressed = true
+		if !lastState.Suppressed {
+			currentState.SuppressedState = lastState.State
+		}
+		currentState.SuppressedDependency = triggerChecker.badDependency
 		return currentState, nil
 	}
 
 	currentState.Suppressed = false
 	currentState.SuppressedState = ""
+	currentState.SuppressedDependency = ""
+	currentState.ReminderCount = getReminderCount(eventInfo, lastState.ReminderCount)
 
 	err := triggerChecker.database.PushNotificationEvent(&moira.NotificationEvent{
 		TriggerID:        triggerChecker.triggerID,
@@ -113,10 +145,36 @@ func (triggerChecker *TriggerChecker) compareMetricStates(metric string, current
 		Metric:           metric,
 		MessageEventInfo: eventInfo,
 		Value:            currentState.Value,
+		SilenceID:        triggerChecker.getSilenceID(metric, currentState.Timestamp),
 	}, true)
 	return currentState, err
 }
 
+// isStateChangePending checks that metric stays in new state less than trigger pending interval.
+// In this case metric keeps last state and new state is saved as pending one to be checked next time.
+// Changes from and to NODATA state are not delayed because they are already controlled by trigger TTL
+func (triggerChecker *TriggerChecker) isStateChangePending(currentState *moira.MetricState, lastState moira.MetricState) bool {
+	currentState.PendingState = ""
+	currentState.PendingTimestamp = 0
+	if triggerChecker.trigger.PendingInterval <= 0 || currentState.State == lastState.State ||
+		currentState.State == moira.StateNODATA || lastState.State == moira.StateNODATA {
+		return false
+	}
+
+	pendingTimestamp := currentState.Timestamp
+	if lastState.PendingState == currentState.State {
+		pendingTimestamp = lastState.PendingTimestamp
+	}
+	if currentState.Timestamp-pendingTimestamp >= triggerChecker.trigger.PendingInterval {
+		return false
+	}
+
+	currentState.PendingState = currentState.State
+	currentState.PendingTimestamp = pendingTimestamp
+	currentState.State = lastState.State
+	return true
+}
+
 func getEventOldState(lastCheckState moira.State, lastCheckSuppressedState moira.State, isSuppressed bool) moira.State {
 	if isSuppressed {
 		return lastCheckSuppressedState
@@ -128,17 +186,64 @@ func (triggerChecker *TriggerChecker) isTriggerSuppressed(timestamp int64, maint
 	return !triggerChecker.trigger.Schedule.IsScheduleAllows(timestamp) || maintenanceTimestamp >= timestamp
 }
 
-func isStateChanged(currentStateValue moira.State, lastStateValue moira.State, currentStateTimestamp int64, lastStateEventTimestamp int64, isLastCheckSuppressed bool, lastStateSuppressedValue moira.State, maintenanceInfo moira.MaintenanceInfo) (*moira.EventInfo, bool) {
+// getSilenceID returns ID of silence matching trigger event or event of given metric. Notifications about silenced events are not sent.
+// Trigger event is matched with empty metric
+func (triggerChecker *TriggerChecker) getSilenceID(metric string, timestamp int64) *string {
+	silence := moira.GetMatchingSilence(triggerChecker.silences, triggerChecker.trigger.Tags, metric, timestamp)
+	if silence == nil {
+		return nil
+	}
+	return &silence.ID
+}
+
+// getActualAcknowledgement returns acknowledgement if it is not expired and state was not changed since acknowledgement
+func getActualAcknowledgement(ack *moira.Acknowledgement, currentStateValue moira.State, lastStateValue moira.State, timestamp int64) *moira.Acknowledgement {
+	if currentStateValue != lastStateValue || !ack.IsActive(timestamp) {
+		return nil
+	}
+	return ack
+}
+
+// isReminderAcknowledged checks that event is a reminder about bad state and the state is acknowledged.
+// Reminders are not sent while the state is acknowledged
+func isReminderAcknowledged(eventInfo *moira.EventInfo, ack *moira.Acknowledgement, timestamp int64) bool {
+	return eventInfo != nil && eventInfo.Interval != nil && ack.IsActive(timestamp)
+}
+
+// getReminderInterval returns interval in seconds between reminders about trigger or metric staying in given state,
+// zero interval means that reminders about the state are not sent
+func (triggerChecker *TriggerChecker) getReminderInterval(state moira.State) int64 {
+	defaults := moira.GetDefaultReminders()
+	if triggerChecker.config != nil && triggerChecker.config.Reminders != nil {
+		defaults = triggerChecker.config.Reminders
+	}
+	return triggerChecker.trigger.GetReminderInterval(state, defaults)
+}
+
+// getReminderCount returns sequence number of sent event about the same bad state and sets it to reminder event info.
+// Counter is reset by any event which is not a reminder
+func getReminderCount(eventInfo *moira.EventInfo, lastReminderCount int64) int64 {
+	if eventInfo == nil || eventInfo.Interval == nil || eventInfo.Maintenance != nil {
+		return 0
+	}
+	reminderCount := lastReminderCount + 1
+	eventInfo.ReminderCount = &reminderCount
+	return reminderCount
+}
+
+func isStateChanged(currentStateValue moira.State, lastStateValue moira.State, currentStateTimestamp int64, lastStateEventTimestamp int64, isLastCheckSuppressed bool, lastStateSuppressedValue moira.State, maintenanceInfo moira.MaintenanceInfo, suppressedDependency string, remindInterval int64) (*moira.EventInfo, bool) {
 	if !isLastCheckSuppressed && currentStateValue != lastStateValue {
 		return nil, true
 	}
 
 	if isLastCheckSuppressed && currentStateValue != lastStateSuppressedValue {
+		if suppressedDependency != "" {
+			return &moira.EventInfo{Dependency: &suppressedDependency}, true
+		}
 		return &moira.EventInfo{Maintenance: &maintenanceInfo}, true
 	}
 
-	remindInterval, ok := badStateReminder[currentStateValue]
-	if ok && needRemindAgain(currentStateTimestamp, lastStateEventTimestamp, remindInterval) {
+	if remindInterval > 0 && needRemindAgain(currentStateTimestamp, lastStateEventTimestamp, remindInterval) {
 		interval := remindInterval / 3600
 		return &moira.EventInfo{Interval: &interval}, true
 	}
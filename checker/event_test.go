@@ -91,6 +91,7 @@ func TestCompareMetricStates(t *testing.T) {
 				currentState.Timestamp = 1502809200
 
 				var interval int64 = 24
+				var reminderCount int64 = 1
 				dataBase.EXPECT().PushNotificationEvent(&moira.NotificationEvent{
 					TriggerID:        triggerChecker.triggerID,
 					Timestamp:        currentState.Timestamp,
@@ -99,12 +100,13 @@ func TestCompareMetricStates(t *testing.T) {
 					Metric:           "m1",
 					Value:            currentState.Value,
 					Message:          nil,
-					MessageEventInfo: &moira.EventInfo{Interval: &interval},
+					MessageEventInfo: &moira.EventInfo{Interval: &interval, ReminderCount: &reminderCount},
 				}, true).Return(nil)
 				actual, err := triggerChecker.compareMetricStates("m1", currentState, lastState)
 				So(err, ShouldBeNil)
 				currentState.EventTimestamp = currentState.Timestamp
 				currentState.Suppressed = false
+				currentState.ReminderCount = reminderCount
 				So(actual, ShouldResemble, currentState)
 			})
 
@@ -116,10 +118,12 @@ func TestCompareMetricStates(t *testing.T) {
 				lastState := lastStateExample
 				currentState := currentStateExample
 				lastState.State = moira.StateERROR
+				lastState.ReminderCount = 1
 				currentState.State = moira.StateERROR
 				currentState.Timestamp = 1502809200
 
 				var interval int64 = 24
+				var reminderCount int64 = 2
 				dataBase.EXPECT().PushNotificationEvent(&moira.NotificationEvent{
 					TriggerID:        triggerChecker.triggerID,
 					Timestamp:        currentState.Timestamp,
@@ -128,12 +132,13 @@ func TestCompareMetricStates(t *testing.T) {
 					Metric:           "m1",
 					Value:            currentState.Value,
 					Message:          nil,
-					MessageEventInfo: &moira.EventInfo{Interval: &interval},
+					MessageEventInfo: &moira.EventInfo{Interval: &interval, ReminderCount: &reminderCount},
 				}, true).Return(nil)
 				actual, err := triggerChecker.compareMetricStates("m1", currentState, lastState)
 				So(err, ShouldBeNil)
 				currentState.EventTimestamp = currentState.Timestamp
 				currentState.Suppressed = false
+				currentState.ReminderCount = reminderCount
 				So(actual, ShouldResemble, currentState)
 			})
 
@@ -185,6 +190,424 @@ func TestCompareMetricStates(t *testing.T) {
 	})
 }
 
+func TestCompareMetricStatesWithPendingInterval(t *testing.T) {
+	dataBase, mockCtrl := newMocks(t)
+	defer mockCtrl.Finish()
+	logger, _ := logging.GetLogger("Test")
+
+	triggerChecker := TriggerChecker{
+		triggerID: "SuperId",
+		database:  dataBase,
+		logger:    logger,
+		trigger:   &moira.Trigger{PendingInterval: 120},
+		lastCheck: &moira.CheckData{},
+	}
+
+	lastState := moira.MetricState{
+		State:          moira.StateOK,
+		Timestamp:      1502712000,
+		EventTimestamp: 1502708400,
+	}
+
+	Convey("Test compare metric states with pending interval", t, func() {
+		Convey("New state is pending until pending interval is passed", func() {
+			currentState := moira.MetricState{State: moira.StateERROR, Timestamp: 1502712060}
+			actual, err := triggerChecker.compareMetricStates("m1", currentState, lastState)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, moira.MetricState{
+				State:            moira.StateOK,
+				Timestamp:        1502712060,
+				EventTimestamp:   lastState.EventTimestamp,
+				PendingState:     moira.StateERROR,
+				PendingTimestamp: 1502712060,
+			})
+
+			currentState = moira.MetricState{State: moira.StateERROR, Timestamp: 1502712120}
+			actual, err = triggerChecker.compareMetricStates("m1", currentState, actual)
+			So(err, ShouldBeNil)
+			So(actual.State, ShouldResemble, moira.StateOK)
+			So(actual.PendingTimestamp, ShouldEqual, 1502712060)
+
+			dataBase.EXPECT().PushNotificationEvent(&moira.NotificationEvent{
+				TriggerID: triggerChecker.triggerID,
+				State:     moira.StateERROR,
+				OldState:  moira.StateOK,
+				Timestamp: 1502712180,
+				Metric:    "m1",
+			}, true).Return(nil)
+			currentState = moira.MetricState{State: moira.StateERROR, Timestamp: 1502712180}
+			actual, err = triggerChecker.compareMetricStates("m1", currentState, actual)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, moira.MetricState{
+				State:          moira.StateERROR,
+				Timestamp:      1502712180,
+				EventTimestamp: 1502712180,
+			})
+		})
+
+		Convey("Pending state is reset if metric returns to last state", func() {
+			currentState := moira.MetricState{State: moira.StateERROR, Timestamp: 1502712060}
+			actual, err := triggerChecker.compareMetricStates("m1", currentState, lastState)
+			So(err, ShouldBeNil)
+			So(actual.PendingState, ShouldResemble, moira.StateERROR)
+
+			currentState = moira.MetricState{State: moira.StateOK, Timestamp: 1502712120}
+			actual, err = triggerChecker.compareMetricStates("m1", currentState, actual)
+			So(err, ShouldBeNil)
+			So(actual.PendingState, ShouldBeEmpty)
+			So(actual.PendingTimestamp, ShouldBeZeroValue)
+			So(actual.State, ShouldResemble, moira.StateOK)
+		})
+
+		Convey("Change to NODATA state is not delayed", func() {
+			dataBase.EXPECT().PushNotificationEvent(&moira.NotificationEvent{
+				TriggerID: triggerChecker.triggerID,
+				State:     moira.StateNODATA,
+				OldState:  moira.StateOK,
+				Timestamp: 1502712060,
+				Metric:    "m1",
+			}, true).Return(nil)
+			currentState := moira.MetricState{State: moira.StateNODATA, Timestamp: 1502712060}
+			actual, err := triggerChecker.compareMetricStates("m1", currentState, lastState)
+			So(err, ShouldBeNil)
+			So(actual.State, ShouldResemble, moira.StateNODATA)
+		})
+	})
+}
+
+func TestCompareStatesWithSilences(t *testing.T) {
+	dataBase, mockCtrl := newMocks(t)
+	defer mockCtrl.Finish()
+	logger, _ := logging.GetLogger("Test")
+	silenceID := "silence-id"
+
+	triggerChecker := TriggerChecker{
+		triggerID: "SuperId",
+		database:  dataBase,
+		logger:    logger,
+		trigger:   &moira.Trigger{Name: "Super trigger", Tags: []string{"tag"}},
+		lastCheck: &moira.CheckData{},
+		silences: []*moira.Silence{
+			{ID: silenceID, Metric: "silenced.*", StartTime: 1502712000, EndTime: 1502715600},
+		},
+	}
+
+	lastState := moira.MetricState{
+		State:          moira.StateOK,
+		Timestamp:      1502712000,
+		EventTimestamp: 1502708400,
+	}
+
+	Convey("Test compare states with silences", t, func() {
+		Convey("Event of matching metric is annotated with silence ID", func() {
+			dataBase.EXPECT().PushNotificationEvent(&moira.NotificationEvent{
+				TriggerID: triggerChecker.triggerID,
+				State:     moira.StateERROR,
+				OldState:  moira.StateOK,
+				Timestamp: 1502712060,
+				Metric:    "silenced.metric",
+				SilenceID: &silenceID,
+			}, true).Return(nil)
+			currentState := moira.MetricState{State: moira.StateERROR, Timestamp: 1502712060}
+			actual, err := triggerChecker.compareMetricStates("silenced.metric", currentState, lastState)
+			So(err, ShouldBeNil)
+			So(actual.State, ShouldResemble, moira.StateERROR)
+			So(actual.Suppressed, ShouldBeFalse)
+		})
+
+		Convey("Event of other metric is not annotated", func() {
+			dataBase.EXPECT().PushNotificationEvent(&moira.NotificationEvent{
+				TriggerID: triggerChecker.triggerID,
+				State:     moira.StateERROR,
+				OldState:  moira.StateOK,
+				Timestamp: 1502712060,
+				Metric:    "other.metric",
+			}, true).Return(nil)
+			currentState := moira.MetricState{State: moira.StateERROR, Timestamp: 1502712060}
+			_, err := triggerChecker.compareMetricStates("other.metric", currentState, lastState)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Event after silence end is not annotated", func() {
+			dataBase.EXPECT().PushNotificationEvent(&moira.NotificationEvent{
+				TriggerID: triggerChecker.triggerID,
+				State:     moira.StateERROR,
+				OldState:  moira.StateOK,
+				Timestamp: 1502715600,
+				Metric:    "silenced.metric",
+			}, true).Return(nil)
+			currentState := moira.MetricState{State: moira.StateERROR, Timestamp: 1502715600}
+			_, err := triggerChecker.compareMetricStates("silenced.metric", currentState, lastState)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Trigger event is annotated with silence matching trigger tags", func() {
+			tagsSilenceID := "tags-silence-id"
+			triggerChecker.silences = append(triggerChecker.silences, &moira.Silence{
+				ID: tagsSilenceID, Tags: []string{"tag"}, StartTime: 1502712000, EndTime: 1502715600,
+			})
+			dataBase.EXPECT().PushNotificationEvent(&moira.NotificationEvent{
+				IsTriggerEvent: true,
+				TriggerID:      triggerChecker.triggerID,
+				State:          moira.StateERROR,
+				OldState:       moira.StateOK,
+				Timestamp:      1502712060,
+				Metric:         triggerChecker.trigger.Name,
+				SilenceID:      &tagsSilenceID,
+			}, true).Return(nil)
+			triggerChecker.lastCheck = &moira.CheckData{State: moira.StateOK, Timestamp: 1502712000}
+			_, err := triggerChecker.compareTriggerStates(moira.CheckData{State: moira.StateERROR, Timestamp: 1502712060})
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestCompareStatesWithBadDependency(t *testing.T) {
+	dataBase, mockCtrl := newMocks(t)
+	defer mockCtrl.Finish()
+	logger, _ := logging.GetLogger("Test")
+
+	triggerChecker := TriggerChecker{
+		triggerID:     "SuperId",
+		database:      dataBase,
+		logger:        logger,
+		trigger:       &moira.Trigger{Name: "Super trigger", Dependencies: []string{"ParentId"}},
+		lastCheck:     &moira.CheckData{},
+		badDependency: "ParentId",
+	}
+	lastState := moira.MetricState{
+		State:          moira.StateOK,
+		Timestamp:      1502712000,
+		EventTimestamp: 1502712000,
+	}
+
+	Convey("Test compare states with parent trigger in bad state", t, func() {
+		Convey("Metric event is suppressed while parent trigger is in bad state", func() {
+			currentState := moira.MetricState{State: moira.StateERROR, Timestamp: 1502712060}
+			actual, err := triggerChecker.compareMetricStates("m1", currentState, lastState)
+			So(err, ShouldBeNil)
+			So(actual.Suppressed, ShouldBeTrue)
+			So(actual.SuppressedState, ShouldEqual, moira.StateOK)
+			So(actual.SuppressedDependency, ShouldEqual, "ParentId")
+			So(actual.EventTimestamp, ShouldEqual, 1502712060)
+
+			Convey("Event is sent with dependency info after parent recovery", func() {
+				triggerChecker.badDependency = ""
+				dependency := "ParentId"
+				dataBase.EXPECT().PushNotificationEvent(&moira.NotificationEvent{
+					TriggerID:        triggerChecker.triggerID,
+					State:            moira.StateERROR,
+					OldState:         moira.StateOK,
+					Timestamp:        1502712120,
+					Metric:           "m1",
+					MessageEventInfo: &moira.EventInfo{Dependency: &dependency},
+				}, true).Return(nil)
+				currentState := moira.MetricState{State: moira.StateERROR, Timestamp: 1502712120}
+				actual, err = triggerChecker.compareMetricStates("m1", currentState, actual)
+				So(err, ShouldBeNil)
+				So(actual.Suppressed, ShouldBeFalse)
+				So(actual.SuppressedDependency, ShouldBeEmpty)
+				triggerChecker.badDependency = "ParentId"
+			})
+
+			Convey("No event is sent if metric recovers before parent trigger", func() {
+				currentState := moira.MetricState{State: moira.StateOK, Timestamp: 1502712120}
+				actual, err = triggerChecker.compareMetricStates("m1", currentState, actual)
+				So(err, ShouldBeNil)
+				So(actual.Suppressed, ShouldBeFalse)
+				So(actual.SuppressedDependency, ShouldBeEmpty)
+			})
+		})
+
+		Convey("Trigger event is suppressed while parent trigger is in bad state", func() {
+			triggerChecker.lastCheck = &moira.CheckData{State: moira.StateOK, Timestamp: 1502712000, EventTimestamp: 1502712000}
+			actual, err := triggerChecker.compareTriggerStates(moira.CheckData{State: moira.StateNODATA, Timestamp: 1502712060})
+			So(err, ShouldBeNil)
+			So(actual.Suppressed, ShouldBeTrue)
+			So(actual.SuppressedState, ShouldEqual, moira.StateOK)
+			So(actual.SuppressedDependency, ShouldEqual, "ParentId")
+		})
+	})
+}
+
+func TestCompareStatesWithAcknowledgement(t *testing.T) {
+	dataBase, mockCtrl := newMocks(t)
+	defer mockCtrl.Finish()
+	logger, _ := logging.GetLogger("Test")
+
+	triggerChecker := TriggerChecker{
+		triggerID: "SuperId",
+		database:  dataBase,
+		logger:    logger,
+		trigger:   &moira.Trigger{Name: "Super trigger"},
+		lastCheck: &moira.CheckData{},
+	}
+
+	ack := &moira.Acknowledgement{User: "user", Timestamp: 1502712000}
+	lastState := moira.MetricState{
+		State:           moira.StateERROR,
+		Timestamp:       1502712000,
+		EventTimestamp:  1502600000,
+		Acknowledgement: ack,
+	}
+	var interval int64 = 24
+	var reminderCount int64 = 1
+
+	Convey("Test compare states with acknowledgement", t, func() {
+		Convey("Reminder is not sent while metric is acknowledged", func() {
+			currentState := moira.MetricState{State: moira.StateERROR, Timestamp: 1502712060, Acknowledgement: ack}
+			actual, err := triggerChecker.compareMetricStates("m1", currentState, lastState)
+			So(err, ShouldBeNil)
+			So(actual.Acknowledgement, ShouldResemble, ack)
+			So(actual.EventTimestamp, ShouldEqual, lastState.EventTimestamp)
+		})
+
+		Convey("Reminder is sent after acknowledgement expiry", func() {
+			expiredAck := &moira.Acknowledgement{User: "user", Timestamp: 1502712000, Expiry: 1502712060}
+			dataBase.EXPECT().PushNotificationEvent(&moira.NotificationEvent{
+				TriggerID:        triggerChecker.triggerID,
+				State:            moira.StateERROR,
+				OldState:         moira.StateERROR,
+				Timestamp:        1502712060,
+				Metric:           "m1",
+				MessageEventInfo: &moira.EventInfo{Interval: &interval, ReminderCount: &reminderCount},
+			}, true).Return(nil)
+			currentState := moira.MetricState{State: moira.StateERROR, Timestamp: 1502712060, Acknowledgement: expiredAck}
+			actual, err := triggerChecker.compareMetricStates("m1", currentState, lastState)
+			So(err, ShouldBeNil)
+			So(actual.Acknowledgement, ShouldBeNil)
+			So(actual.EventTimestamp, ShouldEqual, 1502712060)
+		})
+
+		Convey("Acknowledgement is removed when state changes", func() {
+			dataBase.EXPECT().PushNotificationEvent(&moira.NotificationEvent{
+				TriggerID: triggerChecker.triggerID,
+				State:     moira.StateOK,
+				OldState:  moira.StateERROR,
+				Timestamp: 1502712060,
+				Metric:    "m1",
+			}, true).Return(nil)
+			currentState := moira.MetricState{State: moira.StateOK, Timestamp: 1502712060, Acknowledgement: ack}
+			actual, err := triggerChecker.compareMetricStates("m1", currentState, lastState)
+			So(err, ShouldBeNil)
+			So(actual.Acknowledgement, ShouldBeNil)
+		})
+
+		Convey("Trigger reminder is not sent while trigger is acknowledged", func() {
+			triggerChecker.lastCheck = &moira.CheckData{State: moira.StateNODATA, Timestamp: 1502712000, EventTimestamp: 1502600000, Acknowledgement: ack}
+			actual, err := triggerChecker.compareTriggerStates(moira.CheckData{State: moira.StateNODATA, Timestamp: 1502712060, Acknowledgement: ack})
+			So(err, ShouldBeNil)
+			So(actual.Acknowledgement, ShouldResemble, ack)
+			So(actual.EventTimestamp, ShouldEqual, 1502600000)
+		})
+
+		Convey("Trigger acknowledgement is removed when trigger state changes", func() {
+			dataBase.EXPECT().PushNotificationEvent(&moira.NotificationEvent{
+				IsTriggerEvent: true,
+				TriggerID:      triggerChecker.triggerID,
+				State:          moira.StateOK,
+				OldState:       moira.StateNODATA,
+				Timestamp:      1502712060,
+				Metric:         triggerChecker.trigger.Name,
+			}, true).Return(nil)
+			triggerChecker.lastCheck = &moira.CheckData{State: moira.StateNODATA, Timestamp: 1502712000, EventTimestamp: 1502600000, Acknowledgement: ack}
+			actual, err := triggerChecker.compareTriggerStates(moira.CheckData{State: moira.StateOK, Timestamp: 1502712060, Acknowledgement: ack})
+			So(err, ShouldBeNil)
+			So(actual.Acknowledgement, ShouldBeNil)
+		})
+	})
+}
+
+func TestCompareStatesWithReminders(t *testing.T) {
+	dataBase, mockCtrl := newMocks(t)
+	defer mockCtrl.Finish()
+	logger, _ := logging.GetLogger("Test")
+
+	triggerChecker := TriggerChecker{
+		triggerID: "SuperId",
+		database:  dataBase,
+		logger:    logger,
+		config:    &Config{Reminders: map[moira.State]int64{moira.StateERROR: 43200}},
+		trigger:   &moira.Trigger{Name: "Super trigger"},
+		lastCheck: &moira.CheckData{},
+	}
+	lastState := moira.MetricState{
+		State:          moira.StateERROR,
+		Timestamp:      1502712000,
+		EventTimestamp: 1502700000,
+		ReminderCount:  2,
+	}
+
+	Convey("Test compare states with reminders", t, func() {
+		Convey("Reminder interval is taken from checker config", func() {
+			var interval int64 = 12
+			var reminderCount int64 = 3
+			dataBase.EXPECT().PushNotificationEvent(&moira.NotificationEvent{
+				TriggerID:        triggerChecker.triggerID,
+				State:            moira.StateERROR,
+				OldState:         moira.StateERROR,
+				Timestamp:        1502743200,
+				Metric:           "m1",
+				MessageEventInfo: &moira.EventInfo{Interval: &interval, ReminderCount: &reminderCount},
+			}, true).Return(nil)
+			currentState := moira.MetricState{State: moira.StateERROR, Timestamp: 1502743200, ReminderCount: 2}
+			actual, err := triggerChecker.compareMetricStates("m1", currentState, lastState)
+			So(err, ShouldBeNil)
+			So(actual.ReminderCount, ShouldEqual, reminderCount)
+			So(actual.EventTimestamp, ShouldEqual, 1502743200)
+
+			Convey("No reminder about state missing in config", func() {
+				lastState := moira.MetricState{State: moira.StateNODATA, Timestamp: 1502712000, EventTimestamp: 1502600000}
+				currentState := moira.MetricState{State: moira.StateNODATA, Timestamp: 1502743200}
+				actual, err := triggerChecker.compareMetricStates("m1", currentState, lastState)
+				So(err, ShouldBeNil)
+				So(actual.EventTimestamp, ShouldEqual, lastState.EventTimestamp)
+			})
+		})
+
+		Convey("Trigger reminders override checker config", func() {
+			triggerChecker.trigger.Reminders = map[moira.State]int64{moira.StateERROR: 0, moira.StateWARN: 7200}
+			defer func() { triggerChecker.trigger.Reminders = nil }()
+
+			currentState := moira.MetricState{State: moira.StateERROR, Timestamp: 1502743200, ReminderCount: 2}
+			actual, err := triggerChecker.compareMetricStates("m1", currentState, lastState)
+			So(err, ShouldBeNil)
+			So(actual.EventTimestamp, ShouldEqual, lastState.EventTimestamp)
+			So(actual.ReminderCount, ShouldEqual, 2)
+
+			var interval int64 = 2
+			var reminderCount int64 = 1
+			dataBase.EXPECT().PushNotificationEvent(&moira.NotificationEvent{
+				TriggerID:        triggerChecker.triggerID,
+				State:            moira.StateWARN,
+				OldState:         moira.StateWARN,
+				Timestamp:        1502719200,
+				Metric:           "m1",
+				MessageEventInfo: &moira.EventInfo{Interval: &interval, ReminderCount: &reminderCount},
+			}, true).Return(nil)
+			lastWarnState := moira.MetricState{State: moira.StateWARN, Timestamp: 1502712000, EventTimestamp: 1502712000}
+			currentState = moira.MetricState{State: moira.StateWARN, Timestamp: 1502719200}
+			actual, err = triggerChecker.compareMetricStates("m1", currentState, lastWarnState)
+			So(err, ShouldBeNil)
+			So(actual.ReminderCount, ShouldEqual, reminderCount)
+		})
+
+		Convey("Reminder count is reset on state change", func() {
+			dataBase.EXPECT().PushNotificationEvent(&moira.NotificationEvent{
+				TriggerID: triggerChecker.triggerID,
+				State:     moira.StateOK,
+				OldState:  moira.StateERROR,
+				Timestamp: 1502712060,
+				Metric:    "m1",
+			}, true).Return(nil)
+			currentState := moira.MetricState{State: moira.StateOK, Timestamp: 1502712060, ReminderCount: 2}
+			actual, err := triggerChecker.compareMetricStates("m1", currentState, lastState)
+			So(err, ShouldBeNil)
+			So(actual.ReminderCount, ShouldEqual, 0)
+		})
+	})
+}
+
 func TestCompareTriggerStates(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -659,29 +1082,36 @@ func TestIsStateChanged(t *testing.T) {
 		Convey("Test is state changed", func() {
 			Convey("If is last check suppressed and current state not equal last state", func() {
 				lastCheckTest.Suppressed = false
-				eventInfo, needSend := isStateChanged(currentCheckTest.State, lastCheckTest.State, currentCheckTest.Timestamp, lastCheckTest.GetEventTimestamp()-1, lastCheckTest.Suppressed, lastCheckTest.SuppressedState, moira.MaintenanceInfo{})
+				eventInfo, needSend := isStateChanged(currentCheckTest.State, lastCheckTest.State, currentCheckTest.Timestamp, lastCheckTest.GetEventTimestamp()-1, lastCheckTest.Suppressed, lastCheckTest.SuppressedState, moira.MaintenanceInfo{}, "", 86400)
 				So(eventInfo, ShouldBeNil)
 				So(needSend, ShouldBeTrue)
 			})
 
 			Convey("Create EventInfo with MaintenanceInfo", func() {
 				maintenanceInfo := moira.MaintenanceInfo{}
-				eventInfo, needSend := isStateChanged(currentCheckTest.State, lastCheckTest.State, currentCheckTest.Timestamp, lastCheckTest.GetEventTimestamp(), lastCheckTest.Suppressed, lastCheckTest.SuppressedState, maintenanceInfo)
+				eventInfo, needSend := isStateChanged(currentCheckTest.State, lastCheckTest.State, currentCheckTest.Timestamp, lastCheckTest.GetEventTimestamp(), lastCheckTest.Suppressed, lastCheckTest.SuppressedState, maintenanceInfo, "", 86400)
 				So(eventInfo, ShouldNotBeNil)
 				So(eventInfo, ShouldResemble, &moira.EventInfo{Maintenance: &maintenanceInfo})
 				So(needSend, ShouldBeTrue)
 			})
 
+			Convey("Create EventInfo with dependency", func() {
+				dependency := "ParentId"
+				eventInfo, needSend := isStateChanged(currentCheckTest.State, lastCheckTest.State, currentCheckTest.Timestamp, lastCheckTest.GetEventTimestamp(), lastCheckTest.Suppressed, lastCheckTest.SuppressedState, moira.MaintenanceInfo{}, dependency, 86400)
+				So(eventInfo, ShouldResemble, &moira.EventInfo{Dependency: &dependency})
+				So(needSend, ShouldBeTrue)
+			})
+
 			Convey("Create EventInfo with interval", func() {
 				var interval int64 = 24
-				eventInfo, needSend := isStateChanged(moira.StateNODATA, lastCheckTest.State, currentCheckTest.Timestamp, lastCheckTest.GetEventTimestamp()-100000, lastCheckTest.Suppressed, moira.StateNODATA, moira.MaintenanceInfo{})
+				eventInfo, needSend := isStateChanged(moira.StateNODATA, lastCheckTest.State, currentCheckTest.Timestamp, lastCheckTest.GetEventTimestamp()-100000, lastCheckTest.Suppressed, moira.StateNODATA, moira.MaintenanceInfo{}, "", 86400)
 				So(eventInfo, ShouldNotBeNil)
 				So(eventInfo, ShouldResemble, &moira.EventInfo{Interval: &interval})
 				So(needSend, ShouldBeTrue)
 			})
 
 			Convey("No send message", func() {
-				eventInfo, needSend := isStateChanged(moira.StateNODATA, lastCheckTest.State, currentCheckTest.Timestamp, lastCheckTest.GetEventTimestamp(), lastCheckTest.Suppressed, moira.StateNODATA, moira.MaintenanceInfo{})
+				eventInfo, needSend := isStateChanged(moira.StateNODATA, lastCheckTest.State, currentCheckTest.Timestamp, lastCheckTest.GetEventTimestamp(), lastCheckTest.Suppressed, moira.StateNODATA, moira.MaintenanceInfo{}, "", 86400)
 				So(eventInfo, ShouldBeNil)
 				So(needSend, ShouldBeFalse)
 			})
@@ -1,6 +1,7 @@
 package checker
 
 import (
+	"github.com/moira-alert/moira"
 	metricSource "github.com/moira-alert/moira/metric_source"
 )
 
@@ -10,18 +11,20 @@ func (triggerChecker *TriggerChecker) fetchTriggerMetrics() (*metricSource.Trigg
 		return triggerMetricsData, err
 	}
 	triggerChecker.cleanupMetricsValues(metrics, triggerChecker.until)
+	return triggerMetricsData, triggerChecker.checkTriggerHasMetrics(triggerMetricsData)
+}
 
+func (triggerChecker *TriggerChecker) checkTriggerHasMetrics(triggerMetricsData *metricSource.TriggerMetricsData) error {
 	if len(triggerChecker.lastCheck.Metrics) == 0 {
 		if len(triggerMetricsData.Main) == 0 {
-			return triggerMetricsData, ErrTriggerHasNoMetrics{}
+			return ErrTriggerHasNoMetrics{}
 		}
 
 		if triggerMetricsData.HasOnlyWildcards() {
-			return triggerMetricsData, ErrTriggerHasOnlyWildcards{}
+			return ErrTriggerHasOnlyWildcards{}
 		}
 	}
-
-	return triggerMetricsData, nil
+	return nil
 }
 
 func (triggerChecker *TriggerChecker) fetch() (*metricSource.TriggerMetricsData, []string, error) {
@@ -64,6 +67,14 @@ func (triggerChecker *TriggerChecker) fetch() (*metricSource.TriggerMetricsData,
 	if len(wrongTriggerTargets) > 0 {
 		return nil, nil, ErrWrongTriggerTargets(wrongTriggerTargets)
 	}
+	if triggerChecker.trigger.TriggerType == moira.AnomalyTrigger {
+		history, err := metricSource.FetchHistory(triggerChecker.source, triggerChecker.trigger.Targets[0],
+			triggerChecker.from, triggerChecker.until, triggerChecker.trigger.GetAnomalySettings())
+		if err != nil {
+			return nil, nil, err
+		}
+		triggerMetricsData.History = history
+	}
 	return triggerMetricsData, metricsArr, nil
 }
 
@@ -308,6 +308,38 @@ func TestFetch(t *testing.T) {
 			So(metrics, ShouldBeNil)
 		})
 	})
+
+	Convey("Anomaly trigger fetches main target history", t, func() {
+		triggerChecker.trigger.Targets = []string{pattern}
+		triggerChecker.trigger.Patterns = []string{pattern}
+		triggerChecker.trigger.TriggerType = moira.AnomalyTrigger
+		triggerChecker.trigger.Anomaly = &moira.AnomalySettings{Seasons: 2, SeasonLength: 100, Window: 10}
+		metricData := metricSource.MakeMetricData(metric, []float64{0, 1, 2, 3, 4}, retention, from)
+		historyData := metricSource.MakeMetricData(metric, []float64{0, 1, 2, 3, 4}, retention, from-200)
+
+		source.EXPECT().Fetch(pattern, from, until, true).Return(fetchResult, nil)
+		fetchResult.EXPECT().GetMetricsData().Return([]*metricSource.MetricData{metricData})
+		fetchResult.EXPECT().GetPatternMetrics().Return([]string{metric}, nil)
+
+		Convey("History is fetched", func() {
+			source.EXPECT().Fetch(pattern, from-210, until-90, false).Return(fetchResult, nil)
+			fetchResult.EXPECT().GetMetricsData().Return([]*metricSource.MetricData{historyData})
+			actual, metrics, err := triggerChecker.fetch()
+			So(err, ShouldBeNil)
+			So(actual.Main, ShouldResemble, []*metricSource.MetricData{metricData})
+			So(actual.History, ShouldResemble, map[string]*metricSource.MetricData{metric: historyData})
+			So(metrics, ShouldResemble, []string{metric})
+		})
+
+		Convey("History fetch error", func() {
+			historyErr := fmt.Errorf("history error")
+			source.EXPECT().Fetch(pattern, from-210, until-90, false).Return(nil, historyErr)
+			actual, metrics, err := triggerChecker.fetch()
+			So(err, ShouldResemble, historyErr)
+			So(actual, ShouldBeNil)
+			So(metrics, ShouldBeNil)
+		})
+	})
 }
 
 func TestGetExpressionValues(t *testing.T) {
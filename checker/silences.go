@@ -0,0 +1,52 @@
+package checker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/moira-alert/moira"
+)
+
+// SilencesSource gets silences which end after given timestamp, returned silences can be not started yet
+type SilencesSource interface {
+	GetUnexpiredSilences(timestamp int64) ([]*moira.Silence, error)
+}
+
+// SilencesCache keeps silences loaded from database during check cycle, so they are not loaded on every trigger check.
+// Expired silences are removed from database by sweeper, so all stored silences are loaded
+type SilencesCache struct {
+	database        moira.Database
+	refreshInterval time.Duration
+	mutex           sync.Mutex
+	silences        []*moira.Silence
+	loadedAt        time.Time
+}
+
+// NewSilencesCache creates silences cache which reloads silences after given interval
+func NewSilencesCache(database moira.Database, refreshInterval time.Duration) *SilencesCache {
+	return &SilencesCache{
+		database:        database,
+		refreshInterval: refreshInterval,
+	}
+}
+
+// GetUnexpiredSilences returns cached silences which end after given timestamp
+func (cache *SilencesCache) GetUnexpiredSilences(timestamp int64) ([]*moira.Silence, error) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if cache.loadedAt.IsZero() || time.Since(cache.loadedAt) >= cache.refreshInterval {
+		silences, err := cache.database.GetUnexpiredSilences(0)
+		if err != nil {
+			return nil, err
+		}
+		cache.silences = silences
+		cache.loadedAt = time.Now()
+	}
+	unexpired := make([]*moira.Silence, 0)
+	for _, silence := range cache.silences {
+		if silence.EndTime > timestamp {
+			unexpired = append(unexpired, silence)
+		}
+	}
+	return unexpired, nil
+}
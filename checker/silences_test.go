@@ -0,0 +1,53 @@
+package checker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/moira-alert/moira"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSilencesCache(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	silences := []*moira.Silence{
+		{ID: "ended", StartTime: 0, EndTime: 100},
+		{ID: "active", StartTime: 50, EndTime: 200},
+	}
+
+	Convey("Silences are loaded once per refresh interval", t, func() {
+		cache := NewSilencesCache(dataBase, time.Hour)
+		dataBase.EXPECT().GetUnexpiredSilences(int64(0)).Return(silences, nil).Times(1)
+
+		actual, err := cache.GetUnexpiredSilences(0)
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, silences)
+
+		actual, err = cache.GetUnexpiredSilences(100)
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, []*moira.Silence{silences[1]})
+	})
+
+	Convey("Silences are reloaded after refresh interval", t, func() {
+		cache := NewSilencesCache(dataBase, 0)
+		dataBase.EXPECT().GetUnexpiredSilences(int64(0)).Return(silences, nil).Times(2)
+		_, err := cache.GetUnexpiredSilences(0)
+		So(err, ShouldBeNil)
+		_, err = cache.GetUnexpiredSilences(0)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Error get silences", t, func() {
+		cache := NewSilencesCache(dataBase, time.Hour)
+		expected := fmt.Errorf("oooops! Can not get silences")
+		dataBase.EXPECT().GetUnexpiredSilences(int64(0)).Return(nil, expected)
+		actual, err := cache.GetUnexpiredSilences(0)
+		So(err, ShouldResemble, expected)
+		So(actual, ShouldBeNil)
+	})
+}
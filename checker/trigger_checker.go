@@ -9,9 +9,17 @@ import (
 	"github.com/moira-alert/moira/metrics"
 )
 
+// checkDatabase is a part of moira.Database which trigger check writes its results to
+type checkDatabase interface {
+	SetTriggerLastCheck(triggerID string, checkData *moira.CheckData, clusterKey moira.ClusterKey) error
+	PushNotificationEvent(event *moira.NotificationEvent, ui bool) error
+	RemovePatternsMetrics(pattern []string) error
+	RemoveMetricsValues(metrics []string, toTime int64) error
+}
+
 // TriggerChecker represents data, used for handling new trigger state
 type TriggerChecker struct {
-	database moira.Database
+	database checkDatabase
 	logger   moira.Logger
 	config   *Config
 	metrics  *metrics.CheckMetrics
@@ -23,6 +31,9 @@ type TriggerChecker struct {
 	triggerID string
 	trigger   *moira.Trigger
 	lastCheck *moira.CheckData
+	silences  []*moira.Silence
+	// badDependency is ID of parent trigger in bad state, notifications are not sent while it is set
+	badDependency string
 
 	ttl      int64
 	ttlState moira.TTLState
@@ -30,8 +41,10 @@ type TriggerChecker struct {
 
 // MakeTriggerChecker initialize new triggerChecker data
 // if trigger does not exists then return ErrTriggerNotExists error
-// if trigger metrics source does not configured then return ErrMetricSourceIsNotConfigured error.
-func MakeTriggerChecker(triggerID string, dataBase moira.Database, logger moira.Logger, config *Config, sourceProvider *metricSource.SourceProvider, metrics *metrics.CheckerMetrics) (*TriggerChecker, error) {
+// if trigger metrics source does not configured then return ErrMetricSourceIsNotConfigured error,
+// if trigger remote cluster is unknown then return ErrRemoteClusterIsNotConfigured error.
+func MakeTriggerChecker(triggerID string, dataBase moira.Database, logger moira.Logger, config *Config, sourceProvider *metricSource.SourceProvider,
+	silencesSource SilencesSource, metrics *metrics.CheckerMetrics) (*TriggerChecker, error) {
 	until := time.Now().Unix()
 	trigger, err := dataBase.GetTrigger(triggerID)
 	if err != nil {
@@ -46,24 +59,42 @@ func MakeTriggerChecker(triggerID string, dataBase moira.Database, logger moira.
 		return nil, err
 	}
 
+	checkMetrics, err := metrics.GetCheckMetrics(&trigger)
+	if err != nil {
+		return nil, err
+	}
+
 	lastCheck, err := getLastCheck(dataBase, triggerID, until-3600)
 	if err != nil {
 		return nil, err
 	}
 
+	from := calculateFrom(lastCheck.Timestamp, trigger.TTL)
+	silences, err := silencesSource.GetUnexpiredSilences(from)
+	if err != nil {
+		return nil, err
+	}
+
+	badDependency, err := getBadDependency(dataBase, trigger.Dependencies)
+	if err != nil {
+		return nil, err
+	}
+
 	triggerChecker := &TriggerChecker{
 		database: dataBase,
 		logger:   logger,
 		config:   config,
-		metrics:  metrics.GetCheckMetrics(&trigger),
+		metrics:  checkMetrics,
 		source:   source,
 
-		from:  calculateFrom(lastCheck.Timestamp, trigger.TTL),
+		from:  from,
 		until: until,
 
-		triggerID: triggerID,
-		trigger:   &trigger,
-		lastCheck: lastCheck,
+		triggerID:     triggerID,
+		trigger:       &trigger,
+		lastCheck:     lastCheck,
+		silences:      silences,
+		badDependency: badDependency,
 
 		ttl:      trigger.TTL,
 		ttlState: getTTLState(trigger.TTLState),
@@ -92,6 +123,24 @@ func getLastCheck(dataBase moira.Database, triggerID string, emptyLastCheckTimes
 	return &lastCheck, nil
 }
 
+// getBadDependency returns ID of the first parent trigger which is in bad state or empty string if all parents are fine.
+// Parent triggers which were removed or have not been checked yet are ignored
+func getBadDependency(dataBase moira.Database, dependencies []string) (string, error) {
+	for _, parentTriggerID := range dependencies {
+		parentLastCheck, err := dataBase.GetTriggerLastCheck(parentTriggerID)
+		if err == database.ErrNil {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if parentLastCheck.HasBadState() {
+			return parentTriggerID, nil
+		}
+	}
+	return "", nil
+}
+
 func getTTLState(triggerTTLState *moira.TTLState) moira.TTLState {
 	if triggerTTLState != nil {
 		return *triggerTTLState
@@ -10,6 +10,7 @@ import (
 	metricSource "github.com/moira-alert/moira/metric_source"
 	"github.com/moira-alert/moira/metric_source/local"
 	"github.com/moira-alert/moira/metrics"
+	mock_metric_source "github.com/moira-alert/moira/mock/metric_source"
 	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
 	"github.com/op/go-logging"
 	. "github.com/smartystreets/goconvey/convey"
@@ -28,14 +29,14 @@ func TestInitTriggerChecker(t *testing.T) {
 		Convey("Get trigger error", func() {
 			getTriggerError := fmt.Errorf("Oppps! Can't read trigger")
 			dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{}, getTriggerError)
-			_, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateMetricSourceProvider(localSource, nil), &metrics.CheckerMetrics{})
+			_, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateMetricSourceProvider(localSource, nil, nil), dataBase, &metrics.CheckerMetrics{})
 			So(err, ShouldBeError)
 			So(err, ShouldResemble, getTriggerError)
 		})
 
 		Convey("No trigger error", func() {
 			dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{}, database.ErrNil)
-			_, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateMetricSourceProvider(localSource, nil), &metrics.CheckerMetrics{})
+			_, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateMetricSourceProvider(localSource, nil, nil), dataBase, &metrics.CheckerMetrics{})
 			So(err, ShouldBeError)
 			So(err, ShouldResemble, ErrTriggerNotExists)
 		})
@@ -44,16 +45,42 @@ func TestInitTriggerChecker(t *testing.T) {
 			readLastCheckError := fmt.Errorf("Oppps! Can't read last check")
 			dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{TriggerType: moira.RisingTrigger}, nil)
 			dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, readLastCheckError)
-			_, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateMetricSourceProvider(localSource, nil), &metrics.CheckerMetrics{})
+			_, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateMetricSourceProvider(localSource, nil, nil), dataBase, &metrics.CheckerMetrics{})
 			So(err, ShouldBeError)
 			So(err, ShouldResemble, readLastCheckError)
 		})
+
+		Convey("Get silences error", func() {
+			getSilencesError := fmt.Errorf("Oppps! Can't read silences")
+			dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{TriggerType: moira.RisingTrigger}, nil)
+			dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
+			dataBase.EXPECT().GetUnexpiredSilences(gomock.Any()).Return(nil, getSilencesError)
+			_, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateMetricSourceProvider(localSource, nil, nil), dataBase, &metrics.CheckerMetrics{})
+			So(err, ShouldBeError)
+			So(err, ShouldResemble, getSilencesError)
+		})
+
+		Convey("Unknown remote cluster", func() {
+			dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{TriggerSource: moira.GraphiteRemote, ClusterID: "unknown"}, nil)
+			_, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateMetricSourceProvider(localSource, nil, nil), dataBase, &metrics.CheckerMetrics{})
+			So(err, ShouldResemble, metricSource.ErrRemoteClusterIsNotConfigured{ClusterID: "unknown"})
+		})
+
+		Convey("Remote cluster without check metrics", func() {
+			remoteSource := mock_metric_source.NewMockMetricSource(mockCtrl)
+			remoteSource.EXPECT().IsConfigured().Return(true, nil)
+			sourceProvider := metricSource.CreateMetricSourceProvider(localSource, map[moira.ClusterID]metricSource.MetricSource{"cluster": remoteSource}, nil)
+			dataBase.EXPECT().GetTrigger(triggerID).Return(moira.Trigger{TriggerSource: moira.GraphiteRemote, ClusterID: "cluster"}, nil)
+			_, err := MakeTriggerChecker(triggerID, dataBase, logger, config, sourceProvider, dataBase, &metrics.CheckerMetrics{})
+			So(err, ShouldResemble, fmt.Errorf("check metrics of cluster graphite_remote.cluster are not configured"))
+		})
 	})
 
 	var warnValue float64 = 10000
 	var errorValue float64 = 100000
 	var ttl int64 = 900
 	var value float64
+	silences := []*moira.Silence{{ID: "silence-id", Tags: []string{"tag"}, StartTime: 0, EndTime: 1000}}
 
 	trigger := moira.Trigger{
 		ID:          "d39b8510-b2f4-448c-b881-824658c58128",
@@ -100,7 +127,8 @@ func TestInitTriggerChecker(t *testing.T) {
 	Convey("Test trigger checker with lastCheck", t, func() {
 		dataBase.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(lastCheck, nil)
-		actual, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateMetricSourceProvider(localSource, nil), &metrics.CheckerMetrics{})
+		dataBase.EXPECT().GetUnexpiredSilences(gomock.Any()).Return(silences, nil)
+		actual, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateMetricSourceProvider(localSource, nil, nil), dataBase, &metrics.CheckerMetrics{})
 		So(err, ShouldBeNil)
 
 		expected := TriggerChecker{
@@ -113,6 +141,7 @@ func TestInitTriggerChecker(t *testing.T) {
 			ttl:       trigger.TTL,
 			ttlState:  *trigger.TTLState,
 			lastCheck: &lastCheck,
+			silences:  silences,
 			from:      lastCheck.Timestamp - ttl,
 			until:     actual.until,
 		}
@@ -122,7 +151,8 @@ func TestInitTriggerChecker(t *testing.T) {
 	Convey("Test trigger checker without lastCheck", t, func() {
 		dataBase.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
-		actual, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateMetricSourceProvider(localSource, nil), &metrics.CheckerMetrics{})
+		dataBase.EXPECT().GetUnexpiredSilences(gomock.Any()).Return(silences, nil)
+		actual, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateMetricSourceProvider(localSource, nil, nil), dataBase, &metrics.CheckerMetrics{})
 		So(err, ShouldBeNil)
 
 		expected := TriggerChecker{
@@ -139,8 +169,9 @@ func TestInitTriggerChecker(t *testing.T) {
 				State:     moira.StateOK,
 				Timestamp: actual.until - 3600,
 			},
-			from:  actual.until - 3600 - ttl,
-			until: actual.until,
+			silences: silences,
+			from:     actual.until - 3600 - ttl,
+			until:    actual.until,
 		}
 		So(*actual, ShouldResemble, expected)
 	})
@@ -151,7 +182,8 @@ func TestInitTriggerChecker(t *testing.T) {
 	Convey("Test trigger checker without lastCheck and ttl", t, func() {
 		dataBase.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
-		actual, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateMetricSourceProvider(localSource, nil), &metrics.CheckerMetrics{})
+		dataBase.EXPECT().GetUnexpiredSilences(gomock.Any()).Return(silences, nil)
+		actual, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateMetricSourceProvider(localSource, nil, nil), dataBase, &metrics.CheckerMetrics{})
 		So(err, ShouldBeNil)
 
 		expected := TriggerChecker{
@@ -168,8 +200,9 @@ func TestInitTriggerChecker(t *testing.T) {
 				State:     moira.StateOK,
 				Timestamp: actual.until - 3600,
 			},
-			from:  actual.until - 3600 - 600,
-			until: actual.until,
+			silences: silences,
+			from:     actual.until - 3600 - 600,
+			until:    actual.until,
 		}
 		So(*actual, ShouldResemble, expected)
 	})
@@ -177,7 +210,8 @@ func TestInitTriggerChecker(t *testing.T) {
 	Convey("Test trigger checker with lastCheck and without ttl", t, func() {
 		dataBase.EXPECT().GetTrigger(triggerID).Return(trigger, nil)
 		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(lastCheck, nil)
-		actual, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateMetricSourceProvider(localSource, nil), &metrics.CheckerMetrics{})
+		dataBase.EXPECT().GetUnexpiredSilences(gomock.Any()).Return(silences, nil)
+		actual, err := MakeTriggerChecker(triggerID, dataBase, logger, config, metricSource.CreateMetricSourceProvider(localSource, nil, nil), dataBase, &metrics.CheckerMetrics{})
 		So(err, ShouldBeNil)
 
 		expected := TriggerChecker{
@@ -190,9 +224,41 @@ func TestInitTriggerChecker(t *testing.T) {
 			ttl:       0,
 			ttlState:  moira.TTLStateNODATA,
 			lastCheck: &lastCheck,
+			silences:  silences,
 			from:      lastCheck.Timestamp - 600,
 			until:     actual.until,
 		}
 		So(*actual, ShouldResemble, expected)
 	})
 }
+
+func TestGetBadDependency(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	defer mockCtrl.Finish()
+
+	Convey("Trigger without dependencies", t, func() {
+		badDependency, err := getBadDependency(dataBase, nil)
+		So(err, ShouldBeNil)
+		So(badDependency, ShouldBeEmpty)
+	})
+
+	Convey("First parent trigger in bad state is returned", t, func() {
+		dataBase.EXPECT().GetTriggerLastCheck("removed").Return(moira.CheckData{}, database.ErrNil)
+		dataBase.EXPECT().GetTriggerLastCheck("fine").Return(moira.CheckData{State: moira.StateOK}, nil)
+		dataBase.EXPECT().GetTriggerLastCheck("bad").Return(moira.CheckData{
+			State:   moira.StateOK,
+			Metrics: map[string]moira.MetricState{"metric": {State: moira.StateERROR}},
+		}, nil)
+		badDependency, err := getBadDependency(dataBase, []string{"removed", "fine", "bad", "other"})
+		So(err, ShouldBeNil)
+		So(badDependency, ShouldEqual, "bad")
+	})
+
+	Convey("Last check read error", t, func() {
+		readLastCheckError := fmt.Errorf("can't read last check")
+		dataBase.EXPECT().GetTriggerLastCheck("parent").Return(moira.CheckData{}, readLastCheckError)
+		_, err := getBadDependency(dataBase, []string{"parent"})
+		So(err, ShouldResemble, readLastCheckError)
+	})
+}
@@ -56,7 +56,7 @@ func (worker *Checker) handleTriggerInLock(triggerID string, metrics *metrics.Ch
 
 func (worker *Checker) checkTrigger(triggerID string) error {
 	defer worker.Database.DeleteTriggerCheckLock(triggerID)
-	triggerChecker, err := checker.MakeTriggerChecker(triggerID, worker.Database, worker.Logger, worker.Config, worker.SourceProvider, worker.Metrics)
+	triggerChecker, err := checker.MakeTriggerChecker(triggerID, worker.Database, worker.Logger, worker.Config, worker.SourceProvider, worker.SilencesCache, worker.Metrics)
 	if err != nil {
 		if err == checker.ErrTriggerNotExists {
 			return nil
@@ -52,10 +52,17 @@ func (worker *Checker) addTriggerIDsIfNeeded(triggerIDs []string) {
 	}
 }
 
-func (worker *Checker) addRemoteTriggerIDsIfNeeded(triggerIDs []string) {
+func (worker *Checker) addRemoteTriggerIDsIfNeeded(clusterID moira.ClusterID, triggerIDs []string) {
 	needToCheckRemoteTriggerIDs := worker.getTriggerIDsToCheck(triggerIDs)
 	if len(needToCheckRemoteTriggerIDs) > 0 {
-		worker.Database.AddRemoteTriggersToCheck(needToCheckRemoteTriggerIDs)
+		worker.Database.AddRemoteTriggersToCheck(clusterID, needToCheckRemoteTriggerIDs)
+	}
+}
+
+func (worker *Checker) addPrometheusTriggerIDsIfNeeded(triggerIDs []string) {
+	needToCheckPrometheusTriggerIDs := worker.getTriggerIDsToCheck(triggerIDs)
+	if len(needToCheckPrometheusTriggerIDs) > 0 {
+		worker.Database.AddPrometheusTriggersToCheck(needToCheckPrometheusTriggerIDs)
 	}
 }
 
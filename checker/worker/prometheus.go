@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/moira-alert/moira/metric_source/prometheus"
+	w "github.com/moira-alert/moira/worker"
+)
+
+const (
+	prometheusTriggerLockName = "moira-prometheus-checker"
+	prometheusTriggerName     = "Prometheus checker"
+)
+
+func (worker *Checker) prometheusTriggerGetter() error {
+
+	w.NewWorker(
+		prometheusTriggerName,
+		worker.Logger,
+		worker.Database.NewLock(prometheusTriggerLockName, nodataCheckerLockTTL),
+		worker.prometheusTriggerChecker,
+	).Run(worker.tomb.Dying())
+
+	return nil
+}
+
+func (worker *Checker) prometheusTriggerChecker(stop <-chan struct{}) error {
+	checkTicker := time.NewTicker(worker.PrometheusConfig.CheckInterval)
+	worker.Logger.Info(prometheusTriggerName + " started")
+	for {
+		select {
+		case <-stop:
+			worker.Logger.Info(prometheusTriggerName + " stopped")
+			checkTicker.Stop()
+			return nil
+		case <-checkTicker.C:
+			if err := worker.checkPrometheus(); err != nil {
+				worker.Logger.Errorf(prometheusTriggerName+" failed: %s", err.Error())
+			}
+		}
+	}
+}
+
+func (worker *Checker) checkPrometheus() error {
+	source, err := worker.SourceProvider.GetPrometheus()
+	if err != nil {
+		return err
+	}
+	prometheusAvailable, err := source.(*prometheus.Prometheus).IsAvailable()
+	if !prometheusAvailable {
+		worker.Logger.Infof("Prometheus API is unavailable. Stop checking prometheus triggers. Error: %s", err.Error())
+	} else {
+		worker.Logger.Debug("Checking prometheus triggers")
+		triggerIds, err := worker.Database.GetPrometheusTriggerIDs()
+		if err != nil {
+			return err
+		}
+		worker.addPrometheusTriggerIDsIfNeeded(triggerIds)
+	}
+	return nil
+}
@@ -1,8 +1,10 @@
 package worker
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/metric_source/remote"
 	w "github.com/moira-alert/moira/worker"
 )
@@ -12,50 +14,57 @@ const (
 	remoteTriggerName     = "Remote checker"
 )
 
-func (worker *Checker) remoteTriggerGetter() error {
+func (worker *Checker) remoteTriggerGetter(clusterID moira.ClusterID) error {
+	lockName, workerName := remoteTriggerLockName, remoteTriggerName
+	if clusterID != moira.DefaultCluster {
+		lockName = fmt.Sprintf("%s:%s", remoteTriggerLockName, clusterID)
+		workerName = fmt.Sprintf("%s of cluster %s", remoteTriggerName, clusterID)
+	}
 
 	w.NewWorker(
-		remoteTriggerName,
+		workerName,
 		worker.Logger,
-		worker.Database.NewLock(remoteTriggerLockName, nodataCheckerLockTTL),
-		worker.remoteTriggerChecker,
+		worker.Database.NewLock(lockName, nodataCheckerLockTTL),
+		func(stop <-chan struct{}) error {
+			return worker.remoteTriggerChecker(stop, clusterID, workerName)
+		},
 	).Run(worker.tomb.Dying())
 
 	return nil
 }
 
-func (worker *Checker) remoteTriggerChecker(stop <-chan struct{}) error {
-	checkTicker := time.NewTicker(worker.RemoteConfig.CheckInterval)
-	worker.Logger.Info(remoteTriggerName + " started")
+func (worker *Checker) remoteTriggerChecker(stop <-chan struct{}, clusterID moira.ClusterID, workerName string) error {
+	checkTicker := time.NewTicker(worker.RemoteConfigs[clusterID].CheckInterval)
+	worker.Logger.Info(workerName + " started")
 	for {
 		select {
 		case <-stop:
-			worker.Logger.Info(remoteTriggerName + " stopped")
+			worker.Logger.Info(workerName + " stopped")
 			checkTicker.Stop()
 			return nil
 		case <-checkTicker.C:
-			if err := worker.checkRemote(); err != nil {
-				worker.Logger.Errorf(remoteTriggerName+" failed: %s", err.Error())
+			if err := worker.checkRemote(clusterID); err != nil {
+				worker.Logger.Errorf(workerName+" failed: %s", err.Error())
 			}
 		}
 	}
 }
 
-func (worker *Checker) checkRemote() error {
-	source, err := worker.SourceProvider.GetRemote()
+func (worker *Checker) checkRemote(clusterID moira.ClusterID) error {
+	source, err := worker.SourceProvider.GetRemote(clusterID)
 	if err != nil {
 		return err
 	}
 	remoteAvailable, err := source.(*remote.Remote).IsRemoteAvailable()
 	if !remoteAvailable {
-		worker.Logger.Infof("Remote API is unavailable. Stop checking remote triggers. Error: %s", err.Error())
+		worker.Logger.Infof("Remote API of cluster %s is unavailable. Stop checking remote triggers. Error: %s", clusterID, err.Error())
 	} else {
-		worker.Logger.Debug("Checking remote triggers")
-		triggerIds, err := worker.Database.GetRemoteTriggerIDs()
+		worker.Logger.Debugf("Checking remote triggers of cluster %s", clusterID)
+		triggerIds, err := worker.Database.GetRemoteTriggerIDs(clusterID)
 		if err != nil {
 			return err
 		}
-		worker.addRemoteTriggerIDsIfNeeded(triggerIds)
+		worker.addRemoteTriggerIDsIfNeeded(clusterID, triggerIds)
 	}
 	return nil
 }
@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"time"
+
+	w "github.com/moira-alert/moira/worker"
+)
+
+const (
+	expiredSilencesSweeperLockName = "moira-expired-silences-sweeper"
+	expiredSilencesSweeperName     = "Expired silences sweeper"
+	expiredSilencesSweeperTicker   = time.Minute
+)
+
+// expiredSilencesSweeper starts expired silences sweeper and manages its lock in Redis
+// to make sure there is always only one working sweeper
+func (worker *Checker) expiredSilencesSweeper() error {
+	w.NewWorker(
+		expiredSilencesSweeperName,
+		worker.Logger,
+		worker.Database.NewLock(expiredSilencesSweeperLockName, nodataCheckerLockTTL),
+		worker.sweepExpiredSilences,
+	).Run(worker.tomb.Dying())
+
+	return nil
+}
+
+func (worker *Checker) sweepExpiredSilences(stop <-chan struct{}) error {
+	sweepTicker := time.NewTicker(expiredSilencesSweeperTicker)
+	worker.Logger.Infof("Start expired silences sweeper. Remove expired silences every %v", expiredSilencesSweeperTicker)
+	for {
+		select {
+		case <-stop:
+			sweepTicker.Stop()
+			worker.Logger.Info("Expired silences sweeper stopped")
+			return nil
+		case <-sweepTicker.C:
+			count, err := worker.Database.RemoveExpiredSilences(time.Now().Unix())
+			if err != nil {
+				worker.Logger.Errorf("Failed to remove expired silences: %s", err.Error())
+				continue
+			}
+			if count > 0 {
+				worker.Logger.Infof("Removed %d expired silences", count)
+			}
+		}
+	}
+}
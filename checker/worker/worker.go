@@ -8,6 +8,7 @@ import (
 	"github.com/moira-alert/moira/metrics"
 
 	metricSource "github.com/moira-alert/moira/metric_source"
+	"github.com/moira-alert/moira/metric_source/prometheus"
 	"github.com/moira-alert/moira/metric_source/remote"
 	"github.com/patrickmn/go-cache"
 	"gopkg.in/tomb.v2"
@@ -21,16 +22,19 @@ type Checker struct {
 	Logger            moira.Logger
 	Database          moira.Database
 	Config            *checker.Config
-	RemoteConfig      *remote.Config
+	RemoteConfigs     map[moira.ClusterID]*remote.Config
+	PrometheusConfig  *prometheus.Config
 	SourceProvider    *metricSource.SourceProvider
 	Metrics           *metrics.CheckerMetrics
 	TriggerCache      *cache.Cache
 	LazyTriggersCache *cache.Cache
 	PatternCache      *cache.Cache
+	SilencesCache     *checker.SilencesCache
 	lazyTriggerIDs    atomic.Value
 	lastData          int64
 	tomb              tomb.Tomb
-	remoteEnabled     bool
+	remoteClusters    []moira.ClusterID
+	prometheusEnabled bool
 }
 
 // Start start schedule new MetricEvents and check for NODATA triggers
@@ -49,24 +53,41 @@ func (worker *Checker) Start() error {
 
 	worker.lazyTriggerIDs.Store(make(map[string]bool))
 	worker.tomb.Go(worker.lazyTriggersWorker)
+	worker.tomb.Go(worker.expiredSilencesSweeper)
 
 	worker.tomb.Go(worker.localTriggerGetter)
 
-	_, err = worker.SourceProvider.GetRemote()
-	worker.remoteEnabled = err == nil
+	worker.remoteClusters = worker.SourceProvider.GetRemoteClusterIDs()
 
-	if worker.remoteEnabled && worker.Config.MaxParallelRemoteChecks == 0 {
+	if len(worker.remoteClusters) > 0 && worker.Config.MaxParallelRemoteChecks == 0 {
 		worker.Config.MaxParallelRemoteChecks = runtime.NumCPU()
 		worker.Logger.Infof("MaxParallelRemoteChecks is not configured, set it to the number of CPU - %d", worker.Config.MaxParallelRemoteChecks)
 	}
 
-	if worker.remoteEnabled {
-		worker.tomb.Go(worker.remoteTriggerGetter)
-		worker.Logger.Info("Remote checker started")
-	} else {
+	for _, clusterID := range worker.remoteClusters {
+		clusterID := clusterID
+		worker.tomb.Go(func() error { return worker.remoteTriggerGetter(clusterID) })
+		worker.Logger.Infof("Remote checker of cluster %s started", clusterID)
+	}
+	if len(worker.remoteClusters) == 0 {
 		worker.Logger.Info("Remote checker disabled")
 	}
 
+	_, err = worker.SourceProvider.GetPrometheus()
+	worker.prometheusEnabled = err == nil
+
+	if worker.prometheusEnabled && worker.Config.MaxParallelPrometheusChecks == 0 {
+		worker.Config.MaxParallelPrometheusChecks = runtime.NumCPU()
+		worker.Logger.Infof("MaxParallelPrometheusChecks is not configured, set it to the number of CPU - %d", worker.Config.MaxParallelPrometheusChecks)
+	}
+
+	if worker.prometheusEnabled {
+		worker.tomb.Go(worker.prometheusTriggerGetter)
+		worker.Logger.Info("Prometheus checker started")
+	} else {
+		worker.Logger.Info("Prometheus checker disabled")
+	}
+
 	worker.Logger.Infof("Start %v parallel local checker(s)", worker.Config.MaxParallelChecks)
 	localTriggerIdsToCheckChan := worker.startTriggerToCheckGetter(worker.Database.GetLocalTriggersToCheck, worker.Config.MaxParallelChecks)
 	for i := 0; i < worker.Config.MaxParallelChecks; i++ {
@@ -78,12 +99,26 @@ func (worker *Checker) Start() error {
 		})
 	}
 
-	if worker.remoteEnabled {
-		worker.Logger.Infof("Start %v parallel remote checker(s)", worker.Config.MaxParallelRemoteChecks)
-		remoteTriggerIdsToCheckChan := worker.startTriggerToCheckGetter(worker.Database.GetRemoteTriggersToCheck, worker.Config.MaxParallelRemoteChecks)
+	for _, clusterID := range worker.remoteClusters {
+		clusterID := clusterID
+		worker.Logger.Infof("Start %v parallel remote checker(s) of cluster %s", worker.Config.MaxParallelRemoteChecks, clusterID)
+		remoteTriggerIdsToCheckChan := worker.startTriggerToCheckGetter(func(count int) ([]string, error) {
+			return worker.Database.GetRemoteTriggersToCheck(clusterID, count)
+		}, worker.Config.MaxParallelRemoteChecks)
+		remoteMetrics := worker.Metrics.RemoteMetrics[clusterID]
 		for i := 0; i < worker.Config.MaxParallelRemoteChecks; i++ {
 			worker.tomb.Go(func() error {
-				return worker.startTriggerHandler(remoteTriggerIdsToCheckChan, worker.Metrics.RemoteMetrics)
+				return worker.startTriggerHandler(remoteTriggerIdsToCheckChan, remoteMetrics)
+			})
+		}
+	}
+
+	if worker.prometheusEnabled {
+		worker.Logger.Infof("Start %v parallel prometheus checker(s)", worker.Config.MaxParallelPrometheusChecks)
+		prometheusTriggerIdsToCheckChan := worker.startTriggerToCheckGetter(worker.Database.GetPrometheusTriggersToCheck, worker.Config.MaxParallelPrometheusChecks)
+		for i := 0; i < worker.Config.MaxParallelPrometheusChecks; i++ {
+			worker.tomb.Go(func() error {
+				return worker.startTriggerHandler(prometheusTriggerIdsToCheckChan, worker.Metrics.PrometheusMetrics)
 			})
 		}
 	}
@@ -101,7 +136,7 @@ func (worker *Checker) Start() error {
 
 func (worker *Checker) checkTriggersToCheckCount() error {
 	checkTicker := time.NewTicker(time.Millisecond * 100)
-	var triggersToCheckCount, remoteTriggersToCheckCount int64
+	var triggersToCheckCount, remoteTriggersToCheckCount, prometheusTriggersToCheckCount int64
 	var err error
 	for {
 		select {
@@ -112,10 +147,16 @@ func (worker *Checker) checkTriggersToCheckCount() error {
 			if err == nil {
 				worker.Metrics.LocalMetrics.TriggersToCheckCount.Update(triggersToCheckCount)
 			}
-			if worker.remoteEnabled {
-				remoteTriggersToCheckCount, err = worker.Database.GetRemoteTriggersToCheckCount()
+			for _, clusterID := range worker.remoteClusters {
+				remoteTriggersToCheckCount, err = worker.Database.GetRemoteTriggersToCheckCount(clusterID)
+				if err == nil {
+					worker.Metrics.RemoteMetrics[clusterID].TriggersToCheckCount.Update(remoteTriggersToCheckCount)
+				}
+			}
+			if worker.prometheusEnabled {
+				prometheusTriggersToCheckCount, err = worker.Database.GetPrometheusTriggersToCheckCount()
 				if err == nil {
-					worker.Metrics.RemoteMetrics.TriggersToCheckCount.Update(remoteTriggersToCheckCount)
+					worker.Metrics.PrometheusMetrics.TriggersToCheckCount.Update(prometheusTriggersToCheckCount)
 				}
 			}
 		}
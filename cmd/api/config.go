@@ -4,17 +4,22 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/gosexy/to"
+
+	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/api"
 	"github.com/moira-alert/moira/cmd"
 )
 
 type config struct {
-	Redis     cmd.RedisConfig     `yaml:"redis"`
-	Logger    cmd.LoggerConfig    `yaml:"log"`
-	API       apiConfig           `yaml:"api"`
-	Web       webConfig           `yaml:"web"`
-	Telemetry cmd.TelemetryConfig `yaml:"telemetry"`
-	Remote    cmd.RemoteConfig    `yaml:"remote"`
+	Redis      cmd.RedisConfig      `yaml:"redis"`
+	Logger     cmd.LoggerConfig     `yaml:"log"`
+	API        apiConfig            `yaml:"api"`
+	Web        webConfig            `yaml:"web"`
+	Telemetry  cmd.TelemetryConfig  `yaml:"telemetry"`
+	Remote     cmd.RemoteConfig     `yaml:"remote"`
+	Remotes    []cmd.RemoteConfig   `yaml:"remotes"`
+	Prometheus cmd.PrometheusConfig `yaml:"prometheus"`
 }
 
 type apiConfig struct {
@@ -22,6 +27,18 @@ type apiConfig struct {
 	Listen string `yaml:"listen"`
 	// If true, CORS for cross-domain requests will be enabled. This option can be used only for debugging purposes.
 	EnableCORS bool `yaml:"enable_cors"`
+	// Signing secret of slack app, used to verify interactive acknowledge button callbacks sent to /api/ack/slack.
+	// Callbacks are rejected if empty
+	SlackSigningSecret string `yaml:"slack_signing_secret"`
+	// Logins of Moira administrators. Administrators can change triggers, subscriptions and silences of any team,
+	// manage all teams and use administrative methods: /api/notification, /api/health/notifier, removal of patterns,
+	// tags and all events
+	Admins []string `yaml:"admins"`
+	// Time local metrics are kept for, should be equal to checker metrics_ttl. Anomaly triggers on local metrics
+	// which need longer history to calculate baseline are rejected
+	LocalMetricsTTL string `yaml:"local_metrics_ttl"`
+	// Maximum time window of trigger backtest, longer backtests requested via /api/trigger/check are rejected
+	MaxBacktestWindow string `yaml:"max_backtest_window"`
 }
 
 type webConfig struct {
@@ -29,6 +46,8 @@ type webConfig struct {
 	SupportEmail string `yaml:"supportEmail"`
 	// If true, users will be able to choose Graphite as trigger metrics data source
 	RemoteAllowed bool
+	// If true, users will be able to choose Prometheus as trigger metrics data source
+	PrometheusAllowed bool
 	// List of enabled contact types
 	Contacts []webContact `yaml:"contacts"`
 }
@@ -49,12 +68,16 @@ type webContact struct {
 
 func (config *apiConfig) getSettings() *api.Config {
 	return &api.Config{
-		Listen:     config.Listen,
-		EnableCORS: config.EnableCORS,
+		Listen:             config.Listen,
+		EnableCORS:         config.EnableCORS,
+		SlackSigningSecret: config.SlackSigningSecret,
+		Admins:             config.Admins,
+		LocalMetricsTTL:    int64(to.Duration(config.LocalMetricsTTL).Seconds()),
+		MaxBacktestWindow:  int64(to.Duration(config.MaxBacktestWindow).Seconds()),
 	}
 }
 
-func (config *webConfig) getSettings(isRemoteEnabled bool) ([]byte, error) {
+func (config *webConfig) getSettings(remoteClusters []moira.ClusterID, isPrometheusEnabled bool) ([]byte, error) {
 	webContacts := make([]api.WebContact, 0, len(config.Contacts))
 	for _, configContact := range config.Contacts {
 		contact := api.WebContact{
@@ -67,9 +90,11 @@ func (config *webConfig) getSettings(isRemoteEnabled bool) ([]byte, error) {
 		webContacts = append(webContacts, contact)
 	}
 	configContent, err := json.Marshal(api.WebConfig{
-		SupportEmail:  config.SupportEmail,
-		RemoteAllowed: isRemoteEnabled,
-		Contacts:      webContacts,
+		SupportEmail:      config.SupportEmail,
+		RemoteAllowed:     len(remoteClusters) > 0,
+		RemoteClusters:    remoteClusters,
+		PrometheusAllowed: isPrometheusEnabled,
+		Contacts:          webContacts,
 	})
 	if err != nil {
 		return make([]byte, 0), fmt.Errorf("failed to parse web config: %s", err.Error())
@@ -89,11 +114,14 @@ func getDefault() config {
 			LogLevel: "info",
 		},
 		API: apiConfig{
-			Listen:     ":8081",
-			EnableCORS: false,
+			Listen:            ":8081",
+			EnableCORS:        false,
+			LocalMetricsTTL:   "1h",
+			MaxBacktestWindow: "24h",
 		},
 		Web: webConfig{
-			RemoteAllowed: false,
+			RemoteAllowed:     false,
+			PrometheusAllowed: false,
 		},
 		Telemetry: cmd.TelemetryConfig{
 			Listen: ":8091",
@@ -109,5 +137,9 @@ func getDefault() config {
 		Remote: cmd.RemoteConfig{
 			Timeout: "60s",
 		},
+		Prometheus: cmd.PrometheusConfig{
+			Timeout: "60s",
+			Step:    "60s",
+		},
 	}
 }
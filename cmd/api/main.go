@@ -13,12 +13,14 @@ import (
 
 	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/api/handler"
+	"github.com/moira-alert/moira/api/stream"
 	"github.com/moira-alert/moira/cmd"
 	"github.com/moira-alert/moira/database/redis"
 	"github.com/moira-alert/moira/index"
 	"github.com/moira-alert/moira/logging/go-logging"
 	metricSource "github.com/moira-alert/moira/metric_source"
 	"github.com/moira-alert/moira/metric_source/local"
+	"github.com/moira-alert/moira/metric_source/prometheus"
 	"github.com/moira-alert/moira/metric_source/remote"
 )
 
@@ -102,16 +104,26 @@ func main() {
 	logger.Infof("Start listening by address: [%s]", apiConfig.Listen)
 
 	localSource := local.Create(database)
-	remoteConfig := config.Remote.GetRemoteSourceSettings()
-	remoteSource := remote.Create(remoteConfig)
-	metricSourceProvider := metricSource.CreateMetricSourceProvider(localSource, remoteSource)
+	remoteConfigs, err := cmd.GetRemoteSourcesSettings(config.Remote, config.Remotes)
+	if err != nil {
+		logger.Fatalf("Can not configure remote sources: %s", err.Error())
+	}
+	remoteSources := remote.CreateClusters(remoteConfigs)
+	prometheusConfig := config.Prometheus.GetPrometheusSourceSettings()
+	prometheusSource := prometheus.Create(prometheusConfig)
+	metricSourceProvider := metricSource.CreateMetricSourceProvider(localSource, remoteSources, prometheusSource)
 
-	webConfigContent, err := config.Web.getSettings(remoteConfig.Enabled)
+	webConfigContent, err := config.Web.getSettings(metricSourceProvider.GetRemoteClusterIDs(), prometheusConfig.Enabled)
 	if err != nil {
 		logger.Fatal(err)
 	}
 
-	httpHandler := handler.NewHandler(database, logger, searchIndex, apiConfig, metricSourceProvider, webConfigContent)
+	eventsStream := stream.NewEventsStream(database, logger)
+	if err = eventsStream.Start(); err != nil {
+		logger.Fatalf("Failed to start events stream: %s", err.Error())
+	}
+
+	httpHandler := handler.NewHandler(database, logger, searchIndex, apiConfig, metricSourceProvider, webConfigContent, eventsStream)
 	server := &http.Server{
 		Handler: httpHandler,
 	}
@@ -120,6 +132,8 @@ func main() {
 		server.Serve(listener)
 	}()
 	defer Stop(logger, server)
+	// events stream is stopped first to close long-living stream connections before server shutdown
+	defer eventsStream.Stop()
 
 	logger.Infof("Moira Api Started (version: %s)", MoiraVersion)
 	ch := make(chan os.Signal, 1)
@@ -1,17 +1,22 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/gosexy/to"
+	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/checker"
 	"github.com/moira-alert/moira/cmd"
 )
 
 type config struct {
-	Redis     cmd.RedisConfig     `yaml:"redis"`
-	Logger    cmd.LoggerConfig    `yaml:"log"`
-	Checker   checkerConfig       `yaml:"checker"`
-	Telemetry cmd.TelemetryConfig `yaml:"telemetry"`
-	Remote    cmd.RemoteConfig    `yaml:"remote"`
+	Redis      cmd.RedisConfig      `yaml:"redis"`
+	Logger     cmd.LoggerConfig     `yaml:"log"`
+	Checker    checkerConfig        `yaml:"checker"`
+	Telemetry  cmd.TelemetryConfig  `yaml:"telemetry"`
+	Remote     cmd.RemoteConfig     `yaml:"remote"`
+	Remotes    []cmd.RemoteConfig   `yaml:"remotes"`
+	Prometheus cmd.PrometheusConfig `yaml:"prometheus"`
 }
 
 type checkerConfig struct {
@@ -30,9 +35,18 @@ type checkerConfig struct {
 	MaxParallelChecks int `yaml:"max_parallel_checks"`
 	// Max concurrent remote checkers to run. Equals to the number of processor cores found on Moira host by default or when variable is defined as 0.
 	MaxParallelRemoteChecks int `yaml:"max_parallel_remote_checks"`
+	// Max concurrent prometheus checkers to run. Equals to the number of processor cores found on Moira host by default or when variable is defined as 0.
+	MaxParallelPrometheusChecks int `yaml:"max_parallel_prometheus_checks"`
+	// Intervals between reminders about trigger or metric staying in bad state, e.g. ERROR: 24h. Could be set for WARN, ERROR, NODATA and EXCEPTION states.
+	// Reminders about the state are not sent if interval is 0 or not set. Trigger can override these intervals state by state.
+	Reminders map[string]string `yaml:"reminders"`
 }
 
-func (config *checkerConfig) getSettings() *checker.Config {
+func (config *checkerConfig) getSettings() (*checker.Config, error) {
+	reminders := config.getReminders()
+	if err := moira.ValidateReminders(reminders); err != nil {
+		return nil, fmt.Errorf("invalid reminders: %s", err.Error())
+	}
 	return &checker.Config{
 		MetricsTTLSeconds:           int64(to.Duration(config.MetricsTTL).Seconds()),
 		CheckInterval:               to.Duration(config.CheckInterval),
@@ -41,7 +55,17 @@ func (config *checkerConfig) getSettings() *checker.Config {
 		StopCheckingIntervalSeconds: int64(to.Duration(config.StopCheckingInterval).Seconds()),
 		MaxParallelChecks:           config.MaxParallelChecks,
 		MaxParallelRemoteChecks:     config.MaxParallelRemoteChecks,
+		MaxParallelPrometheusChecks: config.MaxParallelPrometheusChecks,
+		Reminders:                   reminders,
+	}, nil
+}
+
+func (config *checkerConfig) getReminders() map[moira.State]int64 {
+	reminders := make(map[moira.State]int64, len(config.Reminders))
+	for state, interval := range config.Reminders {
+		reminders[moira.State(state)] = int64(to.Duration(interval).Seconds())
 	}
+	return reminders
 }
 
 func getDefault() config {
@@ -56,13 +80,18 @@ func getDefault() config {
 			LogLevel: "info",
 		},
 		Checker: checkerConfig{
-			NoDataCheckInterval:       "60s",
-			CheckInterval:             "5s",
-			LazyTriggersCheckInterval: "10m",
-			MetricsTTL:                "1h",
-			StopCheckingInterval:      "30s",
-			MaxParallelChecks:         0,
-			MaxParallelRemoteChecks:   0,
+			NoDataCheckInterval:         "60s",
+			CheckInterval:               "5s",
+			LazyTriggersCheckInterval:   "10m",
+			MetricsTTL:                  "1h",
+			StopCheckingInterval:        "30s",
+			MaxParallelChecks:           0,
+			MaxParallelRemoteChecks:     0,
+			MaxParallelPrometheusChecks: 0,
+			Reminders: map[string]string{
+				string(moira.StateERROR):  "24h",
+				string(moira.StateNODATA): "24h",
+			},
 		},
 		Telemetry: cmd.TelemetryConfig{
 			Listen: ":8092",
@@ -79,5 +108,10 @@ func getDefault() config {
 			CheckInterval: "60s",
 			Timeout:       "60s",
 		},
+		Prometheus: cmd.PrometheusConfig{
+			CheckInterval: "60s",
+			Timeout:       "60s",
+			Step:          "60s",
+		},
 	}
 }
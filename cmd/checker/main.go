@@ -10,6 +10,7 @@ import (
 
 	metricSource "github.com/moira-alert/moira/metric_source"
 	"github.com/moira-alert/moira/metric_source/local"
+	"github.com/moira-alert/moira/metric_source/prometheus"
 	"github.com/moira-alert/moira/metric_source/remote"
 	"github.com/patrickmn/go-cache"
 
@@ -77,14 +78,22 @@ func main() {
 	databaseSettings := config.Redis.GetSettings()
 	database := redis.NewDatabase(logger, databaseSettings, redis.Checker)
 
-	remoteConfig := config.Remote.GetRemoteSourceSettings()
+	remoteConfigs, err := cmd.GetRemoteSourcesSettings(config.Remote, config.Remotes)
+	if err != nil {
+		logger.Fatalf("Can not configure remote sources: %s", err.Error())
+	}
+	prometheusConfig := config.Prometheus.GetPrometheusSourceSettings()
 	localSource := local.Create(database)
-	remoteSource := remote.Create(remoteConfig)
-	metricSourceProvider := metricSource.CreateMetricSourceProvider(localSource, remoteSource)
+	remoteSources := remote.CreateClusters(remoteConfigs)
+	prometheusSource := prometheus.Create(prometheusConfig)
+	metricSourceProvider := metricSource.CreateMetricSourceProvider(localSource, remoteSources, prometheusSource)
 
-	isConfigured, _ := remoteSource.IsConfigured()
-	checkerMetrics := metrics.ConfigureCheckerMetrics(telemetry.Metrics, isConfigured)
-	checkerSettings := config.Checker.getSettings()
+	isPrometheusConfigured, _ := prometheusSource.IsConfigured()
+	checkerMetrics := metrics.ConfigureCheckerMetrics(telemetry.Metrics, metricSourceProvider.GetRemoteClusterIDs(), isPrometheusConfigured)
+	checkerSettings, err := config.Checker.getSettings()
+	if err != nil {
+		logger.Fatalf("Can not configure checker: %s", err.Error())
+	}
 	if triggerID != nil && *triggerID != "" {
 		checkSingleTrigger(database, checkerMetrics, checkerSettings, metricSourceProvider)
 	}
@@ -93,12 +102,14 @@ func main() {
 		Logger:            logger,
 		Database:          database,
 		Config:            checkerSettings,
-		RemoteConfig:      remoteConfig,
+		RemoteConfigs:     getRemoteConfigsByClusterID(remoteConfigs),
+		PrometheusConfig:  prometheusConfig,
 		SourceProvider:    metricSourceProvider,
 		Metrics:           checkerMetrics,
 		TriggerCache:      cache.New(checkerSettings.CheckInterval, time.Minute*60),
 		LazyTriggersCache: cache.New(time.Minute*10, time.Minute*60),
 		PatternCache:      cache.New(checkerSettings.CheckInterval, time.Minute*60),
+		SilencesCache:     checker.NewSilencesCache(database, checkerSettings.CheckInterval),
 	}
 	err = checkerWorker.Start()
 	if err != nil {
@@ -114,7 +125,7 @@ func main() {
 }
 
 func checkSingleTrigger(database moira.Database, metrics *metrics.CheckerMetrics, settings *checker.Config, sourceProvider *metricSource.SourceProvider) {
-	triggerChecker, err := checker.MakeTriggerChecker(*triggerID, database, logger, settings, sourceProvider, metrics)
+	triggerChecker, err := checker.MakeTriggerChecker(*triggerID, database, logger, settings, sourceProvider, database, metrics)
 	if err != nil {
 		logger.Errorf("Failed initialize trigger checker: %s", err.Error())
 		os.Exit(1)
@@ -126,6 +137,14 @@ func checkSingleTrigger(database moira.Database, metrics *metrics.CheckerMetrics
 	os.Exit(0)
 }
 
+func getRemoteConfigsByClusterID(remoteConfigs []*remote.Config) map[moira.ClusterID]*remote.Config {
+	configs := make(map[moira.ClusterID]*remote.Config, len(remoteConfigs))
+	for _, remoteConfig := range remoteConfigs {
+		configs[remoteConfig.ClusterID] = remoteConfig
+	}
+	return configs
+}
+
 func stopChecker(service *worker.Checker) {
 	if err := service.Stop(); err != nil {
 		logger.Errorf("Failed to Stop Moira Checker: %v", err)
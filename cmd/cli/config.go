@@ -5,10 +5,15 @@ import (
 )
 
 type config struct {
-	LogFile  string          `yaml:"log_file"`
-	LogLevel string          `yaml:"log_level"`
-	Redis    cmd.RedisConfig `yaml:"redis"`
-	Cleanup  cleanupConfig   `yaml:"cleanup"`
+	LogFile    string               `yaml:"log_file"`
+	LogLevel   string               `yaml:"log_level"`
+	Redis      cmd.RedisConfig      `yaml:"redis"`
+	Cleanup    cleanupConfig        `yaml:"cleanup"`
+	Remote     cmd.RemoteConfig     `yaml:"remote"`
+	Remotes    []cmd.RemoteConfig   `yaml:"remotes"`
+	Prometheus cmd.PrometheusConfig `yaml:"prometheus"`
+	// Time local metrics are kept for, should be equal to checker metrics_ttl
+	LocalMetricsTTL string `yaml:"local_metrics_ttl"`
 }
 
 type cleanupConfig struct {
@@ -29,5 +34,13 @@ func getDefault() config {
 		Cleanup: cleanupConfig{
 			Whitelist: []string{},
 		},
+		Remote: cmd.RemoteConfig{
+			Timeout: "60s",
+		},
+		Prometheus: cmd.PrometheusConfig{
+			Timeout: "60s",
+			Step:    "60s",
+		},
+		LocalMetricsTTL: "1h",
 	}
 }
@@ -6,6 +6,8 @@ import (
 	"os"
 	"strings"
 
+	"github.com/gosexy/to"
+
 	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/cmd"
 	"github.com/moira-alert/moira/database/redis"
@@ -48,8 +50,26 @@ var (
 	toUser   = flag.String("to-user", "", "Transfer subscriptions and contacts to user.")
 )
 
+var (
+	exportTriggers = flag.String("export-triggers", "", "Export triggers to given JSON or YAML file. Use '-tags' to export only triggers marked with all given tags")
+	importTriggers = flag.String("import-triggers", "", "Create and update triggers from given JSON or YAML file. Use '-dry-run' to only print changes")
+	triggersTags   = flag.String("tags", "", "Comma separated tags of triggers to export")
+	dryRun         = flag.Bool("dry-run", false, "Print changes which would be made on triggers import without saving them")
+)
+
+var (
+	teamsList  = flag.Bool("teams", false, "Print all teams with their members")
+	teamCreate = flag.String("team-create", "", "Create team with given name. Use '-team-admin' to set login of team admin")
+	teamAdmin  = flag.String("team-admin", "", "Login of admin of created team")
+	teamDel    = flag.String("team-del", "", "Delete team with given ID. Team owning triggers or subscriptions is not deleted")
+	team       = flag.String("team", "", "ID of team to change members of. Use with '-member-add' or '-member-del'")
+	memberAdd  = flag.String("member-add", "", "Add user to team or change role of team member. Use '-role' to set member role")
+	memberRole = flag.String("role", string(moira.TeamRoleViewer), "Role of added team member: viewer, editor or admin")
+	memberDel  = flag.String("member-del", "", "Remove user from team")
+)
+
 func main() {
-	confCleanup, logger, dataBase := initApp()
+	conf, logger, dataBase := initApp()
 
 	if *update {
 		fromVersion := checkValidVersion(logger, updateFromVersion, true)
@@ -92,14 +112,63 @@ func main() {
 	}
 
 	if *cleanup {
-		logger.Debugf("User whitelist: %#v", confCleanup.Whitelist)
-		if err := handleCleanup(logger, dataBase, confCleanup); err != nil {
+		logger.Debugf("User whitelist: %#v", conf.Cleanup.Whitelist)
+		if err := handleCleanup(logger, dataBase, conf.Cleanup); err != nil {
+			logger.Error(err)
+		}
+	}
+
+	if *exportTriggers != "" {
+		if err := exportTriggersToFile(dataBase, *exportTriggers, getTriggersTags(*triggersTags)); err != nil {
+			logger.Error(err)
+		}
+	}
+
+	if *teamsList {
+		if err := printTeams(dataBase, os.Stdout); err != nil {
+			logger.Error(err)
+		}
+	}
+
+	if *teamCreate != "" {
+		teamID, err := createTeam(dataBase, *teamCreate, *teamAdmin)
+		if err != nil {
+			logger.Error(err)
+		} else {
+			logger.Infof("Team %s created with ID %s", *teamCreate, teamID)
+		}
+	}
+
+	if *teamDel != "" {
+		if err := deleteTeam(dataBase, *teamDel); err != nil {
+			logger.Error(err)
+		}
+	}
+
+	if *team != "" && *memberAdd != "" {
+		if err := setTeamMember(dataBase, *team, *memberAdd, moira.TeamRole(*memberRole)); err != nil {
+			logger.Error(err)
+		}
+	}
+
+	if *team != "" && *memberDel != "" {
+		if err := removeTeamMember(dataBase, *team, *memberDel); err != nil {
+			logger.Error(err)
+		}
+	}
+
+	if *importTriggers != "" {
+		metricSourceProvider, err := createMetricSourceProvider(conf, dataBase)
+		if err != nil {
+			logger.Fatalf("Can not configure metric sources: %s", err.Error())
+		}
+		if err := importTriggersFromFile(logger, dataBase, metricSourceProvider, int64(to.Duration(conf.LocalMetricsTTL).Seconds()), *importTriggers, *dryRun); err != nil {
 			logger.Error(err)
 		}
 	}
 }
 
-func initApp() (cleanupConfig, moira.Logger, moira.Database) {
+func initApp() (config, moira.Logger, moira.Database) {
 	flag.Parse()
 	if *printVersion {
 		fmt.Println("Moira - alerting system based on graphite data")
@@ -129,7 +198,7 @@ func initApp() (cleanupConfig, moira.Logger, moira.Database) {
 
 	databaseSettings := config.Redis.GetSettings()
 	dataBase := redis.NewDatabase(logger, databaseSettings, redis.Cli)
-	return config.Cleanup, logger, dataBase
+	return config, logger, dataBase
 }
 
 func checkValidVersion(logger moira.Logger, updateFromVersion *string, isUpdate bool) string {
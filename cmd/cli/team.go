@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api"
+	"github.com/moira-alert/moira/api/controller"
+	"github.com/moira-alert/moira/api/dto"
+)
+
+// printTeams writes all teams with their members, one team per line
+func printTeams(database moira.Database, writer io.Writer) error {
+	teams, errorResponse := controller.GetUserTeams(database, cliUserLogin, true)
+	if errorResponse != nil {
+		return getErrorResponseError(errorResponse)
+	}
+	sort.Slice(teams.List, func(i, j int) bool {
+		return teams.List[i].Name < teams.List[j].Name
+	})
+	for _, team := range teams.List {
+		members := make([]string, 0, len(team.Members))
+		for login, role := range team.Members {
+			members = append(members, fmt.Sprintf("%s:%s", login, role))
+		}
+		sort.Strings(members)
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", team.ID, team.Name, strings.Join(members, ","))
+	}
+	return nil
+}
+
+// createTeam creates team with given name and admin and returns ID of created team
+func createTeam(database moira.Database, name, admin string) (string, error) {
+	if admin == "" {
+		return "", fmt.Errorf("team admin must be set")
+	}
+	team := &dto.Team{
+		Name:    name,
+		Members: map[string]moira.TeamRole{admin: moira.TeamRoleAdmin},
+	}
+	if errorResponse := controller.CreateTeam(database, team, cliUserLogin); errorResponse != nil {
+		return "", getErrorResponseError(errorResponse)
+	}
+	return team.ID, nil
+}
+
+// deleteTeam removes team, team owning triggers or subscriptions is not removed
+func deleteTeam(database moira.Database, teamID string) error {
+	team, errorResponse := controller.CheckUserPermissionsForTeam(database, teamID, cliUserLogin, true, moira.TeamRoleAdmin)
+	if errorResponse != nil {
+		return getErrorResponseError(errorResponse)
+	}
+	if errorResponse = controller.RemoveTeam(database, team, cliUserLogin); errorResponse != nil {
+		return getErrorResponseError(errorResponse)
+	}
+	return nil
+}
+
+// setTeamMember adds user to team or changes role of team member
+func setTeamMember(database moira.Database, teamID, login string, role moira.TeamRole) error {
+	if !role.IsValid() {
+		return fmt.Errorf("invalid team role '%s'", role)
+	}
+	return updateTeamMembers(database, teamID, func(members map[string]moira.TeamRole) {
+		members[login] = role
+	})
+}
+
+// removeTeamMember removes user from team, last team admin can not be removed
+func removeTeamMember(database moira.Database, teamID, login string) error {
+	return updateTeamMembers(database, teamID, func(members map[string]moira.TeamRole) {
+		delete(members, login)
+	})
+}
+
+func updateTeamMembers(database moira.Database, teamID string, update func(members map[string]moira.TeamRole)) error {
+	existing, errorResponse := controller.CheckUserPermissionsForTeam(database, teamID, cliUserLogin, true, moira.TeamRoleAdmin)
+	if errorResponse != nil {
+		return getErrorResponseError(errorResponse)
+	}
+	team := dto.Team(existing)
+	team.Members = make(map[string]moira.TeamRole, len(existing.Members))
+	for login, role := range existing.Members {
+		team.Members[login] = role
+	}
+	update(team.Members)
+	if errorResponse = controller.UpdateTeam(database, &team, existing, cliUserLogin); errorResponse != nil {
+		return getErrorResponseError(errorResponse)
+	}
+	return nil
+}
+
+func getErrorResponseError(errorResponse *api.ErrorResponse) error {
+	return fmt.Errorf("%s: %s", errorResponse.StatusText, errorResponse.ErrorText)
+}
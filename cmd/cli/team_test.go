@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+)
+
+func TestTeams(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	team := moira.Team{ID: "teamID", Name: "Team", Members: map[string]moira.TeamRole{"admin": moira.TeamRoleAdmin, "viewer": moira.TeamRoleViewer}}
+
+	Convey("Print teams", t, func() {
+		dataBase.EXPECT().GetAllTeamIDs().Return([]string{team.ID}, nil)
+		dataBase.EXPECT().GetTeams([]string{team.ID}).Return([]*moira.Team{&team}, nil)
+		writer := &bytes.Buffer{}
+		So(printTeams(dataBase, writer), ShouldBeNil)
+		So(writer.String(), ShouldEqual, "teamID\tTeam\tadmin:admin,viewer:viewer\n")
+	})
+
+	Convey("Create team", t, func() {
+		Convey("Without admin", func() {
+			_, err := createTeam(dataBase, "Team", "")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("With admin", func() {
+			dataBase.EXPECT().SaveTeam(gomock.Any()).Do(func(created *moira.Team) {
+				So(created.Name, ShouldEqual, "Team")
+				So(created.Members, ShouldResemble, map[string]moira.TeamRole{"admin": moira.TeamRoleAdmin})
+			}).Return(nil)
+			dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+			teamID, err := createTeam(dataBase, "Team", "admin")
+			So(err, ShouldBeNil)
+			So(teamID, ShouldNotBeEmpty)
+		})
+	})
+
+	Convey("Delete team", t, func() {
+		Convey("Not existing team", func() {
+			dataBase.EXPECT().GetTeam(team.ID).Return(moira.Team{}, database.ErrNil)
+			So(deleteTeam(dataBase, team.ID), ShouldNotBeNil)
+		})
+
+		Convey("Existing team", func() {
+			dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+			dataBase.EXPECT().GetTeamTriggerIDs(team.ID).Return([]string{}, nil)
+			dataBase.EXPECT().GetTeamSubscriptionIDs(team.ID).Return([]string{}, nil)
+			dataBase.EXPECT().RemoveTeam(team.ID).Return(nil)
+			dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+			So(deleteTeam(dataBase, team.ID), ShouldBeNil)
+		})
+	})
+
+	Convey("Change team members", t, func() {
+		Convey("Set member role", func() {
+			dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+			dataBase.EXPECT().SaveTeam(&moira.Team{
+				ID:      team.ID,
+				Name:    team.Name,
+				Members: map[string]moira.TeamRole{"admin": moira.TeamRoleAdmin, "viewer": moira.TeamRoleEditor},
+			}).Return(nil)
+			dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+			So(setTeamMember(dataBase, team.ID, "viewer", moira.TeamRoleEditor), ShouldBeNil)
+			So(team.Members["viewer"], ShouldEqual, moira.TeamRoleViewer)
+		})
+
+		Convey("Invalid role", func() {
+			So(setTeamMember(dataBase, team.ID, "viewer", "owner"), ShouldNotBeNil)
+		})
+
+		Convey("Remove member", func() {
+			dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+			dataBase.EXPECT().SaveTeam(&moira.Team{
+				ID:      team.ID,
+				Name:    team.Name,
+				Members: map[string]moira.TeamRole{"admin": moira.TeamRoleAdmin},
+			}).Return(nil)
+			dataBase.EXPECT().SaveAuditRecord(gomock.Any()).Return(nil)
+			So(removeTeamMember(dataBase, team.ID, "viewer"), ShouldBeNil)
+		})
+
+		Convey("Last admin can not be removed", func() {
+			dataBase.EXPECT().GetTeam(team.ID).Return(team, nil)
+			So(removeTeamMember(dataBase, team.ID, "admin"), ShouldNotBeNil)
+		})
+	})
+}
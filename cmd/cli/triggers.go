@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/api/controller"
+	"github.com/moira-alert/moira/api/dto"
+	"github.com/moira-alert/moira/api/middleware"
+	"github.com/moira-alert/moira/cmd"
+	metricSource "github.com/moira-alert/moira/metric_source"
+	"github.com/moira-alert/moira/metric_source/local"
+	"github.com/moira-alert/moira/metric_source/prometheus"
+	"github.com/moira-alert/moira/metric_source/remote"
+)
+
+// cliUserLogin is used as user login in audit records of triggers changed by cli
+const cliUserLogin = "moira-cli"
+
+func exportTriggersToFile(database moira.Database, fileName string, tags []string) error {
+	bundle, errorResponse := controller.ExportTriggers(database, tags)
+	if errorResponse != nil {
+		return errorResponse.Err
+	}
+	content, err := dto.MarshalTriggersBundle(bundle, getTriggersBundleFormat(fileName))
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, content, 0644)
+}
+
+func importTriggersFromFile(logger moira.Logger, database moira.Database, metricSourceProvider *metricSource.SourceProvider, localMetricsTTL int64, fileName string, dryRun bool) error {
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	bundle, err := dto.UnmarshalTriggersBundle(content, getTriggersBundleFormat(fileName))
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest("POST", "/api/trigger/import", nil)
+	if err != nil {
+		return err
+	}
+	middleware.SetTriggerTargetsSourceProvider(request, metricSourceProvider)
+	middleware.SetLocalMetricsTTL(request, localMetricsTTL)
+	timeSeriesNames, err := bundle.Validate(request)
+	if err != nil {
+		return err
+	}
+
+	result, errorResponse := controller.ImportTriggers(database, bundle, timeSeriesNames, dryRun, cliUserLogin)
+	if errorResponse != nil {
+		return fmt.Errorf("%s: %s", errorResponse.StatusText, errorResponse.ErrorText)
+	}
+	for _, trigger := range result.Triggers {
+		logger.Infof("%s trigger '%s' (%s), changed fields: %s", trigger.Action, trigger.Name, trigger.ID, getChangedFields(trigger.Diff))
+	}
+	return nil
+}
+
+func createMetricSourceProvider(conf config, database moira.Database) (*metricSource.SourceProvider, error) {
+	remoteConfigs, err := cmd.GetRemoteSourcesSettings(conf.Remote, conf.Remotes)
+	if err != nil {
+		return nil, err
+	}
+	localSource := local.Create(database)
+	remoteSources := remote.CreateClusters(remoteConfigs)
+	prometheusSource := prometheus.Create(conf.Prometheus.GetPrometheusSourceSettings())
+	return metricSource.CreateMetricSourceProvider(localSource, remoteSources, prometheusSource), nil
+}
+
+// getTriggersBundleFormat returns YAML format for files with .yml and .yaml extensions and JSON for others
+func getTriggersBundleFormat(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".yml", ".yaml":
+		return dto.TriggersBundleYAML
+	default:
+		return dto.TriggersBundleJSON
+	}
+}
+
+func getTriggersTags(tags string) []string {
+	result := make([]string, 0)
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+func getChangedFields(diff map[string]moira.AuditFieldDiff) string {
+	fields := make([]string, 0, len(diff))
+	for field := range diff {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, ", ")
+}
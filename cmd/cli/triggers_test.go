@@ -0,0 +1,25 @@
+package main
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/moira-alert/moira/api/dto"
+)
+
+func TestGetTriggersBundleFormat(t *testing.T) {
+	Convey("Format is chosen by file extension", t, func() {
+		So(getTriggersBundleFormat("triggers.yml"), ShouldEqual, dto.TriggersBundleYAML)
+		So(getTriggersBundleFormat("/tmp/triggers.YAML"), ShouldEqual, dto.TriggersBundleYAML)
+		So(getTriggersBundleFormat("triggers.json"), ShouldEqual, dto.TriggersBundleJSON)
+		So(getTriggersBundleFormat("triggers"), ShouldEqual, dto.TriggersBundleJSON)
+	})
+}
+
+func TestGetTriggersTags(t *testing.T) {
+	Convey("Tags are split by comma", t, func() {
+		So(getTriggersTags(""), ShouldBeEmpty)
+		So(getTriggersTags("first, second,,third "), ShouldResemble, []string{"first", "second", "third"})
+	})
+}
@@ -5,10 +5,12 @@ import (
 	"io/ioutil"
 	"strings"
 
+	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/metrics"
 
 	"github.com/gosexy/to"
 	"github.com/moira-alert/moira/image_store/s3"
+	prometheusSource "github.com/moira-alert/moira/metric_source/prometheus"
 	remoteSource "github.com/moira-alert/moira/metric_source/remote"
 	"gopkg.in/yaml.v2"
 
@@ -89,6 +91,8 @@ type ProfilerConfig struct {
 
 // RemoteConfig is remote graphite settings structure
 type RemoteConfig struct {
+	// Name of remote graphite cluster, triggers refer to it by cluster_id. Could be omitted in 'remote' section to configure default cluster
+	ClusterID string `yaml:"cluster_id"`
 	// graphite url e.g http://graphite/render
 	URL string `yaml:"url"`
 	// Min period to perform triggers re-check. Note: Reducing of this value leads to increasing of CPU and memory usage values
@@ -103,6 +107,24 @@ type RemoteConfig struct {
 	Enabled bool `yaml:"enabled"`
 }
 
+// PrometheusConfig is remote prometheus settings structure
+type PrometheusConfig struct {
+	// prometheus url e.g http://prometheus:9090
+	URL string `yaml:"url"`
+	// Min period to perform triggers re-check. Note: Reducing of this value leads to increasing of CPU and memory usage values
+	CheckInterval string `yaml:"check_interval"`
+	// Timeout for prometheus requests
+	Timeout string `yaml:"timeout"`
+	// Query resolution step for range queries
+	Step string `yaml:"step"`
+	// Username for basic auth
+	User string `yaml:"user"`
+	// Password for basic auth
+	Password string `yaml:"password"`
+	// If true, prometheus worker will be enabled.
+	Enabled bool `yaml:"enabled"`
+}
+
 // ImageStoreConfig defines the configuration for all the image stores to be initialized by InitImageStores
 type ImageStoreConfig struct {
 	S3 s3.Config `yaml:"s3"`
@@ -110,10 +132,55 @@ type ImageStoreConfig struct {
 
 // GetRemoteSourceSettings returns remote config parsed from moira config files
 func (config *RemoteConfig) GetRemoteSourceSettings() *remoteSource.Config {
+	clusterID := moira.ClusterID(config.ClusterID)
+	if clusterID == "" {
+		clusterID = moira.DefaultCluster
+	}
 	return &remoteSource.Config{
+		ClusterID:     clusterID,
+		URL:           config.URL,
+		CheckInterval: to.Duration(config.CheckInterval),
+		Timeout:       to.Duration(config.Timeout),
+		User:          config.User,
+		Password:      config.Password,
+		Enabled:       config.Enabled,
+	}
+}
+
+// GetRemoteSourcesSettings returns settings of default remote cluster configured in 'remote' section
+// and settings of named remote clusters configured in 'remotes' section.
+// Named clusters inherit check interval and timeout of 'remote' section if they are not set
+func GetRemoteSourcesSettings(defaultRemote RemoteConfig, remotes []RemoteConfig) ([]*remoteSource.Config, error) {
+	defaultSettings := defaultRemote.GetRemoteSourceSettings()
+	settings := []*remoteSource.Config{defaultSettings}
+	clusterIDs := map[moira.ClusterID]bool{defaultSettings.ClusterID: true}
+	for _, remote := range remotes {
+		if remote.ClusterID == "" {
+			return nil, fmt.Errorf("cluster_id is required for remotes, url: %s", remote.URL)
+		}
+		clusterSettings := remote.GetRemoteSourceSettings()
+		if clusterIDs[clusterSettings.ClusterID] {
+			return nil, fmt.Errorf("remote cluster '%s' is configured more than once", clusterSettings.ClusterID)
+		}
+		clusterIDs[clusterSettings.ClusterID] = true
+		if remote.CheckInterval == "" {
+			clusterSettings.CheckInterval = defaultSettings.CheckInterval
+		}
+		if remote.Timeout == "" {
+			clusterSettings.Timeout = defaultSettings.Timeout
+		}
+		settings = append(settings, clusterSettings)
+	}
+	return settings, nil
+}
+
+// GetPrometheusSourceSettings returns prometheus config parsed from moira config files
+func (config *PrometheusConfig) GetPrometheusSourceSettings() *prometheusSource.Config {
+	return &prometheusSource.Config{
 		URL:           config.URL,
 		CheckInterval: to.Duration(config.CheckInterval),
 		Timeout:       to.Duration(config.Timeout),
+		Step:          to.Duration(config.Step),
 		User:          config.User,
 		Password:      config.Password,
 		Enabled:       config.Enabled,
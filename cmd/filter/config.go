@@ -14,6 +14,9 @@ type config struct {
 type filterConfig struct {
 	// Metrics listener uri
 	Listen string `yaml:"listen"`
+	// Prometheus metrics HTTP listener uri. Metrics in prometheus text exposition format are accepted at /api/v1/import/prometheus
+	// and prometheus remote write requests at /api/v1/write. Prometheus listener is disabled if empty.
+	PrometheusListen string `yaml:"prometheus_listen"`
 	// Retentions config file path.
 	// Simply use your original storage-schemas.conf or create new if you're using Moira without existing Graphite installation.
 	RetentionConfig string `yaml:"retention_config"`
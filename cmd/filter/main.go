@@ -17,6 +17,7 @@ import (
 	"github.com/moira-alert/moira/filter/heartbeat"
 	matchedmetrics "github.com/moira-alert/moira/filter/matched_metrics"
 	"github.com/moira-alert/moira/filter/patterns"
+	"github.com/moira-alert/moira/filter/prometheus"
 	"github.com/moira-alert/moira/logging/go-logging"
 	"github.com/moira-alert/moira/metrics"
 )
@@ -127,6 +128,15 @@ func main() {
 	defer metricsMatcher.Wait()  // First stop listener
 	defer stopListener(listener) // Then waiting for metrics matcher handle all received events
 
+	// Start prometheus metrics listener, it must be stopped before metrics listener closes lines channel
+	if config.Filter.PrometheusListen != "" {
+		prometheusListener := prometheus.NewListener(config.Filter.PrometheusListen, logger)
+		if err := prometheusListener.Listen(lineChan); err != nil {
+			logger.Fatalf("Failed to start prometheus listener: %s", err.Error())
+		}
+		defer stopPrometheusListener(prometheusListener)
+	}
+
 	logger.Infof("Moira Filter started. Version: %s", MoiraVersion)
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
@@ -140,6 +150,12 @@ func stopListener(listener *connection.MetricsListener) {
 	}
 }
 
+func stopPrometheusListener(listener *prometheus.Listener) {
+	if err := listener.Stop(); err != nil {
+		logger.Errorf("Failed to stop prometheus listener: %v", err)
+	}
+}
+
 func stopHeartbeatWorker(heartbeatWorker *heartbeat.Worker) {
 	if err := heartbeatWorker.Stop(); err != nil {
 		logger.Errorf("Failed to stop heartbeat worker: %v", err)
@@ -18,6 +18,8 @@ type config struct {
 	Notifier    notifierConfig       `yaml:"notifier"`
 	Telemetry   cmd.TelemetryConfig  `yaml:"telemetry"`
 	Remote      cmd.RemoteConfig     `yaml:"remote"`
+	Remotes     []cmd.RemoteConfig   `yaml:"remotes"`
+	Prometheus  cmd.PrometheusConfig `yaml:"prometheus"`
 	ImageStores cmd.ImageStoreConfig `yaml:"image_store"`
 }
 
@@ -36,6 +38,18 @@ type notifierConfig struct {
 	Timezone string `yaml:"timezone"`
 	// Format for email sender. Default is "15:04 02.01.2006". See https://golang.org/pkg/time/#Time.Format for more details about golang time formatting.
 	DateTimeFormat string `yaml:"date_time_format"`
+	// Default throttling levels of subscriptions with enabled throttling. Levels are checked in order, first matching level is applied.
+	// Subscription can override these levels
+	ThrottlingLevels []throttlingLevelConfig `yaml:"throttling_levels"`
+}
+
+type throttlingLevelConfig struct {
+	// Period to count trigger events in
+	Duration string `yaml:"duration"`
+	// Delay of next notification if trigger events count reaches Count
+	Delay string `yaml:"delay"`
+	// Trigger events count to start throttling at
+	Count int64 `yaml:"count"`
 }
 
 type selfStateConfig struct {
@@ -43,6 +57,8 @@ type selfStateConfig struct {
 	Enabled bool `yaml:"enabled"`
 	// If true, Self state monitor will check remote checker status
 	RemoteTriggersEnabled bool `yaml:"remote_triggers_enabled"`
+	// If true, Self state monitor will check prometheus checker status
+	PrometheusTriggersEnabled bool `yaml:"prometheus_triggers_enabled"`
 	// Max Redis disconnect delay to send alert when reached
 	RedisDisconnectDelay string `yaml:"redis_disconect_delay"`
 	// Max Filter metrics receive delay to send alert when reached
@@ -51,10 +67,14 @@ type selfStateConfig struct {
 	LastCheckDelay string `yaml:"last_check_delay"`
 	// Max Remote triggers Checker checks perform delay to send alert when reached
 	LastRemoteCheckDelay string `yaml:"last_remote_check_delay"`
+	// Max Prometheus triggers Checker checks perform delay to send alert when reached
+	LastPrometheusCheckDelay string `yaml:"last_prometheus_check_delay"`
 	// Contact list for Self state monitor alerts
 	Contacts []map[string]string `yaml:"contacts"`
 	// Self state monitor alerting interval
 	NoticeInterval string `yaml:"notice_interval"`
+	// Count of undelivered notifications in dead-letter queue to send alert when reached, 0 disables alert
+	FailedNotificationsThreshold int64 `yaml:"failed_notifications_threshold"`
 }
 
 func getDefault() config {
@@ -73,14 +93,19 @@ func getDefault() config {
 			SenderTimeout:    "10s",
 			ResendingTimeout: "1:00",
 			SelfState: selfStateConfig{
-				Enabled:                 false,
-				RedisDisconnectDelay:    "30s",
-				LastMetricReceivedDelay: "60s",
-				LastCheckDelay:          "60s",
-				NoticeInterval:          "300s",
+				Enabled:                  false,
+				RedisDisconnectDelay:     "30s",
+				LastMetricReceivedDelay:  "60s",
+				LastCheckDelay:           "60s",
+				LastPrometheusCheckDelay: "300s",
+				NoticeInterval:           "300s",
 			},
 			FrontURI: "http://localhost",
 			Timezone: "UTC",
+			ThrottlingLevels: []throttlingLevelConfig{
+				{Duration: "3h", Delay: "1h", Count: 20},
+				{Duration: "1h", Delay: "30m", Count: 10},
+			},
 		},
 		Telemetry: cmd.TelemetryConfig{
 			Listen: ":8093",
@@ -96,11 +121,15 @@ func getDefault() config {
 		Remote: cmd.RemoteConfig{
 			Timeout: "60s",
 		},
+		Prometheus: cmd.PrometheusConfig{
+			Timeout: "60s",
+			Step:    "60s",
+		},
 		ImageStores: cmd.ImageStoreConfig{},
 	}
 }
 
-func (config *notifierConfig) getSettings(logger moira.Logger) notifier.Config {
+func (config *notifierConfig) getSettings(logger moira.Logger) (notifier.Config, error) {
 	location, err := time.LoadLocation(config.Timezone)
 	if err != nil {
 		logger.Warningf("Timezone '%s' load failed: %s. Use UTC.", config.Timezone, err.Error())
@@ -117,6 +146,11 @@ func (config *notifierConfig) getSettings(logger moira.Logger) notifier.Config {
 		logger.Infof("Format '%v' parsed successfully. Current time format: %v", format, time.Now().Format(format))
 	}
 
+	throttlingLevels, err := config.getThrottlingLevels()
+	if err != nil {
+		return notifier.Config{}, err
+	}
+
 	return notifier.Config{
 		SelfStateEnabled:  config.SelfState.Enabled,
 		SelfStateContacts: config.SelfState.Contacts,
@@ -126,7 +160,23 @@ func (config *notifierConfig) getSettings(logger moira.Logger) notifier.Config {
 		FrontURL:          config.FrontURI,
 		Location:          location,
 		DateTimeFormat:    format,
+		ThrottlingLevels:  throttlingLevels,
+	}, nil
+}
+
+func (config *notifierConfig) getThrottlingLevels() ([]moira.ThrottlingLevel, error) {
+	levels := make([]moira.ThrottlingLevel, 0, len(config.ThrottlingLevels))
+	for _, level := range config.ThrottlingLevels {
+		levels = append(levels, moira.ThrottlingLevel{
+			Duration: int64(to.Duration(level.Duration).Seconds()),
+			Delay:    int64(to.Duration(level.Delay).Seconds()),
+			Count:    level.Count,
+		})
+	}
+	if err := moira.ValidateThrottlingLevels(levels); err != nil {
+		return nil, fmt.Errorf("invalid throttling levels: %s", err.Error())
 	}
+	return levels, nil
 }
 
 func checkDateTimeFormat(format string) error {
@@ -138,15 +188,19 @@ func checkDateTimeFormat(format string) error {
 	return nil
 }
 
-func (config *selfStateConfig) getSettings() selfstate.Config {
+func (config *selfStateConfig) getSettings(remoteClusters []moira.ClusterID) selfstate.Config {
 	return selfstate.Config{
-		Enabled:                        config.Enabled,
-		RemoteTriggersEnabled:          config.RemoteTriggersEnabled,
-		RedisDisconnectDelaySeconds:    int64(to.Duration(config.RedisDisconnectDelay).Seconds()),
-		LastMetricReceivedDelaySeconds: int64(to.Duration(config.LastMetricReceivedDelay).Seconds()),
-		LastCheckDelaySeconds:          int64(to.Duration(config.LastCheckDelay).Seconds()),
-		LastRemoteCheckDelaySeconds:    int64(to.Duration(config.LastRemoteCheckDelay).Seconds()),
-		Contacts:                       config.Contacts,
-		NoticeIntervalSeconds:          int64(to.Duration(config.NoticeInterval).Seconds()),
+		Enabled:                         config.Enabled,
+		RemoteTriggersEnabled:           config.RemoteTriggersEnabled,
+		RemoteClusters:                  remoteClusters,
+		PrometheusTriggersEnabled:       config.PrometheusTriggersEnabled,
+		RedisDisconnectDelaySeconds:     int64(to.Duration(config.RedisDisconnectDelay).Seconds()),
+		LastMetricReceivedDelaySeconds:  int64(to.Duration(config.LastMetricReceivedDelay).Seconds()),
+		LastCheckDelaySeconds:           int64(to.Duration(config.LastCheckDelay).Seconds()),
+		LastRemoteCheckDelaySeconds:     int64(to.Duration(config.LastRemoteCheckDelay).Seconds()),
+		LastPrometheusCheckDelaySeconds: int64(to.Duration(config.LastPrometheusCheckDelay).Seconds()),
+		Contacts:                        config.Contacts,
+		NoticeIntervalSeconds:           int64(to.Duration(config.NoticeInterval).Seconds()),
+		FailedNotificationsThreshold:    config.FailedNotificationsThreshold,
 	}
 }
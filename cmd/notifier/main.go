@@ -9,6 +9,7 @@ import (
 
 	metricSource "github.com/moira-alert/moira/metric_source"
 	"github.com/moira-alert/moira/metric_source/local"
+	"github.com/moira-alert/moira/metric_source/prometheus"
 	"github.com/moira-alert/moira/metric_source/remote"
 
 	"github.com/moira-alert/moira"
@@ -78,14 +79,22 @@ func main() {
 	database := redis.NewDatabase(logger, databaseSettings, redis.Notifier)
 
 	localSource := local.Create(database)
-	remoteConfig := config.Remote.GetRemoteSourceSettings()
-	remoteSource := remote.Create(remoteConfig)
-	metricSourceProvider := metricSource.CreateMetricSourceProvider(localSource, remoteSource)
+	remoteConfigs, err := cmd.GetRemoteSourcesSettings(config.Remote, config.Remotes)
+	if err != nil {
+		logger.Fatalf("Can not configure remote sources: %s", err.Error())
+	}
+	remoteSources := remote.CreateClusters(remoteConfigs)
+	prometheusConfig := config.Prometheus.GetPrometheusSourceSettings()
+	prometheusSource := prometheus.Create(prometheusConfig)
+	metricSourceProvider := metricSource.CreateMetricSourceProvider(localSource, remoteSources, prometheusSource)
 
 	// Initialize the image store
 	imageStoreMap := cmd.InitImageStores(config.ImageStores, logger)
 
-	notifierConfig := config.Notifier.getSettings(logger)
+	notifierConfig, err := config.Notifier.getSettings(logger)
+	if err != nil {
+		logger.Fatalf("Can not configure notifier: %s", err.Error())
+	}
 
 	sender := notifier.NewNotifier(database, logger, notifierConfig, notifierMetrics, metricSourceProvider, imageStoreMap)
 
@@ -98,7 +107,7 @@ func main() {
 	selfState := &selfstate.SelfCheckWorker{
 		Logger:   logger,
 		DB:       database,
-		Config:   config.Notifier.SelfState.getSettings(),
+		Config:   config.Notifier.SelfState.getSettings(metricSourceProvider.GetRemoteClusterIDs()),
 		Notifier: sender,
 	}
 	if err := selfState.Start(); err != nil {
@@ -119,7 +128,7 @@ func main() {
 	fetchEventsWorker := &events.FetchEventsWorker{
 		Logger:    logger,
 		Database:  database,
-		Scheduler: notifier.NewScheduler(database, logger, notifierMetrics),
+		Scheduler: notifier.NewScheduler(database, logger, notifierMetrics, notifierConfig.ThrottlingLevels),
 		Metrics:   notifierMetrics,
 	}
 	fetchEventsWorker.Start()
@@ -0,0 +1,104 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database/redis/reply"
+)
+
+var auditRecordsTTL int64 = 3600 * 24 * 90
+
+// SaveAuditRecord writes audit record and adds it to global and entity audit logs, records older than 90 days are deleted
+func (connector *DbConnector) SaveAuditRecord(record *moira.AuditRecord) error {
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	c := connector.pool.Get()
+	defer c.Close()
+	c.Send("MULTI")
+	c.Send("SET", auditRecordKey(record.ID), recordBytes, "EX", auditRecordsTTL)
+	for _, logKey := range []string{auditLogKey, entityAuditLogKey(record.EntityType, record.EntityID)} {
+		c.Send("ZADD", logKey, record.Timestamp, record.ID)
+		c.Send("ZREMRANGEBYSCORE", logKey, "-inf", record.Timestamp-auditRecordsTTL)
+		c.Send("EXPIRE", logKey, auditRecordsTTL)
+	}
+	_, err = c.Do("EXEC")
+	if err != nil {
+		return fmt.Errorf("failed to EXEC: %s", err.Error())
+	}
+	return nil
+}
+
+// GetAuditRecord returns audit record by given id, if no value, return database.ErrNil error
+func (connector *DbConnector) GetAuditRecord(id string) (moira.AuditRecord, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	return reply.AuditRecord(c.Do("GET", auditRecordKey(id)))
+}
+
+// GetAuditRecords returns page of global audit log sorted from newest to oldest records
+func (connector *DbConnector) GetAuditRecords(start int64, size int64) ([]*moira.AuditRecord, error) {
+	return connector.getAuditRecords(auditLogKey, start, size)
+}
+
+// GetAuditRecordsCount returns count of records in global audit log
+func (connector *DbConnector) GetAuditRecordsCount() (int64, error) {
+	return connector.getAuditRecordsCount(auditLogKey)
+}
+
+// GetEntityAuditRecords returns page of given entity audit log sorted from newest to oldest records
+func (connector *DbConnector) GetEntityAuditRecords(entityType moira.AuditEntityType, entityID string, start int64, size int64) ([]*moira.AuditRecord, error) {
+	return connector.getAuditRecords(entityAuditLogKey(entityType, entityID), start, size)
+}
+
+// GetEntityAuditRecordsCount returns count of records in given entity audit log
+func (connector *DbConnector) GetEntityAuditRecordsCount(entityType moira.AuditEntityType, entityID string) (int64, error) {
+	return connector.getAuditRecordsCount(entityAuditLogKey(entityType, entityID))
+}
+
+func (connector *DbConnector) getAuditRecords(logKey string, start int64, size int64) ([]*moira.AuditRecord, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	recordIDs, err := redis.Strings(c.Do("ZREVRANGE", logKey, start, start+size-1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit records ids: %s", err.Error())
+	}
+	if len(recordIDs) == 0 {
+		return make([]*moira.AuditRecord, 0), nil
+	}
+
+	c.Send("MULTI")
+	for _, id := range recordIDs {
+		c.Send("GET", auditRecordKey(id))
+	}
+	return reply.AuditRecords(c.Do("EXEC"))
+}
+
+func (connector *DbConnector) getAuditRecordsCount(logKey string) (int64, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	count, err := redis.Int64(c.Do("ZCARD", logKey))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get audit records count: %s", err.Error())
+	}
+	return count, nil
+}
+
+var auditLogKey = "moira-audit-log"
+
+func auditRecordKey(id string) string {
+	return "moira-audit-record:" + id
+}
+
+func entityAuditLogKey(entityType moira.AuditEntityType, entityID string) string {
+	return fmt.Sprintf("moira-audit-log:%s:%s", entityType, entityID)
+}
@@ -0,0 +1,159 @@
+package redis
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/op/go-logging"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+func TestAuditRecords(t *testing.T) {
+	logger, _ := logging.GetLogger("dataBase")
+	dataBase := newTestDatabase(logger, config)
+	dataBase.flush()
+	defer dataBase.flush()
+
+	Convey("Audit records manipulation", t, func() {
+		Convey("Should be no records", func() {
+			actual, err := dataBase.GetAuditRecords(0, 10)
+			So(err, ShouldBeNil)
+			So(actual, ShouldBeEmpty)
+
+			count, err := dataBase.GetAuditRecordsCount()
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 0)
+
+			_, err = dataBase.GetAuditRecord(auditRecords[0].ID)
+			So(err, ShouldResemble, database.ErrNil)
+		})
+
+		Convey("Should get saved records", func() {
+			for _, record := range auditRecords {
+				err := dataBase.SaveAuditRecord(record)
+				So(err, ShouldBeNil)
+			}
+
+			actual, err := dataBase.GetAuditRecord(auditRecords[1].ID)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, *auditRecords[1])
+
+			records, err := dataBase.GetAuditRecords(0, 10)
+			So(err, ShouldBeNil)
+			So(records, ShouldResemble, []*moira.AuditRecord{auditRecords[2], auditRecords[1], auditRecords[0]})
+
+			records, err = dataBase.GetAuditRecords(1, 1)
+			So(err, ShouldBeNil)
+			So(records, ShouldResemble, []*moira.AuditRecord{auditRecords[1]})
+
+			count, err := dataBase.GetAuditRecordsCount()
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 3)
+
+			records, err = dataBase.GetEntityAuditRecords(moira.AuditEntityTrigger, "trigger-id", 0, 10)
+			So(err, ShouldBeNil)
+			So(records, ShouldResemble, []*moira.AuditRecord{auditRecords[1], auditRecords[0]})
+
+			count, err = dataBase.GetEntityAuditRecordsCount(moira.AuditEntityTrigger, "trigger-id")
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 2)
+
+			count, err = dataBase.GetEntityAuditRecordsCount(moira.AuditEntityContact, "trigger-id")
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 0)
+		})
+
+		Convey("Should delete outdated records", func() {
+			for _, record := range auditRecords {
+				err := dataBase.SaveAuditRecord(record)
+				So(err, ShouldBeNil)
+			}
+			So(dataBase.getTTL(auditRecordKey(auditRecords[0].ID)), ShouldBeGreaterThan, 0)
+
+			newRecord := *auditRecords[1]
+			newRecord.ID = "new-record-id"
+			newRecord.Timestamp = auditRecords[0].Timestamp + auditRecordsTTL + 1
+			err := dataBase.SaveAuditRecord(&newRecord)
+			So(err, ShouldBeNil)
+
+			records, err := dataBase.GetAuditRecords(0, 10)
+			So(err, ShouldBeNil)
+			So(records, ShouldResemble, []*moira.AuditRecord{&newRecord, auditRecords[2], auditRecords[1]})
+
+			records, err = dataBase.GetEntityAuditRecords(moira.AuditEntityTrigger, "trigger-id", 0, 10)
+			So(err, ShouldBeNil)
+			So(records, ShouldResemble, []*moira.AuditRecord{&newRecord, auditRecords[1]})
+			So(dataBase.getTTL(entityAuditLogKey(moira.AuditEntityTrigger, "trigger-id")), ShouldBeGreaterThan, 0)
+		})
+	})
+}
+
+func TestAuditRecordsErrorConnection(t *testing.T) {
+	logger, _ := logging.GetLogger("dataBase")
+	dataBase := newTestDatabase(logger, emptyConfig)
+	dataBase.flush()
+	defer dataBase.flush()
+
+	Convey("Should throw error when no connection", t, func() {
+		err := dataBase.SaveAuditRecord(auditRecords[0])
+		So(err, ShouldNotBeNil)
+
+		_, err = dataBase.GetAuditRecord(auditRecords[0].ID)
+		So(err, ShouldNotBeNil)
+
+		_, err = dataBase.GetAuditRecords(0, 10)
+		So(err, ShouldNotBeNil)
+
+		_, err = dataBase.GetAuditRecordsCount()
+		So(err, ShouldNotBeNil)
+
+		_, err = dataBase.GetEntityAuditRecords(moira.AuditEntityTrigger, "trigger-id", 0, 10)
+		So(err, ShouldNotBeNil)
+
+		_, err = dataBase.GetEntityAuditRecordsCount(moira.AuditEntityTrigger, "trigger-id")
+		So(err, ShouldNotBeNil)
+	})
+}
+
+var auditRecords = []*moira.AuditRecord{
+	{
+		ID:         "audit-record-1",
+		Timestamp:  100,
+		User:       "user",
+		EntityType: moira.AuditEntityTrigger,
+		EntityID:   "trigger-id",
+		Action:     moira.AuditActionCreate,
+		After:      json.RawMessage(`{"name":"first"}`),
+		Diff: map[string]moira.AuditFieldDiff{
+			"name": {After: json.RawMessage(`"first"`)},
+		},
+	},
+	{
+		ID:         "audit-record-2",
+		Timestamp:  200,
+		User:       "user",
+		EntityType: moira.AuditEntityTrigger,
+		EntityID:   "trigger-id",
+		Action:     moira.AuditActionUpdate,
+		Before:     json.RawMessage(`{"name":"first"}`),
+		After:      json.RawMessage(`{"name":"second"}`),
+		Diff: map[string]moira.AuditFieldDiff{
+			"name": {Before: json.RawMessage(`"first"`), After: json.RawMessage(`"second"`)},
+		},
+	},
+	{
+		ID:         "audit-record-3",
+		Timestamp:  300,
+		User:       "user",
+		EntityType: moira.AuditEntityContact,
+		EntityID:   "contact-id",
+		Action:     moira.AuditActionRemove,
+		Before:     json.RawMessage(`{"value":"mail@example.com"}`),
+		Diff: map[string]moira.AuditFieldDiff{
+			"value": {Before: json.RawMessage(`"mail@example.com"`)},
+		},
+	},
+}
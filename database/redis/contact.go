@@ -103,6 +103,7 @@ func (connector *DbConnector) RemoveContact(contactID string) error {
 
 	c.Send("MULTI")
 	c.Send("DEL", contactKey(contactID))
+	c.Send("DEL", contactDeliveriesKey(contactID))
 	c.Send("SREM", userContactsKey(existing.User), contactID)
 	_, err = c.Do("EXEC")
 	if err != nil {
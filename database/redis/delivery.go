@@ -0,0 +1,88 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database/redis/reply"
+)
+
+var deliveriesTTL int64 = 3600 * 24 * 14
+
+// AddNotificationDelivery adds delivery to contact and triggers delivery histories and deletes deliveries older than 14 days
+func (connector *DbConnector) AddNotificationDelivery(delivery *moira.NotificationDelivery) error {
+	deliveryBytes, err := json.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(delivery.TriggerIDs)+1)
+	if delivery.ContactID != "" {
+		keys = append(keys, contactDeliveriesKey(delivery.ContactID))
+	}
+	for _, triggerID := range delivery.TriggerIDs {
+		if triggerID != "" {
+			keys = append(keys, triggerDeliveriesKey(triggerID))
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	c := connector.pool.Get()
+	defer c.Close()
+	c.Send("MULTI")
+	for _, key := range keys {
+		c.Send("ZADD", key, delivery.Timestamp, deliveryBytes)
+		c.Send("ZREMRANGEBYSCORE", key, "-inf", time.Now().Unix()-deliveriesTTL)
+		c.Send("EXPIRE", key, deliveriesTTL)
+	}
+	if _, err = c.Do("EXEC"); err != nil {
+		return fmt.Errorf("failed to EXEC: %s", err.Error())
+	}
+	return nil
+}
+
+// GetContactDeliveries gets given count of the latest deliveries to contact starting from given position and total count of contact deliveries
+func (connector *DbConnector) GetContactDeliveries(contactID string, start, size int64) ([]*moira.NotificationDelivery, int64, error) {
+	return connector.getDeliveries(contactDeliveriesKey(contactID), start, size)
+}
+
+// GetTriggerDeliveries gets given count of the latest deliveries of trigger notifications starting from given position and total count of trigger deliveries
+func (connector *DbConnector) GetTriggerDeliveries(triggerID string, start, size int64) ([]*moira.NotificationDelivery, int64, error) {
+	return connector.getDeliveries(triggerDeliveriesKey(triggerID), start, size)
+}
+
+func (connector *DbConnector) getDeliveries(key string, start, size int64) ([]*moira.NotificationDelivery, int64, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("ZREVRANGE", key, start, start+size-1)
+	c.Send("ZCARD", key)
+	rawResponse, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to EXEC: %s", err.Error())
+	}
+	deliveries, err := reply.Deliveries(rawResponse[0], nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := redis.Int64(rawResponse[1], nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+func contactDeliveriesKey(contactID string) string {
+	return "moira-contact-deliveries:" + contactID
+}
+
+func triggerDeliveriesKey(triggerID string) string {
+	return "moira-trigger-deliveries:" + triggerID
+}
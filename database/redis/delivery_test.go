@@ -0,0 +1,95 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/logging/go-logging"
+)
+
+func TestNotificationDeliveries(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "info", "test")
+	dataBase := newTestDatabase(logger, config)
+	dataBase.flush()
+	defer dataBase.flush()
+
+	Convey("Notification deliveries manipulation", t, func() {
+		now := time.Now().Unix()
+		delivery1 := moira.NotificationDelivery{ContactID: "contactID", TriggerIDs: []string{"triggerID1"}, Result: moira.DeliverySent, Attempt: 1, Timestamp: now - 20}
+		delivery2 := moira.NotificationDelivery{ContactID: "contactID", TriggerIDs: []string{"triggerID1", "triggerID2"}, Result: moira.DeliveryFailed, Error: "timeout", Attempt: 1, Timestamp: now - 10}
+		delivery3 := moira.NotificationDelivery{ContactID: "otherContactID", TriggerIDs: []string{"triggerID1"}, Result: moira.DeliverySent, Attempt: 2, Timestamp: now}
+
+		Convey("Empty history", func() {
+			actual, total, err := dataBase.GetContactDeliveries("contactID", 0, 10)
+			So(err, ShouldBeNil)
+			So(actual, ShouldBeEmpty)
+			So(total, ShouldEqual, 0)
+		})
+
+		Convey("Deliveries are added to contact and triggers histories", func() {
+			for _, delivery := range []moira.NotificationDelivery{delivery1, delivery2, delivery3} {
+				delivery := delivery
+				So(dataBase.AddNotificationDelivery(&delivery), ShouldBeNil)
+			}
+
+			actual, total, err := dataBase.GetContactDeliveries("contactID", 0, 10)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, []*moira.NotificationDelivery{&delivery2, &delivery1})
+			So(total, ShouldEqual, 2)
+
+			actual, total, err = dataBase.GetTriggerDeliveries("triggerID1", 0, 1)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, []*moira.NotificationDelivery{&delivery3})
+			So(total, ShouldEqual, 3)
+
+			actual, total, err = dataBase.GetTriggerDeliveries("triggerID1", 1, 2)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, []*moira.NotificationDelivery{&delivery2, &delivery1})
+			So(total, ShouldEqual, 3)
+
+			actual, total, err = dataBase.GetTriggerDeliveries("triggerID2", 0, 10)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, []*moira.NotificationDelivery{&delivery2})
+			So(total, ShouldEqual, 1)
+		})
+
+		Convey("Old deliveries are deleted", func() {
+			old := moira.NotificationDelivery{ContactID: "oldContactID", Timestamp: now - deliveriesTTL - 1}
+			So(dataBase.AddNotificationDelivery(&old), ShouldBeNil)
+			actual, total, err := dataBase.GetContactDeliveries("oldContactID", 0, 10)
+			So(err, ShouldBeNil)
+			So(actual, ShouldBeEmpty)
+			So(total, ShouldEqual, 0)
+		})
+
+		Convey("Deliveries are removed with contact", func() {
+			contact := moira.ContactData{ID: "removedContactID", User: "user"}
+			So(dataBase.SaveContact(&contact), ShouldBeNil)
+			So(dataBase.AddNotificationDelivery(&moira.NotificationDelivery{ContactID: contact.ID, Timestamp: now}), ShouldBeNil)
+			So(dataBase.RemoveContact(contact.ID), ShouldBeNil)
+			_, total, err := dataBase.GetContactDeliveries(contact.ID, 0, 10)
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 0)
+		})
+	})
+}
+
+func TestNotificationDeliveriesErrorConnection(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "info", "test")
+	dataBase := newTestDatabase(logger, emptyConfig)
+	dataBase.flush()
+	defer dataBase.flush()
+	Convey("Should throw error when no connection", t, func() {
+		err := dataBase.AddNotificationDelivery(&moira.NotificationDelivery{ContactID: "contactID"})
+		So(err, ShouldNotBeNil)
+
+		_, _, err = dataBase.GetContactDeliveries("contactID", 0, 10)
+		So(err, ShouldNotBeNil)
+
+		_, _, err = dataBase.GetTriggerDeliveries("triggerID", 0, 10)
+		So(err, ShouldNotBeNil)
+	})
+}
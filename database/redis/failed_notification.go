@@ -0,0 +1,92 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database/redis/reply"
+)
+
+// AddFailedNotifications stores notifications which were not delivered until resending timeout passed
+func (connector *DbConnector) AddFailedNotifications(notifications []*moira.FailedNotification) error {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	for _, notification := range notifications {
+		bytes, err := json.Marshal(notification)
+		if err != nil {
+			return err
+		}
+		c.Send("HSET", failedNotificationsKey, notification.ID, bytes)
+		c.Send("ZADD", failedNotificationsIDsKey, notification.Timestamp, notification.ID)
+	}
+	if _, err := c.Do("EXEC"); err != nil {
+		return fmt.Errorf("failed to EXEC: %s", err.Error())
+	}
+	return nil
+}
+
+// GetFailedNotifications gets failed notifications in given range ordered by failure time and total count of failed notifications
+func (connector *DbConnector) GetFailedNotifications(start, end int64) ([]*moira.FailedNotification, int64, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("ZRANGE", failedNotificationsIDsKey, start, end)
+	c.Send("ZCARD", failedNotificationsIDsKey)
+	rawResponse, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to EXEC: %s", err.Error())
+	}
+	ids, err := redis.Strings(rawResponse[0], nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := redis.Int64(rawResponse[1], nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(ids) == 0 {
+		return make([]*moira.FailedNotification, 0), total, nil
+	}
+	notifications, err := reply.FailedNotifications(c.Do("HMGET", redis.Args{}.Add(failedNotificationsKey).AddFlat(ids)...))
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+// GetFailedNotificationsCount returns count of failed notifications
+func (connector *DbConnector) GetFailedNotificationsCount() (int64, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	return redis.Int64(c.Do("ZCARD", failedNotificationsIDsKey))
+}
+
+// FetchFailedNotifications gets failed notifications by given ids and deletes them, not existing ids are skipped
+func (connector *DbConnector) FetchFailedNotifications(ids []string) ([]*moira.FailedNotification, error) {
+	if len(ids) == 0 {
+		return make([]*moira.FailedNotification, 0), nil
+	}
+
+	c := connector.pool.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("HMGET", redis.Args{}.Add(failedNotificationsKey).AddFlat(ids)...)
+	c.Send("HDEL", redis.Args{}.Add(failedNotificationsKey).AddFlat(ids)...)
+	c.Send("ZREM", redis.Args{}.Add(failedNotificationsIDsKey).AddFlat(ids)...)
+	rawResponse, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to EXEC: %s", err.Error())
+	}
+	return reply.FailedNotifications(rawResponse[0], nil)
+}
+
+var failedNotificationsKey = "moira-failed-notifications"
+var failedNotificationsIDsKey = "moira-failed-notifications-ids"
@@ -0,0 +1,92 @@
+package redis
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/logging/go-logging"
+)
+
+func TestFailedNotifications(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "info", "test")
+	dataBase := newTestDatabase(logger, config)
+	dataBase.flush()
+	defer dataBase.flush()
+
+	Convey("Failed notifications manipulation", t, func() {
+		notification1 := moira.FailedNotification{ID: "id1", Contact: moira.ContactData{ID: "contactID"}, Error: "timeout", Timestamp: 10}
+		notification2 := moira.FailedNotification{ID: "id2", Contact: moira.ContactData{ID: "contactID"}, Error: "bad request", Timestamp: 20}
+		notification3 := moira.FailedNotification{ID: "id3", Contact: moira.ContactData{ID: "contactID"}, Error: "timeout", Timestamp: 15}
+
+		Convey("Empty queue", func() {
+			actual, total, err := dataBase.GetFailedNotifications(0, -1)
+			So(err, ShouldBeNil)
+			So(actual, ShouldBeEmpty)
+			So(total, ShouldEqual, 0)
+
+			count, err := dataBase.GetFailedNotificationsCount()
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 0)
+		})
+
+		Convey("Add, get and fetch", func() {
+			err := dataBase.AddFailedNotifications([]*moira.FailedNotification{&notification1, &notification2, &notification3})
+			So(err, ShouldBeNil)
+
+			actual, total, err := dataBase.GetFailedNotifications(0, -1)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, []*moira.FailedNotification{&notification1, &notification3, &notification2})
+			So(total, ShouldEqual, 3)
+
+			actual, total, err = dataBase.GetFailedNotifications(1, 1)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, []*moira.FailedNotification{&notification3})
+			So(total, ShouldEqual, 3)
+
+			actual, err = dataBase.FetchFailedNotifications([]string{"id2", "not existing id"})
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, []*moira.FailedNotification{&notification2})
+
+			count, err := dataBase.GetFailedNotificationsCount()
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 2)
+
+			actual, err = dataBase.FetchFailedNotifications([]string{"id1", "id3"})
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, []*moira.FailedNotification{&notification1, &notification3})
+
+			actual, total, err = dataBase.GetFailedNotifications(0, -1)
+			So(err, ShouldBeNil)
+			So(actual, ShouldBeEmpty)
+			So(total, ShouldEqual, 0)
+		})
+
+		Convey("Fetch without ids", func() {
+			actual, err := dataBase.FetchFailedNotifications(nil)
+			So(err, ShouldBeNil)
+			So(actual, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestFailedNotificationsErrorConnection(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "info", "test")
+	dataBase := newTestDatabase(logger, emptyConfig)
+	dataBase.flush()
+	defer dataBase.flush()
+	Convey("Should throw error when no connection", t, func() {
+		err := dataBase.AddFailedNotifications([]*moira.FailedNotification{{ID: "id"}})
+		So(err, ShouldNotBeNil)
+
+		_, _, err = dataBase.GetFailedNotifications(0, -1)
+		So(err, ShouldNotBeNil)
+
+		_, err = dataBase.GetFailedNotificationsCount()
+		So(err, ShouldNotBeNil)
+
+		_, err = dataBase.FetchFailedNotifications([]string{"id"})
+		So(err, ShouldNotBeNil)
+	})
+}
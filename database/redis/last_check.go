@@ -19,8 +19,8 @@ func (connector *DbConnector) GetTriggerLastCheck(triggerID string) (moira.Check
 }
 
 // SetTriggerLastCheck sets trigger last check data
-func (connector *DbConnector) SetTriggerLastCheck(triggerID string, checkData *moira.CheckData, isRemote bool) error {
-	selfStateCheckCountKey := connector.getSelfStateCheckCountKey(isRemote)
+func (connector *DbConnector) SetTriggerLastCheck(triggerID string, checkData *moira.CheckData, clusterKey moira.ClusterKey) error {
+	selfStateCheckCountKey := connector.getSelfStateCheckCountKey(clusterKey)
 	bytes, err := json.Marshal(checkData)
 	if err != nil {
 		return err
@@ -51,14 +51,18 @@ func (connector *DbConnector) SetTriggerLastCheck(triggerID string, checkData *m
 	return nil
 }
 
-func (connector *DbConnector) getSelfStateCheckCountKey(isRemote bool) string {
+func (connector *DbConnector) getSelfStateCheckCountKey(clusterKey moira.ClusterKey) string {
 	if connector.source != Checker {
 		return ""
 	}
-	if isRemote {
-		return selfStateRemoteChecksCounterKey
+	switch clusterKey.TriggerSource {
+	case moira.GraphiteRemote:
+		return selfStateRemoteChecksCounterKey(clusterKey.ClusterID)
+	case moira.PrometheusRemote:
+		return selfStatePrometheusChecksCounterKey
+	default:
+		return selfStateChecksCounterKey
 	}
-	return selfStateChecksCounterKey
 }
 
 // RemoveTriggerLastCheck removes trigger last check data
@@ -82,6 +86,34 @@ func (connector *DbConnector) RemoveTriggerLastCheck(triggerID string) error {
 // If during the update lastCheck was updated from another place, try update again
 // If CheckData does not contain one of given metrics it will ignore this metric
 func (connector *DbConnector) SetTriggerCheckMaintenance(triggerID string, metrics map[string]int64, triggerMaintenance *int64, userLogin string, timeCallMaintenance int64) error {
+	return connector.updateTriggerLastCheck(triggerID, func(lastCheck *moira.CheckData) {
+		metricsCheck := lastCheck.Metrics
+		if len(metricsCheck) > 0 {
+			for metric, value := range metrics {
+				data, ok := metricsCheck[metric]
+				if !ok {
+					continue
+				}
+				moira.SetMaintenanceUserAndTime(&data, value, userLogin, timeCallMaintenance)
+				metricsCheck[metric] = data
+			}
+		}
+		if triggerMaintenance != nil {
+			moira.SetMaintenanceUserAndTime(lastCheck, *triggerMaintenance, userLogin, timeCallMaintenance)
+		}
+	})
+}
+
+// SetTriggerCheckAcknowledgement sets acknowledgement of given trigger metrics, if metrics are empty,
+// trigger and all its metrics in bad state are acknowledged. Nil acknowledgement removes existing acknowledgements
+func (connector *DbConnector) SetTriggerCheckAcknowledgement(triggerID string, metrics []string, ack *moira.Acknowledgement) error {
+	return connector.updateTriggerLastCheck(triggerID, func(lastCheck *moira.CheckData) {
+		lastCheck.SetAcknowledgement(metrics, ack)
+	})
+}
+
+// updateTriggerLastCheck applies update to trigger last check and retries it if last check was changed concurrently
+func (connector *DbConnector) updateTriggerLastCheck(triggerID string, update func(lastCheck *moira.CheckData)) error {
 	c := connector.pool.Get()
 	defer c.Close()
 	var readingErr error
@@ -96,20 +128,7 @@ func (connector *DbConnector) SetTriggerCheckMaintenance(triggerID string, metri
 		if err != nil {
 			return fmt.Errorf("failed to parse lastCheck json %s: %s", lastCheckString, err.Error())
 		}
-		metricsCheck := lastCheck.Metrics
-		if len(metricsCheck) > 0 {
-			for metric, value := range metrics {
-				data, ok := metricsCheck[metric]
-				if !ok {
-					continue
-				}
-				moira.SetMaintenanceUserAndTime(&data, value, userLogin, timeCallMaintenance)
-				metricsCheck[metric] = data
-			}
-		}
-		if triggerMaintenance != nil {
-			moira.SetMaintenanceUserAndTime(&lastCheck, *triggerMaintenance, userLogin, timeCallMaintenance)
-		}
+		update(&lastCheck)
 		newLastCheck, err := json.Marshal(lastCheck)
 		if err != nil {
 			return err
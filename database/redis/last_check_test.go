@@ -22,7 +22,7 @@ func TestLastCheck(t *testing.T) {
 	Convey("LastCheck manipulation", t, func() {
 		Convey("Test read write delete", func() {
 			triggerID := uuid.Must(uuid.NewV4()).String()
-			err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, false)
+			err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, moira.DefaultLocalCluster)
 			So(err, ShouldBeNil)
 
 			actual, err := dataBase.GetTriggerLastCheck(triggerID)
@@ -54,7 +54,7 @@ func TestLastCheck(t *testing.T) {
 
 			Convey("While no metrics", func() {
 				triggerID := uuid.Must(uuid.NewV4()).String()
-				err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckWithNoMetrics, false)
+				err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckWithNoMetrics, moira.DefaultLocalCluster)
 				So(err, ShouldBeNil)
 
 				err = dataBase.SetTriggerCheckMaintenance(triggerID, map[string]int64{"metric1": 1, "metric5": 5}, nil, "", 0)
@@ -67,7 +67,7 @@ func TestLastCheck(t *testing.T) {
 
 			Convey("While no metrics to change", func() {
 				triggerID := uuid.Must(uuid.NewV4()).String()
-				err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, false)
+				err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, moira.DefaultLocalCluster)
 				So(err, ShouldBeNil)
 
 				err = dataBase.SetTriggerCheckMaintenance(triggerID, map[string]int64{"metric11": 1, "metric55": 5}, nil, "", 0)
@@ -81,7 +81,7 @@ func TestLastCheck(t *testing.T) {
 			Convey("Has metrics to change", func() {
 				checkData := lastCheckTest
 				triggerID := uuid.Must(uuid.NewV4()).String()
-				err := dataBase.SetTriggerLastCheck(triggerID, &checkData, false)
+				err := dataBase.SetTriggerLastCheck(triggerID, &checkData, moira.DefaultLocalCluster)
 				So(err, ShouldBeNil)
 
 				err = dataBase.SetTriggerCheckMaintenance(triggerID, map[string]int64{"metric1": 1, "metric5": 5}, nil, "", 0)
@@ -108,7 +108,7 @@ func TestLastCheck(t *testing.T) {
 
 			Convey("Set metrics maintenance while no metrics", func() {
 				triggerID := uuid.Must(uuid.NewV4()).String()
-				err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckWithNoMetrics, false)
+				err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckWithNoMetrics, moira.DefaultLocalCluster)
 				So(err, ShouldBeNil)
 
 				err = dataBase.SetTriggerCheckMaintenance(triggerID, map[string]int64{"metric1": 1, "metric5": 5}, nil, "", 0)
@@ -121,7 +121,7 @@ func TestLastCheck(t *testing.T) {
 
 			Convey("Set trigger maintenance while no metrics", func() {
 				triggerID := uuid.Must(uuid.NewV4()).String()
-				err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckWithNoMetrics, false)
+				err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckWithNoMetrics, moira.DefaultLocalCluster)
 				So(err, ShouldBeNil)
 
 				triggerMaintenanceTS = 1000
@@ -136,7 +136,7 @@ func TestLastCheck(t *testing.T) {
 
 			Convey("Set metrics maintenance while no metrics to change", func() {
 				triggerID := uuid.Must(uuid.NewV4()).String()
-				err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, false)
+				err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, moira.DefaultLocalCluster)
 				So(err, ShouldBeNil)
 
 				err = dataBase.SetTriggerCheckMaintenance(triggerID, map[string]int64{"metric11": 1, "metric55": 5}, nil, "", 0)
@@ -151,7 +151,7 @@ func TestLastCheck(t *testing.T) {
 				newLastCheckTest := lastCheckTest
 				newLastCheckTest.Maintenance = 1000
 				triggerID := uuid.Must(uuid.NewV4()).String()
-				err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, false)
+				err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, moira.DefaultLocalCluster)
 				So(err, ShouldBeNil)
 
 				triggerMaintenanceTS = 1000
@@ -166,7 +166,7 @@ func TestLastCheck(t *testing.T) {
 			Convey("Set metrics maintenance while has metrics to change", func() {
 				checkData := lastCheckTest
 				triggerID := uuid.Must(uuid.NewV4()).String()
-				err := dataBase.SetTriggerLastCheck(triggerID, &checkData, false)
+				err := dataBase.SetTriggerLastCheck(triggerID, &checkData, moira.DefaultLocalCluster)
 				So(err, ShouldBeNil)
 
 				err = dataBase.SetTriggerCheckMaintenance(triggerID, map[string]int64{"metric1": 1, "metric5": 5}, nil, "", 0)
@@ -186,7 +186,7 @@ func TestLastCheck(t *testing.T) {
 			Convey("Set trigger and metrics maintenance while has metrics to change", func() {
 				checkData := lastCheckTest
 				triggerID := uuid.Must(uuid.NewV4()).String()
-				err := dataBase.SetTriggerLastCheck(triggerID, &checkData, false)
+				err := dataBase.SetTriggerLastCheck(triggerID, &checkData, moira.DefaultLocalCluster)
 				So(err, ShouldBeNil)
 
 				triggerMaintenanceTS = 1000
@@ -208,7 +208,7 @@ func TestLastCheck(t *testing.T) {
 			Convey("Set trigger maintenance to 0 and metrics maintenance", func() {
 				checkData := lastCheckTest
 				triggerID := uuid.Must(uuid.NewV4()).String()
-				err := dataBase.SetTriggerLastCheck(triggerID, &checkData, false)
+				err := dataBase.SetTriggerLastCheck(triggerID, &checkData, moira.DefaultLocalCluster)
 				So(err, ShouldBeNil)
 
 				triggerMaintenanceTS = 0
@@ -230,7 +230,7 @@ func TestLastCheck(t *testing.T) {
 			So(dataBase.checkDataScoreChanged(triggerID, &lastCheckWithNoMetrics), ShouldBeTrue)
 
 			// set new last check. Should add a trigger to a reindex set
-			err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckWithNoMetrics, false)
+			err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckWithNoMetrics, moira.DefaultLocalCluster)
 			So(err, ShouldBeNil)
 
 			So(dataBase.checkDataScoreChanged(triggerID, &lastCheckWithNoMetrics), ShouldBeFalse)
@@ -243,7 +243,7 @@ func TestLastCheck(t *testing.T) {
 
 			time.Sleep(time.Second)
 
-			err = dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, false)
+			err = dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, moira.DefaultLocalCluster)
 			So(err, ShouldBeNil)
 
 			actual, err = dataBase.FetchTriggersToReindex(time.Now().Unix() - 10)
@@ -267,6 +267,65 @@ func TestLastCheck(t *testing.T) {
 	})
 }
 
+func TestLastCheckAcknowledgement(t *testing.T) {
+	logger, _ := logging.GetLogger("dataBase")
+	dataBase := newTestDatabase(logger, config)
+	dataBase.flush()
+	defer dataBase.flush()
+
+	ack := &moira.Acknowledgement{User: "user", Timestamp: 1504509981, Expiry: 1504519981}
+
+	Convey("Test set trigger check acknowledgement", t, func() {
+		Convey("While no check", func() {
+			triggerID := uuid.Must(uuid.NewV4()).String()
+			err := dataBase.SetTriggerCheckAcknowledgement(triggerID, nil, ack)
+			So(err, ShouldBeNil)
+
+			_, err = dataBase.GetTriggerLastCheck(triggerID)
+			So(err, ShouldResemble, database.ErrNil)
+		})
+
+		Convey("Acknowledge given metrics and remove acknowledgement", func() {
+			triggerID := uuid.Must(uuid.NewV4()).String()
+			err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, moira.DefaultLocalCluster)
+			So(err, ShouldBeNil)
+
+			err = dataBase.SetTriggerCheckAcknowledgement(triggerID, []string{"metric1", "metric11"}, ack)
+			So(err, ShouldBeNil)
+
+			actual, err := dataBase.GetTriggerLastCheck(triggerID)
+			So(err, ShouldBeNil)
+			So(actual.Acknowledgement, ShouldBeNil)
+			So(actual.Metrics["metric1"].Acknowledgement, ShouldResemble, ack)
+			So(actual.Metrics["metric2"].Acknowledgement, ShouldBeNil)
+			So(actual.Metrics, ShouldNotContainKey, "metric11")
+
+			err = dataBase.SetTriggerCheckAcknowledgement(triggerID, nil, nil)
+			So(err, ShouldBeNil)
+
+			actual, err = dataBase.GetTriggerLastCheck(triggerID)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, lastCheckTest)
+		})
+
+		Convey("Acknowledge whole trigger", func() {
+			triggerID := uuid.Must(uuid.NewV4()).String()
+			err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, moira.DefaultLocalCluster)
+			So(err, ShouldBeNil)
+
+			err = dataBase.SetTriggerCheckAcknowledgement(triggerID, nil, ack)
+			So(err, ShouldBeNil)
+
+			actual, err := dataBase.GetTriggerLastCheck(triggerID)
+			So(err, ShouldBeNil)
+			So(actual.Acknowledgement, ShouldResemble, ack)
+			for _, metricState := range actual.Metrics {
+				So(metricState.Acknowledgement, ShouldResemble, ack)
+			}
+		})
+	})
+}
+
 func TestRemoteLastCheck(t *testing.T) {
 	logger, _ := logging.GetLogger("dataBase")
 	dataBase := newTestDatabase(logger, config)
@@ -276,7 +335,7 @@ func TestRemoteLastCheck(t *testing.T) {
 	Convey("LastCheck manipulation", t, func() {
 		Convey("Test read write delete", func() {
 			triggerID := uuid.Must(uuid.NewV4()).String()
-			err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, true)
+			err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, moira.DefaultGraphiteRemoteCluster)
 			So(err, ShouldBeNil)
 
 			actual, err := dataBase.GetTriggerLastCheck(triggerID)
@@ -308,7 +367,7 @@ func TestRemoteLastCheck(t *testing.T) {
 
 			Convey("While no metrics", func() {
 				triggerID := uuid.Must(uuid.NewV4()).String()
-				err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckWithNoMetrics, true)
+				err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckWithNoMetrics, moira.DefaultGraphiteRemoteCluster)
 				So(err, ShouldBeNil)
 
 				err = dataBase.SetTriggerCheckMaintenance(triggerID, map[string]int64{"metric1": 1, "metric5": 5}, nil, "", 0)
@@ -321,7 +380,7 @@ func TestRemoteLastCheck(t *testing.T) {
 
 			Convey("While no metrics to change", func() {
 				triggerID := uuid.Must(uuid.NewV4()).String()
-				err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, true)
+				err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, moira.DefaultGraphiteRemoteCluster)
 				So(err, ShouldBeNil)
 
 				err = dataBase.SetTriggerCheckMaintenance(triggerID, map[string]int64{"metric11": 1, "metric55": 5}, nil, "", 0)
@@ -335,7 +394,7 @@ func TestRemoteLastCheck(t *testing.T) {
 			Convey("Has metrics to change", func() {
 				checkData := lastCheckTest
 				triggerID := uuid.Must(uuid.NewV4()).String()
-				err := dataBase.SetTriggerLastCheck(triggerID, &checkData, true)
+				err := dataBase.SetTriggerLastCheck(triggerID, &checkData, moira.DefaultGraphiteRemoteCluster)
 				So(err, ShouldBeNil)
 
 				err = dataBase.SetTriggerCheckMaintenance(triggerID, map[string]int64{"metric1": 1, "metric5": 5}, nil, "", 0)
@@ -365,7 +424,7 @@ func TestLastCheckErrorConnection(t *testing.T) {
 		So(actual1, ShouldResemble, moira.CheckData{})
 		So(err, ShouldNotBeNil)
 
-		err = dataBase.SetTriggerLastCheck("123", &lastCheckTest, false)
+		err = dataBase.SetTriggerLastCheck("123", &lastCheckTest, moira.DefaultLocalCluster)
 		So(err, ShouldNotBeNil)
 
 		err = dataBase.RemoveTriggerLastCheck("123")
@@ -375,6 +434,9 @@ func TestLastCheckErrorConnection(t *testing.T) {
 		err = dataBase.SetTriggerCheckMaintenance("123", map[string]int64{}, &triggerMaintenanceTS, "", 0)
 		So(err, ShouldNotBeNil)
 
+		err = dataBase.SetTriggerCheckAcknowledgement("123", nil, &moira.Acknowledgement{User: "user"})
+		So(err, ShouldNotBeNil)
+
 		actual2, err := dataBase.GetTriggerLastCheck("123")
 		So(actual2, ShouldResemble, moira.CheckData{})
 		So(err, ShouldNotBeNil)
@@ -398,7 +460,7 @@ func TestMaintenanceUserSave(t *testing.T) {
 			newLastCheckTest.MaintenanceInfo.StartUser = &userLogin
 			newLastCheckTest.MaintenanceInfo.StartTime = &startTime
 			triggerID := uuid.Must(uuid.NewV4()).String()
-			err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, false)
+			err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, moira.DefaultLocalCluster)
 			So(err, ShouldBeNil)
 
 			triggerMaintenanceTS = 1000
@@ -416,7 +478,7 @@ func TestMaintenanceUserSave(t *testing.T) {
 			newLastCheckTest.MaintenanceInfo.StopUser = &userLogin
 			newLastCheckTest.MaintenanceInfo.StopTime = &startTime
 			triggerID := uuid.Must(uuid.NewV4()).String()
-			err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, false)
+			err := dataBase.SetTriggerLastCheck(triggerID, &lastCheckTest, moira.DefaultLocalCluster)
 			So(err, ShouldBeNil)
 
 			triggerMaintenanceTS = 1000
@@ -435,7 +497,7 @@ func TestMaintenanceUserSave(t *testing.T) {
 		checkData.MaintenanceInfo = moira.MaintenanceInfo{}
 		userLogin := "test"
 		var timeCallMaintenance = int64(3)
-		err := dataBase.SetTriggerLastCheck(triggerID, &checkData, false)
+		err := dataBase.SetTriggerLastCheck(triggerID, &checkData, moira.DefaultLocalCluster)
 		So(err, ShouldBeNil)
 
 		triggerMaintenanceTS = 1000
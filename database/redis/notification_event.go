@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gomodule/redigo/redis"
+	"gopkg.in/tomb.v2"
 
 	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/database"
@@ -14,6 +15,9 @@ import (
 
 var eventsTTL int64 = 3600 * 24 * 30
 
+// eventsStreamSize is a count of the latest streamed events kept to resume stream after reconnect
+var eventsStreamSize int64 = 1000
+
 // GetNotificationEvents gets NotificationEvents by given triggerID and interval
 func (connector *DbConnector) GetNotificationEvents(triggerID string, start int64, size int64) ([]*moira.NotificationEvent, error) {
 	c := connector.pool.Get()
@@ -32,7 +36,7 @@ func (connector *DbConnector) GetNotificationEvents(triggerID string, start int6
 }
 
 // PushNotificationEvent adds new NotificationEvent to events list and to given triggerID events list and deletes events who are older than 30 days
-// If ui=true, then add to ui events list
+// If ui=true, then add to ui events list and publish to events stream
 func (connector *DbConnector) PushNotificationEvent(event *moira.NotificationEvent, ui bool) error {
 	eventBytes, err := json.Marshal(event)
 	if err != nil {
@@ -41,6 +45,18 @@ func (connector *DbConnector) PushNotificationEvent(event *moira.NotificationEve
 
 	c := connector.pool.Get()
 	defer c.Close()
+
+	var streamedEventID int64
+	var streamedEventBytes []byte
+	if ui {
+		if streamedEventID, err = redis.Int64(c.Do("INCR", notificationEventsStreamIDKey)); err != nil {
+			return fmt.Errorf("failed to INCR %s: %s", notificationEventsStreamIDKey, err.Error())
+		}
+		if streamedEventBytes, err = json.Marshal(moira.StreamedNotificationEvent{ID: streamedEventID, Event: *event}); err != nil {
+			return err
+		}
+	}
+
 	c.Send("MULTI")
 	c.Send("LPUSH", notificationEventsList, eventBytes)
 	if event.TriggerID != "" {
@@ -50,6 +66,9 @@ func (connector *DbConnector) PushNotificationEvent(event *moira.NotificationEve
 	if ui {
 		c.Send("LPUSH", notificationEventsUIList, eventBytes)
 		c.Send("LTRIM", notificationEventsUIList, 0, 100)
+		c.Send("ZADD", notificationEventsStreamKey, streamedEventID, streamedEventBytes)
+		c.Send("ZREMRANGEBYRANK", notificationEventsStreamKey, 0, -eventsStreamSize-1)
+		c.Send("PUBLISH", notificationEventsStreamChannel, streamedEventBytes)
 	}
 	_, err = c.Do("EXEC")
 	if err != nil {
@@ -95,6 +114,46 @@ func (connector *DbConnector) FetchNotificationEvent() (moira.NotificationEvent,
 	return event, nil
 }
 
+// GetStreamedNotificationEvents gets the latest events published to events stream after event with given ID
+func (connector *DbConnector) GetStreamedNotificationEvents(afterID int64) ([]*moira.StreamedNotificationEvent, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	events, err := reply.StreamedEvents(c.Do("ZRANGEBYSCORE", notificationEventsStreamKey, fmt.Sprintf("(%d", afterID), "+inf"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get streamed events after %d: %s", afterID, err.Error())
+	}
+	return events, nil
+}
+
+// SubscribeNotificationEvents creates subscription for events published to events stream and returns channel for this events
+func (connector *DbConnector) SubscribeNotificationEvents(tomb *tomb.Tomb) (<-chan *moira.StreamedNotificationEvent, error) {
+	eventsChannel := make(chan *moira.StreamedNotificationEvent, pubSubWorkerChannelSize)
+	dataChannel, err := connector.manageSubscriptions(tomb, notificationEventsStreamChannel)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for {
+			data, ok := <-dataChannel
+			if !ok {
+				connector.logger.Info("No more subscriptions, channel is closed. Stop process data...")
+				close(eventsChannel)
+				return
+			}
+			event := &moira.StreamedNotificationEvent{}
+			if err := json.Unmarshal(data, event); err != nil {
+				connector.logger.Errorf("Failed to parse StreamedNotificationEvent: %s, error : %v", string(data), err)
+				continue
+			}
+			eventsChannel <- event
+		}
+	}()
+
+	return eventsChannel, nil
+}
+
 // RemoveAllNotificationEvents removes all notification events from database
 func (connector *DbConnector) RemoveAllNotificationEvents() error {
 	c := connector.pool.Get()
@@ -109,6 +168,9 @@ func (connector *DbConnector) RemoveAllNotificationEvents() error {
 
 var notificationEventsList = "moira-trigger-events"
 var notificationEventsUIList = "moira-trigger-events-ui"
+var notificationEventsStreamKey = "moira-trigger-events-stream"
+var notificationEventsStreamIDKey = "moira-trigger-events-stream-id"
+var notificationEventsStreamChannel = "moira-trigger-events-stream-channel"
 
 func triggerEventsKey(triggerID string) string {
 	return "moira-trigger-events:" + triggerID
@@ -6,6 +6,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/op/go-logging"
 	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/tomb.v2"
 
 	"time"
 
@@ -172,6 +173,63 @@ func TestNotificationEvents(t *testing.T) {
 	})
 }
 
+func TestStreamedNotificationEvents(t *testing.T) {
+	logger, _ := logging.GetLogger("dataBase")
+	dataBase := newTestDatabase(logger, config)
+	dataBase.flush()
+	defer dataBase.flush()
+
+	Convey("Streamed notification events manipulation", t, func() {
+		var tomb1 tomb.Tomb
+		ch, err := dataBase.SubscribeNotificationEvents(&tomb1)
+		So(err, ShouldBeNil)
+		So(ch, ShouldNotBeNil)
+
+		Convey("Only ui events are streamed", func() {
+			So(dataBase.PushNotificationEvent(&notificationEvent, false), ShouldBeNil)
+			So(dataBase.PushNotificationEvent(&notificationEvent1, true), ShouldBeNil)
+			So(dataBase.PushNotificationEvent(&notificationEvent2, true), ShouldBeNil)
+
+			expected := []*moira.StreamedNotificationEvent{
+				{ID: 1, Event: notificationEvent1},
+				{ID: 2, Event: notificationEvent2},
+			}
+			So(<-ch, ShouldResemble, expected[0])
+			So(<-ch, ShouldResemble, expected[1])
+
+			actual, err := dataBase.GetStreamedNotificationEvents(0)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, expected)
+
+			actual, err = dataBase.GetStreamedNotificationEvents(1)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, expected[1:])
+
+			actual, err = dataBase.GetStreamedNotificationEvents(2)
+			So(err, ShouldBeNil)
+			So(actual, ShouldBeEmpty)
+		})
+
+		Convey("Only the latest events are kept", func() {
+			eventsStreamSize = 2
+			defer func() { eventsStreamSize = 1000 }()
+			So(dataBase.PushNotificationEvent(&notificationEvent, true), ShouldBeNil)
+			<-ch
+
+			actual, err := dataBase.GetStreamedNotificationEvents(0)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, []*moira.StreamedNotificationEvent{
+				{ID: 2, Event: notificationEvent2},
+				{ID: 3, Event: notificationEvent},
+			})
+		})
+
+		tomb1.Kill(nil)
+		_, ok := <-ch
+		So(ok, ShouldBeFalse)
+	})
+}
+
 func TestNotificationEventErrorConnection(t *testing.T) {
 	logger, _ := logging.GetLogger("dataBase")
 	dataBase := newTestDatabase(logger, emptyConfig)
@@ -201,6 +259,15 @@ func TestNotificationEventErrorConnection(t *testing.T) {
 		actual2, err := dataBase.FetchNotificationEvent()
 		So(actual2, ShouldResemble, moira.NotificationEvent{})
 		So(err, ShouldNotBeNil)
+
+		actual3, err := dataBase.GetStreamedNotificationEvents(0)
+		So(actual3, ShouldBeNil)
+		So(err, ShouldNotBeNil)
+
+		var tomb1 tomb.Tomb
+		ch, err := dataBase.SubscribeNotificationEvents(&tomb1)
+		So(ch, ShouldBeNil)
+		So(err, ShouldNotBeNil)
 	})
 }
 
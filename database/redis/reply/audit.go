@@ -0,0 +1,50 @@
+package reply
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// AuditRecord converts redis DB reply to moira.AuditRecord object
+func AuditRecord(rep interface{}, err error) (moira.AuditRecord, error) {
+	record := moira.AuditRecord{}
+	bytes, err := redis.Bytes(rep, err)
+	if err != nil {
+		if err == redis.ErrNil {
+			return record, database.ErrNil
+		}
+		return record, fmt.Errorf("failed to read audit record: %s", err.Error())
+	}
+	err = json.Unmarshal(bytes, &record)
+	if err != nil {
+		return record, fmt.Errorf("failed to parse audit record json %s: %s", string(bytes), err.Error())
+	}
+	return record, nil
+}
+
+// AuditRecords converts redis DB reply to moira.AuditRecord objects array, missing records are skipped
+func AuditRecords(rep interface{}, err error) ([]*moira.AuditRecord, error) {
+	values, err := redis.Values(rep, err)
+	if err != nil {
+		if err == redis.ErrNil {
+			return make([]*moira.AuditRecord, 0), nil
+		}
+		return nil, fmt.Errorf("failed to read audit records: %s", err.Error())
+	}
+	records := make([]*moira.AuditRecord, 0, len(values))
+	for _, value := range values {
+		record, err2 := AuditRecord(value, err)
+		if err2 == database.ErrNil {
+			continue
+		}
+		if err2 != nil {
+			return nil, err2
+		}
+		records = append(records, &record)
+	}
+	return records, nil
+}
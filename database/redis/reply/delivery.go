@@ -0,0 +1,29 @@
+package reply
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/moira-alert/moira"
+)
+
+// Deliveries converts redis DB reply to moira.NotificationDelivery objects array
+func Deliveries(rep interface{}, err error) ([]*moira.NotificationDelivery, error) {
+	values, err := redis.ByteSlices(rep, err)
+	if err != nil {
+		if err == redis.ErrNil {
+			return make([]*moira.NotificationDelivery, 0), nil
+		}
+		return nil, fmt.Errorf("failed to read deliveries: %s", err.Error())
+	}
+	deliveries := make([]*moira.NotificationDelivery, 0, len(values))
+	for _, value := range values {
+		delivery := &moira.NotificationDelivery{}
+		if err = json.Unmarshal(value, delivery); err != nil {
+			return nil, fmt.Errorf("failed to parse delivery json %s: %s", string(value), err.Error())
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
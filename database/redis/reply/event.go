@@ -48,3 +48,23 @@ func Events(rep interface{}, err error) ([]*moira.NotificationEvent, error) {
 	}
 	return events, nil
 }
+
+// StreamedEvents converts redis DB reply to moira.StreamedNotificationEvent objects array
+func StreamedEvents(rep interface{}, err error) ([]*moira.StreamedNotificationEvent, error) {
+	values, err := redis.ByteSlices(rep, err)
+	if err != nil {
+		if err == redis.ErrNil {
+			return make([]*moira.StreamedNotificationEvent, 0), nil
+		}
+		return nil, fmt.Errorf("failed to read streamed events: %s", err.Error())
+	}
+	events := make([]*moira.StreamedNotificationEvent, 0, len(values))
+	for _, value := range values {
+		event := &moira.StreamedNotificationEvent{}
+		if err = json.Unmarshal(value, event); err != nil {
+			return nil, fmt.Errorf("failed to parse streamed event json %s: %s", string(value), err.Error())
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
@@ -0,0 +1,36 @@
+package reply
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/moira-alert/moira"
+)
+
+// FailedNotifications converts redis DB reply to moira.FailedNotification objects array, missing notifications are skipped
+func FailedNotifications(rep interface{}, err error) ([]*moira.FailedNotification, error) {
+	values, err := redis.Values(rep, err)
+	if err != nil {
+		if err == redis.ErrNil {
+			return make([]*moira.FailedNotification, 0), nil
+		}
+		return nil, fmt.Errorf("failed to read failed notifications: %s", err.Error())
+	}
+	notifications := make([]*moira.FailedNotification, 0, len(values))
+	for _, value := range values {
+		bytes, err := redis.Bytes(value, nil)
+		if err == redis.ErrNil {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read failed notification: %s", err.Error())
+		}
+		notification := &moira.FailedNotification{}
+		if err = json.Unmarshal(bytes, notification); err != nil {
+			return nil, fmt.Errorf("failed to parse failed notification json %s: %s", string(bytes), err.Error())
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, nil
+}
@@ -0,0 +1,50 @@
+package reply
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// Silence converts redis DB reply to moira.Silence object
+func Silence(rep interface{}, err error) (moira.Silence, error) {
+	silence := moira.Silence{}
+	bytes, err := redis.Bytes(rep, err)
+	if err != nil {
+		if err == redis.ErrNil {
+			return silence, database.ErrNil
+		}
+		return silence, fmt.Errorf("failed to read silence: %s", err.Error())
+	}
+	err = json.Unmarshal(bytes, &silence)
+	if err != nil {
+		return silence, fmt.Errorf("failed to parse silence json %s: %s", string(bytes), err.Error())
+	}
+	return silence, nil
+}
+
+// Silences converts redis DB reply to moira.Silence objects array, missing silences are skipped
+func Silences(rep interface{}, err error) ([]*moira.Silence, error) {
+	values, err := redis.Values(rep, err)
+	if err != nil {
+		if err == redis.ErrNil {
+			return make([]*moira.Silence, 0), nil
+		}
+		return nil, fmt.Errorf("failed to read silences: %s", err.Error())
+	}
+	silences := make([]*moira.Silence, 0, len(values))
+	for _, value := range values {
+		silence, err2 := Silence(value, err)
+		if err2 == database.ErrNil {
+			continue
+		}
+		if err2 != nil {
+			return nil, err2
+		}
+		silences = append(silences, &silence)
+	}
+	return silences, nil
+}
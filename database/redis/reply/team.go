@@ -0,0 +1,50 @@
+package reply
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// Team converts redis DB reply to moira.Team object
+func Team(rep interface{}, err error) (moira.Team, error) {
+	team := moira.Team{}
+	bytes, err := redis.Bytes(rep, err)
+	if err != nil {
+		if err == redis.ErrNil {
+			return team, database.ErrNil
+		}
+		return team, fmt.Errorf("failed to read team: %s", err.Error())
+	}
+	err = json.Unmarshal(bytes, &team)
+	if err != nil {
+		return team, fmt.Errorf("failed to parse team json %s: %s", string(bytes), err.Error())
+	}
+	return team, nil
+}
+
+// Teams converts redis DB reply to moira.Team objects array
+func Teams(rep interface{}, err error) ([]*moira.Team, error) {
+	values, err := redis.Values(rep, err)
+	if err != nil {
+		if err == redis.ErrNil {
+			return make([]*moira.Team, 0), nil
+		}
+		return nil, fmt.Errorf("failed to read teams: %s", err.Error())
+	}
+	teams := make([]*moira.Team, len(values))
+	for i, value := range values {
+		team, err2 := Team(value, err)
+		if err2 != nil && err2 != database.ErrNil {
+			return nil, err2
+		} else if err2 == database.ErrNil {
+			teams[i] = nil
+		} else {
+			teams[i] = &team
+		}
+	}
+	return teams, nil
+}
@@ -0,0 +1,27 @@
+package reply
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+// TrackerIssue converts redis DB reply to moira.TrackerIssue object
+func TrackerIssue(rep interface{}, err error) (moira.TrackerIssue, error) {
+	issue := moira.TrackerIssue{}
+	bytes, err := redis.Bytes(rep, err)
+	if err != nil {
+		if err == redis.ErrNil {
+			return issue, database.ErrNil
+		}
+		return issue, fmt.Errorf("failed to read tracker issue: %s", err.Error())
+	}
+	err = json.Unmarshal(bytes, &issue)
+	if err != nil {
+		return issue, fmt.Errorf("failed to parse tracker issue json %s: %s", string(bytes), err.Error())
+	}
+	return issue, nil
+}
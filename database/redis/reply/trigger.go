@@ -12,45 +12,70 @@ import (
 
 // Duty hack for moira.Trigger TTL int64 and stored trigger TTL string compatibility
 type triggerStorageElement struct {
-	ID               string              `json:"id"`
-	Name             string              `json:"name"`
-	Desc             *string             `json:"desc,omitempty"`
-	Targets          []string            `json:"targets"`
-	WarnValue        *float64            `json:"warn_value"`
-	ErrorValue       *float64            `json:"error_value"`
-	TriggerType      string              `json:"trigger_type,omitempty"`
-	Tags             []string            `json:"tags"`
-	TTLState         *moira.TTLState     `json:"ttl_state,omitempty"`
-	Schedule         *moira.ScheduleData `json:"sched,omitempty"`
-	Expression       *string             `json:"expr,omitempty"`
-	PythonExpression *string             `json:"expression,omitempty"`
-	Patterns         []string            `json:"patterns"`
-	TTL              string              `json:"ttl,omitempty"`
-	IsRemote         bool                `json:"is_remote"`
-	MuteNewMetrics   bool                `json:"mute_new_metrics,omitempty"`
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name"`
+	Desc             *string                `json:"desc,omitempty"`
+	Targets          []string               `json:"targets"`
+	WarnValue        *float64               `json:"warn_value"`
+	ErrorValue       *float64               `json:"error_value"`
+	WarnRecovery     *float64               `json:"warn_recovery_value,omitempty"`
+	ErrorRecovery    *float64               `json:"error_recovery_value,omitempty"`
+	PendingInterval  int64                  `json:"pending_interval,omitempty"`
+	TriggerType      string                 `json:"trigger_type,omitempty"`
+	Tags             []string               `json:"tags"`
+	TTLState         *moira.TTLState        `json:"ttl_state,omitempty"`
+	Schedule         *moira.ScheduleData    `json:"sched,omitempty"`
+	Expression       *string                `json:"expr,omitempty"`
+	PythonExpression *string                `json:"expression,omitempty"`
+	Patterns         []string               `json:"patterns"`
+	TTL              string                 `json:"ttl,omitempty"`
+	IsRemote         bool                   `json:"is_remote"`
+	TriggerSource    moira.TriggerSource    `json:"trigger_source,omitempty"`
+	ClusterID        moira.ClusterID        `json:"cluster_id,omitempty"`
+	MuteNewMetrics   bool                   `json:"mute_new_metrics,omitempty"`
+	Anomaly          *moira.AnomalySettings `json:"anomaly,omitempty"`
+	Dependencies     []string               `json:"dependencies,omitempty"`
+	Reminders        map[moira.State]int64  `json:"reminders,omitempty"`
+	TeamID           string                 `json:"team_id,omitempty"`
 }
 
 func (storageElement *triggerStorageElement) toTrigger() moira.Trigger {
 	return moira.Trigger{
-		ID:               storageElement.ID,
-		Name:             storageElement.Name,
-		Desc:             storageElement.Desc,
-		Targets:          storageElement.Targets,
-		WarnValue:        storageElement.WarnValue,
-		ErrorValue:       storageElement.ErrorValue,
-		TriggerType:      storageElement.TriggerType,
-		Tags:             storageElement.Tags,
-		TTLState:         storageElement.TTLState,
-		Schedule:         storageElement.Schedule,
-		Expression:       storageElement.Expression,
-		PythonExpression: storageElement.PythonExpression,
-		Patterns:         storageElement.Patterns,
-		TTL:              getTriggerTTL(storageElement.TTL),
-		IsRemote:         storageElement.IsRemote,
-		MuteNewMetrics:   storageElement.MuteNewMetrics,
+		ID:                 storageElement.ID,
+		Name:               storageElement.Name,
+		Desc:               storageElement.Desc,
+		Targets:            storageElement.Targets,
+		WarnValue:          storageElement.WarnValue,
+		ErrorValue:         storageElement.ErrorValue,
+		WarnRecoveryValue:  storageElement.WarnRecovery,
+		ErrorRecoveryValue: storageElement.ErrorRecovery,
+		PendingInterval:    storageElement.PendingInterval,
+		TriggerType:        storageElement.TriggerType,
+		Tags:               storageElement.Tags,
+		TTLState:           storageElement.TTLState,
+		Schedule:           storageElement.Schedule,
+		Expression:         storageElement.Expression,
+		PythonExpression:   storageElement.PythonExpression,
+		Patterns:           storageElement.Patterns,
+		TTL:                getTriggerTTL(storageElement.TTL),
+		TriggerSource:      storageElement.getTriggerSource(),
+		ClusterID:          storageElement.ClusterID,
+		MuteNewMetrics:     storageElement.MuteNewMetrics,
+		Anomaly:            storageElement.Anomaly,
+		Dependencies:       storageElement.Dependencies,
+		Reminders:          storageElement.Reminders,
+		TeamID:             storageElement.TeamID,
 	}
 }
 
+// getTriggerSource returns trigger source, remote triggers saved before trigger source was introduced are resolved by is_remote flag
+func (storageElement *triggerStorageElement) getTriggerSource() moira.TriggerSource {
+	if storageElement.TriggerSource == moira.TriggerSourceNotSet && storageElement.IsRemote {
+		return moira.GraphiteRemote
+	}
+	return storageElement.TriggerSource
+}
+
 func toTriggerStorageElement(trigger *moira.Trigger, triggerID string) *triggerStorageElement {
 	return &triggerStorageElement{
 		ID:               triggerID,
@@ -59,6 +84,9 @@ func toTriggerStorageElement(trigger *moira.Trigger, triggerID string) *triggerS
 		Targets:          trigger.Targets,
 		WarnValue:        trigger.WarnValue,
 		ErrorValue:       trigger.ErrorValue,
+		WarnRecovery:     trigger.WarnRecoveryValue,
+		ErrorRecovery:    trigger.ErrorRecoveryValue,
+		PendingInterval:  trigger.PendingInterval,
 		TriggerType:      trigger.TriggerType,
 		Tags:             trigger.Tags,
 		TTLState:         trigger.TTLState,
@@ -67,8 +95,14 @@ func toTriggerStorageElement(trigger *moira.Trigger, triggerID string) *triggerS
 		PythonExpression: trigger.PythonExpression,
 		Patterns:         trigger.Patterns,
 		TTL:              getTriggerTTLString(trigger.TTL),
-		IsRemote:         trigger.IsRemote,
+		IsRemote:         trigger.TriggerSource == moira.GraphiteRemote,
+		TriggerSource:    trigger.TriggerSource,
+		ClusterID:        trigger.ClusterID,
 		MuteNewMetrics:   trigger.MuteNewMetrics,
+		Anomaly:          trigger.Anomaly,
+		Dependencies:     trigger.Dependencies,
+		Reminders:        trigger.Reminders,
+		TeamID:           trigger.TeamID,
 	}
 }
 
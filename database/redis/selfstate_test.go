@@ -24,7 +24,7 @@ func TestSelfCheckWithWritesInChecker(t *testing.T) {
 			So(count, ShouldEqual, 0)
 			So(err, ShouldBeNil)
 
-			count, err = dataBase.GetRemoteChecksUpdatesCount()
+			count, err = dataBase.GetRemoteChecksUpdatesCount(moira.DefaultCluster)
 			So(count, ShouldEqual, 0)
 			So(err, ShouldBeNil)
 		})
@@ -39,17 +39,28 @@ func TestSelfCheckWithWritesInChecker(t *testing.T) {
 		})
 
 		Convey("Update metrics checks updates count", func() {
-			err := dataBase.SetTriggerLastCheck("123", &lastCheckTest, false)
+			err := dataBase.SetTriggerLastCheck("123", &lastCheckTest, moira.DefaultLocalCluster)
 			So(err, ShouldBeNil)
 
 			count, err := dataBase.GetChecksUpdatesCount()
 			So(count, ShouldEqual, 1)
 			So(err, ShouldBeNil)
 
-			err = dataBase.SetTriggerLastCheck("12345", &lastCheckTest, true)
+			err = dataBase.SetTriggerLastCheck("12345", &lastCheckTest, moira.DefaultGraphiteRemoteCluster)
 			So(err, ShouldBeNil)
 
-			count, err = dataBase.GetRemoteChecksUpdatesCount()
+			count, err = dataBase.GetRemoteChecksUpdatesCount(moira.DefaultCluster)
+			So(count, ShouldEqual, 1)
+			So(err, ShouldBeNil)
+
+			err = dataBase.SetTriggerLastCheck("123456", &lastCheckTest, moira.DefaultPrometheusRemoteCluster)
+			So(err, ShouldBeNil)
+
+			count, err = dataBase.GetPrometheusChecksUpdatesCount()
+			So(count, ShouldEqual, 1)
+			So(err, ShouldBeNil)
+
+			count, err = dataBase.GetRemoteChecksUpdatesCount(moira.DefaultCluster)
 			So(count, ShouldEqual, 1)
 			So(err, ShouldBeNil)
 		})
@@ -70,17 +81,24 @@ func testSelfCheckWithWritesInDBSource(t *testing.T, dbSource DBSource) {
 	defer dataBase.flush()
 	Convey(fmt.Sprintf("Self state triggers manipulation in %s", dbSource), t, func() {
 		Convey("Update metrics checks updates count", func() {
-			err := dataBase.SetTriggerLastCheck("123", &lastCheckTest, false)
+			err := dataBase.SetTriggerLastCheck("123", &lastCheckTest, moira.DefaultLocalCluster)
 			So(err, ShouldBeNil)
 
 			count, err := dataBase.GetChecksUpdatesCount()
 			So(count, ShouldEqual, 0)
 			So(err, ShouldBeNil)
 
-			err = dataBase.SetTriggerLastCheck("12345", &lastCheckTest, true)
+			err = dataBase.SetTriggerLastCheck("12345", &lastCheckTest, moira.DefaultGraphiteRemoteCluster)
+			So(err, ShouldBeNil)
+
+			count, err = dataBase.GetRemoteChecksUpdatesCount(moira.DefaultCluster)
+			So(count, ShouldEqual, 0)
+			So(err, ShouldBeNil)
+
+			err = dataBase.SetTriggerLastCheck("123456", &lastCheckTest, moira.DefaultPrometheusRemoteCluster)
 			So(err, ShouldBeNil)
 
-			count, err = dataBase.GetRemoteChecksUpdatesCount()
+			count, err = dataBase.GetPrometheusChecksUpdatesCount()
 			So(count, ShouldEqual, 0)
 			So(err, ShouldBeNil)
 		})
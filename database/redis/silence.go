@@ -0,0 +1,120 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database/redis/reply"
+)
+
+// SaveSilence writes silence and adds it to silences list ordered by silence end time
+func (connector *DbConnector) SaveSilence(silence *moira.Silence) error {
+	silenceBytes, err := json.Marshal(silence)
+	if err != nil {
+		return err
+	}
+
+	c := connector.pool.Get()
+	defer c.Close()
+	c.Send("MULTI")
+	c.Send("SET", silenceKey(silence.ID), silenceBytes)
+	c.Send("ZADD", silencesListKey, silence.EndTime, silence.ID)
+	_, err = c.Do("EXEC")
+	if err != nil {
+		return fmt.Errorf("failed to EXEC: %s", err.Error())
+	}
+	return nil
+}
+
+// GetSilence returns silence by given id, if no value, return database.ErrNil error
+func (connector *DbConnector) GetSilence(id string) (moira.Silence, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	return reply.Silence(c.Do("GET", silenceKey(id)))
+}
+
+// GetSilences returns all silences ordered by end time
+func (connector *DbConnector) GetSilences() ([]*moira.Silence, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	silenceIDs, err := redis.Strings(c.Do("ZRANGE", silencesListKey, 0, -1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get silences ids: %s", err.Error())
+	}
+	return connector.getSilences(c, silenceIDs)
+}
+
+// GetUnexpiredSilences returns silences which end after given timestamp ordered by end time,
+// returned silences can be not started yet
+func (connector *DbConnector) GetUnexpiredSilences(timestamp int64) ([]*moira.Silence, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	silenceIDs, err := redis.Strings(c.Do("ZRANGEBYSCORE", silencesListKey, fmt.Sprintf("(%d", timestamp), "+inf"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get silences ids: %s", err.Error())
+	}
+	return connector.getSilences(c, silenceIDs)
+}
+
+// RemoveSilence deletes silence by given id
+func (connector *DbConnector) RemoveSilence(id string) error {
+	c := connector.pool.Get()
+	defer c.Close()
+	c.Send("MULTI")
+	c.Send("DEL", silenceKey(id))
+	c.Send("ZREM", silencesListKey, id)
+	_, err := c.Do("EXEC")
+	if err != nil {
+		return fmt.Errorf("failed to EXEC: %s", err.Error())
+	}
+	return nil
+}
+
+// RemoveExpiredSilences deletes silences which end before or at given timestamp and returns count of deleted silences
+func (connector *DbConnector) RemoveExpiredSilences(to int64) (int64, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	silenceIDs, err := redis.Strings(c.Do("ZRANGEBYSCORE", silencesListKey, "-inf", to))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired silences ids: %s", err.Error())
+	}
+	if len(silenceIDs) == 0 {
+		return 0, nil
+	}
+
+	c.Send("MULTI")
+	for _, id := range silenceIDs {
+		c.Send("DEL", silenceKey(id))
+		c.Send("ZREM", silencesListKey, id)
+	}
+	_, err = c.Do("EXEC")
+	if err != nil {
+		return 0, fmt.Errorf("failed to EXEC: %s", err.Error())
+	}
+	return int64(len(silenceIDs)), nil
+}
+
+func (connector *DbConnector) getSilences(c redis.Conn, silenceIDs []string) ([]*moira.Silence, error) {
+	if len(silenceIDs) == 0 {
+		return make([]*moira.Silence, 0), nil
+	}
+
+	c.Send("MULTI")
+	for _, id := range silenceIDs {
+		c.Send("GET", silenceKey(id))
+	}
+	return reply.Silences(c.Do("EXEC"))
+}
+
+var silencesListKey = "moira-silences"
+
+func silenceKey(id string) string {
+	return "moira-silence:" + id
+}
@@ -0,0 +1,147 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/op/go-logging"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+func TestSilences(t *testing.T) {
+	logger, _ := logging.GetLogger("dataBase")
+	dataBase := newTestDatabase(logger, config)
+	dataBase.flush()
+	defer dataBase.flush()
+
+	Convey("Silences manipulation", t, func() {
+		Convey("Should be no silences", func() {
+			actual, err := dataBase.GetSilences()
+			So(err, ShouldBeNil)
+			So(actual, ShouldBeEmpty)
+
+			_, err = dataBase.GetSilence(silences[0].ID)
+			So(err, ShouldResemble, database.ErrNil)
+
+			count, err := dataBase.RemoveExpiredSilences(1000)
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 0)
+		})
+
+		Convey("Should get saved silences", func() {
+			for _, silence := range silences {
+				err := dataBase.SaveSilence(silence)
+				So(err, ShouldBeNil)
+			}
+
+			actual, err := dataBase.GetSilence(silences[1].ID)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, *silences[1])
+
+			all, err := dataBase.GetSilences()
+			So(err, ShouldBeNil)
+			So(all, ShouldResemble, []*moira.Silence{silences[2], silences[0], silences[1]})
+
+			unexpired, err := dataBase.GetUnexpiredSilences(200)
+			So(err, ShouldBeNil)
+			So(unexpired, ShouldResemble, []*moira.Silence{silences[0], silences[1]})
+		})
+
+		Convey("Should update silence end time", func() {
+			updated := *silences[1]
+			updated.EndTime = 150
+			err := dataBase.SaveSilence(&updated)
+			So(err, ShouldBeNil)
+
+			unexpired, err := dataBase.GetUnexpiredSilences(200)
+			So(err, ShouldBeNil)
+			So(unexpired, ShouldResemble, []*moira.Silence{silences[0]})
+
+			err = dataBase.SaveSilence(silences[1])
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Should remove silence", func() {
+			err := dataBase.RemoveSilence(silences[0].ID)
+			So(err, ShouldBeNil)
+
+			_, err = dataBase.GetSilence(silences[0].ID)
+			So(err, ShouldResemble, database.ErrNil)
+
+			all, err := dataBase.GetSilences()
+			So(err, ShouldBeNil)
+			So(all, ShouldResemble, []*moira.Silence{silences[2], silences[1]})
+		})
+
+		Convey("Should remove expired silences", func() {
+			count, err := dataBase.RemoveExpiredSilences(200)
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 1)
+
+			_, err = dataBase.GetSilence(silences[2].ID)
+			So(err, ShouldResemble, database.ErrNil)
+
+			all, err := dataBase.GetSilences()
+			So(err, ShouldBeNil)
+			So(all, ShouldResemble, []*moira.Silence{silences[1]})
+		})
+	})
+}
+
+func TestSilencesErrorConnection(t *testing.T) {
+	logger, _ := logging.GetLogger("dataBase")
+	dataBase := newTestDatabase(logger, emptyConfig)
+	dataBase.flush()
+	defer dataBase.flush()
+
+	Convey("Should throw error when no connection", t, func() {
+		err := dataBase.SaveSilence(silences[0])
+		So(err, ShouldNotBeNil)
+
+		_, err = dataBase.GetSilence(silences[0].ID)
+		So(err, ShouldNotBeNil)
+
+		_, err = dataBase.GetSilences()
+		So(err, ShouldNotBeNil)
+
+		_, err = dataBase.GetUnexpiredSilences(0)
+		So(err, ShouldNotBeNil)
+
+		err = dataBase.RemoveSilence(silences[0].ID)
+		So(err, ShouldNotBeNil)
+
+		_, err = dataBase.RemoveExpiredSilences(0)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+var silences = []*moira.Silence{
+	{
+		ID:        "silence-1",
+		Tags:      []string{"tag"},
+		StartTime: 100,
+		EndTime:   300,
+		User:      "user",
+		Comment:   "planned works",
+		CreatedAt: 100,
+	},
+	{
+		ID:        "silence-2",
+		Metric:    "my.*.metric",
+		StartTime: 100,
+		EndTime:   400,
+		User:      "user",
+		CreatedAt: 100,
+	},
+	{
+		ID:        "silence-3",
+		Tags:      []string{"tag"},
+		Metric:    "my.metric",
+		StartTime: 0,
+		EndTime:   200,
+		User:      "user",
+		CreatedAt: 0,
+	},
+}
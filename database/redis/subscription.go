@@ -162,6 +162,9 @@ func (connector *DbConnector) removeSubscription(subscription *moira.Subscriptio
 		c.Send("SREM", tagSubscriptionKey(tag), subscription.ID)
 	}
 	c.Send("SREM", anyTagsSubscriptionsKey, subscription.ID)
+	if subscription.TeamID != "" {
+		c.Send("SREM", teamSubscriptionsKey(subscription.TeamID), subscription.ID)
+	}
 	c.Send("DEL", subscriptionKey(subscription.ID))
 	if _, err := c.Do("EXEC"); err != nil {
 		return fmt.Errorf("failed to EXEC: %s", err.Error())
@@ -232,6 +235,9 @@ func addSendSubscriptionRequest(c redis.Conn, subscription moira.SubscriptionDat
 		if oldSubscription.User != subscription.User {
 			c.Send("SREM", userSubscriptionsKey(oldSubscription.User), subscription.ID)
 		}
+		if oldSubscription.TeamID != "" && oldSubscription.TeamID != subscription.TeamID {
+			c.Send("SREM", teamSubscriptionsKey(oldSubscription.TeamID), subscription.ID)
+		}
 	}
 
 	for _, tag := range subscription.Tags {
@@ -243,6 +249,9 @@ func addSendSubscriptionRequest(c redis.Conn, subscription moira.SubscriptionDat
 	}
 
 	c.Send("SADD", userSubscriptionsKey(subscription.User), subscription.ID)
+	if subscription.TeamID != "" {
+		c.Send("SADD", teamSubscriptionsKey(subscription.TeamID), subscription.ID)
+	}
 	c.Send("SET", subscriptionKey(subscription.ID), bytes)
 	return nil
 }
@@ -0,0 +1,172 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	"github.com/moira-alert/moira/database/redis/reply"
+)
+
+// GetTeam returns team data by given ID, if no value, return database.ErrNil error
+func (connector *DbConnector) GetTeam(teamID string) (moira.Team, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	team, err := reply.Team(c.Do("GET", teamKey(teamID)))
+	if err != nil {
+		return team, err
+	}
+	team.ID = teamID
+	return team, nil
+}
+
+// GetTeams returns teams data by given IDs, len of teamIDs is equal to len of returned values array.
+// If there is no object by current ID, then nil is returned
+func (connector *DbConnector) GetTeams(teamIDs []string) ([]*moira.Team, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	for _, teamID := range teamIDs {
+		c.Send("GET", teamKey(teamID))
+	}
+	teams, err := reply.Teams(c.Do("EXEC"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams: %s", err.Error())
+	}
+	for i, team := range teams {
+		if team != nil {
+			team.ID = teamIDs[i]
+		}
+	}
+	return teams, nil
+}
+
+// GetAllTeamIDs returns IDs of all teams
+func (connector *DbConnector) GetAllTeamIDs() ([]string, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	teamIDs, err := redis.Strings(c.Do("SMEMBERS", teamsListKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams list: %s", err.Error())
+	}
+	return teamIDs, nil
+}
+
+// SaveTeam writes team data and updates teams of its members
+func (connector *DbConnector) SaveTeam(team *moira.Team) error {
+	var oldTeam *moira.Team
+	if existing, err := connector.GetTeam(team.ID); err == nil {
+		oldTeam = &existing
+	} else if err != database.ErrNil {
+		return fmt.Errorf("failed to get team: %s", err.Error())
+	}
+
+	teamBytes, err := json.Marshal(team)
+	if err != nil {
+		return err
+	}
+
+	c := connector.pool.Get()
+	defer c.Close()
+	c.Send("MULTI")
+	if oldTeam != nil {
+		for login := range oldTeam.Members {
+			if _, ok := team.Members[login]; !ok {
+				c.Send("SREM", userTeamsKey(login), team.ID)
+			}
+		}
+	}
+	for login := range team.Members {
+		c.Send("SADD", userTeamsKey(login), team.ID)
+	}
+	c.Send("SET", teamKey(team.ID), teamBytes)
+	c.Send("SADD", teamsListKey, team.ID)
+	if _, err = c.Do("EXEC"); err != nil {
+		return fmt.Errorf("failed to EXEC: %s", err.Error())
+	}
+	return nil
+}
+
+// RemoveTeam deletes team data and removes team from teams of its members
+func (connector *DbConnector) RemoveTeam(teamID string) error {
+	team, err := connector.GetTeam(teamID)
+	if err != nil {
+		if err == database.ErrNil {
+			return nil
+		}
+		return err
+	}
+
+	c := connector.pool.Get()
+	defer c.Close()
+	c.Send("MULTI")
+	for login := range team.Members {
+		c.Send("SREM", userTeamsKey(login), teamID)
+	}
+	c.Send("DEL", teamKey(teamID))
+	c.Send("SREM", teamsListKey, teamID)
+	if _, err = c.Do("EXEC"); err != nil {
+		return fmt.Errorf("failed to EXEC: %s", err.Error())
+	}
+	return nil
+}
+
+// GetUserTeamIDs returns IDs of teams user is member of
+func (connector *DbConnector) GetUserTeamIDs(login string) ([]string, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	teamIDs, err := redis.Strings(c.Do("SMEMBERS", userTeamsKey(login)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams for user login %s: %s", login, err.Error())
+	}
+	return teamIDs, nil
+}
+
+// GetTeamTriggerIDs returns IDs of triggers owned by team
+func (connector *DbConnector) GetTeamTriggerIDs(teamID string) ([]string, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	triggerIDs, err := redis.Strings(c.Do("SMEMBERS", teamTriggersKey(teamID)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get triggers for team %s: %s", teamID, err.Error())
+	}
+	return triggerIDs, nil
+}
+
+// GetTeamSubscriptionIDs returns IDs of subscriptions owned by team
+func (connector *DbConnector) GetTeamSubscriptionIDs(teamID string) ([]string, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	subscriptionIDs, err := redis.Strings(c.Do("SMEMBERS", teamSubscriptionsKey(teamID)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscriptions for team %s: %s", teamID, err.Error())
+	}
+	return subscriptionIDs, nil
+}
+
+var teamsListKey = "moira-teams-list"
+
+func teamKey(teamID string) string {
+	return "moira-team:" + teamID
+}
+
+func userTeamsKey(login string) string {
+	return "moira-user-teams:" + login
+}
+
+func teamTriggersKey(teamID string) string {
+	return "moira-team-triggers:" + teamID
+}
+
+func teamSubscriptionsKey(teamID string) string {
+	return "moira-team-subscriptions:" + teamID
+}
@@ -0,0 +1,151 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/op/go-logging"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+)
+
+func TestTeams(t *testing.T) {
+	logger, _ := logging.GetLogger("dataBase")
+	dataBase := newTestDatabase(logger, config)
+	dataBase.flush()
+	defer dataBase.flush()
+
+	Convey("Teams manipulation", t, func() {
+		team := moira.Team{
+			ID:      "teamID",
+			Name:    "Team",
+			Members: map[string]moira.TeamRole{"admin": moira.TeamRoleAdmin, "viewer": moira.TeamRoleViewer},
+		}
+
+		Convey("Not existing team", func() {
+			_, err := dataBase.GetTeam(team.ID)
+			So(err, ShouldResemble, database.ErrNil)
+
+			teams, err := dataBase.GetTeams([]string{team.ID})
+			So(err, ShouldBeNil)
+			So(teams, ShouldResemble, []*moira.Team{nil})
+
+			So(dataBase.RemoveTeam(team.ID), ShouldBeNil)
+		})
+
+		Convey("Save, update and remove team", func() {
+			So(dataBase.SaveTeam(&team), ShouldBeNil)
+
+			actual, err := dataBase.GetTeam(team.ID)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, team)
+
+			teams, err := dataBase.GetTeams([]string{team.ID, "otherTeamID"})
+			So(err, ShouldBeNil)
+			So(teams, ShouldResemble, []*moira.Team{&team, nil})
+
+			teamIDs, err := dataBase.GetAllTeamIDs()
+			So(err, ShouldBeNil)
+			So(teamIDs, ShouldResemble, []string{team.ID})
+
+			teamIDs, err = dataBase.GetUserTeamIDs("viewer")
+			So(err, ShouldBeNil)
+			So(teamIDs, ShouldResemble, []string{team.ID})
+
+			updated := team
+			updated.Members = map[string]moira.TeamRole{"admin": moira.TeamRoleAdmin, "editor": moira.TeamRoleEditor}
+			So(dataBase.SaveTeam(&updated), ShouldBeNil)
+
+			teamIDs, err = dataBase.GetUserTeamIDs("viewer")
+			So(err, ShouldBeNil)
+			So(teamIDs, ShouldBeEmpty)
+			teamIDs, err = dataBase.GetUserTeamIDs("editor")
+			So(err, ShouldBeNil)
+			So(teamIDs, ShouldResemble, []string{team.ID})
+
+			So(dataBase.RemoveTeam(team.ID), ShouldBeNil)
+			_, err = dataBase.GetTeam(team.ID)
+			So(err, ShouldResemble, database.ErrNil)
+			teamIDs, err = dataBase.GetAllTeamIDs()
+			So(err, ShouldBeNil)
+			So(teamIDs, ShouldBeEmpty)
+			teamIDs, err = dataBase.GetUserTeamIDs("admin")
+			So(err, ShouldBeNil)
+			So(teamIDs, ShouldBeEmpty)
+		})
+
+		Convey("Team triggers", func() {
+			trigger := moira.Trigger{ID: "teamTriggerID", Targets: []string{"my.metric"}, Patterns: []string{"my.metric"}, TeamID: team.ID}
+			So(dataBase.SaveTrigger(trigger.ID, &trigger), ShouldBeNil)
+
+			triggerIDs, err := dataBase.GetTeamTriggerIDs(team.ID)
+			So(err, ShouldBeNil)
+			So(triggerIDs, ShouldResemble, []string{trigger.ID})
+
+			trigger.TeamID = "otherTeamID"
+			So(dataBase.SaveTrigger(trigger.ID, &trigger), ShouldBeNil)
+			triggerIDs, err = dataBase.GetTeamTriggerIDs(team.ID)
+			So(err, ShouldBeNil)
+			So(triggerIDs, ShouldBeEmpty)
+
+			So(dataBase.RemoveTrigger(trigger.ID), ShouldBeNil)
+			triggerIDs, err = dataBase.GetTeamTriggerIDs("otherTeamID")
+			So(err, ShouldBeNil)
+			So(triggerIDs, ShouldBeEmpty)
+		})
+
+		Convey("Team subscriptions", func() {
+			subscription := moira.SubscriptionData{ID: "teamSubscriptionID", User: "admin", Tags: []string{"tag"}, TeamID: team.ID}
+			So(dataBase.SaveSubscription(&subscription), ShouldBeNil)
+
+			subscriptionIDs, err := dataBase.GetTeamSubscriptionIDs(team.ID)
+			So(err, ShouldBeNil)
+			So(subscriptionIDs, ShouldResemble, []string{subscription.ID})
+
+			subscription.TeamID = "otherTeamID"
+			So(dataBase.SaveSubscriptions([]*moira.SubscriptionData{&subscription}), ShouldBeNil)
+			subscriptionIDs, err = dataBase.GetTeamSubscriptionIDs(team.ID)
+			So(err, ShouldBeNil)
+			So(subscriptionIDs, ShouldBeEmpty)
+
+			So(dataBase.RemoveSubscription(subscription.ID), ShouldBeNil)
+			subscriptionIDs, err = dataBase.GetTeamSubscriptionIDs("otherTeamID")
+			So(err, ShouldBeNil)
+			So(subscriptionIDs, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestTeamsErrorConnection(t *testing.T) {
+	logger, _ := logging.GetLogger("dataBase")
+	dataBase := newTestDatabase(logger, emptyConfig)
+	dataBase.flush()
+	defer dataBase.flush()
+
+	Convey("Should throw error when no connection", t, func() {
+		_, err := dataBase.GetTeam("teamID")
+		So(err, ShouldNotBeNil)
+
+		_, err = dataBase.GetTeams([]string{"teamID"})
+		So(err, ShouldNotBeNil)
+
+		_, err = dataBase.GetAllTeamIDs()
+		So(err, ShouldNotBeNil)
+
+		err = dataBase.SaveTeam(&moira.Team{ID: "teamID"})
+		So(err, ShouldNotBeNil)
+
+		err = dataBase.RemoveTeam("teamID")
+		So(err, ShouldNotBeNil)
+
+		_, err = dataBase.GetUserTeamIDs("login")
+		So(err, ShouldNotBeNil)
+
+		_, err = dataBase.GetTeamTriggerIDs("teamID")
+		So(err, ShouldNotBeNil)
+
+		_, err = dataBase.GetTeamSubscriptionIDs("teamID")
+		So(err, ShouldNotBeNil)
+	})
+}
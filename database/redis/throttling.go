@@ -7,33 +7,45 @@ import (
 	"github.com/gomodule/redigo/redis"
 )
 
-// GetTriggerThrottling get throttling or scheduled notifications delay for given triggerID
+// GetTriggerThrottling get throttling or scheduled notifications delay for given triggerID.
+// Next delivery time is the latest one among trigger subscriptions
 func (connector *DbConnector) GetTriggerThrottling(triggerID string) (time.Time, time.Time) {
 	c := connector.pool.Get()
 	defer c.Close()
 
-	next, _ := redis.Int64(c.Do("GET", notifierNextKey(triggerID)))
+	subscriptionsNext, _ := redis.Int64s(c.Do("HVALS", notifierSubscriptionsNextKey(triggerID)))
+	beginning, _ := redis.Int64(c.Do("GET", notifierThrottlingBeginningKey(triggerID)))
+
+	return time.Unix(getLatestThrottling(subscriptionsNext), 0), time.Unix(beginning, 0)
+}
+
+// GetSubscriptionThrottling get throttling or scheduled notifications delay of given subscription for given triggerID
+func (connector *DbConnector) GetSubscriptionThrottling(triggerID, subscriptionID string) (time.Time, time.Time) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	next, _ := redis.Int64(c.Do("HGET", notifierSubscriptionsNextKey(triggerID), subscriptionID))
 	beginning, _ := redis.Int64(c.Do("GET", notifierThrottlingBeginningKey(triggerID)))
 
 	return time.Unix(next, 0), time.Unix(beginning, 0)
 }
 
-// SetTriggerThrottling store throttling or scheduled notifications delay for given triggerID
-func (connector *DbConnector) SetTriggerThrottling(triggerID string, next time.Time) error {
+// SetSubscriptionThrottling store throttling or scheduled notifications delay of given subscription for given triggerID
+func (connector *DbConnector) SetSubscriptionThrottling(triggerID, subscriptionID string, next time.Time) error {
 	c := connector.pool.Get()
 	defer c.Close()
-	_, err := c.Do("SET", notifierNextKey(triggerID), next.Unix())
+	_, err := c.Do("HSET", notifierSubscriptionsNextKey(triggerID), subscriptionID, next.Unix())
 	return err
 }
 
-// DeleteTriggerThrottling deletes throttling and scheduled notifications delay for given triggerID
+// DeleteTriggerThrottling deletes throttling and scheduled notifications delay of all subscriptions for given triggerID
 func (connector *DbConnector) DeleteTriggerThrottling(triggerID string) error {
 	c := connector.pool.Get()
 	defer c.Close()
 
 	c.Send("MULTI")
 	c.Send("SET", notifierThrottlingBeginningKey(triggerID), time.Now().Unix())
-	c.Send("DEL", notifierNextKey(triggerID))
+	c.Send("DEL", notifierSubscriptionsNextKey(triggerID))
 	_, err := c.Do("EXEC")
 	if err != nil {
 		return fmt.Errorf("failed to EXEC: %s", err.Error())
@@ -41,10 +53,20 @@ func (connector *DbConnector) DeleteTriggerThrottling(triggerID string) error {
 	return nil
 }
 
+func getLatestThrottling(subscriptionsNext []int64) int64 {
+	var next int64
+	for _, subscriptionNext := range subscriptionsNext {
+		if subscriptionNext > next {
+			next = subscriptionNext
+		}
+	}
+	return next
+}
+
 func notifierThrottlingBeginningKey(triggerID string) string {
 	return "moira-notifier-throttling-beginning:" + triggerID
 }
 
-func notifierNextKey(triggerID string) string {
-	return "moira-notifier-next:" + triggerID
+func notifierSubscriptionsNextKey(triggerID string) string {
+	return "moira-notifier-subscriptions-next:" + triggerID
 }
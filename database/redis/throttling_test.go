@@ -9,6 +9,41 @@ import (
 	"time"
 )
 
+func TestSubscriptionThrottling(t *testing.T) {
+	logger, _ := logging.GetLogger("dataBase")
+	dataBase := newTestDatabase(logger, config)
+	dataBase.flush()
+	defer dataBase.flush()
+
+	Convey("Subscription throttling manipulation", t, func() {
+		triggerID := "trigger-id"
+		next := time.Now().Add(time.Hour).Truncate(time.Second)
+		later := next.Add(time.Hour)
+
+		err := dataBase.SetSubscriptionThrottling(triggerID, "subscription-1", next)
+		So(err, ShouldBeNil)
+		err = dataBase.SetSubscriptionThrottling(triggerID, "subscription-2", later)
+		So(err, ShouldBeNil)
+
+		actualNext, _ := dataBase.GetSubscriptionThrottling(triggerID, "subscription-1")
+		So(actualNext, ShouldResemble, next)
+		actualNext, _ = dataBase.GetSubscriptionThrottling(triggerID, "subscription-3")
+		So(actualNext, ShouldResemble, time.Unix(0, 0))
+
+		actualNext, _ = dataBase.GetTriggerThrottling(triggerID)
+		So(actualNext, ShouldResemble, later)
+
+		err = dataBase.DeleteTriggerThrottling(triggerID)
+		So(err, ShouldBeNil)
+
+		actualNext, beginning := dataBase.GetSubscriptionThrottling(triggerID, "subscription-2")
+		So(actualNext, ShouldResemble, time.Unix(0, 0))
+		So(beginning, ShouldNotResemble, time.Unix(0, 0))
+		actualNext, _ = dataBase.GetTriggerThrottling(triggerID)
+		So(actualNext, ShouldResemble, time.Unix(0, 0))
+	})
+}
+
 func TestThrottlingErrorConnection(t *testing.T) {
 	logger, _ := logging.GetLogger("dataBase")
 	dataBase := newTestDatabase(logger, emptyConfig)
@@ -19,7 +54,11 @@ func TestThrottlingErrorConnection(t *testing.T) {
 		So(t1, ShouldResemble, time.Unix(0, 0))
 		So(t2, ShouldResemble, time.Unix(0, 0))
 
-		err := dataBase.SetTriggerThrottling("", time.Now())
+		t1, t2 = dataBase.GetSubscriptionThrottling("", "")
+		So(t1, ShouldResemble, time.Unix(0, 0))
+		So(t2, ShouldResemble, time.Unix(0, 0))
+
+		err := dataBase.SetSubscriptionThrottling("", "", time.Now())
 		So(err, ShouldNotBeNil)
 
 		err = dataBase.DeleteTriggerThrottling("")
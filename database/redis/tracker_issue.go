@@ -0,0 +1,46 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database/redis/reply"
+)
+
+// GetTriggerIssue returns issue opened in issue tracker about trigger by given contact, if no value, return database.ErrNil error
+func (connector *DbConnector) GetTriggerIssue(triggerID, contactID string) (moira.TrackerIssue, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	return reply.TrackerIssue(c.Do("HGET", triggerIssuesKey(triggerID), contactID))
+}
+
+// SaveTriggerIssue stores issue opened in issue tracker about trigger by given contact
+func (connector *DbConnector) SaveTriggerIssue(triggerID, contactID string, issue *moira.TrackerIssue) error {
+	issueBytes, err := json.Marshal(issue)
+	if err != nil {
+		return err
+	}
+
+	c := connector.pool.Get()
+	defer c.Close()
+	if _, err := c.Do("HSET", triggerIssuesKey(triggerID), contactID, issueBytes); err != nil {
+		return fmt.Errorf("failed to save tracker issue: %s", err.Error())
+	}
+	return nil
+}
+
+// RemoveTriggerIssue deletes resolved issue about trigger opened by given contact
+func (connector *DbConnector) RemoveTriggerIssue(triggerID, contactID string) error {
+	c := connector.pool.Get()
+	defer c.Close()
+	if _, err := c.Do("HDEL", triggerIssuesKey(triggerID), contactID); err != nil {
+		return fmt.Errorf("failed to remove tracker issue: %s", err.Error())
+	}
+	return nil
+}
+
+func triggerIssuesKey(triggerID string) string {
+	return fmt.Sprintf("moira-tracker-issues:%s", triggerID)
+}
@@ -0,0 +1,76 @@
+package redis
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	"github.com/moira-alert/moira/logging/go-logging"
+)
+
+func TestTrackerIssueStoring(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "info", "test")
+	dataBase := newTestDatabase(logger, config)
+	dataBase.flush()
+	defer dataBase.flush()
+
+	Convey("Tracker issues manipulation", t, func() {
+		issue := moira.TrackerIssue{Key: "PROJECT-1", Metrics: []string{"metric"}}
+
+		Convey("No issue", func() {
+			_, err := dataBase.GetTriggerIssue("triggerID", "contactID")
+			So(err, ShouldResemble, database.ErrNil)
+		})
+
+		Convey("Save, get and remove issue", func() {
+			err := dataBase.SaveTriggerIssue("triggerID", "contactID", &issue)
+			So(err, ShouldBeNil)
+
+			actual, err := dataBase.GetTriggerIssue("triggerID", "contactID")
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, issue)
+
+			_, err = dataBase.GetTriggerIssue("triggerID", "otherContactID")
+			So(err, ShouldResemble, database.ErrNil)
+
+			err = dataBase.RemoveTriggerIssue("triggerID", "contactID")
+			So(err, ShouldBeNil)
+
+			_, err = dataBase.GetTriggerIssue("triggerID", "contactID")
+			So(err, ShouldResemble, database.ErrNil)
+		})
+
+		Convey("Issues are removed with trigger", func() {
+			trigger := moira.Trigger{ID: "triggerID", Targets: []string{"target"}, Patterns: []string{"pattern"}}
+			err := dataBase.SaveTrigger(trigger.ID, &trigger)
+			So(err, ShouldBeNil)
+			err = dataBase.SaveTriggerIssue(trigger.ID, "contactID", &issue)
+			So(err, ShouldBeNil)
+
+			err = dataBase.RemoveTrigger(trigger.ID)
+			So(err, ShouldBeNil)
+
+			_, err = dataBase.GetTriggerIssue(trigger.ID, "contactID")
+			So(err, ShouldResemble, database.ErrNil)
+		})
+	})
+}
+
+func TestTrackerIssueErrorConnection(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "info", "test")
+	dataBase := newTestDatabase(logger, emptyConfig)
+	dataBase.flush()
+	defer dataBase.flush()
+	Convey("Should throw error when no connection", t, func() {
+		_, err := dataBase.GetTriggerIssue("triggerID", "contactID")
+		So(err, ShouldNotBeNil)
+
+		err = dataBase.SaveTriggerIssue("triggerID", "contactID", &moira.TrackerIssue{})
+		So(err, ShouldNotBeNil)
+
+		err = dataBase.RemoveTriggerIssue("triggerID", "contactID")
+		So(err, ShouldNotBeNil)
+	})
+}
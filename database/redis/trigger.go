@@ -26,24 +26,35 @@ func (connector *DbConnector) GetAllTriggerIDs() ([]string, error) {
 func (connector *DbConnector) GetLocalTriggerIDs() ([]string, error) {
 	c := connector.pool.Get()
 	defer c.Close()
-	triggerIds, err := redis.Strings(c.Do("SDIFF", triggersListKey, remoteTriggersListKey))
+	triggerIds, err := redis.Strings(c.Do("SDIFF", triggersListKey, remoteTriggersListKey(moira.DefaultCluster), clusteredRemoteTriggersListKey, prometheusTriggersListKey))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get triggers-list: %s", err.Error())
 	}
 	return triggerIds, nil
 }
 
-// GetRemoteTriggerIDs gets moira remote triggerIDs
-func (connector *DbConnector) GetRemoteTriggerIDs() ([]string, error) {
+// GetRemoteTriggerIDs gets moira remote triggerIDs of given cluster
+func (connector *DbConnector) GetRemoteTriggerIDs(clusterID moira.ClusterID) ([]string, error) {
 	c := connector.pool.Get()
 	defer c.Close()
-	triggerIds, err := redis.Strings(c.Do("SMEMBERS", remoteTriggersListKey))
+	triggerIds, err := redis.Strings(c.Do("SMEMBERS", remoteTriggersListKey(clusterID)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get remote triggers-list: %s", err.Error())
 	}
 	return triggerIds, nil
 }
 
+// GetPrometheusTriggerIDs gets moira prometheus triggerIDs
+func (connector *DbConnector) GetPrometheusTriggerIDs() ([]string, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+	triggerIds, err := redis.Strings(c.Do("SMEMBERS", prometheusTriggersListKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prometheus triggers-list: %s", err.Error())
+	}
+	return triggerIds, nil
+}
+
 // GetTrigger gets trigger and trigger tags by given ID and return it in merged object
 func (connector *DbConnector) GetTrigger(triggerID string) (moira.Trigger, error) {
 	c := connector.pool.Get()
@@ -103,6 +114,18 @@ func (connector *DbConnector) GetPatternTriggerIDs(pattern string) ([]string, er
 	return triggerIds, nil
 }
 
+// GetTriggerDependents gets IDs of triggers which depend on trigger with given ID
+func (connector *DbConnector) GetTriggerDependents(triggerID string) ([]string, error) {
+	c := connector.pool.Get()
+	defer c.Close()
+
+	triggerIDs, err := redis.Strings(c.Do("SMEMBERS", triggerDependentsKey(triggerID)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve dependent triggers for trigger: %s, error: %s", triggerID, err.Error())
+	}
+	return triggerIDs, nil
+}
+
 // RemovePatternTriggerIDs removes all triggerIDs list accepted to given pattern
 func (connector *DbConnector) RemovePatternTriggerIDs(pattern string) error {
 	c := connector.pool.Get()
@@ -120,7 +143,7 @@ func (connector *DbConnector) RemovePatternTriggerIDs(pattern string) error {
 // If given trigger contains new tags then create it.
 // If given trigger has no subscription on it, add it to triggers-without-subscriptions
 func (connector *DbConnector) SaveTrigger(triggerID string, trigger *moira.Trigger) error {
-	if trigger.IsRemote {
+	if trigger.TriggerSource.IsRemote() {
 		trigger.Patterns = make([]string, 0)
 	}
 
@@ -169,19 +192,27 @@ func (connector *DbConnector) updateTrigger(triggerID string, newTrigger *moira.
 		for _, pattern := range moira.GetStringListsDiff(oldTrigger.Patterns, newTrigger.Patterns) {
 			c.Send("SREM", patternTriggersKey(pattern), triggerID)
 		}
-		if oldTrigger.IsRemote && !newTrigger.IsRemote {
-			c.Send("SREM", remoteTriggersListKey, triggerID)
+		for _, listKey := range moira.GetStringListsDiff(triggerSourceListKeys(oldTrigger), triggerSourceListKeys(newTrigger)) {
+			c.Send("SREM", listKey, triggerID)
 		}
 
 		for _, tag := range moira.GetStringListsDiff(oldTrigger.Tags, newTrigger.Tags) {
 			c.Send("SREM", triggerTagsKey(triggerID), tag)
 			c.Send("SREM", tagTriggersKey(tag), triggerID)
 		}
+		for _, parentTriggerID := range moira.GetStringListsDiff(oldTrigger.Dependencies, newTrigger.Dependencies) {
+			c.Send("SREM", triggerDependentsKey(parentTriggerID), triggerID)
+		}
+		if oldTrigger.TeamID != "" && oldTrigger.TeamID != newTrigger.TeamID {
+			c.Send("SREM", teamTriggersKey(oldTrigger.TeamID), triggerID)
+		}
 	}
 	c.Send("SET", triggerKey(triggerID), bytes)
 	c.Send("SADD", triggersListKey, triggerID)
-	if newTrigger.IsRemote {
-		c.Send("SADD", remoteTriggersListKey, triggerID)
+	if newTrigger.TriggerSource.IsRemote() {
+		for _, listKey := range triggerSourceListKeys(newTrigger) {
+			c.Send("SADD", listKey, triggerID)
+		}
 	} else {
 		for _, pattern := range newTrigger.Patterns {
 			c.Send("SADD", patternsListKey, pattern)
@@ -193,6 +224,12 @@ func (connector *DbConnector) updateTrigger(triggerID string, newTrigger *moira.
 		c.Send("SADD", tagTriggersKey(tag), triggerID)
 		c.Send("SADD", tagsKey, tag)
 	}
+	for _, parentTriggerID := range newTrigger.Dependencies {
+		c.Send("SADD", triggerDependentsKey(parentTriggerID), triggerID)
+	}
+	if newTrigger.TeamID != "" {
+		c.Send("SADD", teamTriggersKey(newTrigger.TeamID), triggerID)
+	}
 	if connector.source != Cli {
 		c.Send("ZADD", triggersToReindexKey, time.Now().Unix(), triggerID)
 	}
@@ -229,8 +266,14 @@ func (connector *DbConnector) removeTrigger(triggerID string, trigger *moira.Tri
 	c.Send("DEL", triggerKey(triggerID))
 	c.Send("DEL", triggerTagsKey(triggerID))
 	c.Send("DEL", triggerEventsKey(triggerID))
+	c.Send("DEL", triggerIssuesKey(triggerID))
+	c.Send("DEL", triggerDeliveriesKey(triggerID))
 	c.Send("SREM", triggersListKey, triggerID)
-	c.Send("SREM", remoteTriggersListKey, triggerID)
+	c.Send("SREM", remoteTriggersListKey(moira.DefaultCluster), triggerID)
+	c.Send("SREM", prometheusTriggersListKey, triggerID)
+	for _, listKey := range triggerSourceListKeys(trigger) {
+		c.Send("SREM", listKey, triggerID)
+	}
 	c.Send("SREM", unusedTriggersKey, triggerID)
 	for _, tag := range trigger.Tags {
 		c.Send("SREM", tagTriggersKey(tag), triggerID)
@@ -238,6 +281,12 @@ func (connector *DbConnector) removeTrigger(triggerID string, trigger *moira.Tri
 	for _, pattern := range trigger.Patterns {
 		c.Send("SREM", patternTriggersKey(pattern), triggerID)
 	}
+	for _, parentTriggerID := range trigger.Dependencies {
+		c.Send("SREM", triggerDependentsKey(parentTriggerID), triggerID)
+	}
+	if trigger.TeamID != "" {
+		c.Send("SREM", teamTriggersKey(trigger.TeamID), triggerID)
+	}
 	c.Send("ZADD", triggersToReindexKey, time.Now().Unix(), triggerID)
 
 	if _, err := c.Do("EXEC"); err != nil {
@@ -258,7 +307,7 @@ func (connector *DbConnector) GetTriggerChecks(triggerIDs []string) ([]*moira.Tr
 		c.Send("GET", triggerKey(triggerID))
 		c.Send("SMEMBERS", triggerTagsKey(triggerID))
 		c.Send("GET", metricLastCheckKey(triggerID))
-		c.Send("GET", notifierNextKey(triggerID))
+		c.Send("HVALS", notifierSubscriptionsNextKey(triggerID))
 	}
 	rawResponse, err := redis.Values(c.Do("EXEC"))
 	if err != nil {
@@ -285,7 +334,8 @@ func (connector *DbConnector) GetTriggerChecks(triggerIDs []string) ([]*moira.Tr
 		if err != nil && err != database.ErrNil {
 			return nil, err
 		}
-		throttling, _ := redis.Int64(slice[4], nil)
+		subscriptionsThrottling, _ := redis.Int64s(slice[4], nil)
+		throttling := getLatestThrottling(subscriptionsThrottling)
 		if time.Now().Unix() >= throttling {
 			throttling = 0
 		}
@@ -351,7 +401,34 @@ func (connector *DbConnector) triggerHasSubscriptions(trigger *moira.Trigger) (b
 }
 
 var triggersListKey = "moira-triggers-list"
-var remoteTriggersListKey = "moira-remote-triggers-list"
+var prometheusTriggersListKey = "moira-prometheus-triggers-list"
+
+// clusteredRemoteTriggersListKey contains remote triggers of all clusters except default one
+var clusteredRemoteTriggersListKey = "moira-clustered-remote-triggers-list"
+
+// remoteTriggersListKey returns key of remote triggers list of given cluster, default cluster keeps the key used before clusters were introduced
+func remoteTriggersListKey(clusterID moira.ClusterID) string {
+	if clusterID == moira.DefaultCluster {
+		return "moira-remote-triggers-list"
+	}
+	return "moira-remote-triggers-list:" + string(clusterID)
+}
+
+// triggerSourceListKeys returns keys of the lists containing given remote trigger, local triggers have no such lists
+func triggerSourceListKeys(trigger *moira.Trigger) []string {
+	clusterKey := trigger.ClusterKey()
+	switch clusterKey.TriggerSource {
+	case moira.GraphiteRemote:
+		if clusterKey.ClusterID == moira.DefaultCluster {
+			return []string{remoteTriggersListKey(clusterKey.ClusterID)}
+		}
+		return []string{remoteTriggersListKey(clusterKey.ClusterID), clusteredRemoteTriggersListKey}
+	case moira.PrometheusRemote:
+		return []string{prometheusTriggersListKey}
+	default:
+		return []string{}
+	}
+}
 
 func triggerKey(triggerID string) string {
 	return "moira-trigger:" + triggerID
@@ -361,6 +438,10 @@ func triggerTagsKey(triggerID string) string {
 	return "moira-trigger-tags:" + triggerID
 }
 
+func triggerDependentsKey(triggerID string) string {
+	return "moira-trigger-dependents:" + triggerID
+}
+
 func patternTriggersKey(pattern string) string {
 	return "moira-pattern-triggers:" + pattern
 }
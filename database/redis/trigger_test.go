@@ -242,7 +242,7 @@ func TestTriggerStoring(t *testing.T) {
 			So(actualTriggerChecks, ShouldResemble, []*moira.TriggerCheck{triggerCheck})
 
 			//Add check data
-			err = dataBase.SetTriggerLastCheck(trigger.ID, &lastCheckTest, false)
+			err = dataBase.SetTriggerLastCheck(trigger.ID, &lastCheckTest, moira.DefaultLocalCluster)
 			So(err, ShouldBeNil)
 
 			triggerCheck.LastCheck = lastCheckTest
@@ -251,7 +251,7 @@ func TestTriggerStoring(t *testing.T) {
 			So(actualTriggerChecks, ShouldResemble, []*moira.TriggerCheck{triggerCheck})
 
 			//And throttling
-			err = dataBase.SetTriggerThrottling(trigger.ID, time.Now().Add(-time.Minute))
+			err = dataBase.SetSubscriptionThrottling(trigger.ID, "subscription", time.Now().Add(-time.Minute))
 			So(err, ShouldBeNil)
 
 			//But it is foul
@@ -261,7 +261,7 @@ func TestTriggerStoring(t *testing.T) {
 
 			//Now good throttling
 			th := time.Now().Add(time.Minute)
-			err = dataBase.SetTriggerThrottling(trigger.ID, th)
+			err = dataBase.SetSubscriptionThrottling(trigger.ID, "subscription", th)
 			So(err, ShouldBeNil)
 
 			triggerCheck.Throttling = th.Unix()
@@ -518,12 +518,12 @@ func TestRemoteTrigger(t *testing.T) {
 	dataBase := newTestDatabase(logger, config)
 	pattern := "test.pattern.remote1"
 	trigger := &moira.Trigger{
-		ID:          "triggerID-0000000000010",
-		Name:        "remote",
-		Targets:     []string{"test.target.remote1"},
-		Patterns:    []string{pattern},
-		IsRemote:    true,
-		TriggerType: moira.RisingTrigger,
+		ID:            "triggerID-0000000000010",
+		Name:          "remote",
+		Targets:       []string{"test.target.remote1"},
+		Patterns:      []string{pattern},
+		TriggerSource: moira.GraphiteRemote,
+		TriggerType:   moira.RisingTrigger,
 	}
 	dataBase.flush()
 	defer dataBase.flush()
@@ -547,7 +547,7 @@ func TestRemoteTrigger(t *testing.T) {
 			So(ids, ShouldResemble, []string{})
 		})
 		Convey("Trigger should be added to remote triggers collection", func() {
-			ids, err := dataBase.GetRemoteTriggerIDs()
+			ids, err := dataBase.GetRemoteTriggerIDs(moira.DefaultCluster)
 			So(err, ShouldBeNil)
 			So(ids, ShouldResemble, []string{trigger.ID})
 		})
@@ -564,7 +564,7 @@ func TestRemoteTrigger(t *testing.T) {
 	})
 
 	Convey("Update remote trigger as local", t, func() {
-		trigger.IsRemote = false
+		trigger.TriggerSource = moira.GraphiteLocal
 		trigger.Patterns = []string{pattern}
 		Convey("Trigger should be saved correctly", func() {
 			err := dataBase.SaveTrigger(trigger.ID, trigger)
@@ -584,7 +584,7 @@ func TestRemoteTrigger(t *testing.T) {
 			So(ids, ShouldResemble, []string{trigger.ID})
 		})
 		Convey("Trigger shouldn't be added to remote triggers collection", func() {
-			ids, err := dataBase.GetRemoteTriggerIDs()
+			ids, err := dataBase.GetRemoteTriggerIDs(moira.DefaultCluster)
 			So(err, ShouldBeNil)
 			So(ids, ShouldResemble, []string{})
 		})
@@ -604,7 +604,7 @@ func TestRemoteTrigger(t *testing.T) {
 			So(patterns, ShouldResemble, trigger.Patterns)
 		})
 
-		trigger.IsRemote = true
+		trigger.TriggerSource = moira.GraphiteRemote
 		Convey("Update this trigger as remote", func() {
 			err := dataBase.SaveTrigger(trigger.ID, trigger)
 			So(err, ShouldBeNil)
@@ -623,7 +623,7 @@ func TestRemoteTrigger(t *testing.T) {
 			So(ids, ShouldResemble, []string{trigger.ID})
 		})
 		Convey("Trigger should be added to remote triggers collection", func() {
-			ids, err := dataBase.GetRemoteTriggerIDs()
+			ids, err := dataBase.GetRemoteTriggerIDs(moira.DefaultCluster)
 			So(err, ShouldBeNil)
 			So(ids, ShouldResemble, []string{trigger.ID})
 		})
@@ -640,6 +640,181 @@ func TestRemoteTrigger(t *testing.T) {
 	})
 }
 
+func TestPrometheusTrigger(t *testing.T) {
+	logger, _ := logging.GetLogger("dataBase")
+	dataBase := newTestDatabase(logger, config)
+	pattern := "test.pattern.prometheus1"
+	trigger := &moira.Trigger{
+		ID:            "triggerID-0000000000011",
+		Name:          "prometheus",
+		Targets:       []string{"rate(http_requests_total[5m])"},
+		Patterns:      []string{pattern},
+		TriggerSource: moira.PrometheusRemote,
+		TriggerType:   moira.RisingTrigger,
+	}
+	dataBase.flush()
+	defer dataBase.flush()
+
+	Convey("Saving prometheus trigger", t, func() {
+		Convey("Trigger should be saved correctly", func() {
+			err := dataBase.SaveTrigger(trigger.ID, trigger)
+			So(err, ShouldBeNil)
+			actual, err := dataBase.GetTrigger(trigger.ID)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, *trigger)
+			So(actual.Patterns, ShouldBeEmpty)
+		})
+		Convey("Trigger should not be added to local and remote triggers collections", func() {
+			ids, err := dataBase.GetLocalTriggerIDs()
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []string{})
+			ids, err = dataBase.GetRemoteTriggerIDs(moira.DefaultCluster)
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []string{})
+		})
+		Convey("Trigger should be added to prometheus triggers collection", func() {
+			ids, err := dataBase.GetPrometheusTriggerIDs()
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []string{trigger.ID})
+		})
+	})
+
+	Convey("Update prometheus trigger as graphite remote", t, func() {
+		trigger.TriggerSource = moira.GraphiteRemote
+		err := dataBase.SaveTrigger(trigger.ID, trigger)
+		So(err, ShouldBeNil)
+		Convey("Trigger should be moved to remote triggers collection", func() {
+			ids, err := dataBase.GetPrometheusTriggerIDs()
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []string{})
+			ids, err = dataBase.GetRemoteTriggerIDs(moira.DefaultCluster)
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []string{trigger.ID})
+		})
+	})
+
+	Convey("Remove prometheus trigger", t, func() {
+		trigger.TriggerSource = moira.PrometheusRemote
+		err := dataBase.SaveTrigger(trigger.ID, trigger)
+		So(err, ShouldBeNil)
+		err = dataBase.RemoveTrigger(trigger.ID)
+		So(err, ShouldBeNil)
+		ids, err := dataBase.GetPrometheusTriggerIDs()
+		So(err, ShouldBeNil)
+		So(ids, ShouldResemble, []string{})
+	})
+}
+
+func TestRemoteClusterTrigger(t *testing.T) {
+	logger, _ := logging.GetLogger("dataBase")
+	dataBase := newTestDatabase(logger, config)
+	trigger := &moira.Trigger{
+		ID:            "triggerID-0000000000012",
+		Name:          "remote cluster",
+		Targets:       []string{"test.target.cluster1"},
+		TriggerSource: moira.GraphiteRemote,
+		ClusterID:     "staging",
+		TriggerType:   moira.RisingTrigger,
+	}
+	dataBase.flush()
+	defer dataBase.flush()
+
+	Convey("Saving trigger of named remote cluster", t, func() {
+		err := dataBase.SaveTrigger(trigger.ID, trigger)
+		So(err, ShouldBeNil)
+		actual, err := dataBase.GetTrigger(trigger.ID)
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, *trigger)
+
+		Convey("Trigger should be added only to its cluster triggers collection", func() {
+			ids, err := dataBase.GetRemoteTriggerIDs("staging")
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []string{trigger.ID})
+			ids, err = dataBase.GetRemoteTriggerIDs(moira.DefaultCluster)
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []string{})
+			ids, err = dataBase.GetLocalTriggerIDs()
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []string{})
+		})
+	})
+
+	Convey("Move trigger to default remote cluster", t, func() {
+		trigger.ClusterID = moira.DefaultCluster
+		err := dataBase.SaveTrigger(trigger.ID, trigger)
+		So(err, ShouldBeNil)
+		ids, err := dataBase.GetRemoteTriggerIDs("staging")
+		So(err, ShouldBeNil)
+		So(ids, ShouldResemble, []string{})
+		ids, err = dataBase.GetRemoteTriggerIDs(moira.DefaultCluster)
+		So(err, ShouldBeNil)
+		So(ids, ShouldResemble, []string{trigger.ID})
+		ids, err = dataBase.GetLocalTriggerIDs()
+		So(err, ShouldBeNil)
+		So(ids, ShouldResemble, []string{})
+	})
+
+	Convey("Remove trigger of named remote cluster", t, func() {
+		trigger.ClusterID = "staging"
+		err := dataBase.SaveTrigger(trigger.ID, trigger)
+		So(err, ShouldBeNil)
+		err = dataBase.RemoveTrigger(trigger.ID)
+		So(err, ShouldBeNil)
+		ids, err := dataBase.GetRemoteTriggerIDs("staging")
+		So(err, ShouldBeNil)
+		So(ids, ShouldResemble, []string{})
+	})
+}
+
+func TestTriggerDependents(t *testing.T) {
+	logger, _ := logging.GetLogger("dataBase")
+	dataBase := newTestDatabase(logger, config)
+	trigger := &moira.Trigger{
+		ID:           "triggerID-0000000000013",
+		Name:         "dependent trigger",
+		Targets:      []string{"test.target.dependent"},
+		Patterns:     []string{"test.target.dependent"},
+		TriggerType:  moira.RisingTrigger,
+		Dependencies: []string{"parent-1", "parent-2"},
+	}
+	dataBase.flush()
+	defer dataBase.flush()
+
+	Convey("Saving trigger with dependencies", t, func() {
+		err := dataBase.SaveTrigger(trigger.ID, trigger)
+		So(err, ShouldBeNil)
+		actual, err := dataBase.GetTrigger(trigger.ID)
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, *trigger)
+
+		for _, parentTriggerID := range trigger.Dependencies {
+			ids, err := dataBase.GetTriggerDependents(parentTriggerID)
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []string{trigger.ID})
+		}
+	})
+
+	Convey("Updating trigger dependencies", t, func() {
+		trigger.Dependencies = []string{"parent-2"}
+		err := dataBase.SaveTrigger(trigger.ID, trigger)
+		So(err, ShouldBeNil)
+		ids, err := dataBase.GetTriggerDependents("parent-1")
+		So(err, ShouldBeNil)
+		So(ids, ShouldResemble, []string{})
+		ids, err = dataBase.GetTriggerDependents("parent-2")
+		So(err, ShouldBeNil)
+		So(ids, ShouldResemble, []string{trigger.ID})
+	})
+
+	Convey("Removing trigger with dependencies", t, func() {
+		err := dataBase.RemoveTrigger(trigger.ID)
+		So(err, ShouldBeNil)
+		ids, err := dataBase.GetTriggerDependents("parent-2")
+		So(err, ShouldBeNil)
+		So(ids, ShouldResemble, []string{})
+	})
+}
+
 func TestTriggerErrorConnection(t *testing.T) {
 	logger, _ := logging.GetLogger("dataBase")
 	dataBase := newTestDatabase(logger, emptyConfig)
@@ -672,11 +847,20 @@ func TestTriggerErrorConnection(t *testing.T) {
 		So(err, ShouldNotBeNil)
 		So(actual4, ShouldBeNil)
 
+		actual5, err := dataBase.GetTriggerDependents("")
+		So(err, ShouldNotBeNil)
+		So(actual5, ShouldBeNil)
+
 		err = dataBase.RemovePatternTriggerIDs("")
 		So(err, ShouldNotBeNil)
 	})
 }
 
+var (
+	testWarnValue         = float64(10)
+	testWarnRecoveryValue = float64(8)
+)
+
 var triggers = []moira.Trigger{
 	{
 		ID:          "triggerID-0000000000001",
@@ -688,12 +872,15 @@ var triggers = []moira.Trigger{
 		TTLState:    &moira.TTLStateNODATA,
 	},
 	{
-		ID:          "triggerID-0000000000001",
-		Name:        "test trigger 1 v2.0",
-		Targets:     []string{"test.target.1", "test.target.2"},
-		Tags:        []string{"test-tag-2", "test-tag-1"},
-		Patterns:    []string{"test.pattern.2", "test.pattern.1"},
-		TriggerType: moira.RisingTrigger,
+		ID:                "triggerID-0000000000001",
+		Name:              "test trigger 1 v2.0",
+		Targets:           []string{"test.target.1", "test.target.2"},
+		Tags:              []string{"test-tag-2", "test-tag-1"},
+		Patterns:          []string{"test.pattern.2", "test.pattern.1"},
+		TriggerType:       moira.RisingTrigger,
+		WarnValue:         &testWarnValue,
+		WarnRecoveryValue: &testWarnRecoveryValue,
+		PendingInterval:   300,
 	},
 	{
 		ID:          "triggerID-0000000000001",
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/gomodule/redigo/redis"
+	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/database"
 )
 
@@ -12,9 +13,14 @@ func (connector *DbConnector) AddLocalTriggersToCheck(triggerIDs []string) error
 	return connector.addTriggersToCheck(localTriggersToCheckKey, triggerIDs)
 }
 
-// AddRemoteTriggersToCheck gets remote trigger IDs and save it to Redis Set
-func (connector *DbConnector) AddRemoteTriggersToCheck(triggerIDs []string) error {
-	return connector.addTriggersToCheck(remoteTriggersToCheckKey, triggerIDs)
+// AddRemoteTriggersToCheck gets remote trigger IDs of given cluster and save it to Redis Set
+func (connector *DbConnector) AddRemoteTriggersToCheck(clusterID moira.ClusterID, triggerIDs []string) error {
+	return connector.addTriggersToCheck(remoteTriggersToCheckKey(clusterID), triggerIDs)
+}
+
+// AddPrometheusTriggersToCheck gets prometheus trigger IDs and save it to Redis Set
+func (connector *DbConnector) AddPrometheusTriggersToCheck(triggerIDs []string) error {
+	return connector.addTriggersToCheck(prometheusTriggersToCheckKey, triggerIDs)
 }
 
 // GetLocalTriggersToCheck return random trigger ID from Redis Set
@@ -23,9 +29,14 @@ func (connector *DbConnector) GetLocalTriggersToCheck(count int) ([]string, erro
 
 }
 
-// GetRemoteTriggersToCheck return random remote trigger ID from Redis Set
-func (connector *DbConnector) GetRemoteTriggersToCheck(count int) ([]string, error) {
-	return connector.getTriggersToCheck(remoteTriggersToCheckKey, count)
+// GetRemoteTriggersToCheck return random remote trigger ID of given cluster from Redis Set
+func (connector *DbConnector) GetRemoteTriggersToCheck(clusterID moira.ClusterID, count int) ([]string, error) {
+	return connector.getTriggersToCheck(remoteTriggersToCheckKey(clusterID), count)
+}
+
+// GetPrometheusTriggersToCheck return random prometheus trigger ID from Redis Set
+func (connector *DbConnector) GetPrometheusTriggersToCheck(count int) ([]string, error) {
+	return connector.getTriggersToCheck(prometheusTriggersToCheckKey, count)
 }
 
 // GetLocalTriggersToCheckCount return number of triggers ID to check from Redis Set
@@ -33,9 +44,14 @@ func (connector *DbConnector) GetLocalTriggersToCheckCount() (int64, error) {
 	return connector.getTriggersToCheckCount(localTriggersToCheckKey)
 }
 
-// GetRemoteTriggersToCheckCount return number of remote triggers ID to check from Redis Set
-func (connector *DbConnector) GetRemoteTriggersToCheckCount() (int64, error) {
-	return connector.getTriggersToCheckCount(remoteTriggersToCheckKey)
+// GetRemoteTriggersToCheckCount return number of remote triggers ID of given cluster to check from Redis Set
+func (connector *DbConnector) GetRemoteTriggersToCheckCount(clusterID moira.ClusterID) (int64, error) {
+	return connector.getTriggersToCheckCount(remoteTriggersToCheckKey(clusterID))
+}
+
+// GetPrometheusTriggersToCheckCount return number of prometheus triggers ID to check from Redis Set
+func (connector *DbConnector) GetPrometheusTriggersToCheckCount() (int64, error) {
+	return connector.getTriggersToCheckCount(prometheusTriggersToCheckKey)
 }
 
 func (connector *DbConnector) addTriggersToCheck(key string, triggerIDs []string) error {
@@ -79,5 +95,12 @@ func (connector *DbConnector) getTriggersToCheckCount(key string) (int64, error)
 	return triggersToCheckCount, nil
 }
 
-var remoteTriggersToCheckKey = "moira-remote-triggers-to-check"
+func remoteTriggersToCheckKey(clusterID moira.ClusterID) string {
+	if clusterID == moira.DefaultCluster {
+		return "moira-remote-triggers-to-check"
+	}
+	return "moira-remote-triggers-to-check:" + string(clusterID)
+}
+
+var prometheusTriggersToCheckKey = "moira-prometheus-triggers-to-check"
 var localTriggersToCheckKey = "moira-triggers-to-check"
@@ -6,6 +6,7 @@ import (
 	"github.com/gofrs/uuid"
 	. "github.com/smartystreets/goconvey/convey"
 
+	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/logging/go-logging"
 )
 
@@ -115,61 +116,61 @@ func TestRemoteTriggerToCheck(t *testing.T) {
 		triggerID5 := uuid.Must(uuid.NewV4()).String()
 		triggerID6 := uuid.Must(uuid.NewV4()).String()
 
-		actual, err := dataBase.GetRemoteTriggersToCheck(1)
+		actual, err := dataBase.GetRemoteTriggersToCheck(moira.DefaultCluster, 1)
 		So(err, ShouldBeNil)
 		So(actual, ShouldBeEmpty)
 
-		count, err := dataBase.GetRemoteTriggersToCheckCount()
+		count, err := dataBase.GetRemoteTriggersToCheckCount(moira.DefaultCluster)
 		So(err, ShouldBeNil)
 		So(count, ShouldEqual, 0)
 
-		err = dataBase.AddRemoteTriggersToCheck([]string{triggerID1})
+		err = dataBase.AddRemoteTriggersToCheck(moira.DefaultCluster, []string{triggerID1})
 		So(err, ShouldBeNil)
 
-		count, err = dataBase.GetRemoteTriggersToCheckCount()
+		count, err = dataBase.GetRemoteTriggersToCheckCount(moira.DefaultCluster)
 		So(err, ShouldBeNil)
 		So(count, ShouldEqual, 1)
 
-		actual, err = dataBase.GetRemoteTriggersToCheck(1)
+		actual, err = dataBase.GetRemoteTriggersToCheck(moira.DefaultCluster, 1)
 		So(err, ShouldBeNil)
 		So(actual, ShouldResemble, []string{triggerID1})
 
-		count, err = dataBase.GetRemoteTriggersToCheckCount()
+		count, err = dataBase.GetRemoteTriggersToCheckCount(moira.DefaultCluster)
 		So(err, ShouldBeNil)
 		So(count, ShouldEqual, 0)
 
-		err = dataBase.AddRemoteTriggersToCheck([]string{triggerID1})
+		err = dataBase.AddRemoteTriggersToCheck(moira.DefaultCluster, []string{triggerID1})
 		So(err, ShouldBeNil)
 
-		err = dataBase.AddRemoteTriggersToCheck([]string{triggerID1})
+		err = dataBase.AddRemoteTriggersToCheck(moira.DefaultCluster, []string{triggerID1})
 		So(err, ShouldBeNil)
 
-		count, err = dataBase.GetRemoteTriggersToCheckCount()
+		count, err = dataBase.GetRemoteTriggersToCheckCount(moira.DefaultCluster)
 		So(err, ShouldBeNil)
 		So(count, ShouldEqual, 1)
 
-		actual, err = dataBase.GetRemoteTriggersToCheck(1)
+		actual, err = dataBase.GetRemoteTriggersToCheck(moira.DefaultCluster, 1)
 		So(err, ShouldBeNil)
 		So(actual, ShouldResemble, []string{triggerID1})
 
-		actual, err = dataBase.GetRemoteTriggersToCheck(1)
+		actual, err = dataBase.GetRemoteTriggersToCheck(moira.DefaultCluster, 1)
 		So(err, ShouldBeNil)
 		So(actual, ShouldBeEmpty)
 
 		triggerArr := []string{triggerID1, triggerID2, triggerID3, triggerID4, triggerID5, triggerID6}
-		err = dataBase.AddRemoteTriggersToCheck(triggerArr)
+		err = dataBase.AddRemoteTriggersToCheck(moira.DefaultCluster, triggerArr)
 		So(err, ShouldBeNil)
 
-		count, err = dataBase.GetRemoteTriggersToCheckCount()
+		count, err = dataBase.GetRemoteTriggersToCheckCount(moira.DefaultCluster)
 		So(err, ShouldBeNil)
 		So(count, ShouldEqual, 6)
 
-		actual, err = dataBase.GetRemoteTriggersToCheck(1)
+		actual, err = dataBase.GetRemoteTriggersToCheck(moira.DefaultCluster, 1)
 		So(err, ShouldBeNil)
 		So(actual[0], ShouldBeIn, triggerArr)
 		triggerArr = removeValue(triggerArr, actual[0])
 
-		actual, err = dataBase.GetRemoteTriggersToCheck(2)
+		actual, err = dataBase.GetRemoteTriggersToCheck(moira.DefaultCluster, 2)
 		So(err, ShouldBeNil)
 		So(actual, ShouldHaveLength, 2)
 		So(actual[0], ShouldBeIn, triggerArr)
@@ -177,14 +178,14 @@ func TestRemoteTriggerToCheck(t *testing.T) {
 		triggerArr = removeValue(triggerArr, actual[0])
 		triggerArr = removeValue(triggerArr, actual[1])
 
-		actual, err = dataBase.GetRemoteTriggersToCheck(6)
+		actual, err = dataBase.GetRemoteTriggersToCheck(moira.DefaultCluster, 6)
 		So(err, ShouldBeNil)
 		So(actual, ShouldHaveLength, 3)
 		So(actual[0], ShouldBeIn, triggerArr)
 		So(actual[1], ShouldBeIn, triggerArr)
 		So(actual[2], ShouldBeIn, triggerArr)
 
-		actual, err = dataBase.GetRemoteTriggersToCheck(5)
+		actual, err = dataBase.GetRemoteTriggersToCheck(moira.DefaultCluster, 5)
 		So(err, ShouldBeNil)
 		So(actual, ShouldBeEmpty)
 
@@ -200,10 +201,10 @@ func TestRemoteTriggerToCheckConnection(t *testing.T) {
 	dataBase.flush()
 	defer dataBase.flush()
 	Convey("Should throw error when no connection", t, func() {
-		err := dataBase.AddRemoteTriggersToCheck([]string{"123"})
+		err := dataBase.AddRemoteTriggersToCheck(moira.DefaultCluster, []string{"123"})
 		So(err, ShouldNotBeNil)
 
-		triggerID, err := dataBase.GetRemoteTriggersToCheck(1)
+		triggerID, err := dataBase.GetRemoteTriggersToCheck(moira.DefaultCluster, 1)
 		So(triggerID, ShouldBeEmpty)
 		So(err, ShouldNotBeNil)
 	})
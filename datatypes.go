@@ -23,8 +23,10 @@ const (
 )
 
 const (
-	format        = "15:04 02.01.2006"
-	remindMessage = "This metric has been in bad state for more than %v hours - please, fix."
+	format               = "15:04 02.01.2006"
+	remindMessage        = "This metric has been in bad state for more than %v hours - please, fix."
+	dependencyMessage    = "This metric changed its state while parent trigger %s was in bad state."
+	reminderCountMessage = "%s reminder. %s"
 )
 
 // NotificationEvent represents trigger state changes event
@@ -40,12 +42,22 @@ type NotificationEvent struct {
 	OldState         State      `json:"old_state"`
 	Message          *string    `json:"msg,omitempty"`
 	MessageEventInfo *EventInfo `json:"event_message"`
+	SilenceID        *string    `json:"silence_id,omitempty"`
+}
+
+// StreamedNotificationEvent represents notification event published to real-time events stream,
+// ID is a sequence number of event in stream used to resume stream after reconnect
+type StreamedNotificationEvent struct {
+	ID    int64             `json:"id"`
+	Event NotificationEvent `json:"event"`
 }
 
 // EventInfo - a base for creating messages.
 type EventInfo struct {
-	Maintenance *MaintenanceInfo `json:"maintenance,omitempty"`
-	Interval    *int64           `json:"interval,omitempty"`
+	Maintenance   *MaintenanceInfo `json:"maintenance,omitempty"`
+	Interval      *int64           `json:"interval,omitempty"`
+	Dependency    *string          `json:"dependency,omitempty"`
+	ReminderCount *int64           `json:"reminder_count,omitempty"`
 }
 
 // CreateMessage - creates a message based on EventInfo.
@@ -60,7 +72,15 @@ func (event *NotificationEvent) CreateMessage(location *time.Location) string {
 	}
 
 	if event.MessageEventInfo.Interval != nil && event.MessageEventInfo.Maintenance == nil {
-		return fmt.Sprintf(remindMessage, *event.MessageEventInfo.Interval)
+		message := fmt.Sprintf(remindMessage, *event.MessageEventInfo.Interval)
+		if event.MessageEventInfo.ReminderCount != nil {
+			message = fmt.Sprintf(reminderCountMessage, getOrdinal(*event.MessageEventInfo.ReminderCount), message)
+		}
+		return message
+	}
+
+	if event.MessageEventInfo.Dependency != nil {
+		return fmt.Sprintf(dependencyMessage, *event.MessageEventInfo.Dependency)
 	}
 
 	if event.MessageEventInfo.Maintenance == nil {
@@ -105,14 +125,15 @@ type NotificationEvents []NotificationEvent
 
 // TriggerData represents trigger object
 type TriggerData struct {
-	ID         string   `json:"id"`
-	Name       string   `json:"name"`
-	Desc       string   `json:"desc"`
-	Targets    []string `json:"targets"`
-	WarnValue  float64  `json:"warn_value"`
-	ErrorValue float64  `json:"error_value"`
-	IsRemote   bool     `json:"is_remote"`
-	Tags       []string `json:"__notifier_trigger_tags"`
+	ID            string        `json:"id"`
+	Name          string        `json:"name"`
+	Desc          string        `json:"desc"`
+	Targets       []string      `json:"targets"`
+	WarnValue     float64       `json:"warn_value"`
+	ErrorValue    float64       `json:"error_value"`
+	IsRemote      bool          `json:"is_remote"`
+	TriggerSource TriggerSource `json:"trigger_source,omitempty"`
+	Tags          []string      `json:"__notifier_trigger_tags"`
 }
 
 // GetTriggerURI gets frontUri and returns triggerUrl, returns empty string on selfcheck and test notifications
@@ -123,27 +144,107 @@ func (trigger TriggerData) GetTriggerURI(frontURI string) string {
 	return ""
 }
 
-// ContactData represents contact object
+// TeamRole is a role of team member which determines what member can do with team triggers and subscriptions
+type TeamRole string
+
+// TeamRole values
+const (
+	// TeamRoleViewer can only view team triggers and subscriptions
+	TeamRoleViewer TeamRole = "viewer"
+	// TeamRoleEditor can create, change and remove team triggers and subscriptions
+	TeamRoleEditor TeamRole = "editor"
+	// TeamRoleAdmin can also change team settings and members and remove team
+	TeamRoleAdmin TeamRole = "admin"
+)
+
+var teamRoleLevels = map[TeamRole]int{
+	TeamRoleViewer: 1,
+	TeamRoleEditor: 2,
+	TeamRoleAdmin:  3,
+}
+
+// IsValid checks that role is one of known team roles
+func (role TeamRole) IsValid() bool {
+	_, ok := teamRoleLevels[role]
+	return ok
+}
+
+// Includes checks that role grants all permissions of other role
+func (role TeamRole) Includes(other TeamRole) bool {
+	return role.IsValid() && teamRoleLevels[role] >= teamRoleLevels[other]
+}
+
+// Team represents group of users sharing triggers and subscriptions
+type Team struct {
+	ID          string              `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Members     map[string]TeamRole `json:"members"`
+}
+
+// GetRole returns role of team member, empty role is returned if user is not a member
+func (team *Team) GetRole(login string) TeamRole {
+	return team.Members[login]
+}
+
+// ContactData represents contact object. Events of contact with digest interval in seconds are batched across triggers
+// and sent once per interval. Message template overrides message template of contact sender
 type ContactData struct {
-	Type  string `json:"type"`
-	Value string `json:"value"`
-	ID    string `json:"id"`
-	User  string `json:"user"`
+	Type            string `json:"type"`
+	Value           string `json:"value"`
+	ID              string `json:"id"`
+	User            string `json:"user"`
+	DigestInterval  int64  `json:"digest_interval,omitempty"`
+	MessageTemplate string `json:"message_template,omitempty"`
 }
 
 // SubscriptionData represents user subscription
 type SubscriptionData struct {
-	Contacts          []string     `json:"contacts"`
-	Tags              []string     `json:"tags"`
-	Schedule          ScheduleData `json:"sched"`
-	Plotting          PlottingData `json:"plotting"`
-	ID                string       `json:"id"`
-	Enabled           bool         `json:"enabled"`
-	AnyTags           bool         `json:"any_tags"`
-	IgnoreWarnings    bool         `json:"ignore_warnings,omitempty"`
-	IgnoreRecoverings bool         `json:"ignore_recoverings,omitempty"`
-	ThrottlingEnabled bool         `json:"throttling"`
-	User              string       `json:"user"`
+	Contacts          []string          `json:"contacts"`
+	Tags              []string          `json:"tags"`
+	Schedule          ScheduleData      `json:"sched"`
+	Plotting          PlottingData      `json:"plotting"`
+	ID                string            `json:"id"`
+	Enabled           bool              `json:"enabled"`
+	AnyTags           bool              `json:"any_tags"`
+	IgnoreWarnings    bool              `json:"ignore_warnings,omitempty"`
+	IgnoreRecoverings bool              `json:"ignore_recoverings,omitempty"`
+	ThrottlingEnabled bool              `json:"throttling"`
+	User              string            `json:"user"`
+	Escalations       []EscalationData  `json:"escalations,omitempty"`
+	ThrottlingLevels  []ThrottlingLevel `json:"throttling_levels,omitempty"`
+	TeamID            string            `json:"team_id,omitempty"`
+}
+
+// ThrottlingLevel represents subscription throttling rule. If trigger switches Count times or more in last Duration seconds,
+// next notification is delayed for Delay seconds
+type ThrottlingLevel struct {
+	Duration int64 `json:"duration"`
+	Delay    int64 `json:"delay"`
+	Count    int64 `json:"count"`
+}
+
+// ValidateThrottlingLevels checks that every throttling level has positive duration, delay and events count
+func ValidateThrottlingLevels(levels []ThrottlingLevel) error {
+	for i, level := range levels {
+		if level.Duration <= 0 {
+			return fmt.Errorf("throttling level #%d duration must be positive", i+1)
+		}
+		if level.Delay <= 0 {
+			return fmt.Errorf("throttling level #%d delay must be positive", i+1)
+		}
+		if level.Count <= 0 {
+			return fmt.Errorf("throttling level #%d count must be positive", i+1)
+		}
+	}
+	return nil
+}
+
+// EscalationData represents escalation step of subscription. If trigger or metric stays in ERROR or NODATA state
+// for OffsetInMinutes after event, escalation contacts are notified in addition to subscription contacts
+type EscalationData struct {
+	Contacts        []string `json:"contacts"`
+	OffsetInMinutes int64    `json:"offset_in_minutes"`
 }
 
 // PlottingData represents plotting settings
@@ -175,6 +276,59 @@ type ScheduledNotification struct {
 	Throttled bool              `json:"throttled"`
 	SendFail  int               `json:"send_fail"`
 	Timestamp int64             `json:"timestamp"`
+	// Escalation is a number of subscription escalation step starting with 1, 0 means that notification is not escalated
+	Escalation int `json:"escalation,omitempty"`
+}
+
+// FailedNotification represents notification which was not delivered until resending timeout passed
+type FailedNotification struct {
+	ID        string            `json:"id"`
+	Event     NotificationEvent `json:"event"`
+	Trigger   TriggerData       `json:"trigger"`
+	Contact   ContactData       `json:"contact"`
+	Plotting  PlottingData      `json:"plotting"`
+	Throttled bool              `json:"throttled"`
+	SendFail  int               `json:"send_fail"`
+	// Error is the last sending error
+	Error     string `json:"error"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// ToScheduledNotification returns notification to send failed notification again at given timestamp
+func (notification *FailedNotification) ToScheduledNotification(timestamp int64) *ScheduledNotification {
+	return &ScheduledNotification{
+		Event:     notification.Event,
+		Trigger:   notification.Trigger,
+		Contact:   notification.Contact,
+		Plotting:  notification.Plotting,
+		Throttled: notification.Throttled,
+		Timestamp: timestamp,
+	}
+}
+
+// DeliveryResult is a result of notification package sending
+type DeliveryResult string
+
+// DeliveryResult values
+const (
+	DeliverySent   DeliveryResult = "sent"
+	DeliveryFailed DeliveryResult = "failed"
+)
+
+// NotificationDelivery represents attempt to send notification package to contact
+type NotificationDelivery struct {
+	ContactID    string              `json:"contact_id"`
+	ContactType  string              `json:"contact_type"`
+	ContactValue string              `json:"contact_value"`
+	TriggerIDs   []string            `json:"trigger_ids"`
+	Events       []NotificationEvent `json:"events"`
+	Result       DeliveryResult      `json:"result"`
+	Error        string              `json:"error,omitempty"`
+	// Latency is a sending duration in milliseconds
+	Latency int64 `json:"latency"`
+	// Attempt is a number of sending attempt starting with 1
+	Attempt   int   `json:"attempt"`
+	Timestamp int64 `json:"timestamp"`
 }
 
 // MatchedMetric represents parsed and matched metric data
@@ -201,26 +355,112 @@ const (
 	RisingTrigger = "rising"
 	// ExpressionTrigger represents trigger type with custom user expression
 	ExpressionTrigger = "expression"
+	// AnomalyTrigger represents trigger type, in which WARN and ERROR values are numbers of standard deviations
+	// the metric value may deviate from its seasonal baseline
+	AnomalyTrigger = "anomaly"
 )
 
+// TriggerSource represents the metrics source which trigger targets are evaluated against
+type TriggerSource string
+
+const (
+	// TriggerSourceNotSet is used for triggers saved before trigger source was introduced
+	TriggerSourceNotSet TriggerSource = ""
+	// GraphiteLocal represents triggers with graphite targets evaluated by Moira itself on metrics stored in Redis
+	GraphiteLocal TriggerSource = "graphite_local"
+	// GraphiteRemote represents triggers with graphite targets fetched from remote graphite-web render API
+	GraphiteRemote TriggerSource = "graphite_remote"
+	// PrometheusRemote represents triggers with PromQL targets fetched from remote Prometheus query_range API
+	PrometheusRemote TriggerSource = "prometheus_remote"
+)
+
+// FillInIfNotSet resolves not set trigger source using deprecated is_remote trigger flag
+func (triggerSource TriggerSource) FillInIfNotSet(isRemote bool) TriggerSource {
+	if triggerSource != TriggerSourceNotSet {
+		return triggerSource
+	}
+	if isRemote {
+		return GraphiteRemote
+	}
+	return GraphiteLocal
+}
+
+// IsRemote returns true if trigger metrics are fetched from outside of Moira
+func (triggerSource TriggerSource) IsRemote() bool {
+	return triggerSource == GraphiteRemote || triggerSource == PrometheusRemote
+}
+
+// ClusterID represents the name of metrics source cluster, e.g. one of remote graphite clusters
+type ClusterID string
+
+// DefaultCluster is used for triggers which have no cluster set and for metrics sources configured without a name
+const DefaultCluster ClusterID = "default"
+
+// ClusterKey identifies the metrics source cluster that trigger targets are evaluated against
+type ClusterKey struct {
+	TriggerSource TriggerSource
+	ClusterID     ClusterID
+}
+
+var (
+	// DefaultLocalCluster is the key of local graphite metrics stored in Redis
+	DefaultLocalCluster = MakeClusterKey(GraphiteLocal, DefaultCluster)
+	// DefaultGraphiteRemoteCluster is the key of remote graphite cluster configured without a name
+	DefaultGraphiteRemoteCluster = MakeClusterKey(GraphiteRemote, DefaultCluster)
+	// DefaultPrometheusRemoteCluster is the key of remote prometheus storage
+	DefaultPrometheusRemoteCluster = MakeClusterKey(PrometheusRemote, DefaultCluster)
+)
+
+// MakeClusterKey creates cluster key, not set trigger source is resolved as local and empty cluster as default one
+func MakeClusterKey(triggerSource TriggerSource, clusterID ClusterID) ClusterKey {
+	if triggerSource == TriggerSourceNotSet {
+		triggerSource = GraphiteLocal
+	}
+	if clusterID == "" {
+		clusterID = DefaultCluster
+	}
+	return ClusterKey{
+		TriggerSource: triggerSource,
+		ClusterID:     clusterID,
+	}
+}
+
+// String returns cluster key representation, e.g. graphite_remote.default
+func (clusterKey ClusterKey) String() string {
+	return fmt.Sprintf("%s.%s", clusterKey.TriggerSource, clusterKey.ClusterID)
+}
+
 // Trigger represents trigger data object
 type Trigger struct {
-	ID               string        `json:"id"`
-	Name             string        `json:"name"`
-	Desc             *string       `json:"desc,omitempty"`
-	Targets          []string      `json:"targets"`
-	WarnValue        *float64      `json:"warn_value"`
-	ErrorValue       *float64      `json:"error_value"`
-	TriggerType      string        `json:"trigger_type"`
-	Tags             []string      `json:"tags"`
-	TTLState         *TTLState     `json:"ttl_state,omitempty"`
-	TTL              int64         `json:"ttl,omitempty"`
-	Schedule         *ScheduleData `json:"sched,omitempty"`
-	Expression       *string       `json:"expression,omitempty"`
-	PythonExpression *string       `json:"python_expression,omitempty"`
-	Patterns         []string      `json:"patterns"`
-	IsRemote         bool          `json:"is_remote"`
-	MuteNewMetrics   bool          `json:"mute_new_metrics"`
+	ID                 string           `json:"id"`
+	Name               string           `json:"name"`
+	Desc               *string          `json:"desc,omitempty"`
+	Targets            []string         `json:"targets"`
+	WarnValue          *float64         `json:"warn_value"`
+	ErrorValue         *float64         `json:"error_value"`
+	WarnRecoveryValue  *float64         `json:"warn_recovery_value,omitempty"`
+	ErrorRecoveryValue *float64         `json:"error_recovery_value,omitempty"`
+	PendingInterval    int64            `json:"pending_interval,omitempty"`
+	TriggerType        string           `json:"trigger_type"`
+	Tags               []string         `json:"tags"`
+	TTLState           *TTLState        `json:"ttl_state,omitempty"`
+	TTL                int64            `json:"ttl,omitempty"`
+	Schedule           *ScheduleData    `json:"sched,omitempty"`
+	Expression         *string          `json:"expression,omitempty"`
+	PythonExpression   *string          `json:"python_expression,omitempty"`
+	Patterns           []string         `json:"patterns"`
+	TriggerSource      TriggerSource    `json:"trigger_source,omitempty"`
+	ClusterID          ClusterID        `json:"cluster_id,omitempty"`
+	MuteNewMetrics     bool             `json:"mute_new_metrics"`
+	Anomaly            *AnomalySettings `json:"anomaly,omitempty"`
+	Dependencies       []string         `json:"dependencies,omitempty"`
+	Reminders          map[State]int64  `json:"reminders,omitempty"`
+	TeamID             string           `json:"team_id,omitempty"`
+}
+
+// ClusterKey returns the key of metrics source cluster used by trigger
+func (trigger *Trigger) ClusterKey() ClusterKey {
+	return MakeClusterKey(trigger.TriggerSource, trigger.ClusterID)
 }
 
 // TriggerCheck represents trigger data with last check data and check timestamp
@@ -250,18 +490,26 @@ type CheckData struct {
 	Suppressed                   bool                   `json:"suppressed,omitempty"`
 	SuppressedState              State                  `json:"suppressed_state,omitempty"`
 	Message                      string                 `json:"msg,omitempty"`
+	Acknowledgement              *Acknowledgement       `json:"acknowledgement,omitempty"`
+	SuppressedDependency         string                 `json:"suppressed_dependency,omitempty"`
+	ReminderCount                int64                  `json:"reminder_count,omitempty"`
 }
 
 // MetricState represents metric state data for given timestamp
 type MetricState struct {
-	EventTimestamp  int64           `json:"event_timestamp"`
-	State           State           `json:"state"`
-	Suppressed      bool            `json:"suppressed"`
-	SuppressedState State           `json:"suppressed_state,omitempty"`
-	Timestamp       int64           `json:"timestamp"`
-	Value           *float64        `json:"value,omitempty"`
-	Maintenance     int64           `json:"maintenance,omitempty"`
-	MaintenanceInfo MaintenanceInfo `json:"maintenance_info"`
+	EventTimestamp       int64            `json:"event_timestamp"`
+	State                State            `json:"state"`
+	Suppressed           bool             `json:"suppressed"`
+	SuppressedState      State            `json:"suppressed_state,omitempty"`
+	PendingState         State            `json:"pending_state,omitempty"`
+	PendingTimestamp     int64            `json:"pending_timestamp,omitempty"`
+	Timestamp            int64            `json:"timestamp"`
+	Value                *float64         `json:"value,omitempty"`
+	Maintenance          int64            `json:"maintenance,omitempty"`
+	MaintenanceInfo      MaintenanceInfo  `json:"maintenance_info"`
+	Acknowledgement      *Acknowledgement `json:"acknowledgement,omitempty"`
+	SuppressedDependency string           `json:"suppressed_dependency,omitempty"`
+	ReminderCount        int64            `json:"reminder_count,omitempty"`
 }
 
 // SetMaintenance set maintenance user, time for MetricState
@@ -476,6 +724,23 @@ func (checkData *CheckData) UpdateScore() int64 {
 	return checkData.Score
 }
 
+// IsEscalationContact checks that contact is used in one of subscription escalations
+func (subscription *SubscriptionData) IsEscalationContact(contactID string) bool {
+	for _, escalation := range subscription.Escalations {
+		for _, escalationContactID := range escalation.Contacts {
+			if escalationContactID == contactID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NeedEscalate checks that subscription escalations should be scheduled for given event
+func (subscription *SubscriptionData) NeedEscalate(event *NotificationEvent) bool {
+	return len(subscription.Escalations) > 0 && (event.State == StateERROR || event.State == StateNODATA)
+}
+
 // MustIgnore returns true if given state transition must be ignored
 func (subscription *SubscriptionData) MustIgnore(eventData *NotificationEvent) bool {
 	if oldStateWeight, ok := eventStateWeight[eventData.OldState]; ok {
@@ -152,6 +152,19 @@ func TestNotificationEvent_CreateMessage(t *testing.T) {
 			event := NotificationEvent{MessageEventInfo: &EventInfo{Interval: &interval}}
 			So(event.CreateMessage(nil), ShouldEqual, message)
 		})
+		Convey("Test: creating remind message with reminder count", func() {
+			message := "3rd reminder. This metric has been in bad state for more than 2 hours - please, fix."
+			var interval int64 = 2
+			var count int64 = 3
+			event := NotificationEvent{MessageEventInfo: &EventInfo{Interval: &interval, ReminderCount: &count}}
+			So(event.CreateMessage(nil), ShouldEqual, message)
+		})
+		Convey("Test: creating dependency message", func() {
+			message := "This metric changed its state while parent trigger parent-trigger-id was in bad state."
+			dependency := "parent-trigger-id"
+			event := NotificationEvent{MessageEventInfo: &EventInfo{Dependency: &dependency}}
+			So(event.CreateMessage(nil), ShouldEqual, message)
+		})
 		Convey("Test: check for void MaintenanceInfo", func() {
 			event := NotificationEvent{MessageEventInfo: &EventInfo{}}
 			So(event.CreateMessage(nil), ShouldEqual, "")
@@ -331,6 +344,48 @@ func TestTrigger_IsSimple(t *testing.T) {
 	})
 }
 
+func TestTriggerSource_FillInIfNotSet(t *testing.T) {
+	Convey("Trigger source is not set", t, func() {
+		So(TriggerSourceNotSet.FillInIfNotSet(false), ShouldEqual, GraphiteLocal)
+		So(TriggerSourceNotSet.FillInIfNotSet(true), ShouldEqual, GraphiteRemote)
+	})
+
+	Convey("Trigger source is set", t, func() {
+		So(PrometheusRemote.FillInIfNotSet(false), ShouldEqual, PrometheusRemote)
+		So(PrometheusRemote.FillInIfNotSet(true), ShouldEqual, PrometheusRemote)
+		So(GraphiteLocal.FillInIfNotSet(true), ShouldEqual, GraphiteLocal)
+	})
+
+	Convey("Is remote", t, func() {
+		So(GraphiteLocal.IsRemote(), ShouldBeFalse)
+		So(GraphiteRemote.IsRemote(), ShouldBeTrue)
+		So(PrometheusRemote.IsRemote(), ShouldBeTrue)
+	})
+}
+
+func TestMakeClusterKey(t *testing.T) {
+	Convey("Trigger source and cluster are not set", t, func() {
+		So(MakeClusterKey(TriggerSourceNotSet, ""), ShouldResemble, DefaultLocalCluster)
+	})
+
+	Convey("Cluster is not set", t, func() {
+		So(MakeClusterKey(GraphiteRemote, ""), ShouldResemble, DefaultGraphiteRemoteCluster)
+	})
+
+	Convey("Trigger source and cluster are set", t, func() {
+		clusterKey := MakeClusterKey(GraphiteRemote, "dc1")
+		So(clusterKey, ShouldResemble, ClusterKey{TriggerSource: GraphiteRemote, ClusterID: "dc1"})
+		So(clusterKey.String(), ShouldEqual, "graphite_remote.dc1")
+	})
+
+	Convey("Trigger cluster key", t, func() {
+		trigger := Trigger{TriggerSource: GraphiteRemote, ClusterID: "dc2"}
+		So(trigger.ClusterKey(), ShouldResemble, ClusterKey{TriggerSource: GraphiteRemote, ClusterID: "dc2"})
+		trigger = Trigger{}
+		So(trigger.ClusterKey(), ShouldResemble, DefaultLocalCluster)
+	})
+}
+
 func TestCheckData_GetEventTimestamp(t *testing.T) {
 	Convey("Get event timestamp", t, func() {
 		checkData := CheckData{Timestamp: 800, EventTimestamp: 0}
@@ -409,6 +464,30 @@ func getDefaultSchedule() ScheduleData {
 	}
 }
 
+func TestSubscriptionData_Escalations(t *testing.T) {
+	subscription := SubscriptionData{
+		Contacts:    []string{"first"},
+		Escalations: []EscalationData{{Contacts: []string{"second"}, OffsetInMinutes: 10}},
+	}
+
+	Convey("Escalation contacts", t, func() {
+		So(subscription.IsEscalationContact("second"), ShouldBeTrue)
+		So(subscription.IsEscalationContact("first"), ShouldBeFalse)
+	})
+
+	Convey("Escalations are needed for bad states only", t, func() {
+		So(subscription.NeedEscalate(&NotificationEvent{State: StateERROR}), ShouldBeTrue)
+		So(subscription.NeedEscalate(&NotificationEvent{State: StateNODATA}), ShouldBeTrue)
+		So(subscription.NeedEscalate(&NotificationEvent{State: StateWARN}), ShouldBeFalse)
+		So(subscription.NeedEscalate(&NotificationEvent{State: StateOK}), ShouldBeFalse)
+		So(subscription.NeedEscalate(&NotificationEvent{State: StateTEST}), ShouldBeFalse)
+	})
+
+	Convey("Subscription without escalations", t, func() {
+		So((&SubscriptionData{}).NeedEscalate(&NotificationEvent{State: StateERROR}), ShouldBeFalse)
+	})
+}
+
 func TestSubscriptionData_MustIgnore(testing *testing.T) {
 	type testCase struct {
 		State    State
@@ -684,3 +763,32 @@ func testMaintenance(conveyMessage string, actualInfo MaintenanceInfo, maintenan
 
 	})
 }
+
+func TestValidateThrottlingLevels(t *testing.T) {
+	Convey("Valid throttling levels", t, func() {
+		levels := []ThrottlingLevel{
+			{Duration: 10800, Delay: 3600, Count: 20},
+			{Duration: 3600, Delay: 1800, Count: 10},
+		}
+		So(ValidateThrottlingLevels(levels), ShouldBeNil)
+		So(ValidateThrottlingLevels(nil), ShouldBeNil)
+	})
+
+	Convey("Throttling level without duration", t, func() {
+		levels := []ThrottlingLevel{{Delay: 3600, Count: 20}}
+		So(ValidateThrottlingLevels(levels), ShouldResemble, fmt.Errorf("throttling level #1 duration must be positive"))
+	})
+
+	Convey("Throttling level with negative delay", t, func() {
+		levels := []ThrottlingLevel{
+			{Duration: 10800, Delay: 3600, Count: 20},
+			{Duration: 3600, Delay: -1, Count: 10},
+		}
+		So(ValidateThrottlingLevels(levels), ShouldResemble, fmt.Errorf("throttling level #2 delay must be positive"))
+	})
+
+	Convey("Throttling level without count", t, func() {
+		levels := []ThrottlingLevel{{Duration: 3600, Delay: 1800}}
+		So(ValidateThrottlingLevels(levels), ShouldResemble, fmt.Errorf("throttling level #1 count must be positive"))
+	})
+}
@@ -0,0 +1,19 @@
+package moira
+
+// IsBadState checks that state should suppress notifications about dependent triggers
+func IsBadState(state State) bool {
+	return state != "" && state != StateOK && state != StateTEST
+}
+
+// HasBadState checks that trigger itself or any of its metrics is in bad state
+func (checkData *CheckData) HasBadState() bool {
+	if IsBadState(checkData.State) {
+		return true
+	}
+	for _, metricState := range checkData.Metrics {
+		if IsBadState(metricState.State) {
+			return true
+		}
+	}
+	return false
+}
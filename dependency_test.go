@@ -0,0 +1,28 @@
+package moira
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCheckDataHasBadState(t *testing.T) {
+	Convey("Check data without bad states", t, func() {
+		So((&CheckData{}).HasBadState(), ShouldBeFalse)
+		So((&CheckData{State: StateOK, Metrics: map[string]MetricState{"metric": {State: StateOK}}}).HasBadState(), ShouldBeFalse)
+		So((&CheckData{State: StateTEST}).HasBadState(), ShouldBeFalse)
+	})
+
+	Convey("Trigger in bad state", t, func() {
+		So((&CheckData{State: StateNODATA}).HasBadState(), ShouldBeTrue)
+		So((&CheckData{State: StateEXCEPTION}).HasBadState(), ShouldBeTrue)
+	})
+
+	Convey("Metric in bad state", t, func() {
+		checkData := CheckData{State: StateOK, Metrics: map[string]MetricState{
+			"first":  {State: StateOK},
+			"second": {State: StateERROR},
+		}}
+		So(checkData.HasBadState(), ShouldBeTrue)
+	})
+}
@@ -0,0 +1,17 @@
+package moira
+
+// GroupByTrigger splits events by trigger ID, groups are ordered by first event of trigger
+func (events NotificationEvents) GroupByTrigger() []NotificationEvents {
+	groups := make([]NotificationEvents, 0)
+	groupIndexes := make(map[string]int)
+	for _, event := range events {
+		index, ok := groupIndexes[event.TriggerID]
+		if !ok {
+			index = len(groups)
+			groupIndexes[event.TriggerID] = index
+			groups = append(groups, make(NotificationEvents, 0))
+		}
+		groups[index] = append(groups[index], event)
+	}
+	return groups
+}
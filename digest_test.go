@@ -0,0 +1,25 @@
+package moira
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGroupByTrigger(t *testing.T) {
+	Convey("Events are grouped by trigger in order of first trigger event", t, func() {
+		events := NotificationEvents{
+			{TriggerID: "second", Metric: "m1"},
+			{TriggerID: "first", Metric: "m2"},
+			{TriggerID: "second", Metric: "m3"},
+		}
+		So(events.GroupByTrigger(), ShouldResemble, []NotificationEvents{
+			{{TriggerID: "second", Metric: "m1"}, {TriggerID: "second", Metric: "m3"}},
+			{{TriggerID: "first", Metric: "m2"}},
+		})
+	})
+
+	Convey("No events", t, func() {
+		So(NotificationEvents{}.GroupByTrigger(), ShouldBeEmpty)
+	})
+}
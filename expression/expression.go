@@ -16,6 +16,9 @@ var exprWarnRising, _ = govaluate.NewEvaluableExpression("t1 >= WARN_VALUE ? WAR
 var exprErrRising, _ = govaluate.NewEvaluableExpression("t1 >= ERROR_VALUE ? ERROR : OK")
 var exprWarnFalling, _ = govaluate.NewEvaluableExpression("t1 <= WARN_VALUE ? WARN : OK")
 var exprErrFalling, _ = govaluate.NewEvaluableExpression("t1 <= ERROR_VALUE ? ERROR : OK")
+var exprWarnErrorAnomaly, _ = govaluate.NewEvaluableExpression("DEVIATION >= ERROR_VALUE ? ERROR : (DEVIATION >= WARN_VALUE ? WARN : OK)")
+var exprWarnAnomaly, _ = govaluate.NewEvaluableExpression("DEVIATION >= WARN_VALUE ? WARN : OK")
+var exprErrAnomaly, _ = govaluate.NewEvaluableExpression("DEVIATION >= ERROR_VALUE ? ERROR : OK")
 
 var exprCache = cache.New(cache.NoExpiration, cache.NoExpiration)
 
@@ -32,13 +35,19 @@ func (err ErrInvalidExpression) Error() string {
 type TriggerExpression struct {
 	Expression *string
 
-	WarnValue   *float64
-	ErrorValue  *float64
-	TriggerType string
+	WarnValue          *float64
+	ErrorValue         *float64
+	WarnRecoveryValue  *float64
+	ErrorRecoveryValue *float64
+	TriggerType        string
 
 	MainTargetValue         float64
 	AdditionalTargetsValues map[string]float64
 	PreviousState           moira.State
+
+	// Deviation is an absolute number of standard deviations main target value deviates from its baseline,
+	// used by anomaly triggers
+	Deviation float64
 }
 
 // Get realizing govaluate.Parameters interface used in evaluable expression
@@ -66,6 +75,8 @@ func (triggerExpression TriggerExpression) Get(name string) (interface{}, error)
 		return triggerExpression.MainTargetValue, nil
 	case "PREV_STATE":
 		return triggerExpression.PreviousState, nil
+	case "DEVIATION":
+		return triggerExpression.Deviation, nil
 	default:
 		value, ok := triggerExpression.AdditionalTargetsValues[name]
 		if !ok {
@@ -87,12 +98,37 @@ func (triggerExpression *TriggerExpression) Evaluate() (moira.State, error) {
 	}
 	switch res := result.(type) {
 	case moira.State:
-		return res, nil
+		return triggerExpression.applyRecoveryValues(res), nil
 	default:
 		return "", ErrInvalidExpression{internalError: fmt.Errorf("expression result must be state value")}
 	}
 }
 
+// applyRecoveryValues keeps previous WARN or ERROR state of rising and falling triggers
+// until main target value crosses corresponding recovery value
+func (triggerExpression *TriggerExpression) applyRecoveryValues(state moira.State) moira.State {
+	var isNotRecovered func(value, recoveryValue float64) bool
+	switch triggerExpression.TriggerType {
+	case moira.RisingTrigger:
+		isNotRecovered = func(value, recoveryValue float64) bool { return value > recoveryValue }
+	case moira.FallingTrigger:
+		isNotRecovered = func(value, recoveryValue float64) bool { return value < recoveryValue }
+	default:
+		return state
+	}
+	value := triggerExpression.MainTargetValue
+	previousState := triggerExpression.PreviousState
+	if previousState == moira.StateERROR && state != moira.StateERROR &&
+		triggerExpression.ErrorRecoveryValue != nil && isNotRecovered(value, *triggerExpression.ErrorRecoveryValue) {
+		return moira.StateERROR
+	}
+	if (previousState == moira.StateERROR || previousState == moira.StateWARN) && state == moira.StateOK &&
+		triggerExpression.WarnRecoveryValue != nil && isNotRecovered(value, *triggerExpression.WarnRecoveryValue) {
+		return moira.StateWARN
+	}
+	return state
+}
+
 func getExpression(triggerExpression *TriggerExpression) (*govaluate.EvaluableExpression, error) {
 	if triggerExpression.TriggerType == moira.ExpressionTrigger {
 		if triggerExpression.Expression == nil || *triggerExpression.Expression == "" {
@@ -126,6 +162,14 @@ func getSimpleExpression(triggerExpression *TriggerExpression) (*govaluate.Evalu
 		} else {
 			return exprWarnRising, nil
 		}
+	case moira.AnomalyTrigger:
+		if triggerExpression.ErrorValue != nil && triggerExpression.WarnValue != nil {
+			return exprWarnErrorAnomaly, nil
+		} else if triggerExpression.ErrorValue != nil {
+			return exprErrAnomaly, nil
+		} else {
+			return exprWarnAnomaly, nil
+		}
 	}
 	return nil, fmt.Errorf("wrong set of parametres: warn_value - %v, error_value - %v, trigger_type: %v",
 		triggerExpression.WarnValue, triggerExpression.ErrorValue, triggerExpression.TriggerType)
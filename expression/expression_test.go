@@ -87,6 +87,86 @@ func TestExpression(t *testing.T) {
 		So(err, ShouldBeNil)
 		So(result, ShouldResemble, moira.StateNODATA)
 	})
+
+	Convey("Test Anomaly", t, func() {
+		warnValue := 2.0
+		errorValue := 3.0
+		result, err := (&TriggerExpression{MainTargetValue: 100.0, Deviation: 1.5, WarnValue: &warnValue, ErrorValue: &errorValue, TriggerType: moira.AnomalyTrigger}).Evaluate()
+		So(err, ShouldBeNil)
+		So(result, ShouldResemble, moira.StateOK)
+
+		result, err = (&TriggerExpression{MainTargetValue: 100.0, Deviation: 2.5, WarnValue: &warnValue, ErrorValue: &errorValue, TriggerType: moira.AnomalyTrigger}).Evaluate()
+		So(err, ShouldBeNil)
+		So(result, ShouldResemble, moira.StateWARN)
+
+		result, err = (&TriggerExpression{MainTargetValue: 100.0, Deviation: 3.0, WarnValue: &warnValue, ErrorValue: &errorValue, TriggerType: moira.AnomalyTrigger}).Evaluate()
+		So(err, ShouldBeNil)
+		So(result, ShouldResemble, moira.StateERROR)
+
+		result, err = (&TriggerExpression{MainTargetValue: 100.0, Deviation: 2.5, WarnValue: &warnValue, TriggerType: moira.AnomalyTrigger}).Evaluate()
+		So(err, ShouldBeNil)
+		So(result, ShouldResemble, moira.StateWARN)
+
+		result, err = (&TriggerExpression{MainTargetValue: 100.0, Deviation: 2.5, ErrorValue: &errorValue, TriggerType: moira.AnomalyTrigger}).Evaluate()
+		So(err, ShouldBeNil)
+		So(result, ShouldResemble, moira.StateOK)
+	})
+
+	Convey("Test Recovery Values", t, func() {
+		warnValue, warnRecoveryValue := 60.0, 50.0
+		errorValue, errorRecoveryValue := 90.0, 80.0
+		rising := TriggerExpression{WarnValue: &warnValue, ErrorValue: &errorValue, WarnRecoveryValue: &warnRecoveryValue, ErrorRecoveryValue: &errorRecoveryValue, TriggerType: moira.RisingTrigger}
+
+		Convey("Rising trigger keeps ERROR state until value is less than error recovery value", func() {
+			rising.PreviousState = moira.StateERROR
+			rising.MainTargetValue = 85.0
+			result, err := rising.Evaluate()
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, moira.StateERROR)
+
+			rising.MainTargetValue = 70.0
+			result, err = rising.Evaluate()
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, moira.StateWARN)
+
+			rising.MainTargetValue = 55.0
+			result, err = rising.Evaluate()
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, moira.StateWARN)
+
+			rising.MainTargetValue = 40.0
+			result, err = rising.Evaluate()
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, moira.StateOK)
+		})
+
+		Convey("Rising trigger does not use recovery values if previous state is OK", func() {
+			rising.PreviousState = moira.StateOK
+			rising.MainTargetValue = 85.0
+			result, err := rising.Evaluate()
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, moira.StateWARN)
+
+			rising.MainTargetValue = 55.0
+			result, err = rising.Evaluate()
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, moira.StateOK)
+		})
+
+		Convey("Falling trigger keeps WARN state until value is greater than warn recovery value", func() {
+			warnValue, warnRecoveryValue := 30.0, 40.0
+			falling := TriggerExpression{WarnValue: &warnValue, WarnRecoveryValue: &warnRecoveryValue, TriggerType: moira.FallingTrigger, PreviousState: moira.StateWARN}
+			falling.MainTargetValue = 35.0
+			result, err := falling.Evaluate()
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, moira.StateWARN)
+
+			falling.MainTargetValue = 45.0
+			result, err = falling.Evaluate()
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, moira.StateOK)
+		})
+	})
 }
 
 func TestGetExpressionValue(t *testing.T) {
@@ -0,0 +1,95 @@
+package prometheus
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/moira-alert/moira"
+)
+
+const (
+	// TextFormatPath accepts metrics in prometheus text exposition format
+	TextFormatPath = "/api/v1/import/prometheus"
+	// RemoteWritePath accepts prometheus remote write requests
+	RemoteWritePath = "/api/v1/write"
+
+	shutdownTimeout = 10 * time.Second
+	// maxRequestBodySize limits size of request body, remote write requests are limited before decompression
+	maxRequestBodySize = 32 * 1024 * 1024
+)
+
+// Listener accepts prometheus metrics over HTTP and sends them to filter lines channel as graphite plaintext lines
+type Listener struct {
+	logger moira.Logger
+	server *http.Server
+}
+
+// NewListener creates new prometheus metrics listener
+func NewListener(listen string, logger moira.Logger) *Listener {
+	return &Listener{
+		logger: logger,
+		server: &http.Server{Addr: listen},
+	}
+}
+
+// Listen starts HTTP server, all converted metrics are sent to lineChan
+func (listener *Listener) Listen(lineChan chan<- []byte) error {
+	netListener, err := net.Listen("tcp", listener.server.Addr)
+	if err != nil {
+		return err
+	}
+	listener.server.Handler = listener.handler(lineChan)
+	go func() {
+		if err := listener.server.Serve(netListener); err != nil && err != http.ErrServerClosed {
+			listener.logger.Errorf("Prometheus listener failed: %s", err.Error())
+		}
+	}()
+	listener.logger.Infof("Moira Filter Prometheus Listener started on %s", listener.server.Addr)
+	return nil
+}
+
+// Stop stops accepting metrics and waits for handling of current requests
+func (listener *Listener) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return listener.server.Shutdown(ctx)
+}
+
+func (listener *Listener) handler(lineChan chan<- []byte) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(TextFormatPath, listener.handle(lineChan, func(request *http.Request) ([][]byte, error) {
+		return ParseTextFormat(request.Body, time.Now().Unix())
+	}))
+	mux.HandleFunc(RemoteWritePath, listener.handle(lineChan, func(request *http.Request) ([][]byte, error) {
+		body, err := ioutil.ReadAll(request.Body)
+		if err != nil {
+			return nil, err
+		}
+		return ParseRemoteWrite(body)
+	}))
+	return mux
+}
+
+// handle parses metrics from request with given parse func and sends them to lineChan
+func (listener *Listener) handle(lineChan chan<- []byte, parse func(request *http.Request) ([][]byte, error)) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost && request.Method != http.MethodPut {
+			http.Error(writer, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		request.Body = http.MaxBytesReader(writer, request.Body, maxRequestBodySize)
+		lines, err := parse(request)
+		if err != nil {
+			listener.logger.Infof("Cannot parse prometheus metrics from %s: %s", request.RemoteAddr, err.Error())
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, line := range lines {
+			lineChan <- line
+		}
+		writer.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,75 @@
+package prometheus
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/moira-alert/moira/logging/go-logging"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestListenerHandler(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "info", "test")
+	listener := NewListener(":0", logger)
+	lineChan := make(chan []byte, 10)
+	handler := listener.handler(lineChan)
+
+	Convey("Prometheus listener handler", t, func() {
+		Convey("Text format metrics are sent to lines channel", func() {
+			request := httptest.NewRequest(http.MethodPost, TextFormatPath, strings.NewReader("metric{label=\"value\"} 1 1500000000000\n"))
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, request)
+			So(response.Code, ShouldEqual, http.StatusNoContent)
+			So(lineChan, ShouldHaveLength, 1)
+			So(string(<-lineChan), ShouldEqual, "metric;label=value 1 1500000000")
+		})
+
+		Convey("Remote write metrics are sent to lines channel", func() {
+			data, _ := proto.Marshal(&WriteRequest{Timeseries: []*TimeSeries{
+				{Labels: []*Label{{Name: "__name__", Value: "metric"}}, Samples: []*Sample{{Value: 2, Timestamp: 1500000000000}}},
+			}})
+			request := httptest.NewRequest(http.MethodPost, RemoteWritePath, bytes.NewReader(snappy.Encode(nil, data)))
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, request)
+			So(response.Code, ShouldEqual, http.StatusNoContent)
+			So(lineChan, ShouldHaveLength, 1)
+			So(string(<-lineChan), ShouldEqual, "metric 2 1500000000")
+		})
+
+		Convey("Invalid metrics are rejected", func() {
+			request := httptest.NewRequest(http.MethodPost, TextFormatPath, strings.NewReader("metric{label=\"value\" 1\n"))
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, request)
+			So(response.Code, ShouldEqual, http.StatusBadRequest)
+			So(lineChan, ShouldBeEmpty)
+		})
+
+		Convey("Non-ASCII labels are rejected", func() {
+			request := httptest.NewRequest(http.MethodPost, TextFormatPath, strings.NewReader("metric{label=\"значение\"} 1\n"))
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, request)
+			So(response.Code, ShouldEqual, http.StatusBadRequest)
+			So(lineChan, ShouldBeEmpty)
+		})
+
+		Convey("Too large requests are rejected", func() {
+			request := httptest.NewRequest(http.MethodPost, RemoteWritePath, bytes.NewReader(make([]byte, maxRequestBodySize+1)))
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, request)
+			So(response.Code, ShouldEqual, http.StatusBadRequest)
+			So(lineChan, ShouldBeEmpty)
+		})
+
+		Convey("Only POST and PUT requests are accepted", func() {
+			request := httptest.NewRequest(http.MethodGet, TextFormatPath, nil)
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, request)
+			So(response.Code, ShouldEqual, http.StatusMethodNotAllowed)
+		})
+	})
+}
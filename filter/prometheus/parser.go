@@ -0,0 +1,196 @@
+package prometheus
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/moira-alert/moira"
+)
+
+const (
+	metricNameLabel = "__name__"
+	// maxDecodedRemoteWriteSize limits size of decompressed remote write request
+	maxDecodedRemoteWriteSize = 128 * 1024 * 1024
+)
+
+var labelValueReplacer = strings.NewReplacer(" ", "_", ";", "_")
+
+// ParseTextFormat converts metrics in prometheus text exposition format to graphite plaintext lines
+// "<name>;<label>=<value> <value> <timestamp>" which are handled by filter as any other incoming metrics.
+// Samples without timestamp get the given one, summaries and histograms are split to
+// <name>{quantile}, <name>_bucket{le}, <name>_sum and <name>_count series like prometheus does
+func ParseTextFormat(input io.Reader, timestamp int64) ([][]byte, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(input)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse prometheus text format: %s", err.Error())
+	}
+	lines := make([][]byte, 0, len(families))
+	for name, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := getLabels(metric.GetLabel())
+			if err := checkLabels(name, labels); err != nil {
+				return nil, err
+			}
+			metricTimestamp := timestamp
+			if metric.TimestampMs != nil {
+				metricTimestamp = metric.GetTimestampMs() / 1000
+			}
+			add := func(name string, labels map[string]string, value float64) {
+				if line := formatLine(name, labels, value, metricTimestamp); line != nil {
+					lines = append(lines, line)
+				}
+			}
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				add(name, labels, metric.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add(name, labels, metric.GetGauge().GetValue())
+			case dto.MetricType_SUMMARY:
+				summary := metric.GetSummary()
+				for _, quantile := range summary.GetQuantile() {
+					add(name, withLabel(labels, "quantile", formatFloat(quantile.GetQuantile())), quantile.GetValue())
+				}
+				add(name+"_sum", labels, summary.GetSampleSum())
+				add(name+"_count", labels, float64(summary.GetSampleCount()))
+			case dto.MetricType_HISTOGRAM:
+				histogram := metric.GetHistogram()
+				for _, bucket := range histogram.GetBucket() {
+					add(name+"_bucket", withLabel(labels, "le", formatFloat(bucket.GetUpperBound())), float64(bucket.GetCumulativeCount()))
+				}
+				add(name+"_sum", labels, histogram.GetSampleSum())
+				add(name+"_count", labels, float64(histogram.GetSampleCount()))
+			default:
+				add(name, labels, metric.GetUntyped().GetValue())
+			}
+		}
+	}
+	return lines, nil
+}
+
+// ParseRemoteWrite converts snappy compressed prometheus remote write request to graphite plaintext lines
+func ParseRemoteWrite(input []byte) ([][]byte, error) {
+	decodedLen, err := snappy.DecodedLen(input)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decompress remote write request: %s", err.Error())
+	}
+	if decodedLen > maxDecodedRemoteWriteSize {
+		return nil, fmt.Errorf("decompressed remote write request size %d exceeds limit of %d bytes", decodedLen, maxDecodedRemoteWriteSize)
+	}
+	decoded, err := snappy.Decode(nil, input)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decompress remote write request: %s", err.Error())
+	}
+	var request WriteRequest
+	if err := proto.Unmarshal(decoded, &request); err != nil {
+		return nil, fmt.Errorf("cannot parse remote write request: %s", err.Error())
+	}
+	lines := make([][]byte, 0, len(request.Timeseries))
+	for _, timeSeries := range request.Timeseries {
+		var name string
+		labels := make(map[string]string, len(timeSeries.Labels))
+		for _, label := range timeSeries.Labels {
+			if label.Name == metricNameLabel {
+				name = label.Value
+				continue
+			}
+			if label.Value != "" {
+				labels[label.Name] = label.Value
+			}
+		}
+		if name == "" {
+			continue
+		}
+		if err := checkLabels(name, labels); err != nil {
+			return nil, err
+		}
+		for _, sample := range timeSeries.Samples {
+			if line := formatLine(name, labels, sample.Value, sample.Timestamp/1000); line != nil {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines, nil
+}
+
+// formatLine returns graphite plaintext line with labels sorted by name or nil if value is NaN or Inf.
+// Spaces and semicolons in label values are replaced with underscores
+func formatLine(name string, labels map[string]string, value float64, timestamp int64) []byte {
+	if !moira.IsValidFloat64(value) {
+		return nil
+	}
+	labelNames := make([]string, 0, len(labels))
+	for labelName := range labels {
+		labelNames = append(labelNames, labelName)
+	}
+	sort.Strings(labelNames)
+
+	var buffer bytes.Buffer
+	buffer.WriteString(name)
+	for _, labelName := range labelNames {
+		buffer.WriteString(";")
+		buffer.WriteString(labelName)
+		buffer.WriteString("=")
+		buffer.WriteString(labelValueReplacer.Replace(labels[labelName]))
+	}
+	buffer.WriteString(" ")
+	buffer.WriteString(formatFloat(value))
+	buffer.WriteString(" ")
+	buffer.WriteString(strconv.FormatInt(timestamp, 10))
+	return buffer.Bytes()
+}
+
+// checkLabels checks that metric name, label names and values contain printable ASCII characters only,
+// otherwise metric would be dropped by filter
+func checkLabels(name string, labels map[string]string) error {
+	if !isPrintableASCII(name) {
+		return fmt.Errorf("metric name %q contains non-printable or non-ASCII characters", name)
+	}
+	for labelName, labelValue := range labels {
+		if !isPrintableASCII(labelName) || !isPrintableASCII(labelValue) {
+			return fmt.Errorf("label %q=%q of metric %s contains non-printable or non-ASCII characters", labelName, labelValue, name)
+		}
+	}
+	return nil
+}
+
+func isPrintableASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] > 0x7E {
+			return false
+		}
+	}
+	return true
+}
+
+func getLabels(labelPairs []*dto.LabelPair) map[string]string {
+	labels := make(map[string]string, len(labelPairs))
+	for _, labelPair := range labelPairs {
+		if labelPair.GetValue() != "" {
+			labels[labelPair.GetName()] = labelPair.GetValue()
+		}
+	}
+	return labels
+}
+
+func withLabel(labels map[string]string, name, value string) map[string]string {
+	result := make(map[string]string, len(labels)+1)
+	for labelName, labelValue := range labels {
+		result[labelName] = labelValue
+	}
+	result[name] = value
+	return result
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,162 @@
+package prometheus
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/moira-alert/moira/filter"
+)
+
+func TestParseTextFormat(t *testing.T) {
+	Convey("Parse prometheus text format", t, func() {
+		Convey("Counters, gauges and untyped metrics", func() {
+			input := `# HELP http_requests_total The total number of HTTP requests.
+# TYPE http_requests_total counter
+http_requests_total{method="post",code="200"} 1027 1395066363000
+http_requests_total{method="post",code="400"} 3 1395066363000
+# TYPE temperature gauge
+temperature{room="server room"} 21.5
+untyped_metric 42
+`
+			lines, err := ParseTextFormat(strings.NewReader(input), 1500000000)
+			So(err, ShouldBeNil)
+			So(toStrings(lines), ShouldHaveLength, 4)
+			So(toStrings(lines), ShouldContain, "http_requests_total;code=200;method=post 1027 1395066363")
+			So(toStrings(lines), ShouldContain, "http_requests_total;code=400;method=post 3 1395066363")
+			So(toStrings(lines), ShouldContain, "temperature;room=server_room 21.5 1500000000")
+			So(toStrings(lines), ShouldContain, "untyped_metric 42 1500000000")
+		})
+
+		Convey("Summaries and histograms", func() {
+			input := `# TYPE rpc_duration_seconds summary
+rpc_duration_seconds{quantile="0.5"} 4773
+rpc_duration_seconds_sum 1.7560473e+07
+rpc_duration_seconds_count 2693
+# TYPE request_duration_seconds histogram
+request_duration_seconds_bucket{le="0.1"} 33444
+request_duration_seconds_bucket{le="+Inf"} 144320
+request_duration_seconds_sum 53423
+request_duration_seconds_count 144320
+`
+			lines, err := ParseTextFormat(strings.NewReader(input), 1500000000)
+			So(err, ShouldBeNil)
+			So(toStrings(lines), ShouldHaveLength, 7)
+			So(toStrings(lines), ShouldContain, "rpc_duration_seconds;quantile=0.5 4773 1500000000")
+			So(toStrings(lines), ShouldContain, "request_duration_seconds_bucket;le=+Inf 144320 1500000000")
+			So(toStrings(lines), ShouldContain, "request_duration_seconds_count 144320 1500000000")
+			So(toStrings(lines), ShouldContain, "rpc_duration_seconds_sum 17560473 1500000000")
+		})
+
+		Convey("NaN values are skipped", func() {
+			lines, err := ParseTextFormat(strings.NewReader("metric NaN\n"), 1500000000)
+			So(err, ShouldBeNil)
+			So(lines, ShouldBeEmpty)
+		})
+
+		Convey("Invalid input", func() {
+			_, err := ParseTextFormat(strings.NewReader("metric{label=\"value\" 1\n"), 1500000000)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Non-ASCII label value", func() {
+			_, err := ParseTextFormat(strings.NewReader("metric{label=\"значение\"} 1\n"), 1500000000)
+			So(err, ShouldResemble, fmt.Errorf(`label "label"="значение" of metric metric contains non-printable or non-ASCII characters`))
+		})
+
+		Convey("Lines are parsed by filter to the same name and labels", func() {
+			lines, err := ParseTextFormat(strings.NewReader(`metric{first="1",second="2"} 10`+"\n"), 1500000000)
+			So(err, ShouldBeNil)
+			So(lines, ShouldHaveLength, 1)
+			parsedMetric, err := filter.ParseMetric(lines[0])
+			So(err, ShouldBeNil)
+			So(parsedMetric, ShouldResemble, &filter.ParsedMetric{
+				Metric:    "metric;first=1;second=2",
+				Name:      "metric",
+				Labels:    map[string]string{"first": "1", "second": "2"},
+				Value:     10,
+				Timestamp: 1500000000,
+			})
+		})
+	})
+}
+
+func TestParseRemoteWrite(t *testing.T) {
+	Convey("Parse prometheus remote write request", t, func() {
+		Convey("Valid request", func() {
+			request := &WriteRequest{
+				Timeseries: []*TimeSeries{
+					{
+						Labels: []*Label{
+							{Name: "__name__", Value: "up"},
+							{Name: "job", Value: "node"},
+							{Name: "instance", Value: "host:9100"},
+							{Name: "empty", Value: ""},
+						},
+						Samples: []*Sample{
+							{Value: 1, Timestamp: 1500000000123},
+							{Value: math.NaN(), Timestamp: 1500000060000},
+							{Value: 0, Timestamp: 1500000120000},
+						},
+					},
+					{
+						Labels:  []*Label{{Name: "job", Value: "no name"}},
+						Samples: []*Sample{{Value: 1, Timestamp: 1500000000000}},
+					},
+				},
+			}
+			data, err := proto.Marshal(request)
+			So(err, ShouldBeNil)
+
+			lines, err := ParseRemoteWrite(snappy.Encode(nil, data))
+			So(err, ShouldBeNil)
+			So(toStrings(lines), ShouldResemble, []string{
+				"up;instance=host:9100;job=node 1 1500000000",
+				"up;instance=host:9100;job=node 0 1500000120",
+			})
+		})
+
+		Convey("Not compressed request", func() {
+			_, err := ParseRemoteWrite([]byte("up 1"))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Invalid protobuf", func() {
+			_, err := ParseRemoteWrite(snappy.Encode(nil, []byte{0xff, 0xff}))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Too large decompressed request", func() {
+			header := make([]byte, binary.MaxVarintLen64)
+			n := binary.PutUvarint(header, maxDecodedRemoteWriteSize+1)
+			_, err := ParseRemoteWrite(append(header[:n], 0, 0, 0))
+			So(err, ShouldResemble, fmt.Errorf("decompressed remote write request size %d exceeds limit of %d bytes", maxDecodedRemoteWriteSize+1, maxDecodedRemoteWriteSize))
+		})
+
+		Convey("Non-ASCII label value", func() {
+			data, err := proto.Marshal(&WriteRequest{Timeseries: []*TimeSeries{
+				{
+					Labels:  []*Label{{Name: "__name__", Value: "up"}, {Name: "city", Value: "Москва"}},
+					Samples: []*Sample{{Value: 1, Timestamp: 1500000000000}},
+				},
+			}})
+			So(err, ShouldBeNil)
+			_, err = ParseRemoteWrite(snappy.Encode(nil, data))
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func toStrings(lines [][]byte) []string {
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		result = append(result, string(line))
+	}
+	return result
+}
@@ -0,0 +1,45 @@
+package prometheus
+
+import (
+	"github.com/golang/protobuf/proto"
+)
+
+// WriteRequest is a prometheus remote write request, see prometheus/prompb/remote.proto.
+// Only fields used by filter are declared, unknown fields are skipped while unmarshalling
+type WriteRequest struct {
+	Timeseries []*TimeSeries `protobuf:"bytes,1,rep,name=timeseries,proto3"`
+}
+
+// TimeSeries is a prometheus remote write time series, see prometheus/prompb/types.proto
+type TimeSeries struct {
+	Labels  []*Label  `protobuf:"bytes,1,rep,name=labels,proto3"`
+	Samples []*Sample `protobuf:"bytes,2,rep,name=samples,proto3"`
+}
+
+// Label is a prometheus remote write time series label, metric name is passed as __name__ label
+type Label struct {
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3"`
+}
+
+// Sample is a prometheus remote write time series sample, timestamp is in milliseconds
+type Sample struct {
+	Value     float64 `protobuf:"fixed64,1,opt,name=value,proto3"`
+	Timestamp int64   `protobuf:"varint,2,opt,name=timestamp,proto3"`
+}
+
+func (m *WriteRequest) Reset()         { *m = WriteRequest{} }
+func (m *WriteRequest) String() string { return proto.CompactTextString(m) }
+func (*WriteRequest) ProtoMessage()    {}
+
+func (m *TimeSeries) Reset()         { *m = TimeSeries{} }
+func (m *TimeSeries) String() string { return proto.CompactTextString(m) }
+func (*TimeSeries) ProtoMessage()    {}
+
+func (m *Label) Reset()         { *m = Label{} }
+func (m *Label) String() string { return proto.CompactTextString(m) }
+func (*Label) ProtoMessage()    {}
+
+func (m *Sample) Reset()         { *m = Sample{} }
+func (m *Sample) String() string { return proto.CompactTextString(m) }
+func (*Sample) ProtoMessage()    {}
@@ -13,7 +13,8 @@ type Database interface {
 	UpdateMetricsHeartbeat() error
 	GetMetricsUpdatesCount() (int64, error)
 	GetChecksUpdatesCount() (int64, error)
-	GetRemoteChecksUpdatesCount() (int64, error)
+	GetRemoteChecksUpdatesCount(clusterID ClusterID) (int64, error)
+	GetPrometheusChecksUpdatesCount() (int64, error)
 	GetNotifierState() (string, error)
 	SetNotifierState(string) error
 
@@ -24,20 +25,23 @@ type Database interface {
 
 	// LastCheck storing
 	GetTriggerLastCheck(triggerID string) (CheckData, error)
-	SetTriggerLastCheck(triggerID string, checkData *CheckData, isRemote bool) error
+	SetTriggerLastCheck(triggerID string, checkData *CheckData, clusterKey ClusterKey) error
 	RemoveTriggerLastCheck(triggerID string) error
 	SetTriggerCheckMaintenance(triggerID string, metrics map[string]int64, triggerMaintenance *int64, userLogin string, timeCallMaintenance int64) error
+	SetTriggerCheckAcknowledgement(triggerID string, metrics []string, ack *Acknowledgement) error
 
 	// Trigger storing
 	GetLocalTriggerIDs() ([]string, error)
 	GetAllTriggerIDs() ([]string, error)
-	GetRemoteTriggerIDs() ([]string, error)
+	GetRemoteTriggerIDs(clusterID ClusterID) ([]string, error)
+	GetPrometheusTriggerIDs() ([]string, error)
 	GetTrigger(triggerID string) (Trigger, error)
 	GetTriggers(triggerIDs []string) ([]*Trigger, error)
 	GetTriggerChecks(triggerIDs []string) ([]*TriggerCheck, error)
 	SaveTrigger(triggerID string, trigger *Trigger) error
 	RemoveTrigger(triggerID string) error
 	GetPatternTriggerIDs(pattern string) ([]string, error)
+	GetTriggerDependents(triggerID string) ([]string, error)
 	RemovePatternTriggerIDs(pattern string) error
 
 	// SearchResult storing
@@ -46,7 +50,8 @@ type Database interface {
 
 	// Throttling
 	GetTriggerThrottling(triggerID string) (time.Time, time.Time)
-	SetTriggerThrottling(triggerID string, next time.Time) error
+	GetSubscriptionThrottling(triggerID, subscriptionID string) (time.Time, time.Time)
+	SetSubscriptionThrottling(triggerID, subscriptionID string, next time.Time) error
 	DeleteTriggerThrottling(triggerID string) error
 
 	// NotificationEvent storing
@@ -55,6 +60,8 @@ type Database interface {
 	GetNotificationEventCount(triggerID string, from int64) int64
 	FetchNotificationEvent() (NotificationEvent, error)
 	RemoveAllNotificationEvents() error
+	GetStreamedNotificationEvents(afterID int64) ([]*StreamedNotificationEvent, error)
+	SubscribeNotificationEvents(tomb *tomb.Tomb) (<-chan *StreamedNotificationEvent, error)
 
 	// ContactData storing
 	GetContact(contactID string) (ContactData, error)
@@ -81,6 +88,27 @@ type Database interface {
 	AddNotification(notification *ScheduledNotification) error
 	AddNotifications(notification []*ScheduledNotification, timestamp int64) error
 
+	// FailedNotification storing
+	AddFailedNotifications(notifications []*FailedNotification) error
+	GetFailedNotifications(start, end int64) ([]*FailedNotification, int64, error)
+	GetFailedNotificationsCount() (int64, error)
+	FetchFailedNotifications(ids []string) ([]*FailedNotification, error)
+
+	// Team storing
+	GetTeam(teamID string) (Team, error)
+	GetTeams(teamIDs []string) ([]*Team, error)
+	GetAllTeamIDs() ([]string, error)
+	SaveTeam(team *Team) error
+	RemoveTeam(teamID string) error
+	GetUserTeamIDs(login string) ([]string, error)
+	GetTeamTriggerIDs(teamID string) ([]string, error)
+	GetTeamSubscriptionIDs(teamID string) ([]string, error)
+
+	// NotificationDelivery storing
+	AddNotificationDelivery(delivery *NotificationDelivery) error
+	GetContactDeliveries(contactID string, start, size int64) ([]*NotificationDelivery, int64, error)
+	GetTriggerDeliveries(triggerID string, start, size int64) ([]*NotificationDelivery, int64, error)
+
 	// Patterns and metrics storing
 	GetPatterns() ([]string, error)
 	AddPatternMetric(pattern, metric string) error
@@ -100,9 +128,13 @@ type Database interface {
 	GetLocalTriggersToCheck(count int) ([]string, error)
 	GetLocalTriggersToCheckCount() (int64, error)
 
-	AddRemoteTriggersToCheck(triggerIDs []string) error
-	GetRemoteTriggersToCheck(count int) ([]string, error)
-	GetRemoteTriggersToCheckCount() (int64, error)
+	AddRemoteTriggersToCheck(clusterID ClusterID, triggerIDs []string) error
+	GetRemoteTriggersToCheck(clusterID ClusterID, count int) ([]string, error)
+	GetRemoteTriggersToCheckCount(clusterID ClusterID) (int64, error)
+
+	AddPrometheusTriggersToCheck(triggerIDs []string) error
+	GetPrometheusTriggersToCheck(count int) ([]string, error)
+	GetPrometheusTriggersToCheckCount() (int64, error)
 
 	// TriggerCheckLock storing
 	AcquireTriggerCheckLock(triggerID string, timeout int) error
@@ -114,6 +146,11 @@ type Database interface {
 	SetUsernameID(messenger, username, id string) error
 	RemoveUser(messenger, username string) error
 
+	// Issue tracker issues storing
+	GetTriggerIssue(triggerID, contactID string) (TrackerIssue, error)
+	SaveTriggerIssue(triggerID, contactID string, issue *TrackerIssue) error
+	RemoveTriggerIssue(triggerID, contactID string) error
+
 	// Triggers without subscription manipulation
 	MarkTriggersAsUnused(triggerIDs ...string) error
 	GetUnusedTriggerIDs() ([]string, error)
@@ -123,6 +160,22 @@ type Database interface {
 	FetchTriggersToReindex(from int64) ([]string, error)
 	RemoveTriggersToReindex(to int64) error
 
+	// AuditRecord storing
+	SaveAuditRecord(record *AuditRecord) error
+	GetAuditRecord(id string) (AuditRecord, error)
+	GetAuditRecords(start, size int64) ([]*AuditRecord, error)
+	GetAuditRecordsCount() (int64, error)
+	GetEntityAuditRecords(entityType AuditEntityType, entityID string, start, size int64) ([]*AuditRecord, error)
+	GetEntityAuditRecordsCount(entityType AuditEntityType, entityID string) (int64, error)
+
+	// Silence storing
+	SaveSilence(silence *Silence) error
+	GetSilence(id string) (Silence, error)
+	GetSilences() ([]*Silence, error)
+	GetUnexpiredSilences(timestamp int64) ([]*Silence, error)
+	RemoveSilence(id string) error
+	RemoveExpiredSilences(to int64) (int64, error)
+
 	// Creates Lock
 	NewLock(name string, ttl time.Duration) Lock
 }
@@ -153,6 +206,12 @@ type Sender interface {
 	Init(senderSettings map[string]string, logger Logger, location *time.Location, dateTimeFormat string) error
 }
 
+// DigestSender is implemented by senders which can send events of many triggers in a single message.
+// Events of contacts with digest interval are sent as digest, for other senders they are split by trigger
+type DigestSender interface {
+	SendDigest(events NotificationEvents, contact ContactData, triggers map[string]TriggerData, throttled bool) error
+}
+
 // ImageStore is the interface for image storage providers
 type ImageStore interface {
 	StoreImage(image []byte) (string, error)
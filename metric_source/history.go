@@ -0,0 +1,34 @@
+package metricSource
+
+import (
+	"github.com/moira-alert/moira"
+)
+
+// FetchHistory fetches target metrics history required to calculate seasonal baselines
+// of metric values in from-until range, returns history by metric name
+func FetchHistory(source MetricSource, target string, from, until int64, settings *moira.AnomalySettings) (map[string]*MetricData, error) {
+	historyFrom, historyUntil := settings.GetHistoryRange(from, until)
+	fetchResult, err := source.Fetch(target, historyFrom, historyUntil, false)
+	if err != nil {
+		return nil, err
+	}
+	metricsData := fetchResult.GetMetricsData()
+	history := make(map[string]*MetricData, len(metricsData))
+	for _, metricData := range metricsData {
+		history[metricData.Name] = metricData
+	}
+	return history, nil
+}
+
+// FetchTriggerHistory fetches main target history of anomaly trigger from trigger metric source,
+// returns nil history for triggers of other types
+func FetchTriggerHistory(provider *SourceProvider, trigger *moira.Trigger, from, until int64) (map[string]*MetricData, error) {
+	if trigger.TriggerType != moira.AnomalyTrigger || len(trigger.Targets) == 0 {
+		return nil, nil
+	}
+	source, err := provider.GetTriggerMetricSource(trigger)
+	if err != nil {
+		return nil, err
+	}
+	return FetchHistory(source, trigger.Targets[0], from, until, trigger.GetAnomalySettings())
+}
@@ -0,0 +1,52 @@
+package metricSource
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/moira-alert/moira"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type historySource struct {
+	MetricSource
+	metricsData []*MetricData
+	err         error
+	from, until int64
+}
+
+func (source *historySource) Fetch(target string, from int64, until int64, allowRealTimeAlerting bool) (FetchResult, error) {
+	source.from, source.until = from, until
+	return historyFetchResult{metricsData: source.metricsData}, source.err
+}
+
+type historyFetchResult struct {
+	FetchResult
+	metricsData []*MetricData
+}
+
+func (fetchResult historyFetchResult) GetMetricsData() []*MetricData {
+	return fetchResult.metricsData
+}
+
+func TestFetchHistory(t *testing.T) {
+	settings := &moira.AnomalySettings{Seasons: 2, SeasonLength: 1000, Window: 100}
+
+	Convey("History is fetched for previous seasons and returned by metric name", t, func() {
+		first := MakeMetricData("first", []float64{1, 2}, 60, 0)
+		second := MakeMetricData("second", []float64{3, 4}, 60, 0)
+		source := &historySource{metricsData: []*MetricData{first, second}}
+		history, err := FetchHistory(source, "target", 5000, 5600, settings)
+		So(err, ShouldBeNil)
+		So(history, ShouldResemble, map[string]*MetricData{"first": first, "second": second})
+		So(source.from, ShouldEqual, 2900)
+		So(source.until, ShouldEqual, 4700)
+	})
+
+	Convey("Fetch error is returned", t, func() {
+		source := &historySource{err: fmt.Errorf("fetch error")}
+		history, err := FetchHistory(source, "target", 5000, 5600, settings)
+		So(err, ShouldResemble, fmt.Errorf("fetch error"))
+		So(history, ShouldBeNil)
+	})
+}
@@ -0,0 +1,54 @@
+package prometheus
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConfig(t *testing.T) {
+	Convey("Given config without url and enabled", t, func() {
+		cfg := &Config{
+			URL:     "",
+			Enabled: true,
+		}
+		Convey("prometheus triggers should be disabled", func() {
+			So(cfg.isEnabled(), ShouldBeFalse)
+		})
+	})
+
+	Convey("Given config with url and enabled", t, func() {
+		cfg := &Config{
+			URL:     "http://host",
+			Enabled: true,
+		}
+		Convey("prometheus triggers should be enabled", func() {
+			So(cfg.isEnabled(), ShouldBeTrue)
+		})
+	})
+
+	Convey("Given config with url and disabled", t, func() {
+		cfg := &Config{
+			URL:     "http://host",
+			Enabled: false,
+		}
+		Convey("prometheus triggers should be disabled", func() {
+			So(cfg.isEnabled(), ShouldBeFalse)
+		})
+	})
+
+	Convey("Given config without step", t, func() {
+		cfg := &Config{}
+		Convey("default step should be used", func() {
+			So(cfg.getStep(), ShouldEqual, defaultStep)
+		})
+	})
+
+	Convey("Given config with step", t, func() {
+		cfg := &Config{Step: 30 * time.Second}
+		Convey("configured step should be used", func() {
+			So(cfg.getStep(), ShouldEqual, 30)
+		})
+	})
+}
@@ -0,0 +1,114 @@
+package prometheus
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	metricSource "github.com/moira-alert/moira/metric_source"
+)
+
+const defaultStep int64 = 60
+
+// ErrPrometheusStorageDisabled is used to prevent prometheus.Fetch calls when prometheus storage is disabled
+var ErrPrometheusStorageDisabled = fmt.Errorf("remote prometheus storage is not enabled")
+
+// ErrPrometheusTriggerResponse is a custom error when prometheus trigger check fails
+type ErrPrometheusTriggerResponse struct {
+	InternalError error
+	Target        string
+}
+
+// Error is a representation of Error interface method
+func (err ErrPrometheusTriggerResponse) Error() string {
+	return err.InternalError.Error()
+}
+
+// ErrInvalidQuery is used when prometheus rejects trigger target as invalid PromQL expression
+type ErrInvalidQuery struct {
+	Message string
+}
+
+// Error is a representation of Error interface method
+func (err ErrInvalidQuery) Error() string {
+	return fmt.Sprintf("invalid PromQL expression: %s", err.Message)
+}
+
+// Prometheus is implementation of MetricSource interface, which implements fetch metrics method from remote prometheus installation
+type Prometheus struct {
+	config *Config
+	client *http.Client
+}
+
+// Create configures prometheus metric source
+func Create(config *Config) metricSource.MetricSource {
+	return &Prometheus{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Fetch evaluates PromQL target via prometheus query_range API and converts result to expected format
+func (prometheus *Prometheus) Fetch(target string, from, until int64, allowRealTimeAlerting bool) (metricSource.FetchResult, error) {
+	step := prometheus.config.getStep()
+	from -= from % step
+	until -= until % step
+	req, err := prometheus.prepareRequest(from, until, target)
+	if err != nil {
+		return nil, ErrPrometheusTriggerResponse{
+			InternalError: err,
+			Target:        target,
+		}
+	}
+	body, err := prometheus.makeRequest(req)
+	if err != nil {
+		if invalidQueryErr, ok := err.(ErrInvalidQuery); ok {
+			return nil, invalidQueryErr
+		}
+		return nil, ErrPrometheusTriggerResponse{
+			InternalError: err,
+			Target:        target,
+		}
+	}
+	resp, err := decodeBody(body)
+	if err != nil {
+		return nil, ErrPrometheusTriggerResponse{
+			InternalError: err,
+			Target:        target,
+		}
+	}
+	fetchResult, err := convertResponse(resp, from, until, step, allowRealTimeAlerting)
+	if err != nil {
+		return nil, ErrPrometheusTriggerResponse{
+			InternalError: err,
+			Target:        target,
+		}
+	}
+	return &fetchResult, nil
+}
+
+// IsConfigured returns false in cases that user does not properly configure prometheus settings like prometheus URL
+func (prometheus *Prometheus) IsConfigured() (bool, error) {
+	if prometheus.config.isEnabled() {
+		return true, nil
+	}
+	return false, ErrPrometheusStorageDisabled
+}
+
+// IsAvailable checks if prometheus API is available and returns 200 response
+func (prometheus *Prometheus) IsAvailable() (bool, error) {
+	maxRetries := 3
+	until := time.Now().Unix()
+	from := until - 600
+	req, err := prometheus.prepareRequest(from, until, "vector(0)")
+	if err != nil {
+		return false, err
+	}
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		_, err = prometheus.makeRequest(req)
+		if err == nil {
+			return true, nil
+		}
+	}
+	return false, err
+}
@@ -0,0 +1,83 @@
+package prometheus
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	metricSource "github.com/moira-alert/moira/metric_source"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIsConfigured(t *testing.T) {
+	Convey("Prometheus is not configured", t, func() {
+		prometheus := Create(&Config{URL: "", Enabled: true})
+		isConfigured, err := prometheus.IsConfigured()
+		So(isConfigured, ShouldBeFalse)
+		So(err, ShouldResemble, ErrPrometheusStorageDisabled)
+	})
+
+	Convey("Prometheus is configured", t, func() {
+		prometheus := Create(&Config{URL: "http://host", Enabled: true})
+		isConfigured, err := prometheus.IsConfigured()
+		So(isConfigured, ShouldBeTrue)
+		So(err, ShouldBeEmpty)
+	})
+}
+
+func TestIsAvailable(t *testing.T) {
+	Convey("Is available", t, func() {
+		server := createServer([]byte("Some string"), http.StatusOK)
+		prometheus := Prometheus{client: server.Client(), config: &Config{URL: server.URL}}
+		isAvailable, err := prometheus.IsAvailable()
+		So(isAvailable, ShouldBeTrue)
+		So(err, ShouldBeEmpty)
+	})
+
+	Convey("Not available", t, func() {
+		server := createServer([]byte("Some string"), http.StatusInternalServerError)
+		prometheus := Prometheus{client: server.Client(), config: &Config{URL: server.URL}}
+		isAvailable, err := prometheus.IsAvailable()
+		So(isAvailable, ShouldBeFalse)
+		So(err, ShouldResemble, fmt.Errorf("bad response status %d: %s", http.StatusInternalServerError, "Some string"))
+	})
+}
+
+func TestFetch(t *testing.T) {
+	var from int64 = 300
+	var until int64 = 500
+	target := "up"
+
+	Convey("Request success with empty result", t, func() {
+		server := createServer([]byte(`{"status":"success","data":{"resultType":"matrix","result":[]}}`), http.StatusOK)
+		prometheus := Prometheus{client: server.Client(), config: &Config{URL: server.URL}}
+		result, err := prometheus.Fetch(target, from, until, false)
+		So(result, ShouldResemble, &FetchResult{MetricsData: []*metricSource.MetricData{}})
+		So(err, ShouldBeEmpty)
+	})
+
+	Convey("Request success but body is invalid", t, func() {
+		server := createServer([]byte("Some string"), http.StatusOK)
+		prometheus := Prometheus{client: server.Client(), config: &Config{URL: server.URL}}
+		result, err := prometheus.Fetch(target, from, until, false)
+		So(result, ShouldBeEmpty)
+		So(err.Error(), ShouldResemble, "invalid character 'S' looking for beginning of value")
+	})
+
+	Convey("Fail request with InternalServerError", t, func() {
+		server := createServer([]byte("Some string"), http.StatusInternalServerError)
+		prometheus := Prometheus{client: server.Client(), config: &Config{URL: server.URL}}
+		result, err := prometheus.Fetch(target, from, until, false)
+		So(result, ShouldBeEmpty)
+		So(err, ShouldHaveSameTypeAs, ErrPrometheusTriggerResponse{})
+		So(err.Error(), ShouldResemble, fmt.Sprintf("bad response status %d: %s", http.StatusInternalServerError, "Some string"))
+	})
+
+	Convey("Fail request with invalid query", t, func() {
+		server := createServer([]byte(`{"status":"error","errorType":"bad_data","error":"unexpected end of input"}`), http.StatusBadRequest)
+		prometheus := Prometheus{client: server.Client(), config: &Config{URL: server.URL}}
+		result, err := prometheus.Fetch("sum(", from, until, false)
+		So(result, ShouldBeEmpty)
+		So(err, ShouldResemble, ErrInvalidQuery{Message: "unexpected end of input"})
+	})
+}
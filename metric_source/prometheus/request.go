@@ -0,0 +1,59 @@
+package prometheus
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const queryRangePath = "/api/v1/query_range"
+
+func (prometheus *Prometheus) prepareRequest(from, until int64, target string) (*http.Request, error) {
+	req, err := http.NewRequest("GET", strings.TrimSuffix(prometheus.config.URL, "/")+queryRangePath, nil)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	q.Add("query", target)
+	q.Add("start", strconv.FormatInt(from, 10))
+	q.Add("end", strconv.FormatInt(until, 10))
+	q.Add("step", strconv.FormatInt(prometheus.config.getStep(), 10))
+	req.URL.RawQuery = q.Encode()
+	if prometheus.config.User != "" && prometheus.config.Password != "" {
+		req.SetBasicAuth(prometheus.config.User, prometheus.config.Password)
+	}
+	return req, nil
+}
+
+func (prometheus *Prometheus) makeRequest(req *http.Request) ([]byte, error) {
+	var body []byte
+	resp, err := prometheus.client.Do(req)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return body, err
+	}
+	body, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return body, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		if errorResponse, ok := decodeErrorBody(body); ok && errorResponse.ErrorType == errorTypeBadData {
+			return body, ErrInvalidQuery{Message: errorResponse.Error}
+		}
+		return body, fmt.Errorf("bad response status %d: %s", resp.StatusCode, string(body))
+	}
+	return body, nil
+}
+
+func decodeErrorBody(body []byte) (queryRangeResponse, bool) {
+	var resp queryRangeResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return resp, false
+	}
+	return resp, resp.Status == statusError
+}
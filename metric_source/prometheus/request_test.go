@@ -0,0 +1,96 @@
+package prometheus
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPrepareRequest(t *testing.T) {
+	var from int64 = 300
+	var until int64 = 600
+	target := "rate(http_requests_total[5m])"
+
+	Convey("Given valid params", t, func() {
+		prometheus := Prometheus{config: &Config{
+			URL: "http://test/",
+		}}
+		req, err := prometheus.prepareRequest(from, until, target)
+		Convey("url should be encoded correctly without error", func() {
+			So(err, ShouldBeNil)
+			So(req.URL.String(), ShouldEqual, "http://test/api/v1/query_range?end=600&query=rate%28http_requests_total%5B5m%5D%29&start=300&step=60")
+		})
+		Convey("auth header should be empty", func() {
+			So(req.Header.Get("Authorization"), ShouldEqual, "")
+		})
+	})
+
+	Convey("Given valid params with user and password", t, func() {
+		prometheus := Prometheus{config: &Config{
+			URL:      "http://test",
+			User:     "foo",
+			Password: "bar",
+		}}
+		req, err := prometheus.prepareRequest(from, until, target)
+		Convey("auth header should be set without error", func() {
+			u, p, ok := req.BasicAuth()
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+			So(u, ShouldEqual, prometheus.config.User)
+			So(p, ShouldEqual, prometheus.config.Password)
+		})
+	})
+}
+
+func TestMakeRequest(t *testing.T) {
+	var from int64 = 300
+	var until int64 = 600
+	target := "up"
+	body := []byte("Some string")
+
+	Convey("Client returns status OK", t, func() {
+		server := createServer(body, http.StatusOK)
+		prometheus := Prometheus{client: server.Client(), config: &Config{URL: server.URL}}
+		request, _ := prometheus.prepareRequest(from, until, target)
+		actual, err := prometheus.makeRequest(request)
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, body)
+	})
+
+	Convey("Client returns status InternalServerError", t, func() {
+		server := createServer(body, http.StatusInternalServerError)
+		prometheus := Prometheus{client: server.Client(), config: &Config{URL: server.URL}}
+		request, _ := prometheus.prepareRequest(from, until, target)
+		actual, err := prometheus.makeRequest(request)
+		So(err, ShouldResemble, fmt.Errorf("bad response status %d: %s", http.StatusInternalServerError, string(body)))
+		So(actual, ShouldResemble, body)
+	})
+
+	Convey("Client returns bad data error", t, func() {
+		errorBody := []byte(`{"status":"error","errorType":"bad_data","error":"parse error at char 4"}`)
+		server := createServer(errorBody, http.StatusBadRequest)
+		prometheus := Prometheus{client: server.Client(), config: &Config{URL: server.URL}}
+		request, _ := prometheus.prepareRequest(from, until, target)
+		_, err := prometheus.makeRequest(request)
+		So(err, ShouldResemble, ErrInvalidQuery{Message: "parse error at char 4"})
+	})
+
+	Convey("Client calls bad url", t, func() {
+		server := createServer(body, http.StatusOK)
+		prometheus := Prometheus{client: server.Client(), config: &Config{URL: "http://bad/"}}
+		request, _ := prometheus.prepareRequest(from, until, target)
+		actual, err := prometheus.makeRequest(request)
+		So(err, ShouldNotBeEmpty)
+		So(actual, ShouldBeEmpty)
+	})
+}
+
+func createServer(body []byte, statusCode int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(statusCode)
+		rw.Write(body) //nolint
+	}))
+}
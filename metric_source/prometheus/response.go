@@ -0,0 +1,129 @@
+package prometheus
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	metricSource "github.com/moira-alert/moira/metric_source"
+)
+
+const (
+	statusSuccess    = "success"
+	statusError      = "error"
+	errorTypeBadData = "bad_data"
+	resultTypeMatrix = "matrix"
+	metricNameLabel  = "__name__"
+)
+
+type queryRangeResponse struct {
+	Status    string         `json:"status"`
+	Data      queryRangeData `json:"data"`
+	ErrorType string         `json:"errorType,omitempty"`
+	Error     string         `json:"error,omitempty"`
+}
+
+type queryRangeData struct {
+	ResultType string         `json:"resultType"`
+	Result     []sampleStream `json:"result"`
+}
+
+type sampleStream struct {
+	Metric map[string]string `json:"metric"`
+	Values [][2]interface{}  `json:"values"`
+}
+
+func decodeBody(body []byte) (*queryRangeResponse, error) {
+	var resp queryRangeResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Status != statusSuccess {
+		return nil, fmt.Errorf("prometheus query failed with status '%s': %s", resp.Status, resp.Error)
+	}
+	if resp.Data.ResultType != resultTypeMatrix {
+		return nil, fmt.Errorf("unexpected prometheus result type '%s', expected '%s'", resp.Data.ResultType, resultTypeMatrix)
+	}
+	return &resp, nil
+}
+
+// convertResponse converts prometheus range vectors to moira metrics data aligned to from timestamp with given step.
+// Points missing in prometheus response are filled with NaN
+func convertResponse(resp *queryRangeResponse, from, until, step int64, allowRealTimeAlerting bool) (FetchResult, error) {
+	pointsCount := (until-from)/step + 1
+	if !allowRealTimeAlerting {
+		// remove last value
+		pointsCount--
+	}
+	metricsData := make([]*metricSource.MetricData, 0, len(resp.Data.Result))
+	for _, stream := range resp.Data.Result {
+		values := make([]float64, pointsCount)
+		for i := range values {
+			values[i] = math.NaN()
+		}
+		for _, point := range stream.Values {
+			timestamp, value, err := parseSamplePair(point)
+			if err != nil {
+				return FetchResult{}, err
+			}
+			if timestamp < from || (timestamp-from)%step != 0 {
+				continue
+			}
+			index := (timestamp - from) / step
+			if index >= pointsCount {
+				continue
+			}
+			values[index] = value
+		}
+		metricsData = append(metricsData, metricSource.MakeMetricData(getMetricName(stream.Metric), values, step, from))
+	}
+	return FetchResult{MetricsData: metricsData}, nil
+}
+
+func parseSamplePair(point [2]interface{}) (int64, float64, error) {
+	timestamp, ok := point[0].(float64)
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid sample timestamp: %v", point[0])
+	}
+	valueString, ok := point[1].(string)
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid sample value: %v", point[1])
+	}
+	value, err := strconv.ParseFloat(valueString, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid sample value: %s", err.Error())
+	}
+	return int64(timestamp), value, nil
+}
+
+// getMetricName builds metric name in prometheus notation: name{label1="value1",label2="value2"}, labels are sorted by name
+func getMetricName(labels map[string]string) string {
+	labelNames := make([]string, 0, len(labels))
+	for labelName := range labels {
+		if labelName == metricNameLabel {
+			continue
+		}
+		labelNames = append(labelNames, labelName)
+	}
+	sort.Strings(labelNames)
+
+	var builder strings.Builder
+	builder.WriteString(labels[metricNameLabel])
+	if len(labelNames) == 0 && labels[metricNameLabel] != "" {
+		return builder.String()
+	}
+	builder.WriteString("{")
+	for i, labelName := range labelNames {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString(labelName)
+		builder.WriteString("=")
+		builder.WriteString(strconv.Quote(labels[labelName]))
+	}
+	builder.WriteString("}")
+	return builder.String()
+}
@@ -0,0 +1,82 @@
+package prometheus
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDecodeBody(t *testing.T) {
+	Convey("Given empty matrix response", t, func() {
+		body := []byte(`{"status":"success","data":{"resultType":"matrix","result":[]}}`)
+		resp, err := decodeBody(body)
+		Convey("response should be empty and without error", func() {
+			So(err, ShouldBeNil)
+			So(resp.Data.Result, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given vector response", t, func() {
+		body := []byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`)
+		_, err := decodeBody(body)
+		Convey("error should be returned", func() {
+			So(err.Error(), ShouldEqual, "unexpected prometheus result type 'vector', expected 'matrix'")
+		})
+	})
+
+	Convey("Given invalid json", t, func() {
+		_, err := decodeBody([]byte("Some string"))
+		Convey("error should be returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestConvertResponse(t *testing.T) {
+	body := []byte(`{"status":"success","data":{"resultType":"matrix","result":[
+		{"metric":{"__name__":"up","job":"node","instance":"host:9100"},"values":[[120,"1"],[240,"0"],[300,"NaN"]]}
+	]}}`)
+	resp, err := decodeBody(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Given response and allowRealTimeAlerting is set", t, func() {
+		fetchResult, err := convertResponse(resp, 120, 300, 60, true)
+		So(err, ShouldBeNil)
+		So(fetchResult.MetricsData, ShouldHaveLength, 1)
+		metricData := fetchResult.MetricsData[0]
+		Convey("metric name should contain sorted labels", func() {
+			So(metricData.Name, ShouldEqual, `up{instance="host:9100",job="node"}`)
+		})
+		Convey("missing points should be filled with NaN", func() {
+			So(metricData.StartTime, ShouldEqual, 120)
+			So(metricData.StepTime, ShouldEqual, 60)
+			So(metricData.Values, ShouldHaveLength, 4)
+			So(metricData.Values[0], ShouldEqual, 1)
+			So(math.IsNaN(metricData.Values[1]), ShouldBeTrue)
+			So(metricData.Values[2], ShouldEqual, 0)
+			So(math.IsNaN(metricData.Values[3]), ShouldBeTrue)
+		})
+	})
+
+	Convey("Given response and allowRealTimeAlerting is not set", t, func() {
+		fetchResult, err := convertResponse(resp, 120, 300, 60, false)
+		So(err, ShouldBeNil)
+		Convey("response should not contain last value", func() {
+			So(fetchResult.MetricsData[0].Values, ShouldHaveLength, 3)
+		})
+	})
+}
+
+func TestGetMetricName(t *testing.T) {
+	Convey("Metric without labels", t, func() {
+		So(getMetricName(map[string]string{"__name__": "up"}), ShouldEqual, "up")
+	})
+
+	Convey("Aggregation without metric name", t, func() {
+		So(getMetricName(map[string]string{}), ShouldEqual, "{}")
+		So(getMetricName(map[string]string{"job": "node"}), ShouldEqual, `{job="node"}`)
+	})
+}
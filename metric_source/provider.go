@@ -2,6 +2,7 @@ package metricSource
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/moira-alert/moira"
 )
@@ -9,17 +10,39 @@ import (
 // ErrMetricSourceIsNotConfigured is used then metric source return false on IsConfigured method call with nil error
 var ErrMetricSourceIsNotConfigured = fmt.Errorf("metric source is not configured")
 
+// ErrRemoteClusterIsNotConfigured is used when trigger refers to remote cluster which is absent in config
+type ErrRemoteClusterIsNotConfigured struct {
+	ClusterID moira.ClusterID
+}
+
+// Error is a representation of Error interface method
+func (err ErrRemoteClusterIsNotConfigured) Error() string {
+	return fmt.Sprintf("remote cluster '%s' is not configured", err.ClusterID)
+}
+
+// ErrUnknownTriggerSource is used when trigger has metrics source that moira does not know about
+type ErrUnknownTriggerSource struct {
+	TriggerSource moira.TriggerSource
+}
+
+// Error is a representation of Error interface method
+func (err ErrUnknownTriggerSource) Error() string {
+	return fmt.Sprintf("unknown trigger source '%s'", err.TriggerSource)
+}
+
 // SourceProvider is a provider for all known metrics sources
 type SourceProvider struct {
-	local  MetricSource
-	remote MetricSource
+	local      MetricSource
+	remotes    map[moira.ClusterID]MetricSource
+	prometheus MetricSource
 }
 
 // CreateMetricSourceProvider just creates SourceProvider with all known metrics sources
-func CreateMetricSourceProvider(local MetricSource, remote MetricSource) *SourceProvider {
+func CreateMetricSourceProvider(local MetricSource, remotes map[moira.ClusterID]MetricSource, prometheus MetricSource) *SourceProvider {
 	return &SourceProvider{
-		remote: remote,
-		local:  local,
+		remotes:    remotes,
+		local:      local,
+		prometheus: prometheus,
 	}
 }
 
@@ -28,25 +51,57 @@ func (provider *SourceProvider) GetLocal() (MetricSource, error) {
 	return returnSource(provider.local)
 }
 
-// GetRemote gets remote metric source. If it not configured returns not empty error
-func (provider *SourceProvider) GetRemote() (MetricSource, error) {
-	return returnSource(provider.remote)
+// GetRemote gets remote metric source of given cluster. If it not configured returns not empty error
+func (provider *SourceProvider) GetRemote(clusterID moira.ClusterID) (MetricSource, error) {
+	remote, ok := provider.remotes[clusterID]
+	if !ok {
+		return nil, ErrRemoteClusterIsNotConfigured{ClusterID: clusterID}
+	}
+	return returnSource(remote)
+}
+
+// GetRemoteClusterIDs returns sorted names of all configured and enabled remote clusters
+func (provider *SourceProvider) GetRemoteClusterIDs() []moira.ClusterID {
+	clusterIDs := make([]moira.ClusterID, 0, len(provider.remotes))
+	for clusterID := range provider.remotes {
+		if _, err := provider.GetRemote(clusterID); err == nil {
+			clusterIDs = append(clusterIDs, clusterID)
+		}
+	}
+	sort.Slice(clusterIDs, func(i, j int) bool {
+		return clusterIDs[i] < clusterIDs[j]
+	})
+	return clusterIDs
+}
+
+// GetPrometheus gets prometheus metric source. If it not configured returns not empty error
+func (provider *SourceProvider) GetPrometheus() (MetricSource, error) {
+	return returnSource(provider.prometheus)
 }
 
 // GetTriggerMetricSource get metrics source by given trigger. If it not configured returns not empty error
 func (provider *SourceProvider) GetTriggerMetricSource(trigger *moira.Trigger) (MetricSource, error) {
-	return provider.GetMetricSource(trigger.IsRemote)
+	return provider.GetMetricSource(trigger.ClusterKey())
 }
 
-// GetMetricSource return metric source depending on trigger flag: is remote trigger or not. GetLocal if not.
-func (provider *SourceProvider) GetMetricSource(isRemote bool) (MetricSource, error) {
-	if isRemote {
-		return provider.GetRemote()
+// GetMetricSource return metric source depending on trigger source and cluster
+func (provider *SourceProvider) GetMetricSource(clusterKey moira.ClusterKey) (MetricSource, error) {
+	switch clusterKey.TriggerSource {
+	case moira.GraphiteLocal, moira.TriggerSourceNotSet:
+		return provider.GetLocal()
+	case moira.GraphiteRemote:
+		return provider.GetRemote(clusterKey.ClusterID)
+	case moira.PrometheusRemote:
+		return provider.GetPrometheus()
+	default:
+		return nil, ErrUnknownTriggerSource{TriggerSource: clusterKey.TriggerSource}
 	}
-	return provider.GetLocal()
 }
 
 func returnSource(source MetricSource) (MetricSource, error) {
+	if source == nil {
+		return source, ErrMetricSourceIsNotConfigured
+	}
 	isConfigured, err := source.IsConfigured()
 	if !isConfigured && err == nil {
 		return source, ErrMetricSourceIsNotConfigured
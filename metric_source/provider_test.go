@@ -0,0 +1,67 @@
+package metricSource
+
+import (
+	"testing"
+
+	"github.com/moira-alert/moira"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testSource struct {
+	MetricSource
+	configured bool
+}
+
+func (source testSource) IsConfigured() (bool, error) {
+	return source.configured, nil
+}
+
+func TestSourceProvider(t *testing.T) {
+	local := testSource{configured: true}
+	defaultRemote := testSource{configured: true}
+	stagingRemote := testSource{configured: true}
+	disabledRemote := testSource{configured: false}
+	provider := CreateMetricSourceProvider(local, map[moira.ClusterID]MetricSource{
+		moira.DefaultCluster: defaultRemote,
+		"staging":            stagingRemote,
+		"disabled":           disabledRemote,
+	}, nil)
+
+	Convey("GetRemoteClusterIDs returns only enabled clusters", t, func() {
+		So(provider.GetRemoteClusterIDs(), ShouldResemble, []moira.ClusterID{moira.DefaultCluster, "staging"})
+	})
+
+	Convey("GetMetricSource", t, func() {
+		Convey("returns local source for local and not set trigger source", func() {
+			source, err := provider.GetMetricSource(moira.DefaultLocalCluster)
+			So(err, ShouldBeNil)
+			So(source, ShouldResemble, local)
+			source, err = provider.GetMetricSource(moira.ClusterKey{})
+			So(err, ShouldBeNil)
+			So(source, ShouldResemble, local)
+		})
+
+		Convey("returns remote source of requested cluster", func() {
+			source, err := provider.GetMetricSource(moira.DefaultGraphiteRemoteCluster)
+			So(err, ShouldBeNil)
+			So(source, ShouldResemble, defaultRemote)
+			source, err = provider.GetMetricSource(moira.MakeClusterKey(moira.GraphiteRemote, "staging"))
+			So(err, ShouldBeNil)
+			So(source, ShouldResemble, stagingRemote)
+		})
+
+		Convey("returns error for unknown and disabled clusters", func() {
+			_, err := provider.GetMetricSource(moira.MakeClusterKey(moira.GraphiteRemote, "unknown"))
+			So(err, ShouldResemble, ErrRemoteClusterIsNotConfigured{ClusterID: "unknown"})
+			_, err = provider.GetMetricSource(moira.MakeClusterKey(moira.GraphiteRemote, "disabled"))
+			So(err, ShouldResemble, ErrMetricSourceIsNotConfigured)
+		})
+
+		Convey("returns error for not configured prometheus and unknown sources", func() {
+			_, err := provider.GetMetricSource(moira.DefaultPrometheusRemoteCluster)
+			So(err, ShouldResemble, ErrMetricSourceIsNotConfigured)
+			_, err = provider.GetMetricSource(moira.ClusterKey{TriggerSource: "influxdb"})
+			So(err, ShouldResemble, ErrUnknownTriggerSource{TriggerSource: "influxdb"})
+		})
+	})
+}
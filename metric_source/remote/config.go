@@ -1,9 +1,14 @@
 package remote
 
-import "time"
+import (
+	"time"
+
+	"github.com/moira-alert/moira"
+)
 
 // Config represents config from remote storage
 type Config struct {
+	ClusterID     moira.ClusterID
 	URL           string
 	CheckInterval time.Duration
 	Timeout       time.Duration
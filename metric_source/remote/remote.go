@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/moira-alert/moira"
 	metricSource "github.com/moira-alert/moira/metric_source"
 )
 
@@ -36,6 +37,15 @@ func Create(config *Config) metricSource.MetricSource {
 	}
 }
 
+// CreateClusters configures remote metric sources of all given clusters
+func CreateClusters(configs []*Config) map[moira.ClusterID]metricSource.MetricSource {
+	sources := make(map[moira.ClusterID]metricSource.MetricSource, len(configs))
+	for _, config := range configs {
+		sources[config.ClusterID] = Create(config)
+	}
+	return sources
+}
+
 // Fetch fetches remote metrics and converts them to expected format
 func (remote *Remote) Fetch(target string, from, until int64, allowRealTimeAlerting bool) (metricSource.FetchResult, error) {
 	req, err := remote.prepareRequest(from, until, target)
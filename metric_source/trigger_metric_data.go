@@ -2,10 +2,12 @@ package metricSource
 
 import "fmt"
 
-// TriggerMetricsData represent collection of Main target timeseries and collection of additions targets timeseries
+// TriggerMetricsData represent collection of Main target timeseries and collection of additions targets timeseries.
+// History contains previous seasons of Main target timeseries by metric name, it is fetched for anomaly triggers only
 type TriggerMetricsData struct {
 	Main       []*MetricData
 	Additional []*MetricData
+	History    map[string]*MetricData
 }
 
 // MakeEmptyTriggerMetricsData just creates TriggerMetricsData with initialized empty fields
@@ -1,22 +1,38 @@
 package metrics
 
-import "github.com/moira-alert/moira"
+import (
+	"fmt"
+
+	"github.com/moira-alert/moira"
+)
 
 // CheckerMetrics is a collection of metrics used in checker
 type CheckerMetrics struct {
 	LocalMetrics           *CheckMetrics
-	RemoteMetrics          *CheckMetrics
+	RemoteMetrics          map[moira.ClusterID]*CheckMetrics
+	PrometheusMetrics      *CheckMetrics
 	MetricEventsChannelLen Histogram
 	UnusedTriggersCount    Histogram
 	MetricEventsHandleTime Timer
 }
 
-// GetCheckMetrics return check metrics dependent on given trigger type
-func (metrics *CheckerMetrics) GetCheckMetrics(trigger *moira.Trigger) *CheckMetrics {
-	if trigger.IsRemote {
-		return metrics.RemoteMetrics
+// GetCheckMetrics return check metrics dependent on given trigger type,
+// error is returned if metrics of trigger remote cluster or prometheus are not configured
+func (metrics *CheckerMetrics) GetCheckMetrics(trigger *moira.Trigger) (*CheckMetrics, error) {
+	clusterKey := trigger.ClusterKey()
+	var checkMetrics *CheckMetrics
+	switch clusterKey.TriggerSource {
+	case moira.GraphiteRemote:
+		checkMetrics = metrics.RemoteMetrics[clusterKey.ClusterID]
+	case moira.PrometheusRemote:
+		checkMetrics = metrics.PrometheusMetrics
+	default:
+		return metrics.LocalMetrics, nil
+	}
+	if checkMetrics == nil {
+		return nil, fmt.Errorf("check metrics of cluster %s are not configured", clusterKey)
 	}
-	return metrics.LocalMetrics
+	return checkMetrics, nil
 }
 
 // CheckMetrics is a collection of metrics for trigger checks
@@ -28,24 +44,32 @@ type CheckMetrics struct {
 }
 
 // ConfigureCheckerMetrics is checker metrics configurator
-func ConfigureCheckerMetrics(registry Registry, remoteEnabled bool) *CheckerMetrics {
+func ConfigureCheckerMetrics(registry Registry, remoteClusters []moira.ClusterID, prometheusEnabled bool) *CheckerMetrics {
 	m := &CheckerMetrics{
 		LocalMetrics:           configureCheckMetrics(registry, "local"),
+		RemoteMetrics:          make(map[moira.ClusterID]*CheckMetrics, len(remoteClusters)),
 		MetricEventsChannelLen: registry.NewHistogram("metricEvents"),
 		MetricEventsHandleTime: registry.NewTimer("metricEventsHandle"),
 		UnusedTriggersCount:    registry.NewHistogram("triggers", "unused"),
 	}
-	if remoteEnabled {
-		m.RemoteMetrics = configureCheckMetrics(registry, "remote")
+	for _, clusterID := range remoteClusters {
+		if clusterID == moira.DefaultCluster {
+			m.RemoteMetrics[clusterID] = configureCheckMetrics(registry, "remote")
+		} else {
+			m.RemoteMetrics[clusterID] = configureCheckMetrics(registry, "remote", string(clusterID))
+		}
+	}
+	if prometheusEnabled {
+		m.PrometheusMetrics = configureCheckMetrics(registry, "prometheus")
 	}
 	return m
 }
 
-func configureCheckMetrics(registry Registry, prefix string) *CheckMetrics {
+func configureCheckMetrics(registry Registry, prefix ...string) *CheckMetrics {
 	return &CheckMetrics{
-		CheckError:           registry.NewMeter(prefix, "errors", "check"),
-		HandleError:          registry.NewMeter(prefix, "errors", "handle"),
-		TriggersCheckTime:    registry.NewTimer(prefix, "triggers"),
-		TriggersToCheckCount: registry.NewHistogram(prefix, "triggersToCheck"),
+		CheckError:           registry.NewMeter(append(prefix, "errors", "check")...),
+		HandleError:          registry.NewMeter(append(prefix, "errors", "handle")...),
+		TriggersCheckTime:    registry.NewTimer(append(prefix, "triggers")...),
+		TriggersToCheckCount: registry.NewHistogram(append(prefix, "triggersToCheck")...),
 	}
 }
@@ -6,9 +6,12 @@ type NotifierMetrics struct {
 	EventsReceived         Meter
 	EventsMalformed        Meter
 	EventsProcessingFailed Meter
+	EventsSilenced         Meter
 	SendingFailed          Meter
 	SendersOkMetrics       MetersCollection
 	SendersFailedMetrics   MetersCollection
+	SendersRetriedMetrics  MetersCollection
+	SendersDroppedMetrics  MetersCollection
 }
 
 // ConfigureNotifierMetrics is notifier metrics configurator
@@ -18,8 +21,11 @@ func ConfigureNotifierMetrics(registry Registry, prefix string) *NotifierMetrics
 		EventsReceived:         registry.NewMeter("events", "received"),
 		EventsMalformed:        registry.NewMeter("events", "malformed"),
 		EventsProcessingFailed: registry.NewMeter("events", "failed"),
+		EventsSilenced:         registry.NewMeter("events", "silenced"),
 		SendingFailed:          registry.NewMeter("sending", "failed"),
 		SendersOkMetrics:       NewMetersCollection(registry),
 		SendersFailedMetrics:   NewMetersCollection(registry),
+		SendersRetriedMetrics:  NewMetersCollection(registry),
+		SendersDroppedMetrics:  NewMetersCollection(registry),
 	}
 }
@@ -49,6 +49,20 @@ func (mr *MockDatabaseMockRecorder) AcquireTriggerCheckLock(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireTriggerCheckLock", reflect.TypeOf((*MockDatabase)(nil).AcquireTriggerCheckLock), arg0, arg1)
 }
 
+// AddFailedNotifications mocks base method
+func (m *MockDatabase) AddFailedNotifications(arg0 []*moira.FailedNotification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFailedNotifications", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFailedNotifications indicates an expected call of AddFailedNotifications
+func (mr *MockDatabaseMockRecorder) AddFailedNotifications(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFailedNotifications", reflect.TypeOf((*MockDatabase)(nil).AddFailedNotifications), arg0)
+}
+
 // AddLocalTriggersToCheck mocks base method
 func (m *MockDatabase) AddLocalTriggersToCheck(arg0 []string) error {
 	m.ctrl.T.Helper()
@@ -77,6 +91,20 @@ func (mr *MockDatabaseMockRecorder) AddNotification(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNotification", reflect.TypeOf((*MockDatabase)(nil).AddNotification), arg0)
 }
 
+// AddNotificationDelivery mocks base method
+func (m *MockDatabase) AddNotificationDelivery(arg0 *moira.NotificationDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNotificationDelivery", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddNotificationDelivery indicates an expected call of AddNotificationDelivery
+func (mr *MockDatabaseMockRecorder) AddNotificationDelivery(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNotificationDelivery", reflect.TypeOf((*MockDatabase)(nil).AddNotificationDelivery), arg0)
+}
+
 // AddNotifications mocks base method
 func (m *MockDatabase) AddNotifications(arg0 []*moira.ScheduledNotification, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -105,18 +133,32 @@ func (mr *MockDatabaseMockRecorder) AddPatternMetric(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPatternMetric", reflect.TypeOf((*MockDatabase)(nil).AddPatternMetric), arg0, arg1)
 }
 
+// AddPrometheusTriggersToCheck mocks base method
+func (m *MockDatabase) AddPrometheusTriggersToCheck(arg0 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPrometheusTriggersToCheck", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPrometheusTriggersToCheck indicates an expected call of AddPrometheusTriggersToCheck
+func (mr *MockDatabaseMockRecorder) AddPrometheusTriggersToCheck(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPrometheusTriggersToCheck", reflect.TypeOf((*MockDatabase)(nil).AddPrometheusTriggersToCheck), arg0)
+}
+
 // AddRemoteTriggersToCheck mocks base method
-func (m *MockDatabase) AddRemoteTriggersToCheck(arg0 []string) error {
+func (m *MockDatabase) AddRemoteTriggersToCheck(arg0 moira.ClusterID, arg1 []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddRemoteTriggersToCheck", arg0)
+	ret := m.ctrl.Call(m, "AddRemoteTriggersToCheck", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddRemoteTriggersToCheck indicates an expected call of AddRemoteTriggersToCheck
-func (mr *MockDatabaseMockRecorder) AddRemoteTriggersToCheck(arg0 interface{}) *gomock.Call {
+func (mr *MockDatabaseMockRecorder) AddRemoteTriggersToCheck(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRemoteTriggersToCheck", reflect.TypeOf((*MockDatabase)(nil).AddRemoteTriggersToCheck), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRemoteTriggersToCheck", reflect.TypeOf((*MockDatabase)(nil).AddRemoteTriggersToCheck), arg0, arg1)
 }
 
 // DeleteTriggerCheckLock mocks base method
@@ -147,6 +189,21 @@ func (mr *MockDatabaseMockRecorder) DeleteTriggerThrottling(arg0 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTriggerThrottling", reflect.TypeOf((*MockDatabase)(nil).DeleteTriggerThrottling), arg0)
 }
 
+// FetchFailedNotifications mocks base method
+func (m *MockDatabase) FetchFailedNotifications(arg0 []string) ([]*moira.FailedNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchFailedNotifications", arg0)
+	ret0, _ := ret[0].([]*moira.FailedNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchFailedNotifications indicates an expected call of FetchFailedNotifications
+func (mr *MockDatabaseMockRecorder) FetchFailedNotifications(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchFailedNotifications", reflect.TypeOf((*MockDatabase)(nil).FetchFailedNotifications), arg0)
+}
+
 // FetchNotificationEvent mocks base method
 func (m *MockDatabase) FetchNotificationEvent() (moira.NotificationEvent, error) {
 	m.ctrl.T.Helper()
@@ -207,6 +264,21 @@ func (mr *MockDatabaseMockRecorder) GetAllContacts() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllContacts", reflect.TypeOf((*MockDatabase)(nil).GetAllContacts))
 }
 
+// GetAllTeamIDs mocks base method
+func (m *MockDatabase) GetAllTeamIDs() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTeamIDs")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTeamIDs indicates an expected call of GetAllTeamIDs
+func (mr *MockDatabaseMockRecorder) GetAllTeamIDs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTeamIDs", reflect.TypeOf((*MockDatabase)(nil).GetAllTeamIDs))
+}
+
 // GetAllTriggerIDs mocks base method
 func (m *MockDatabase) GetAllTriggerIDs() ([]string, error) {
 	m.ctrl.T.Helper()
@@ -222,6 +294,51 @@ func (mr *MockDatabaseMockRecorder) GetAllTriggerIDs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTriggerIDs", reflect.TypeOf((*MockDatabase)(nil).GetAllTriggerIDs))
 }
 
+// GetAuditRecord mocks base method
+func (m *MockDatabase) GetAuditRecord(arg0 string) (moira.AuditRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditRecord", arg0)
+	ret0, _ := ret[0].(moira.AuditRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditRecord indicates an expected call of GetAuditRecord
+func (mr *MockDatabaseMockRecorder) GetAuditRecord(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditRecord", reflect.TypeOf((*MockDatabase)(nil).GetAuditRecord), arg0)
+}
+
+// GetAuditRecords mocks base method
+func (m *MockDatabase) GetAuditRecords(arg0, arg1 int64) ([]*moira.AuditRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditRecords", arg0, arg1)
+	ret0, _ := ret[0].([]*moira.AuditRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditRecords indicates an expected call of GetAuditRecords
+func (mr *MockDatabaseMockRecorder) GetAuditRecords(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditRecords", reflect.TypeOf((*MockDatabase)(nil).GetAuditRecords), arg0, arg1)
+}
+
+// GetAuditRecordsCount mocks base method
+func (m *MockDatabase) GetAuditRecordsCount() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditRecordsCount")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditRecordsCount indicates an expected call of GetAuditRecordsCount
+func (mr *MockDatabaseMockRecorder) GetAuditRecordsCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditRecordsCount", reflect.TypeOf((*MockDatabase)(nil).GetAuditRecordsCount))
+}
+
 // GetChecksUpdatesCount mocks base method
 func (m *MockDatabase) GetChecksUpdatesCount() (int64, error) {
 	m.ctrl.T.Helper()
@@ -252,6 +369,22 @@ func (mr *MockDatabaseMockRecorder) GetContact(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContact", reflect.TypeOf((*MockDatabase)(nil).GetContact), arg0)
 }
 
+// GetContactDeliveries mocks base method
+func (m *MockDatabase) GetContactDeliveries(arg0 string, arg1, arg2 int64) ([]*moira.NotificationDelivery, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactDeliveries", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*moira.NotificationDelivery)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetContactDeliveries indicates an expected call of GetContactDeliveries
+func (mr *MockDatabaseMockRecorder) GetContactDeliveries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactDeliveries", reflect.TypeOf((*MockDatabase)(nil).GetContactDeliveries), arg0, arg1, arg2)
+}
+
 // GetContacts mocks base method
 func (m *MockDatabase) GetContacts(arg0 []string) ([]*moira.ContactData, error) {
 	m.ctrl.T.Helper()
@@ -267,6 +400,67 @@ func (mr *MockDatabaseMockRecorder) GetContacts(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContacts", reflect.TypeOf((*MockDatabase)(nil).GetContacts), arg0)
 }
 
+// GetEntityAuditRecords mocks base method
+func (m *MockDatabase) GetEntityAuditRecords(arg0 moira.AuditEntityType, arg1 string, arg2, arg3 int64) ([]*moira.AuditRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntityAuditRecords", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*moira.AuditRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntityAuditRecords indicates an expected call of GetEntityAuditRecords
+func (mr *MockDatabaseMockRecorder) GetEntityAuditRecords(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityAuditRecords", reflect.TypeOf((*MockDatabase)(nil).GetEntityAuditRecords), arg0, arg1, arg2, arg3)
+}
+
+// GetEntityAuditRecordsCount mocks base method
+func (m *MockDatabase) GetEntityAuditRecordsCount(arg0 moira.AuditEntityType, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntityAuditRecordsCount", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntityAuditRecordsCount indicates an expected call of GetEntityAuditRecordsCount
+func (mr *MockDatabaseMockRecorder) GetEntityAuditRecordsCount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityAuditRecordsCount", reflect.TypeOf((*MockDatabase)(nil).GetEntityAuditRecordsCount), arg0, arg1)
+}
+
+// GetFailedNotifications mocks base method
+func (m *MockDatabase) GetFailedNotifications(arg0, arg1 int64) ([]*moira.FailedNotification, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFailedNotifications", arg0, arg1)
+	ret0, _ := ret[0].([]*moira.FailedNotification)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFailedNotifications indicates an expected call of GetFailedNotifications
+func (mr *MockDatabaseMockRecorder) GetFailedNotifications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFailedNotifications", reflect.TypeOf((*MockDatabase)(nil).GetFailedNotifications), arg0, arg1)
+}
+
+// GetFailedNotificationsCount mocks base method
+func (m *MockDatabase) GetFailedNotificationsCount() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFailedNotificationsCount")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFailedNotificationsCount indicates an expected call of GetFailedNotificationsCount
+func (mr *MockDatabaseMockRecorder) GetFailedNotificationsCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFailedNotificationsCount", reflect.TypeOf((*MockDatabase)(nil).GetFailedNotificationsCount))
+}
+
 // GetIDByUsername mocks base method
 func (m *MockDatabase) GetIDByUsername(arg0, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -477,64 +671,169 @@ func (mr *MockDatabaseMockRecorder) GetPatterns() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatterns", reflect.TypeOf((*MockDatabase)(nil).GetPatterns))
 }
 
+// GetPrometheusChecksUpdatesCount mocks base method
+func (m *MockDatabase) GetPrometheusChecksUpdatesCount() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrometheusChecksUpdatesCount")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrometheusChecksUpdatesCount indicates an expected call of GetPrometheusChecksUpdatesCount
+func (mr *MockDatabaseMockRecorder) GetPrometheusChecksUpdatesCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrometheusChecksUpdatesCount", reflect.TypeOf((*MockDatabase)(nil).GetPrometheusChecksUpdatesCount))
+}
+
+// GetPrometheusTriggerIDs mocks base method
+func (m *MockDatabase) GetPrometheusTriggerIDs() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrometheusTriggerIDs")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrometheusTriggerIDs indicates an expected call of GetPrometheusTriggerIDs
+func (mr *MockDatabaseMockRecorder) GetPrometheusTriggerIDs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrometheusTriggerIDs", reflect.TypeOf((*MockDatabase)(nil).GetPrometheusTriggerIDs))
+}
+
+// GetPrometheusTriggersToCheck mocks base method
+func (m *MockDatabase) GetPrometheusTriggersToCheck(arg0 int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrometheusTriggersToCheck", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrometheusTriggersToCheck indicates an expected call of GetPrometheusTriggersToCheck
+func (mr *MockDatabaseMockRecorder) GetPrometheusTriggersToCheck(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrometheusTriggersToCheck", reflect.TypeOf((*MockDatabase)(nil).GetPrometheusTriggersToCheck), arg0)
+}
+
+// GetPrometheusTriggersToCheckCount mocks base method
+func (m *MockDatabase) GetPrometheusTriggersToCheckCount() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrometheusTriggersToCheckCount")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrometheusTriggersToCheckCount indicates an expected call of GetPrometheusTriggersToCheckCount
+func (mr *MockDatabaseMockRecorder) GetPrometheusTriggersToCheckCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrometheusTriggersToCheckCount", reflect.TypeOf((*MockDatabase)(nil).GetPrometheusTriggersToCheckCount))
+}
+
 // GetRemoteChecksUpdatesCount mocks base method
-func (m *MockDatabase) GetRemoteChecksUpdatesCount() (int64, error) {
+func (m *MockDatabase) GetRemoteChecksUpdatesCount(arg0 moira.ClusterID) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRemoteChecksUpdatesCount")
+	ret := m.ctrl.Call(m, "GetRemoteChecksUpdatesCount", arg0)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRemoteChecksUpdatesCount indicates an expected call of GetRemoteChecksUpdatesCount
-func (mr *MockDatabaseMockRecorder) GetRemoteChecksUpdatesCount() *gomock.Call {
+func (mr *MockDatabaseMockRecorder) GetRemoteChecksUpdatesCount(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteChecksUpdatesCount", reflect.TypeOf((*MockDatabase)(nil).GetRemoteChecksUpdatesCount))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteChecksUpdatesCount", reflect.TypeOf((*MockDatabase)(nil).GetRemoteChecksUpdatesCount), arg0)
 }
 
 // GetRemoteTriggerIDs mocks base method
-func (m *MockDatabase) GetRemoteTriggerIDs() ([]string, error) {
+func (m *MockDatabase) GetRemoteTriggerIDs(arg0 moira.ClusterID) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRemoteTriggerIDs")
+	ret := m.ctrl.Call(m, "GetRemoteTriggerIDs", arg0)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRemoteTriggerIDs indicates an expected call of GetRemoteTriggerIDs
-func (mr *MockDatabaseMockRecorder) GetRemoteTriggerIDs() *gomock.Call {
+func (mr *MockDatabaseMockRecorder) GetRemoteTriggerIDs(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteTriggerIDs", reflect.TypeOf((*MockDatabase)(nil).GetRemoteTriggerIDs))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteTriggerIDs", reflect.TypeOf((*MockDatabase)(nil).GetRemoteTriggerIDs), arg0)
 }
 
 // GetRemoteTriggersToCheck mocks base method
-func (m *MockDatabase) GetRemoteTriggersToCheck(arg0 int) ([]string, error) {
+func (m *MockDatabase) GetRemoteTriggersToCheck(arg0 moira.ClusterID, arg1 int) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRemoteTriggersToCheck", arg0)
+	ret := m.ctrl.Call(m, "GetRemoteTriggersToCheck", arg0, arg1)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRemoteTriggersToCheck indicates an expected call of GetRemoteTriggersToCheck
-func (mr *MockDatabaseMockRecorder) GetRemoteTriggersToCheck(arg0 interface{}) *gomock.Call {
+func (mr *MockDatabaseMockRecorder) GetRemoteTriggersToCheck(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteTriggersToCheck", reflect.TypeOf((*MockDatabase)(nil).GetRemoteTriggersToCheck), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteTriggersToCheck", reflect.TypeOf((*MockDatabase)(nil).GetRemoteTriggersToCheck), arg0, arg1)
 }
 
 // GetRemoteTriggersToCheckCount mocks base method
-func (m *MockDatabase) GetRemoteTriggersToCheckCount() (int64, error) {
+func (m *MockDatabase) GetRemoteTriggersToCheckCount(arg0 moira.ClusterID) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRemoteTriggersToCheckCount")
+	ret := m.ctrl.Call(m, "GetRemoteTriggersToCheckCount", arg0)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRemoteTriggersToCheckCount indicates an expected call of GetRemoteTriggersToCheckCount
-func (mr *MockDatabaseMockRecorder) GetRemoteTriggersToCheckCount() *gomock.Call {
+func (mr *MockDatabaseMockRecorder) GetRemoteTriggersToCheckCount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteTriggersToCheckCount", reflect.TypeOf((*MockDatabase)(nil).GetRemoteTriggersToCheckCount), arg0)
+}
+
+// GetSilence mocks base method
+func (m *MockDatabase) GetSilence(arg0 string) (moira.Silence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSilence", arg0)
+	ret0, _ := ret[0].(moira.Silence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSilence indicates an expected call of GetSilence
+func (mr *MockDatabaseMockRecorder) GetSilence(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSilence", reflect.TypeOf((*MockDatabase)(nil).GetSilence), arg0)
+}
+
+// GetSilences mocks base method
+func (m *MockDatabase) GetSilences() ([]*moira.Silence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSilences")
+	ret0, _ := ret[0].([]*moira.Silence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSilences indicates an expected call of GetSilences
+func (mr *MockDatabaseMockRecorder) GetSilences() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSilences", reflect.TypeOf((*MockDatabase)(nil).GetSilences))
+}
+
+// GetStreamedNotificationEvents mocks base method
+func (m *MockDatabase) GetStreamedNotificationEvents(arg0 int64) ([]*moira.StreamedNotificationEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStreamedNotificationEvents", arg0)
+	ret0, _ := ret[0].([]*moira.StreamedNotificationEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStreamedNotificationEvents indicates an expected call of GetStreamedNotificationEvents
+func (mr *MockDatabaseMockRecorder) GetStreamedNotificationEvents(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteTriggersToCheckCount", reflect.TypeOf((*MockDatabase)(nil).GetRemoteTriggersToCheckCount))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStreamedNotificationEvents", reflect.TypeOf((*MockDatabase)(nil).GetStreamedNotificationEvents), arg0)
 }
 
 // GetSubscription mocks base method
@@ -552,6 +851,21 @@ func (mr *MockDatabaseMockRecorder) GetSubscription(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockDatabase)(nil).GetSubscription), arg0)
 }
 
+// GetSubscriptionThrottling mocks base method
+func (m *MockDatabase) GetSubscriptionThrottling(arg0, arg1 string) (time.Time, time.Time) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionThrottling", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(time.Time)
+	return ret0, ret1
+}
+
+// GetSubscriptionThrottling indicates an expected call of GetSubscriptionThrottling
+func (mr *MockDatabaseMockRecorder) GetSubscriptionThrottling(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionThrottling", reflect.TypeOf((*MockDatabase)(nil).GetSubscriptionThrottling), arg0, arg1)
+}
+
 // GetSubscriptions mocks base method
 func (m *MockDatabase) GetSubscriptions(arg0 []string) ([]*moira.SubscriptionData, error) {
 	m.ctrl.T.Helper()
@@ -612,6 +926,66 @@ func (mr *MockDatabaseMockRecorder) GetTagsSubscriptions(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagsSubscriptions", reflect.TypeOf((*MockDatabase)(nil).GetTagsSubscriptions), arg0)
 }
 
+// GetTeam mocks base method
+func (m *MockDatabase) GetTeam(arg0 string) (moira.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeam", arg0)
+	ret0, _ := ret[0].(moira.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeam indicates an expected call of GetTeam
+func (mr *MockDatabaseMockRecorder) GetTeam(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockDatabase)(nil).GetTeam), arg0)
+}
+
+// GetTeamSubscriptionIDs mocks base method
+func (m *MockDatabase) GetTeamSubscriptionIDs(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamSubscriptionIDs", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamSubscriptionIDs indicates an expected call of GetTeamSubscriptionIDs
+func (mr *MockDatabaseMockRecorder) GetTeamSubscriptionIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamSubscriptionIDs", reflect.TypeOf((*MockDatabase)(nil).GetTeamSubscriptionIDs), arg0)
+}
+
+// GetTeamTriggerIDs mocks base method
+func (m *MockDatabase) GetTeamTriggerIDs(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamTriggerIDs", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamTriggerIDs indicates an expected call of GetTeamTriggerIDs
+func (mr *MockDatabaseMockRecorder) GetTeamTriggerIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamTriggerIDs", reflect.TypeOf((*MockDatabase)(nil).GetTeamTriggerIDs), arg0)
+}
+
+// GetTeams mocks base method
+func (m *MockDatabase) GetTeams(arg0 []string) ([]*moira.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeams", arg0)
+	ret0, _ := ret[0].([]*moira.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeams indicates an expected call of GetTeams
+func (mr *MockDatabaseMockRecorder) GetTeams(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeams", reflect.TypeOf((*MockDatabase)(nil).GetTeams), arg0)
+}
+
 // GetTrigger mocks base method
 func (m *MockDatabase) GetTrigger(arg0 string) (moira.Trigger, error) {
 	m.ctrl.T.Helper()
@@ -642,6 +1016,52 @@ func (mr *MockDatabaseMockRecorder) GetTriggerChecks(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTriggerChecks", reflect.TypeOf((*MockDatabase)(nil).GetTriggerChecks), arg0)
 }
 
+// GetTriggerDeliveries mocks base method
+func (m *MockDatabase) GetTriggerDeliveries(arg0 string, arg1, arg2 int64) ([]*moira.NotificationDelivery, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTriggerDeliveries", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*moira.NotificationDelivery)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTriggerDeliveries indicates an expected call of GetTriggerDeliveries
+func (mr *MockDatabaseMockRecorder) GetTriggerDeliveries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTriggerDeliveries", reflect.TypeOf((*MockDatabase)(nil).GetTriggerDeliveries), arg0, arg1, arg2)
+}
+
+// GetTriggerDependents mocks base method
+func (m *MockDatabase) GetTriggerDependents(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTriggerDependents", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTriggerDependents indicates an expected call of GetTriggerDependents
+func (mr *MockDatabaseMockRecorder) GetTriggerDependents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTriggerDependents", reflect.TypeOf((*MockDatabase)(nil).GetTriggerDependents), arg0)
+}
+
+// GetTriggerIssue mocks base method
+func (m *MockDatabase) GetTriggerIssue(arg0, arg1 string) (moira.TrackerIssue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTriggerIssue", arg0, arg1)
+	ret0, _ := ret[0].(moira.TrackerIssue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTriggerIssue indicates an expected call of GetTriggerIssue
+func (mr *MockDatabaseMockRecorder) GetTriggerIssue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTriggerIssue", reflect.TypeOf((*MockDatabase)(nil).GetTriggerIssue), arg0, arg1)
+}
+
 // GetTriggerLastCheck mocks base method
 func (m *MockDatabase) GetTriggerLastCheck(arg0 string) (moira.CheckData, error) {
 	m.ctrl.T.Helper()
@@ -703,6 +1123,21 @@ func (mr *MockDatabaseMockRecorder) GetTriggersSearchResults(arg0, arg1, arg2 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTriggersSearchResults", reflect.TypeOf((*MockDatabase)(nil).GetTriggersSearchResults), arg0, arg1, arg2)
 }
 
+// GetUnexpiredSilences mocks base method
+func (m *MockDatabase) GetUnexpiredSilences(arg0 int64) ([]*moira.Silence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnexpiredSilences", arg0)
+	ret0, _ := ret[0].([]*moira.Silence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnexpiredSilences indicates an expected call of GetUnexpiredSilences
+func (mr *MockDatabaseMockRecorder) GetUnexpiredSilences(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnexpiredSilences", reflect.TypeOf((*MockDatabase)(nil).GetUnexpiredSilences), arg0)
+}
+
 // GetUnusedTriggerIDs mocks base method
 func (m *MockDatabase) GetUnusedTriggerIDs() ([]string, error) {
 	m.ctrl.T.Helper()
@@ -748,6 +1183,21 @@ func (mr *MockDatabaseMockRecorder) GetUserSubscriptionIDs(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSubscriptionIDs", reflect.TypeOf((*MockDatabase)(nil).GetUserSubscriptionIDs), arg0)
 }
 
+// GetUserTeamIDs mocks base method
+func (m *MockDatabase) GetUserTeamIDs(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTeamIDs", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTeamIDs indicates an expected call of GetUserTeamIDs
+func (mr *MockDatabaseMockRecorder) GetUserTeamIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTeamIDs", reflect.TypeOf((*MockDatabase)(nil).GetUserTeamIDs), arg0)
+}
+
 // MarkTriggersAsUnused mocks base method
 func (m *MockDatabase) MarkTriggersAsUnused(arg0 ...string) error {
 	m.ctrl.T.Helper()
@@ -854,6 +1304,21 @@ func (mr *MockDatabaseMockRecorder) RemoveContact(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveContact", reflect.TypeOf((*MockDatabase)(nil).RemoveContact), arg0)
 }
 
+// RemoveExpiredSilences mocks base method
+func (m *MockDatabase) RemoveExpiredSilences(arg0 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveExpiredSilences", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveExpiredSilences indicates an expected call of RemoveExpiredSilences
+func (mr *MockDatabaseMockRecorder) RemoveExpiredSilences(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveExpiredSilences", reflect.TypeOf((*MockDatabase)(nil).RemoveExpiredSilences), arg0)
+}
+
 // RemoveMetricValues mocks base method
 func (m *MockDatabase) RemoveMetricValues(arg0 string, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -953,6 +1418,20 @@ func (mr *MockDatabaseMockRecorder) RemovePatternsMetrics(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePatternsMetrics", reflect.TypeOf((*MockDatabase)(nil).RemovePatternsMetrics), arg0)
 }
 
+// RemoveSilence mocks base method
+func (m *MockDatabase) RemoveSilence(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSilence", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSilence indicates an expected call of RemoveSilence
+func (mr *MockDatabaseMockRecorder) RemoveSilence(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSilence", reflect.TypeOf((*MockDatabase)(nil).RemoveSilence), arg0)
+}
+
 // RemoveSubscription mocks base method
 func (m *MockDatabase) RemoveSubscription(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -981,6 +1460,20 @@ func (mr *MockDatabaseMockRecorder) RemoveTag(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTag", reflect.TypeOf((*MockDatabase)(nil).RemoveTag), arg0)
 }
 
+// RemoveTeam mocks base method
+func (m *MockDatabase) RemoveTeam(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTeam", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTeam indicates an expected call of RemoveTeam
+func (mr *MockDatabaseMockRecorder) RemoveTeam(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTeam", reflect.TypeOf((*MockDatabase)(nil).RemoveTeam), arg0)
+}
+
 // RemoveTrigger mocks base method
 func (m *MockDatabase) RemoveTrigger(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -995,6 +1488,20 @@ func (mr *MockDatabaseMockRecorder) RemoveTrigger(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTrigger", reflect.TypeOf((*MockDatabase)(nil).RemoveTrigger), arg0)
 }
 
+// RemoveTriggerIssue mocks base method
+func (m *MockDatabase) RemoveTriggerIssue(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTriggerIssue", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTriggerIssue indicates an expected call of RemoveTriggerIssue
+func (mr *MockDatabaseMockRecorder) RemoveTriggerIssue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTriggerIssue", reflect.TypeOf((*MockDatabase)(nil).RemoveTriggerIssue), arg0, arg1)
+}
+
 // RemoveTriggerLastCheck mocks base method
 func (m *MockDatabase) RemoveTriggerLastCheck(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -1037,6 +1544,20 @@ func (mr *MockDatabaseMockRecorder) RemoveUser(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUser", reflect.TypeOf((*MockDatabase)(nil).RemoveUser), arg0, arg1)
 }
 
+// SaveAuditRecord mocks base method
+func (m *MockDatabase) SaveAuditRecord(arg0 *moira.AuditRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAuditRecord", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAuditRecord indicates an expected call of SaveAuditRecord
+func (mr *MockDatabaseMockRecorder) SaveAuditRecord(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAuditRecord", reflect.TypeOf((*MockDatabase)(nil).SaveAuditRecord), arg0)
+}
+
 // SaveContact mocks base method
 func (m *MockDatabase) SaveContact(arg0 *moira.ContactData) error {
 	m.ctrl.T.Helper()
@@ -1065,6 +1586,20 @@ func (mr *MockDatabaseMockRecorder) SaveMetrics(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMetrics", reflect.TypeOf((*MockDatabase)(nil).SaveMetrics), arg0)
 }
 
+// SaveSilence mocks base method
+func (m *MockDatabase) SaveSilence(arg0 *moira.Silence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSilence", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSilence indicates an expected call of SaveSilence
+func (mr *MockDatabaseMockRecorder) SaveSilence(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSilence", reflect.TypeOf((*MockDatabase)(nil).SaveSilence), arg0)
+}
+
 // SaveSubscription mocks base method
 func (m *MockDatabase) SaveSubscription(arg0 *moira.SubscriptionData) error {
 	m.ctrl.T.Helper()
@@ -1093,6 +1628,20 @@ func (mr *MockDatabaseMockRecorder) SaveSubscriptions(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSubscriptions", reflect.TypeOf((*MockDatabase)(nil).SaveSubscriptions), arg0)
 }
 
+// SaveTeam mocks base method
+func (m *MockDatabase) SaveTeam(arg0 *moira.Team) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTeam", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTeam indicates an expected call of SaveTeam
+func (mr *MockDatabaseMockRecorder) SaveTeam(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTeam", reflect.TypeOf((*MockDatabase)(nil).SaveTeam), arg0)
+}
+
 // SaveTrigger mocks base method
 func (m *MockDatabase) SaveTrigger(arg0 string, arg1 *moira.Trigger) error {
 	m.ctrl.T.Helper()
@@ -1107,6 +1656,20 @@ func (mr *MockDatabaseMockRecorder) SaveTrigger(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTrigger", reflect.TypeOf((*MockDatabase)(nil).SaveTrigger), arg0, arg1)
 }
 
+// SaveTriggerIssue mocks base method
+func (m *MockDatabase) SaveTriggerIssue(arg0, arg1 string, arg2 *moira.TrackerIssue) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTriggerIssue", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTriggerIssue indicates an expected call of SaveTriggerIssue
+func (mr *MockDatabaseMockRecorder) SaveTriggerIssue(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTriggerIssue", reflect.TypeOf((*MockDatabase)(nil).SaveTriggerIssue), arg0, arg1, arg2)
+}
+
 // SaveTriggersSearchResults mocks base method
 func (m *MockDatabase) SaveTriggersSearchResults(arg0 string, arg1 []*moira.SearchResult) error {
 	m.ctrl.T.Helper()
@@ -1135,6 +1698,34 @@ func (mr *MockDatabaseMockRecorder) SetNotifierState(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNotifierState", reflect.TypeOf((*MockDatabase)(nil).SetNotifierState), arg0)
 }
 
+// SetSubscriptionThrottling mocks base method
+func (m *MockDatabase) SetSubscriptionThrottling(arg0, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSubscriptionThrottling", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSubscriptionThrottling indicates an expected call of SetSubscriptionThrottling
+func (mr *MockDatabaseMockRecorder) SetSubscriptionThrottling(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubscriptionThrottling", reflect.TypeOf((*MockDatabase)(nil).SetSubscriptionThrottling), arg0, arg1, arg2)
+}
+
+// SetTriggerCheckAcknowledgement mocks base method
+func (m *MockDatabase) SetTriggerCheckAcknowledgement(arg0 string, arg1 []string, arg2 *moira.Acknowledgement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTriggerCheckAcknowledgement", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTriggerCheckAcknowledgement indicates an expected call of SetTriggerCheckAcknowledgement
+func (mr *MockDatabaseMockRecorder) SetTriggerCheckAcknowledgement(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTriggerCheckAcknowledgement", reflect.TypeOf((*MockDatabase)(nil).SetTriggerCheckAcknowledgement), arg0, arg1, arg2)
+}
+
 // SetTriggerCheckLock mocks base method
 func (m *MockDatabase) SetTriggerCheckLock(arg0 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -1165,7 +1756,7 @@ func (mr *MockDatabaseMockRecorder) SetTriggerCheckMaintenance(arg0, arg1, arg2,
 }
 
 // SetTriggerLastCheck mocks base method
-func (m *MockDatabase) SetTriggerLastCheck(arg0 string, arg1 *moira.CheckData, arg2 bool) error {
+func (m *MockDatabase) SetTriggerLastCheck(arg0 string, arg1 *moira.CheckData, arg2 moira.ClusterKey) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SetTriggerLastCheck", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
@@ -1178,20 +1769,6 @@ func (mr *MockDatabaseMockRecorder) SetTriggerLastCheck(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTriggerLastCheck", reflect.TypeOf((*MockDatabase)(nil).SetTriggerLastCheck), arg0, arg1, arg2)
 }
 
-// SetTriggerThrottling mocks base method
-func (m *MockDatabase) SetTriggerThrottling(arg0 string, arg1 time.Time) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetTriggerThrottling", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// SetTriggerThrottling indicates an expected call of SetTriggerThrottling
-func (mr *MockDatabaseMockRecorder) SetTriggerThrottling(arg0, arg1 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTriggerThrottling", reflect.TypeOf((*MockDatabase)(nil).SetTriggerThrottling), arg0, arg1)
-}
-
 // SetUsernameID mocks base method
 func (m *MockDatabase) SetUsernameID(arg0, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -1221,6 +1798,21 @@ func (mr *MockDatabaseMockRecorder) SubscribeMetricEvents(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeMetricEvents", reflect.TypeOf((*MockDatabase)(nil).SubscribeMetricEvents), arg0)
 }
 
+// SubscribeNotificationEvents mocks base method
+func (m *MockDatabase) SubscribeNotificationEvents(arg0 *tomb.Tomb) (<-chan *moira.StreamedNotificationEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeNotificationEvents", arg0)
+	ret0, _ := ret[0].(<-chan *moira.StreamedNotificationEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeNotificationEvents indicates an expected call of SubscribeNotificationEvents
+func (mr *MockDatabaseMockRecorder) SubscribeNotificationEvents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeNotificationEvents", reflect.TypeOf((*MockDatabase)(nil).SubscribeNotificationEvents), arg0)
+}
+
 // UpdateMetricsHeartbeat mocks base method
 func (m *MockDatabase) UpdateMetricsHeartbeat() error {
 	m.ctrl.T.Helper()
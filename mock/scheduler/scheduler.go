@@ -34,6 +34,20 @@ func (m *MockScheduler) EXPECT() *MockSchedulerMockRecorder {
 	return m.recorder
 }
 
+// ScheduleEscalation mocks base method
+func (m *MockScheduler) ScheduleEscalation(arg0 time.Time, arg1 moira.NotificationEvent, arg2 moira.TriggerData, arg3 moira.ContactData, arg4 moira.PlottingData, arg5 moira.EscalationData, arg6 int) *moira.ScheduledNotification {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleEscalation", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(*moira.ScheduledNotification)
+	return ret0
+}
+
+// ScheduleEscalation indicates an expected call of ScheduleEscalation
+func (mr *MockSchedulerMockRecorder) ScheduleEscalation(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleEscalation", reflect.TypeOf((*MockScheduler)(nil).ScheduleEscalation), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
 // ScheduleNotification mocks base method
 func (m *MockScheduler) ScheduleNotification(arg0 time.Time, arg1 moira.NotificationEvent, arg2 moira.TriggerData, arg3 moira.ContactData, arg4 moira.PlottingData, arg5 bool, arg6 int) *moira.ScheduledNotification {
 	m.ctrl.T.Helper()
@@ -2,6 +2,8 @@ package notifier
 
 import (
 	"time"
+
+	"github.com/moira-alert/moira"
 )
 
 // Config is sending settings including log settings
@@ -17,4 +19,5 @@ type Config struct {
 	FrontURL          string
 	Location          *time.Location
 	DateTimeFormat    string
+	ThrottlingLevels  []moira.ThrottlingLevel
 }
@@ -67,6 +67,12 @@ func (worker *FetchEventsWorker) processEvent(event moira.NotificationEvent) err
 		triggerData   moira.TriggerData
 	)
 
+	if event.State != moira.StateTEST && event.SilenceID != nil {
+		worker.Logger.Debugf("Skip trigger id %s event for metric %s, %s -> %s, silenced by %s", event.TriggerID, event.Metric, event.OldState, event.State, *event.SilenceID)
+		worker.Metrics.EventsSilenced.Mark(1)
+		return nil
+	}
+
 	if event.State != moira.StateTEST {
 		worker.Logger.Debugf("Processing trigger id %s for metric %s == %f, %s -> %s", event.TriggerID, event.Metric, moira.UseFloat64(event.Value), event.OldState, event.State)
 
@@ -79,14 +85,15 @@ func (worker *FetchEventsWorker) processEvent(event moira.NotificationEvent) err
 		}
 
 		triggerData = moira.TriggerData{
-			ID:         trigger.ID,
-			Name:       trigger.Name,
-			Desc:       moira.UseString(trigger.Desc),
-			Targets:    trigger.Targets,
-			WarnValue:  moira.UseFloat64(trigger.WarnValue),
-			ErrorValue: moira.UseFloat64(trigger.ErrorValue),
-			IsRemote:   trigger.IsRemote,
-			Tags:       trigger.Tags,
+			ID:            trigger.ID,
+			Name:          trigger.Name,
+			Desc:          moira.UseString(trigger.Desc),
+			Targets:       trigger.Targets,
+			WarnValue:     moira.UseFloat64(trigger.WarnValue),
+			ErrorValue:    moira.UseFloat64(trigger.ErrorValue),
+			IsRemote:      trigger.TriggerSource.IsRemote(),
+			TriggerSource: trigger.TriggerSource,
+			Tags:          trigger.Tags,
 		}
 
 		worker.Logger.Debugf("Getting subscriptions for tags %v", trigger.Tags)
@@ -106,30 +113,54 @@ func (worker *FetchEventsWorker) processEvent(event moira.NotificationEvent) err
 
 	for _, subscription := range subscriptions {
 		if worker.isNotificationRequired(subscription, triggerData, event) {
+			event.SubscriptionID = &subscription.ID
 			for _, contactID := range subscription.Contacts {
 				contact, err := worker.Database.GetContact(contactID)
 				if err != nil {
 					worker.Logger.Warningf("Failed to get contact: %s, skip handling it, error: %v", contactID, err)
 					continue
 				}
-				event.SubscriptionID = &subscription.ID
 				notification := worker.Scheduler.ScheduleNotification(time.Now(), event, triggerData,
 					contact, subscription.Plotting, false, 0)
-				key := notification.GetKey()
-				if _, exist := duplications[key]; !exist {
-					if err := worker.Database.AddNotification(notification); err != nil {
-						worker.Logger.Errorf("Failed to save scheduled notification: %s", err)
-					}
-					duplications[key] = true
-				} else {
-					worker.Logger.Debugf("Skip duplicated notification for contact %s", notification.Contact)
-				}
+				worker.addNotification(notification, duplications)
+			}
+			if subscription.NeedEscalate(&event) {
+				worker.scheduleEscalations(subscription, event, triggerData, duplications)
 			}
 		}
 	}
 	return nil
 }
 
+// scheduleEscalations schedules notifications of every subscription escalation contacts
+func (worker *FetchEventsWorker) scheduleEscalations(subscription *moira.SubscriptionData, event moira.NotificationEvent, triggerData moira.TriggerData, duplications map[string]bool) {
+	now := time.Now()
+	for i, escalation := range subscription.Escalations {
+		for _, contactID := range escalation.Contacts {
+			contact, err := worker.Database.GetContact(contactID)
+			if err != nil {
+				worker.Logger.Warningf("Failed to get escalation contact: %s, skip handling it, error: %v", contactID, err)
+				continue
+			}
+			notification := worker.Scheduler.ScheduleEscalation(now, event, triggerData,
+				contact, subscription.Plotting, escalation, i+1)
+			worker.addNotification(notification, duplications)
+		}
+	}
+}
+
+func (worker *FetchEventsWorker) addNotification(notification *moira.ScheduledNotification, duplications map[string]bool) {
+	key := notification.GetKey()
+	if _, exist := duplications[key]; exist {
+		worker.Logger.Debugf("Skip duplicated notification for contact %s", notification.Contact)
+		return
+	}
+	if err := worker.Database.AddNotification(notification); err != nil {
+		worker.Logger.Errorf("Failed to save scheduled notification: %s", err)
+	}
+	duplications[key] = true
+}
+
 func (worker *FetchEventsWorker) getNotificationSubscriptions(event moira.NotificationEvent) (*moira.SubscriptionData, error) {
 	if event.SubscriptionID != nil {
 		worker.Logger.Debugf("Getting subscriptionID %s for test message", *event.SubscriptionID)
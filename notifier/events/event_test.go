@@ -31,7 +31,7 @@ func TestEvent(t *testing.T) {
 			Database:  dataBase,
 			Logger:    logger,
 			Metrics:   notifierMetrics,
-			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics),
+			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics, nil),
 		}
 		event := moira.NotificationEvent{
 			State:          moira.StateTEST,
@@ -110,7 +110,7 @@ func TestNoSubscription(t *testing.T) {
 			Database:  dataBase,
 			Logger:    logger,
 			Metrics:   notifierMetrics,
-			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics),
+			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics, nil),
 		}
 
 		event := moira.NotificationEvent{
@@ -128,6 +128,34 @@ func TestNoSubscription(t *testing.T) {
 	})
 }
 
+func TestSilencedEvent(t *testing.T) {
+	Convey("When event is silenced, should not get subscriptions and call AddNotification", t, func() {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+		logger, _ := logging.GetLogger("Events")
+
+		worker := FetchEventsWorker{
+			Database:  dataBase,
+			Logger:    logger,
+			Metrics:   notifierMetrics,
+			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics, nil),
+		}
+
+		silenceID := "silence-id"
+		event := moira.NotificationEvent{
+			Metric:    "generate.event.1",
+			State:     moira.StateERROR,
+			OldState:  moira.StateOK,
+			TriggerID: triggerData.ID,
+			SilenceID: &silenceID,
+		}
+
+		err := worker.processEvent(event)
+		So(err, ShouldBeEmpty)
+	})
+}
+
 func TestDisabledNotification(t *testing.T) {
 	Convey("When subscription event tags is disabled, should not call AddNotification", t, func() {
 		mockCtrl := gomock.NewController(t)
@@ -139,7 +167,7 @@ func TestDisabledNotification(t *testing.T) {
 			Database:  dataBase,
 			Logger:    logger,
 			Metrics:   notifierMetrics,
-			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics),
+			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics, nil),
 		}
 
 		event := moira.NotificationEvent{
@@ -172,7 +200,7 @@ func TestSubscriptionsManagedToIgnoreEvents(t *testing.T) {
 			Database:  dataBase,
 			Logger:    logger,
 			Metrics:   notifierMetrics,
-			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics),
+			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics, nil),
 		}
 
 		event := moira.NotificationEvent{
@@ -197,7 +225,7 @@ func TestSubscriptionsManagedToIgnoreEvents(t *testing.T) {
 			Database:  dataBase,
 			Logger:    logger,
 			Metrics:   notifierMetrics,
-			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics),
+			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics, nil),
 		}
 
 		event := moira.NotificationEvent{
@@ -222,7 +250,7 @@ func TestSubscriptionsManagedToIgnoreEvents(t *testing.T) {
 			Database:  dataBase,
 			Logger:    logger,
 			Metrics:   notifierMetrics,
-			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics),
+			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics, nil),
 		}
 
 		event := moira.NotificationEvent{
@@ -278,6 +306,66 @@ func TestAddNotification(t *testing.T) {
 	})
 }
 
+func TestAddEscalationNotifications(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	logger, _ := logging.GetLogger("Events")
+	scheduler := mock_scheduler.NewMockScheduler(mockCtrl)
+	worker := FetchEventsWorker{
+		Database:  dataBase,
+		Logger:    logger,
+		Metrics:   notifierMetrics,
+		Scheduler: scheduler,
+	}
+
+	escalationContact := moira.ContactData{ID: "ContactID-000000000000002", Type: "email", Value: "mail2@example.com"}
+	escalation := moira.EscalationData{Contacts: []string{escalationContact.ID}, OffsetInMinutes: 30}
+	escalatedSubscription := subscription
+	escalatedSubscription.Escalations = []moira.EscalationData{escalation}
+	notification := moira.ScheduledNotification{Timestamp: 1}
+	escalationNotification := moira.ScheduledNotification{Timestamp: 2, Escalation: 1}
+
+	Convey("When event state is ERROR, should add escalation notifications", t, func() {
+		event := moira.NotificationEvent{
+			Metric:         "generate.event.1",
+			State:          moira.StateERROR,
+			OldState:       moira.StateOK,
+			TriggerID:      triggerData.ID,
+			SubscriptionID: &escalatedSubscription.ID,
+		}
+		dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+		dataBase.EXPECT().GetTagsSubscriptions(triggerData.Tags).Return([]*moira.SubscriptionData{&escalatedSubscription}, nil)
+		dataBase.EXPECT().GetContact(contact.ID).Return(contact, nil)
+		dataBase.EXPECT().GetContact(escalationContact.ID).Return(escalationContact, nil)
+		scheduler.EXPECT().ScheduleNotification(gomock.Any(), event, triggerData, contact, escalatedSubscription.Plotting, false, 0).Return(&notification)
+		scheduler.EXPECT().ScheduleEscalation(gomock.Any(), event, triggerData, escalationContact, escalatedSubscription.Plotting, escalation, 1).Return(&escalationNotification)
+		dataBase.EXPECT().AddNotification(&notification).Return(nil)
+		dataBase.EXPECT().AddNotification(&escalationNotification).Return(nil)
+
+		err := worker.processEvent(event)
+		So(err, ShouldBeEmpty)
+	})
+
+	Convey("When event state is WARN, should not add escalation notifications", t, func() {
+		event := moira.NotificationEvent{
+			Metric:         "generate.event.1",
+			State:          moira.StateWARN,
+			OldState:       moira.StateOK,
+			TriggerID:      triggerData.ID,
+			SubscriptionID: &escalatedSubscription.ID,
+		}
+		dataBase.EXPECT().GetTrigger(event.TriggerID).Return(trigger, nil)
+		dataBase.EXPECT().GetTagsSubscriptions(triggerData.Tags).Return([]*moira.SubscriptionData{&escalatedSubscription}, nil)
+		dataBase.EXPECT().GetContact(contact.ID).Return(contact, nil)
+		scheduler.EXPECT().ScheduleNotification(gomock.Any(), event, triggerData, contact, escalatedSubscription.Plotting, false, 0).Return(&notification)
+		dataBase.EXPECT().AddNotification(&notification).Return(nil)
+
+		err := worker.processEvent(event)
+		So(err, ShouldBeEmpty)
+	})
+}
+
 func TestAddOneNotificationByTwoSubscriptionsWithSame(t *testing.T) {
 	Convey("When good subscription and create 2 same scheduled notifications, should add one new notification", t, func() {
 		mockCtrl := gomock.NewController(t)
@@ -328,7 +416,7 @@ func TestFailReadContact(t *testing.T) {
 			Database:  dataBase,
 			Logger:    logger,
 			Metrics:   notifierMetrics,
-			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics),
+			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics, nil),
 		}
 
 		event := moira.NotificationEvent{
@@ -362,7 +450,7 @@ func TestEmptySubscriptions(t *testing.T) {
 			Database:  dataBase,
 			Logger:    logger,
 			Metrics:   notifierMetrics,
-			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics),
+			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics, nil),
 		}
 
 		event := moira.NotificationEvent{
@@ -391,7 +479,7 @@ func TestEmptySubscriptions(t *testing.T) {
 			Database:  dataBase,
 			Logger:    logger,
 			Metrics:   notifierMetrics,
-			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics),
+			Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics, nil),
 		}
 
 		event := moira.NotificationEvent{
@@ -422,7 +510,7 @@ func TestGetNotificationSubscriptions(t *testing.T) {
 		Database:  dataBase,
 		Logger:    logger,
 		Metrics:   notifierMetrics,
-		Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics),
+		Scheduler: notifier.NewScheduler(dataBase, logger, notifierMetrics, nil),
 	}
 
 	Convey("Error GetSubscription", t, func() {
@@ -8,6 +8,7 @@ import (
 	"gopkg.in/tomb.v2"
 
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
 	"github.com/moira-alert/moira/notifier"
 )
 
@@ -66,8 +67,14 @@ func (worker *FetchNotificationsWorker) processScheduledNotifications() error {
 		return err
 	}
 	notificationPackages := make(map[string]*notifier.NotificationPackage)
+	lastChecks := make(map[string]*moira.CheckData)
 	for _, notification := range notifications {
-		packageKey := fmt.Sprintf("%s:%s:%s", notification.Contact.Type, notification.Contact.Value, notification.Event.TriggerID)
+		if notification.Escalation > 0 && !worker.isEscalationActual(notification, lastChecks) {
+			worker.Logger.Debugf("Cancel escalation #%d notification for contact %s:%s trigger %s metric %s, state was changed",
+				notification.Escalation, notification.Contact.Type, notification.Contact.Value, notification.Event.TriggerID, notification.Event.Metric)
+			continue
+		}
+		packageKey := getPackageKey(notification)
 		p, found := notificationPackages[packageKey]
 		if !found {
 			p = &notifier.NotificationPackage{
@@ -78,6 +85,14 @@ func (worker *FetchNotificationsWorker) processScheduledNotifications() error {
 				Throttled: notification.Throttled,
 				FailCount: notification.SendFail,
 			}
+			if notification.Contact.DigestInterval > 0 {
+				p.Digest = true
+				p.Triggers = make(map[string]moira.TriggerData)
+			}
+		}
+		if p.Digest {
+			p.Triggers[notification.Event.TriggerID] = notification.Trigger
+			p.Throttled = p.Throttled || notification.Throttled
 		}
 		p.Events = append(p.Events, notification.Event)
 		notificationPackages[packageKey] = p
@@ -89,3 +104,54 @@ func (worker *FetchNotificationsWorker) processScheduledNotifications() error {
 	sendingWG.Wait()
 	return nil
 }
+
+// getPackageKey returns key of package to send notification in. Notifications of contact with digest interval
+// are sent in a single package, other notifications are grouped by contact and trigger
+func getPackageKey(notification *moira.ScheduledNotification) string {
+	if notification.Contact.DigestInterval > 0 {
+		return fmt.Sprintf("%s:%s:digest", notification.Contact.Type, notification.Contact.Value)
+	}
+	return fmt.Sprintf("%s:%s:%s", notification.Contact.Type, notification.Contact.Value, notification.Event.TriggerID)
+}
+
+// isEscalationActual checks that trigger or metric of escalated notification event is still in the same state
+// and was not acknowledged or suppressed since. Last checks of triggers are cached in given map
+func (worker *FetchNotificationsWorker) isEscalationActual(notification *moira.ScheduledNotification, lastChecks map[string]*moira.CheckData) bool {
+	triggerID := notification.Event.TriggerID
+	lastCheck, found := lastChecks[triggerID]
+	if !found {
+		check, err := worker.Database.GetTriggerLastCheck(triggerID)
+		if err != nil {
+			if err != database.ErrNil {
+				worker.Logger.Warningf("Failed to get trigger %s last check, escalation is sent: %s", triggerID, err.Error())
+				return true
+			}
+			lastChecks[triggerID] = nil
+			return false
+		}
+		lastCheck = &check
+		lastChecks[triggerID] = lastCheck
+	}
+	return isEventStateActual(lastCheck, &notification.Event, time.Now().Unix())
+}
+
+// isEventStateActual checks that trigger or metric is still in the state of escalated event and was neither
+// suppressed nor acknowledged since the event. Event timestamp of last check is not compared, because
+// reminders of the same state move it between escalation steps
+func isEventStateActual(lastCheck *moira.CheckData, event *moira.NotificationEvent, now int64) bool {
+	if lastCheck == nil {
+		return false
+	}
+	if event.IsTriggerEvent {
+		return lastCheck.State == event.State && !lastCheck.Suppressed &&
+			!isAcknowledgedSince(lastCheck.Acknowledgement, event.Timestamp, now)
+	}
+	metricState, ok := lastCheck.Metrics[event.Metric]
+	return ok && metricState.State == event.State && !metricState.Suppressed &&
+		!isAcknowledgedSince(metricState.Acknowledgement, event.Timestamp, now)
+}
+
+// isAcknowledgedSince checks that acknowledgement is active and was given not earlier than given timestamp
+func isAcknowledgedSince(ack *moira.Acknowledgement, timestamp, now int64) bool {
+	return ack.IsActive(now) && ack.Timestamp >= timestamp
+}
@@ -9,6 +9,7 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
 	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
 	mock_notifier "github.com/moira-alert/moira/mock/notifier"
 	notifier2 "github.com/moira-alert/moira/notifier"
@@ -120,6 +121,126 @@ func TestProcessScheduledEvent(t *testing.T) {
 	})
 }
 
+func TestGetPackageKey(t *testing.T) {
+	Convey("Notifications are packed by contact and trigger", t, func() {
+		notification := &moira.ScheduledNotification{
+			Event:   moira.NotificationEvent{TriggerID: "triggerID"},
+			Contact: moira.ContactData{Type: "mail", Value: "mail@example.com"},
+		}
+		So(getPackageKey(notification), ShouldEqual, "mail:mail@example.com:triggerID")
+	})
+
+	Convey("Digest notifications are packed by contact only", t, func() {
+		notification := &moira.ScheduledNotification{
+			Event:   moira.NotificationEvent{TriggerID: "triggerID"},
+			Contact: moira.ContactData{Type: "mail", Value: "mail@example.com", DigestInterval: 3600},
+		}
+		So(getPackageKey(notification), ShouldEqual, "mail:mail@example.com:digest")
+	})
+}
+
+func TestProcessEscalations(t *testing.T) {
+	triggerID := "triggerID-00000000000001"
+	escalated := moira.ScheduledNotification{
+		Event: moira.NotificationEvent{
+			TriggerID: triggerID,
+			Metric:    "my.metric",
+			State:     moira.StateERROR,
+			OldState:  moira.StateOK,
+			Timestamp: 1441188000,
+		},
+		Contact:    contact1,
+		Timestamp:  1441188915,
+		Escalation: 1,
+	}
+	pkg := notifier2.NotificationPackage{
+		Trigger: escalated.Trigger,
+		Contact: escalated.Contact,
+		Events:  []moira.NotificationEvent{escalated.Event},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	notifier := mock_notifier.NewMockNotifier(mockCtrl)
+	logger, _ := logging.GetLogger("Notification")
+	worker := &FetchNotificationsWorker{
+		Database: dataBase,
+		Logger:   logger,
+		Notifier: notifier,
+	}
+
+	Convey("Metric is still in escalated state, should send escalation", t, func() {
+		dataBase.EXPECT().GetNotifierState().Return(moira.SelfStateOK, nil)
+		dataBase.EXPECT().FetchNotifications(gomock.Any()).Return([]*moira.ScheduledNotification{&escalated}, nil)
+		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{
+			Metrics: map[string]moira.MetricState{
+				"my.metric": {State: moira.StateERROR, EventTimestamp: 1441188000},
+			},
+		}, nil)
+		notifier.EXPECT().Send(&pkg, gomock.Any())
+		err := worker.processScheduledNotifications()
+		So(err, ShouldBeEmpty)
+	})
+
+	Convey("Reminder was sent between escalation steps, should send escalation", t, func() {
+		dataBase.EXPECT().GetNotifierState().Return(moira.SelfStateOK, nil)
+		dataBase.EXPECT().FetchNotifications(gomock.Any()).Return([]*moira.ScheduledNotification{&escalated}, nil)
+		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{
+			Metrics: map[string]moira.MetricState{
+				"my.metric": {State: moira.StateERROR, EventTimestamp: 1441188600, ReminderCount: 1},
+			},
+		}, nil)
+		notifier.EXPECT().Send(&pkg, gomock.Any())
+		err := worker.processScheduledNotifications()
+		So(err, ShouldBeEmpty)
+	})
+
+	Convey("Metric state was changed, should cancel escalation", t, func() {
+		dataBase.EXPECT().GetNotifierState().Return(moira.SelfStateOK, nil)
+		dataBase.EXPECT().FetchNotifications(gomock.Any()).Return([]*moira.ScheduledNotification{&escalated}, nil)
+		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{
+			Metrics: map[string]moira.MetricState{
+				"my.metric": {State: moira.StateOK, EventTimestamp: 1441188600},
+			},
+		}, nil)
+		err := worker.processScheduledNotifications()
+		So(err, ShouldBeEmpty)
+	})
+
+	Convey("Metric is under maintenance, should cancel escalation", t, func() {
+		dataBase.EXPECT().GetNotifierState().Return(moira.SelfStateOK, nil)
+		dataBase.EXPECT().FetchNotifications(gomock.Any()).Return([]*moira.ScheduledNotification{&escalated}, nil)
+		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{
+			Metrics: map[string]moira.MetricState{
+				"my.metric": {State: moira.StateERROR, EventTimestamp: 1441188000, Suppressed: true},
+			},
+		}, nil)
+		err := worker.processScheduledNotifications()
+		So(err, ShouldBeEmpty)
+	})
+
+	Convey("Metric is acknowledged, should cancel escalation", t, func() {
+		dataBase.EXPECT().GetNotifierState().Return(moira.SelfStateOK, nil)
+		dataBase.EXPECT().FetchNotifications(gomock.Any()).Return([]*moira.ScheduledNotification{&escalated}, nil)
+		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{
+			Metrics: map[string]moira.MetricState{
+				"my.metric": {State: moira.StateERROR, EventTimestamp: 1441188000, Acknowledgement: &moira.Acknowledgement{User: "user", Timestamp: 1441188600}},
+			},
+		}, nil)
+		err := worker.processScheduledNotifications()
+		So(err, ShouldBeEmpty)
+	})
+
+	Convey("Trigger was removed, should cancel escalation", t, func() {
+		dataBase.EXPECT().GetNotifierState().Return(moira.SelfStateOK, nil)
+		dataBase.EXPECT().FetchNotifications(gomock.Any()).Return([]*moira.ScheduledNotification{&escalated}, nil)
+		dataBase.EXPECT().GetTriggerLastCheck(triggerID).Return(moira.CheckData{}, database.ErrNil)
+		err := worker.processScheduledNotifications()
+		So(err, ShouldBeEmpty)
+	})
+}
+
 func TestGoRoutine(t *testing.T) {
 	subID5 := "subscriptionID-00000000000005"
 
@@ -5,13 +5,15 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/moira-alert/moira"
 	metricSource "github.com/moira-alert/moira/metric_source"
 	"github.com/moira-alert/moira/metrics"
 	"github.com/moira-alert/moira/plotting"
 )
 
-// NotificationPackage represent sending data
+// NotificationPackage represent sending data. Digest package contains events of many triggers,
+// its Triggers are used instead of Trigger
 type NotificationPackage struct {
 	Events     []moira.NotificationEvent
 	Trigger    moira.TriggerData
@@ -20,13 +22,53 @@ type NotificationPackage struct {
 	FailCount  int
 	Throttled  bool
 	DontResend bool
+	Digest     bool
+	Triggers   map[string]moira.TriggerData
 }
 
 // String returns notification package summary
 func (pkg NotificationPackage) String() string {
+	if pkg.Digest {
+		return fmt.Sprintf("digest package of %d notifications of %d triggers to %s", len(pkg.Events), len(pkg.Triggers), pkg.Contact.Value)
+	}
 	return fmt.Sprintf("package of %d notifications to %s", len(pkg.Events), pkg.Contact.Value)
 }
 
+// GetEventTrigger returns trigger of given package event
+func (pkg NotificationPackage) GetEventTrigger(event *moira.NotificationEvent) moira.TriggerData {
+	if pkg.Digest {
+		return pkg.Triggers[event.TriggerID]
+	}
+	return pkg.Trigger
+}
+
+// GetTriggerIDs returns ids of triggers package events belong to
+func (pkg NotificationPackage) GetTriggerIDs() []string {
+	if !pkg.Digest {
+		return []string{pkg.Trigger.ID}
+	}
+	triggerIDs := make([]string, 0, len(pkg.Triggers))
+	for _, events := range moira.NotificationEvents(pkg.Events).GroupByTrigger() {
+		triggerIDs = append(triggerIDs, events[0].TriggerID)
+	}
+	return triggerIDs
+}
+
+// SplitDigest splits digest package to packages of single trigger events
+func (pkg NotificationPackage) SplitDigest() []NotificationPackage {
+	groups := moira.NotificationEvents(pkg.Events).GroupByTrigger()
+	packages := make([]NotificationPackage, 0, len(groups))
+	for _, events := range groups {
+		triggerPkg := pkg
+		triggerPkg.Events = events
+		triggerPkg.Trigger = pkg.Triggers[events[0].TriggerID]
+		triggerPkg.Digest = false
+		triggerPkg.Triggers = nil
+		packages = append(packages, triggerPkg)
+	}
+	return packages
+}
+
 // GetWindow returns the earliest and the latest notification package timestamps
 func (pkg NotificationPackage) GetWindow() (from, to int64, err error) {
 	timeStamps := make([]int64, 0)
@@ -79,19 +121,25 @@ type StandardNotifier struct {
 	metrics              *metrics.NotifierMetrics
 	metricSourceProvider *metricSource.SourceProvider
 	imageStores          map[string]moira.ImageStore
+	retryPolicies        map[string]RetryPolicy
 }
 
 // NewNotifier is initializer for StandardNotifier
 func NewNotifier(database moira.Database, logger moira.Logger, config Config, metrics *metrics.NotifierMetrics, metricSourceProvider *metricSource.SourceProvider, imageStoreMap map[string]moira.ImageStore) *StandardNotifier {
+	// retry policies are shared with scheduler and filled on senders registration
+	retryPolicies := make(map[string]RetryPolicy)
+	scheduler := NewScheduler(database, logger, metrics, config.ThrottlingLevels)
+	scheduler.retryPolicies = retryPolicies
 	return &StandardNotifier{
 		senders:              make(map[string]chan NotificationPackage),
 		logger:               logger,
 		database:             database,
-		scheduler:            NewScheduler(database, logger, metrics),
+		scheduler:            scheduler,
 		config:               config,
 		metrics:              metrics,
 		metricSourceProvider: metricSourceProvider,
 		imageStores:          imageStoreMap,
+		retryPolicies:        retryPolicies,
 	}
 }
 
@@ -99,7 +147,7 @@ func NewNotifier(database moira.Database, logger moira.Logger, config Config, me
 func (notifier *StandardNotifier) Send(pkg *NotificationPackage, waitGroup *sync.WaitGroup) {
 	ch, found := notifier.senders[pkg.Contact.Type]
 	if !found {
-		notifier.resend(pkg, fmt.Sprintf("Unknown contact type '%s' [%s]", pkg.Contact.Type, pkg))
+		notifier.resend(pkg, fmt.Sprintf("Unknown contact type '%s' [%s]", pkg.Contact.Type, pkg), 0)
 		return
 	}
 	waitGroup.Add(1)
@@ -110,7 +158,7 @@ func (notifier *StandardNotifier) Send(pkg *NotificationPackage, waitGroup *sync
 		case ch <- *pkg:
 			break
 		case <-time.After(notifier.config.SendingTimeout):
-			notifier.resend(pkg, fmt.Sprintf("Timeout sending %s", pkg))
+			notifier.resend(pkg, fmt.Sprintf("Timeout sending %s", pkg), 0)
 			break
 		}
 	}(pkg)
@@ -125,48 +173,161 @@ func (notifier *StandardNotifier) GetSenders() map[string]bool {
 	return hash
 }
 
-func (notifier *StandardNotifier) resend(pkg *NotificationPackage, reason string) {
+// resend schedules package notifications according to sender retry policy, but not earlier than after retryAfter delay
+func (notifier *StandardNotifier) resend(pkg *NotificationPackage, reason string, retryAfter time.Duration) {
 	if pkg.DontResend {
 		return
 	}
+	notifier.markFailed(pkg)
+	retryPolicy := notifier.getRetryPolicy(pkg.Contact.Type)
+	if !retryPolicy.CanRetry(pkg.FailCount, notifier.config.ResendingTimeout) {
+		notifier.logger.Errorf("Can't send message after %d try: %s. Stop resending. Notification interval is timed out", pkg.FailCount, reason)
+		notifier.drop(pkg, reason)
+		return
+	}
+	delay := retryPolicy.GetDelay(pkg.FailCount + 1)
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+	notifier.logger.Warningf("Can't send message after %d try: %s. Retry again after %s", pkg.FailCount, reason, delay)
+	if metric, found := notifier.metrics.SendersRetriedMetrics.GetRegisteredMeter(pkg.Contact.Type); found {
+		metric.Mark(1)
+	}
+	now := time.Now()
+	for _, event := range pkg.Events {
+		notification := notifier.scheduler.ScheduleNotification(now, event,
+			pkg.GetEventTrigger(&event), pkg.Contact, pkg.Plotting, pkg.Throttled, pkg.FailCount+1)
+		if notBefore := now.Add(retryAfter).Unix(); notification.Timestamp < notBefore {
+			notification.Timestamp = notBefore
+		}
+		if err := notifier.database.AddNotification(notification); err != nil {
+			notifier.logger.Errorf("Failed to save scheduled notification: %s", err)
+		}
+	}
+}
+
+func (notifier *StandardNotifier) markFailed(pkg *NotificationPackage) {
 	notifier.metrics.SendingFailed.Mark(1)
 	if metric, found := notifier.metrics.SendersFailedMetrics.GetRegisteredMeter(pkg.Contact.Type); found {
 		metric.Mark(1)
 	}
-	notifier.logger.Warningf("Can't send message after %d try: %s. Retry again after 1 min", pkg.FailCount, reason)
-	if time.Duration(pkg.FailCount)*time.Minute > notifier.config.ResendingTimeout {
-		notifier.logger.Error("Stop resending. Notification interval is timed out")
-	} else {
-		for _, event := range pkg.Events {
-			notification := notifier.scheduler.ScheduleNotification(time.Now(), event,
-				pkg.Trigger, pkg.Contact, pkg.Plotting, pkg.Throttled, pkg.FailCount+1)
-			if err := notifier.database.AddNotification(notification); err != nil {
-				notifier.logger.Errorf("Failed to save scheduled notification: %s", err)
-			}
+}
+
+// drop stops sending package and moves its notifications to the dead-letter queue
+func (notifier *StandardNotifier) drop(pkg *NotificationPackage, reason string) {
+	if metric, found := notifier.metrics.SendersDroppedMetrics.GetRegisteredMeter(pkg.Contact.Type); found {
+		metric.Mark(1)
+	}
+	notifier.saveFailedNotifications(pkg, reason)
+}
+
+func (notifier *StandardNotifier) getRetryPolicy(contactType string) RetryPolicy {
+	if policy, ok := notifier.retryPolicies[contactType]; ok {
+		return policy
+	}
+	return defaultRetryPolicy
+}
+
+// saveFailedNotifications moves package notifications to the dead-letter queue to let them be retried by api
+func (notifier *StandardNotifier) saveFailedNotifications(pkg *NotificationPackage, reason string) {
+	now := time.Now().Unix()
+	notifications := make([]*moira.FailedNotification, 0, len(pkg.Events))
+	for _, event := range pkg.Events {
+		id, err := uuid.NewV4()
+		if err != nil {
+			notifier.logger.Errorf("Failed to generate failed notification id: %s", err.Error())
+			return
 		}
+		notifications = append(notifications, &moira.FailedNotification{
+			ID:        id.String(),
+			Event:     event,
+			Trigger:   pkg.GetEventTrigger(&event),
+			Contact:   pkg.Contact,
+			Plotting:  pkg.Plotting,
+			Throttled: pkg.Throttled,
+			SendFail:  pkg.FailCount,
+			Error:     reason,
+			Timestamp: now,
+		})
+	}
+	if err := notifier.database.AddFailedNotifications(notifications); err != nil {
+		notifier.logger.Errorf("Failed to save failed notifications: %s", err.Error())
 	}
 }
 
 func (notifier *StandardNotifier) runSender(sender moira.Sender, ch chan NotificationPackage) {
 	defer notifier.waitGroup.Done()
 	for pkg := range ch {
-		plot, err := notifier.buildNotificationPackagePlot(pkg)
-		if err != nil {
-			buildErr := fmt.Sprintf("Can't build notification package plot for %s: %s", pkg.Trigger.ID, err.Error())
-			switch err.(type) {
-			case plotting.ErrNoPointsToRender:
-				notifier.logger.Debugf(buildErr)
-			default:
-				notifier.logger.Errorf(buildErr)
-			}
+		if !pkg.Digest {
+			notifier.sendEvents(sender, pkg)
+			continue
 		}
-		err = sender.SendEvents(pkg.Events, pkg.Contact, pkg.Trigger, plot, pkg.Throttled)
-		if err == nil {
-			if metric, found := notifier.metrics.SendersOkMetrics.GetRegisteredMeter(pkg.Contact.Type); found {
-				metric.Mark(1)
+		digestSender, ok := sender.(moira.DigestSender)
+		if !ok {
+			for _, triggerPkg := range pkg.SplitDigest() {
+				notifier.sendEvents(sender, triggerPkg)
 			}
-		} else {
-			notifier.resend(&pkg, err.Error())
+			continue
+		}
+		started := time.Now()
+		err := digestSender.SendDigest(pkg.Events, pkg.Contact, pkg.Triggers, pkg.Throttled)
+		notifier.saveDelivery(&pkg, started, err)
+		notifier.handleSendResult(&pkg, err)
+	}
+}
+
+func (notifier *StandardNotifier) sendEvents(sender moira.Sender, pkg NotificationPackage) {
+	plot, err := notifier.buildNotificationPackagePlot(pkg)
+	if err != nil {
+		buildErr := fmt.Sprintf("Can't build notification package plot for %s: %s", pkg.Trigger.ID, err.Error())
+		switch err.(type) {
+		case plotting.ErrNoPointsToRender:
+			notifier.logger.Debugf(buildErr)
+		default:
+			notifier.logger.Errorf(buildErr)
 		}
 	}
+	started := time.Now()
+	err = sender.SendEvents(pkg.Events, pkg.Contact, pkg.Trigger, plot, pkg.Throttled)
+	notifier.saveDelivery(&pkg, started, err)
+	notifier.handleSendResult(&pkg, err)
+}
+
+// saveDelivery records package sending attempt to contact and triggers delivery histories
+func (notifier *StandardNotifier) saveDelivery(pkg *NotificationPackage, started time.Time, err error) {
+	delivery := &moira.NotificationDelivery{
+		ContactID:    pkg.Contact.ID,
+		ContactType:  pkg.Contact.Type,
+		ContactValue: pkg.Contact.Value,
+		TriggerIDs:   pkg.GetTriggerIDs(),
+		Events:       pkg.Events,
+		Result:       moira.DeliverySent,
+		Latency:      time.Since(started).Milliseconds(),
+		Attempt:      pkg.FailCount + 1,
+		Timestamp:    started.Unix(),
+	}
+	if err != nil {
+		delivery.Result = moira.DeliveryFailed
+		delivery.Error = err.Error()
+	}
+	if err := notifier.database.AddNotificationDelivery(delivery); err != nil {
+		notifier.logger.Warningf("Failed to save delivery of %s: %s", pkg, err.Error())
+	}
+}
+
+func (notifier *StandardNotifier) handleSendResult(pkg *NotificationPackage, err error) {
+	if err == nil {
+		if metric, found := notifier.metrics.SendersOkMetrics.GetRegisteredMeter(pkg.Contact.Type); found {
+			metric.Mark(1)
+		}
+	} else if moira.IsSenderPermanentError(err) {
+		if pkg.DontResend {
+			return
+		}
+		notifier.markFailed(pkg)
+		notifier.logger.Errorf("Can't send message after %d try: %s. Error is permanent, stop resending", pkg.FailCount, err.Error())
+		notifier.drop(pkg, err.Error())
+	} else {
+		notifier.resend(pkg, err.Error(), moira.GetSenderRetryAfter(err))
+	}
 }
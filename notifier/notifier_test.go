@@ -73,6 +73,124 @@ func TestGetWindow(t *testing.T) {
 	})
 }
 
+func TestSplitDigest(t *testing.T) {
+	Convey("Digest package is split by triggers", t, func() {
+		first := moira.TriggerData{ID: "first", Name: "First"}
+		second := moira.TriggerData{ID: "second", Name: "Second"}
+		pkg := NotificationPackage{
+			Events: []moira.NotificationEvent{
+				{TriggerID: "second", Metric: "metric1"},
+				{TriggerID: "first", Metric: "metric2"},
+				{TriggerID: "second", Metric: "metric3"},
+			},
+			Contact:   moira.ContactData{Type: "test", DigestInterval: 3600},
+			Triggers:  map[string]moira.TriggerData{"first": first, "second": second},
+			Throttled: true,
+			Digest:    true,
+		}
+		actual := pkg.SplitDigest()
+		So(actual, ShouldResemble, []NotificationPackage{
+			{
+				Events:    []moira.NotificationEvent{pkg.Events[0], pkg.Events[2]},
+				Trigger:   second,
+				Contact:   pkg.Contact,
+				Throttled: true,
+			},
+			{
+				Events:    []moira.NotificationEvent{pkg.Events[1]},
+				Trigger:   first,
+				Contact:   pkg.Contact,
+				Throttled: true,
+			},
+		})
+	})
+}
+
+func TestGetTriggerIDs(t *testing.T) {
+	Convey("Trigger package", t, func() {
+		pkg := NotificationPackage{Trigger: moira.TriggerData{ID: "triggerID"}}
+		So(pkg.GetTriggerIDs(), ShouldResemble, []string{"triggerID"})
+	})
+
+	Convey("Digest package", t, func() {
+		pkg := NotificationPackage{
+			Events: []moira.NotificationEvent{{TriggerID: "second"}, {TriggerID: "first"}, {TriggerID: "second"}},
+			Digest: true,
+		}
+		So(pkg.GetTriggerIDs(), ShouldResemble, []string{"second", "first"})
+	})
+}
+
+func TestSaveDelivery(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	logger, _ := logging.GetLogger("Notifier")
+	notifier := &StandardNotifier{database: dataBase, logger: logger}
+
+	pkg := NotificationPackage{
+		Events:    []moira.NotificationEvent{{TriggerID: "triggerID", Metric: "metric"}},
+		Trigger:   moira.TriggerData{ID: "triggerID"},
+		Contact:   moira.ContactData{ID: "contactID", Type: "test", Value: "value"},
+		FailCount: 2,
+	}
+	started := time.Now()
+
+	Convey("Successful delivery", t, func() {
+		dataBase.EXPECT().AddNotificationDelivery(&moira.NotificationDelivery{
+			ContactID:    "contactID",
+			ContactType:  "test",
+			ContactValue: "value",
+			TriggerIDs:   []string{"triggerID"},
+			Events:       pkg.Events,
+			Result:       moira.DeliverySent,
+			Latency:      time.Since(started).Milliseconds(),
+			Attempt:      3,
+			Timestamp:    started.Unix(),
+		}).Return(nil)
+		notifier.saveDelivery(&pkg, started, nil)
+	})
+
+	Convey("Failed delivery", t, func() {
+		dataBase.EXPECT().AddNotificationDelivery(gomock.Any()).Do(func(delivery *moira.NotificationDelivery) {
+			So(delivery.Result, ShouldEqual, moira.DeliveryFailed)
+			So(delivery.Error, ShouldEqual, "timeout")
+		}).Return(fmt.Errorf("database error"))
+		notifier.saveDelivery(&pkg, started, fmt.Errorf("timeout"))
+	})
+}
+
+func TestSendDigestBySenderWithoutDigestSupport(t *testing.T) {
+	configureNotifier(t)
+	defer afterTest()
+
+	first := moira.TriggerData{ID: "first"}
+	second := moira.TriggerData{ID: "second"}
+	firstEvents := moira.NotificationEvents{{TriggerID: "first", Metric: "metric1"}}
+	secondEvents := moira.NotificationEvents{{TriggerID: "second", Metric: "metric2"}}
+	pkg := NotificationPackage{
+		Events:   append(append(moira.NotificationEvents{}, firstEvents...), secondEvents...),
+		Contact:  moira.ContactData{Type: "test", DigestInterval: 3600},
+		Triggers: map[string]moira.TriggerData{"first": first, "second": second},
+		Digest:   true,
+	}
+
+	done := make(chan struct{})
+	gomock.InOrder(
+		sender.EXPECT().SendEvents(firstEvents, pkg.Contact, first, plot, false).Return(nil),
+		sender.EXPECT().SendEvents(secondEvents, pkg.Contact, second, plot, false).Return(nil).Do(func(f ...interface{}) { close(done) }),
+	)
+
+	var wg sync.WaitGroup
+	notif.Send(&pkg, &wg)
+	wg.Wait()
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Error("digest was not sent")
+	}
+}
+
 func TestUnknownContactType(t *testing.T) {
 	configureNotifier(t)
 	defer afterTest()
@@ -117,6 +235,84 @@ func TestFailSendEvent(t *testing.T) {
 	time.Sleep(time.Second * 2)
 }
 
+func TestPermanentSendError(t *testing.T) {
+	configureNotifier(t)
+	defer afterTest()
+
+	var eventsData moira.NotificationEvents = []moira.NotificationEvent{event}
+
+	pkg := NotificationPackage{
+		Events: eventsData,
+		Contact: moira.ContactData{
+			Type: "test",
+		},
+	}
+	done := make(chan struct{})
+	sender.EXPECT().SendEvents(eventsData, pkg.Contact, pkg.Trigger, plot, pkg.Throttled).Return(moira.SenderPermanentError{Err: fmt.Errorf("invalid contact")})
+	dataBase.EXPECT().AddFailedNotifications(gomock.Any()).Do(func(notifications []*moira.FailedNotification) {
+		if len(notifications) != 1 || notifications[0].Error != "invalid contact" {
+			t.Errorf("unexpected failed notifications: %v", notifications)
+		}
+		close(done)
+	}).Return(nil)
+
+	var wg sync.WaitGroup
+	notif.Send(&pkg, &wg)
+	wg.Wait()
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Error("notification was not saved to dead-letter queue")
+	}
+}
+
+func TestRetryAfterSendError(t *testing.T) {
+	configureNotifier(t)
+	defer afterTest()
+
+	Convey("Notification is resent not earlier than receiver asks", t, func() {
+		pkg := NotificationPackage{
+			Events:  []moira.NotificationEvent{event},
+			Contact: moira.ContactData{Type: "test"},
+		}
+		notification := moira.ScheduledNotification{Timestamp: time.Now().Unix() + 60}
+		scheduler.EXPECT().ScheduleNotification(gomock.Any(), event, pkg.Trigger, pkg.Contact, pkg.Plotting, pkg.Throttled, 1).Return(&notification)
+		dataBase.EXPECT().AddNotification(&notification).Return(nil)
+		err := moira.SenderRetryAfterError{Err: fmt.Errorf("too many requests"), RetryAfter: 10 * time.Minute}
+		notif.resend(&pkg, err.Error(), moira.GetSenderRetryAfter(err))
+		So(notification.Timestamp, ShouldBeGreaterThanOrEqualTo, time.Now().Unix()+599)
+	})
+}
+
+func TestSenderRetryPolicy(t *testing.T) {
+	configureNotifier(t)
+	defer afterTest()
+
+	Convey("Sender retry policy is registered", t, func() {
+		senderSettings := map[string]string{"type": "retried", "resend_max_attempts": "2"}
+		retriedSender := mock_moira_alert.NewMockSender(mockCtrl)
+		retriedSender.EXPECT().Init(senderSettings, logger, notif.config.Location, notif.config.DateTimeFormat).Return(nil)
+		So(notif.RegisterSender(senderSettings, retriedSender), ShouldBeNil)
+		So(notif.getRetryPolicy("retried"), ShouldResemble, RetryPolicy{InitialDelay: time.Minute, Multiplier: 1, MaxAttempts: 2})
+		So(notif.getRetryPolicy("test"), ShouldResemble, defaultRetryPolicy)
+
+		Convey("Notifications are dropped after max attempts", func() {
+			pkg := NotificationPackage{
+				Events:    []moira.NotificationEvent{event},
+				Contact:   moira.ContactData{Type: "retried"},
+				FailCount: 2,
+			}
+			dataBase.EXPECT().AddFailedNotifications(gomock.Any()).Return(nil)
+			notif.resend(&pkg, "timeout", 0)
+		})
+	})
+
+	Convey("Invalid retry policy", t, func() {
+		err := notif.RegisterSender(map[string]string{"type": "invalid", "resend_multiplier": "0"}, sender)
+		So(err, ShouldNotBeNil)
+	})
+}
+
 func TestTimeout(t *testing.T) {
 	configureNotifier(t)
 	var wg sync.WaitGroup
@@ -186,7 +382,7 @@ func configureNotifier(t *testing.T) {
 	logger, _ = logging.GetLogger("Scheduler")
 	scheduler = mock_scheduler.NewMockScheduler(mockCtrl)
 	sender = mock_moira_alert.NewMockSender(mockCtrl)
-	metricsSourceProvider := metricSource.CreateMetricSourceProvider(local.Create(dataBase), nil)
+	metricsSourceProvider := metricSource.CreateMetricSourceProvider(local.Create(dataBase), nil, nil)
 
 	notif = NewNotifier(dataBase, logger, config, notifierMetrics, metricsSourceProvider, map[string]moira.ImageStore{})
 	notif.scheduler = scheduler
@@ -195,6 +391,7 @@ func configureNotifier(t *testing.T) {
 	}
 
 	sender.EXPECT().Init(senderSettings, logger, location, "15:04 02.01.2006").Return(nil)
+	dataBase.EXPECT().AddNotificationDelivery(gomock.Any()).Return(nil).AnyTimes()
 
 	notif.RegisterSender(senderSettings, sender)
 
@@ -55,8 +55,12 @@ func (notifier *StandardNotifier) buildNotificationPackagePlot(pkg NotificationP
 		return buff.Bytes(), err
 	}
 	metricsData = getMetricDataToShow(metricsData, metricsToShow)
+	history, err := metricSource.FetchTriggerHistory(notifier.metricSourceProvider, trigger, from, to)
+	if err != nil {
+		return buff.Bytes(), err
+	}
 	notifier.logger.Debugf("Build plot for trigger: %s from MetricsData: %v", trigger.ID, metricsData)
-	renderable, err := plotTemplate.GetRenderable(trigger, metricsData)
+	renderable, err := plotTemplate.GetRenderable(trigger, metricsData, history)
 	if err != nil {
 		return buff.Bytes(), err
 	}
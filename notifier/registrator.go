@@ -6,11 +6,15 @@ import (
 
 	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/senders/discord"
+	"github.com/moira-alert/moira/senders/jira"
 	"github.com/moira-alert/moira/senders/mail"
+	"github.com/moira-alert/moira/senders/matrix"
+	"github.com/moira-alert/moira/senders/mattermost"
 	"github.com/moira-alert/moira/senders/msteams"
 	"github.com/moira-alert/moira/senders/opsgenie"
 	"github.com/moira-alert/moira/senders/pagerduty"
 	"github.com/moira-alert/moira/senders/pushover"
+	"github.com/moira-alert/moira/senders/rocketchat"
 	"github.com/moira-alert/moira/senders/script"
 	"github.com/moira-alert/moira/senders/selfstate"
 	"github.com/moira-alert/moira/senders/slack"
@@ -36,6 +40,10 @@ const (
 	victoropsSender   = "victorops"
 	pagerdutySender   = "pagerduty"
 	msTeamsSender     = "msteams"
+	mattermostSender  = "mattermost"
+	rocketchatSender  = "rocketchat"
+	matrixSender      = "matrix"
+	jiraSender        = "jira"
 )
 
 // RegisterSenders watch on senders config and register all configured senders
@@ -58,6 +66,14 @@ func (notifier *StandardNotifier) RegisterSenders(connector moira.Database) erro
 			err = notifier.RegisterSender(senderSettings, &telegram.Sender{DataBase: connector})
 		case msTeamsSender:
 			err = notifier.RegisterSender(senderSettings, &msteams.Sender{})
+		case mattermostSender:
+			err = notifier.RegisterSender(senderSettings, &mattermost.Sender{ImageStores: notifier.imageStores})
+		case rocketchatSender:
+			err = notifier.RegisterSender(senderSettings, &rocketchat.Sender{ImageStores: notifier.imageStores})
+		case matrixSender:
+			err = notifier.RegisterSender(senderSettings, &matrix.Sender{DataBase: connector})
+		case jiraSender:
+			err = notifier.RegisterSender(senderSettings, &jira.Sender{DataBase: connector})
 		case pagerdutySender:
 			err = notifier.RegisterSender(senderSettings, &pagerduty.Sender{ImageStores: notifier.imageStores})
 		case twilioSmsSender, twilioVoiceSender:
@@ -97,14 +113,21 @@ func (notifier *StandardNotifier) RegisterSender(senderSettings map[string]strin
 	default:
 		senderIdent = senderSettings["type"]
 	}
-	err := sender.Init(senderSettings, notifier.logger, notifier.config.Location, notifier.config.DateTimeFormat)
+	retryPolicy, err := parseRetryPolicy(senderSettings)
 	if err != nil {
 		return fmt.Errorf("failed to initialize sender [%s], err [%s]", senderIdent, err.Error())
 	}
+	err = sender.Init(senderSettings, notifier.logger, notifier.config.Location, notifier.config.DateTimeFormat)
+	if err != nil {
+		return fmt.Errorf("failed to initialize sender [%s], err [%s]", senderIdent, err.Error())
+	}
+	notifier.retryPolicies[senderIdent] = retryPolicy
 	eventsChannel := make(chan NotificationPackage)
 	notifier.senders[senderIdent] = eventsChannel
 	notifier.metrics.SendersOkMetrics.RegisterMeter(senderIdent, getGraphiteSenderIdent(senderIdent), "sends_ok")
 	notifier.metrics.SendersFailedMetrics.RegisterMeter(senderIdent, getGraphiteSenderIdent(senderIdent), "sends_failed")
+	notifier.metrics.SendersRetriedMetrics.RegisterMeter(senderIdent, getGraphiteSenderIdent(senderIdent), "sends_retried")
+	notifier.metrics.SendersDroppedMetrics.RegisterMeter(senderIdent, getGraphiteSenderIdent(senderIdent), "sends_dropped")
 	notifier.runSenders(sender, eventsChannel)
 	notifier.logger.Infof("Sender %s registered", senderIdent)
 	return nil
@@ -0,0 +1,81 @@
+package notifier
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy defines how failed notifications of sender are resent. Delay before n-th resending is
+// InitialDelay * Multiplier^(n-1) limited by MaxDelay, resending stops after MaxAttempts tries or when resending timeout passes
+type RetryPolicy struct {
+	InitialDelay time.Duration
+	Multiplier   float64
+	// MaxDelay is the longest delay between resendings, 0 means unlimited
+	MaxDelay time.Duration
+	// MaxAttempts is count of resendings, 0 means notification is resent until resending timeout passes
+	MaxAttempts int
+}
+
+// defaultRetryPolicy resends notifications every minute
+var defaultRetryPolicy = RetryPolicy{InitialDelay: time.Minute, Multiplier: 1}
+
+// parseRetryPolicy reads sender retry policy from sender settings, default policy values are used for missing settings
+func parseRetryPolicy(senderSettings map[string]string) (RetryPolicy, error) {
+	policy := defaultRetryPolicy
+	var err error
+	if value, ok := senderSettings["resend_initial_delay"]; ok {
+		if policy.InitialDelay, err = time.ParseDuration(value); err != nil || policy.InitialDelay <= 0 {
+			return policy, fmt.Errorf("can not read resend_initial_delay from config: '%s' is not a positive duration", value)
+		}
+	}
+	if value, ok := senderSettings["resend_multiplier"]; ok {
+		if policy.Multiplier, err = strconv.ParseFloat(value, 64); err != nil || policy.Multiplier < 1 {
+			return policy, fmt.Errorf("can not read resend_multiplier from config: '%s' is not a number not less than 1", value)
+		}
+	}
+	if value, ok := senderSettings["resend_max_delay"]; ok {
+		if policy.MaxDelay, err = time.ParseDuration(value); err != nil || policy.MaxDelay < 0 {
+			return policy, fmt.Errorf("can not read resend_max_delay from config: '%s' is not a duration", value)
+		}
+	}
+	if value, ok := senderSettings["resend_max_attempts"]; ok {
+		if policy.MaxAttempts, err = strconv.Atoi(value); err != nil || policy.MaxAttempts < 0 {
+			return policy, fmt.Errorf("can not read resend_max_attempts from config: '%s' is not a non-negative number", value)
+		}
+	}
+	return policy, nil
+}
+
+// GetDelay returns delay before given resending starting with 1
+func (policy RetryPolicy) GetDelay(sendFail int) time.Duration {
+	maxDelay := float64(policy.MaxDelay)
+	if maxDelay <= 0 {
+		// keep delay in time.Duration range
+		maxDelay = math.MaxInt64 / 2
+	}
+	delay := float64(policy.InitialDelay)
+	for i := 1; i < sendFail && delay < maxDelay; i++ {
+		delay *= policy.Multiplier
+	}
+	if delay >= maxDelay {
+		return time.Duration(maxDelay)
+	}
+	return time.Duration(delay)
+}
+
+// CanRetry checks whether notification failed sendFail times can be resent again before resending timeout passes
+func (policy RetryPolicy) CanRetry(sendFail int, resendingTimeout time.Duration) bool {
+	if policy.MaxAttempts > 0 && sendFail >= policy.MaxAttempts {
+		return false
+	}
+	var elapsed time.Duration
+	for i := 1; i <= sendFail; i++ {
+		elapsed += policy.GetDelay(i)
+		if elapsed > resendingTimeout {
+			return false
+		}
+	}
+	return true
+}
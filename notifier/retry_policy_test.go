@@ -0,0 +1,75 @@
+package notifier
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseRetryPolicy(t *testing.T) {
+	Convey("Parse retry policy", t, func() {
+		Convey("Default policy", func() {
+			policy, err := parseRetryPolicy(map[string]string{"type": "test"})
+			So(err, ShouldBeNil)
+			So(policy, ShouldResemble, defaultRetryPolicy)
+		})
+
+		Convey("Full settings", func() {
+			policy, err := parseRetryPolicy(map[string]string{
+				"resend_initial_delay": "30s",
+				"resend_multiplier":    "2",
+				"resend_max_delay":     "10m",
+				"resend_max_attempts":  "5",
+			})
+			So(err, ShouldBeNil)
+			So(policy, ShouldResemble, RetryPolicy{InitialDelay: 30 * time.Second, Multiplier: 2, MaxDelay: 10 * time.Minute, MaxAttempts: 5})
+		})
+
+		Convey("Invalid settings", func() {
+			_, err := parseRetryPolicy(map[string]string{"resend_initial_delay": "0s"})
+			So(err, ShouldResemble, fmt.Errorf("can not read resend_initial_delay from config: '0s' is not a positive duration"))
+			_, err = parseRetryPolicy(map[string]string{"resend_multiplier": "0.5"})
+			So(err, ShouldResemble, fmt.Errorf("can not read resend_multiplier from config: '0.5' is not a number not less than 1"))
+			_, err = parseRetryPolicy(map[string]string{"resend_max_delay": "long"})
+			So(err, ShouldNotBeNil)
+			_, err = parseRetryPolicy(map[string]string{"resend_max_attempts": "-1"})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestRetryPolicy(t *testing.T) {
+	Convey("Default policy resends every minute until resending timeout", t, func() {
+		So(defaultRetryPolicy.GetDelay(1), ShouldEqual, time.Minute)
+		So(defaultRetryPolicy.GetDelay(100), ShouldEqual, time.Minute)
+		So(defaultRetryPolicy.CanRetry(0, time.Hour), ShouldBeTrue)
+		So(defaultRetryPolicy.CanRetry(60, time.Hour), ShouldBeTrue)
+		So(defaultRetryPolicy.CanRetry(61, time.Hour), ShouldBeFalse)
+	})
+
+	Convey("Exponential backoff", t, func() {
+		policy := RetryPolicy{InitialDelay: 30 * time.Second, Multiplier: 2, MaxDelay: 5 * time.Minute}
+		So(policy.GetDelay(1), ShouldEqual, 30*time.Second)
+		So(policy.GetDelay(2), ShouldEqual, time.Minute)
+		So(policy.GetDelay(4), ShouldEqual, 4*time.Minute)
+		So(policy.GetDelay(5), ShouldEqual, 5*time.Minute)
+		So(policy.GetDelay(1000), ShouldEqual, 5*time.Minute)
+
+		So(policy.CanRetry(4, 10*time.Minute), ShouldBeTrue)
+		So(policy.CanRetry(5, 10*time.Minute), ShouldBeFalse)
+	})
+
+	Convey("Unlimited delay does not overflow", t, func() {
+		policy := RetryPolicy{InitialDelay: time.Minute, Multiplier: 10}
+		So(policy.GetDelay(1000), ShouldBeGreaterThan, 0)
+		So(policy.CanRetry(1000, 24*time.Hour), ShouldBeFalse)
+	})
+
+	Convey("Attempts are limited", t, func() {
+		policy := RetryPolicy{InitialDelay: time.Minute, Multiplier: 1, MaxAttempts: 3}
+		So(policy.CanRetry(2, time.Hour), ShouldBeTrue)
+		So(policy.CanRetry(3, time.Hour), ShouldBeFalse)
+	})
+}
@@ -12,27 +12,38 @@ import (
 type Scheduler interface {
 	ScheduleNotification(now time.Time, event moira.NotificationEvent, trigger moira.TriggerData,
 		contact moira.ContactData, plotting moira.PlottingData, throttledOld bool, sendfail int) *moira.ScheduledNotification
+	ScheduleEscalation(now time.Time, event moira.NotificationEvent, trigger moira.TriggerData,
+		contact moira.ContactData, plotting moira.PlottingData, escalation moira.EscalationData, escalationNumber int) *moira.ScheduledNotification
 }
 
 // StandardScheduler represents standard event scheduling
 type StandardScheduler struct {
-	logger   moira.Logger
-	database moira.Database
-	metrics  *metrics.NotifierMetrics
+	logger           moira.Logger
+	database         moira.Database
+	metrics          *metrics.NotifierMetrics
+	throttlingLevels []moira.ThrottlingLevel
+	// retryPolicies are retry policies of senders by contact type, default policy is used for missing ones
+	retryPolicies map[string]RetryPolicy
 }
 
-type throttlingLevel struct {
-	duration time.Duration
-	delay    time.Duration
-	count    int64
+// if trigger switches more than .Count times in .Duration seconds, delay next delivery for .Delay seconds
+// processing stops after first condition matches
+var defaultThrottlingLevels = []moira.ThrottlingLevel{
+	{Duration: 3 * 3600, Delay: 3600, Count: 20},
+	{Duration: 3600, Delay: 1800, Count: 10},
 }
 
-// NewScheduler is initializer for StandardScheduler
-func NewScheduler(database moira.Database, logger moira.Logger, metrics *metrics.NotifierMetrics) *StandardScheduler {
+// NewScheduler is initializer for StandardScheduler, default throttling levels are used if throttlingLevels is empty.
+// Subscription throttling levels override these ones
+func NewScheduler(database moira.Database, logger moira.Logger, metrics *metrics.NotifierMetrics, throttlingLevels []moira.ThrottlingLevel) *StandardScheduler {
+	if len(throttlingLevels) == 0 {
+		throttlingLevels = defaultThrottlingLevels
+	}
 	return &StandardScheduler{
-		database: database,
-		logger:   logger,
-		metrics:  metrics,
+		database:         database,
+		logger:           logger,
+		metrics:          metrics,
+		throttlingLevels: throttlingLevels,
 	}
 }
 
@@ -44,7 +55,7 @@ func (scheduler *StandardScheduler) ScheduleNotification(now time.Time, event mo
 		throttled bool
 	)
 	if sendfail > 0 {
-		next = now.Add(time.Minute)
+		next = now.Add(scheduler.getRetryPolicy(contact.Type).GetDelay(sendfail))
 		throttled = throttledOld
 	} else {
 		if event.State == moira.StateTEST {
@@ -54,6 +65,9 @@ func (scheduler *StandardScheduler) ScheduleNotification(now time.Time, event mo
 			next, throttled = scheduler.calculateNextDelivery(now, &event)
 		}
 	}
+	if contact.DigestInterval > 0 && event.State != moira.StateTEST {
+		next = getDigestDeliveryTime(next, contact.DigestInterval)
+	}
 	notification := &moira.ScheduledNotification{
 		Event:     event,
 		Trigger:   trigger,
@@ -71,17 +85,41 @@ func (scheduler *StandardScheduler) ScheduleNotification(now time.Time, event mo
 	return notification
 }
 
-func (scheduler *StandardScheduler) calculateNextDelivery(now time.Time, event *moira.NotificationEvent) (time.Time, bool) {
-	// if trigger switches more than .count times in .length seconds, delay next delivery for .delay seconds
-	// processing stops after first condition matches
-	throttlingLevels := []throttlingLevel{
-		{3 * time.Hour, time.Hour, 20},
-		{time.Hour, time.Hour / 2, 10},
+// ScheduleEscalation schedules notification of escalation contact after escalation offset.
+// Escalated notification is sent only if event state is still actual at that moment
+func (scheduler *StandardScheduler) ScheduleEscalation(now time.Time, event moira.NotificationEvent, trigger moira.TriggerData,
+	contact moira.ContactData, plotting moira.PlottingData, escalation moira.EscalationData, escalationNumber int) *moira.ScheduledNotification {
+	next := now.Add(time.Duration(escalation.OffsetInMinutes) * time.Minute)
+	notification := &moira.ScheduledNotification{
+		Event:      event,
+		Trigger:    trigger,
+		Contact:    contact,
+		Timestamp:  next.Unix(),
+		Plotting:   plotting,
+		Escalation: escalationNumber,
 	}
+	scheduler.logger.Debugf(
+		"Scheduled escalation #%d notification for contact %s:%s trigger %s at %s (%d)",
+		escalationNumber, contact.Type, contact.Value, trigger.Name,
+		next.Format("2006/01/02 15:04:05"), next.Unix())
 
+	return notification
+}
+
+func (scheduler *StandardScheduler) getRetryPolicy(contactType string) RetryPolicy {
+	if policy, ok := scheduler.retryPolicies[contactType]; ok {
+		return policy
+	}
+	return defaultRetryPolicy
+}
+
+// calculateNextDelivery applies throttling and schedule of event subscription.
+// Throttling is kept separately for every subscription, so noisy trigger is throttled only for subscriptions with stricter levels
+func (scheduler *StandardScheduler) calculateNextDelivery(now time.Time, event *moira.NotificationEvent) (time.Time, bool) {
 	alarmFatigue := false
+	subscriptionID := moira.UseString(event.SubscriptionID)
 
-	next, beginning := scheduler.database.GetTriggerThrottling(event.TriggerID)
+	next, beginning := scheduler.database.GetSubscriptionThrottling(event.TriggerID, subscriptionID)
 
 	if next.After(now) {
 		alarmFatigue = true
@@ -89,33 +127,35 @@ func (scheduler *StandardScheduler) calculateNextDelivery(now time.Time, event *
 		next = now
 	}
 
-	subscription, err := scheduler.database.GetSubscription(moira.UseString(event.SubscriptionID))
+	subscription, err := scheduler.database.GetSubscription(subscriptionID)
 	if err != nil {
 		scheduler.metrics.SubsMalformed.Mark(1)
-		scheduler.logger.Debugf("Failed get subscription by id: %s. %s", moira.UseString(event.SubscriptionID), err.Error())
+		scheduler.logger.Debugf("Failed get subscription by id: %s. %s", subscriptionID, err.Error())
 		return next, alarmFatigue
 	}
 
 	if subscription.ThrottlingEnabled {
 		if next.After(now) {
-			scheduler.logger.Debugf("Using existing throttling for trigger %s: %s", event.TriggerID, next)
+			scheduler.logger.Debugf("Using existing throttling for trigger %s and subscription %s: %s", event.TriggerID, subscriptionID, next)
 		} else {
-			for _, level := range throttlingLevels {
-				from := now.Add(-level.duration)
+			for _, level := range scheduler.getThrottlingLevels(&subscription) {
+				duration := time.Duration(level.Duration) * time.Second
+				delay := time.Duration(level.Delay) * time.Second
+				from := now.Add(-duration)
 				if from.Before(beginning) {
 					from = beginning
 				}
 				count := scheduler.database.GetNotificationEventCount(event.TriggerID, from.Unix())
-				if count >= level.count {
-					next = now.Add(level.delay)
-					scheduler.logger.Debugf("Trigger %s switched %d times in last %s, delaying next notification for %s",
-						event.TriggerID, count, level.duration, level.delay)
-					if err = scheduler.database.SetTriggerThrottling(event.TriggerID, next); err != nil {
-						scheduler.logger.Errorf("Failed to set trigger throttling timestamp: %s", err)
+				if count >= level.Count {
+					next = now.Add(delay)
+					scheduler.logger.Debugf("Trigger %s switched %d times in last %s, delaying next notification for subscription %s for %s",
+						event.TriggerID, count, duration, subscriptionID, delay)
+					if err = scheduler.database.SetSubscriptionThrottling(event.TriggerID, subscriptionID, next); err != nil {
+						scheduler.logger.Errorf("Failed to set subscription throttling timestamp: %s", err)
 					}
 					alarmFatigue = true
 					break
-				} else if count == level.count-1 {
+				} else if count == level.Count-1 {
 					alarmFatigue = true
 				}
 			}
@@ -125,11 +165,28 @@ func (scheduler *StandardScheduler) calculateNextDelivery(now time.Time, event *
 	}
 	next, err = calculateNextDelivery(&subscription.Schedule, next)
 	if err != nil {
-		scheduler.logger.Errorf("Failed to apply schedule for subscriptionID: %s. %s.", moira.UseString(event.SubscriptionID), err)
+		scheduler.logger.Errorf("Failed to apply schedule for subscriptionID: %s. %s.", subscriptionID, err)
 	}
 	return next, alarmFatigue
 }
 
+func (scheduler *StandardScheduler) getThrottlingLevels(subscription *moira.SubscriptionData) []moira.ThrottlingLevel {
+	if len(subscription.ThrottlingLevels) != 0 {
+		return subscription.ThrottlingLevels
+	}
+	return scheduler.throttlingLevels
+}
+
+// getDigestDeliveryTime returns the end of digest interval containing given time. Digest intervals are aligned to unix epoch,
+// so notifications of all contact triggers scheduled in the same interval are fetched and sent together
+func getDigestDeliveryTime(next time.Time, digestInterval int64) time.Time {
+	timestamp := next.Unix()
+	if remainder := timestamp % digestInterval; remainder != 0 {
+		timestamp += digestInterval - remainder
+	}
+	return time.Unix(timestamp, 0)
+}
+
 func calculateNextDelivery(schedule *moira.ScheduleData, nextTime time.Time) (time.Time, error) {
 
 	if len(schedule.Days) != 0 && len(schedule.Days) != 7 {
@@ -49,7 +49,7 @@ func TestThrottling(t *testing.T) {
 	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
 	logger, _ := logging.GetLogger("Scheduler")
 	metrics2 := metrics.ConfigureNotifierMetrics(metrics.NewDummyRegistry(), "notifier")
-	scheduler := NewScheduler(dataBase, logger, metrics2)
+	scheduler := NewScheduler(dataBase, logger, metrics2, nil)
 
 	now := time.Now()
 
@@ -82,6 +82,17 @@ func TestThrottling(t *testing.T) {
 		So(notification, ShouldResemble, &expected2)
 	})
 
+	Convey("Test sendFail more than 0, and sender has retry policy, should send message after policy delay", t, func() {
+		scheduler.retryPolicies = map[string]RetryPolicy{contact.Type: {InitialDelay: time.Minute, Multiplier: 2}}
+		defer func() { scheduler.retryPolicies = nil }()
+		expected2 := expected
+		expected2.SendFail = 3
+		expected2.Timestamp = now.Add(4 * time.Minute).Unix()
+
+		notification := scheduler.ScheduleNotification(now, event, trigger, contact, plottingData, false, 3)
+		So(notification, ShouldResemble, &expected2)
+	})
+
 	Convey("Test event state is TEST and no send fails, should return now notification time", t, func() {
 		subID := "SubscriptionID-000000000000001"
 		testEvent := moira.NotificationEvent{
@@ -100,12 +111,22 @@ func TestThrottling(t *testing.T) {
 	})
 
 	Convey("Test no throttling and no subscription, should return now notification time", t, func() {
-		dataBase.EXPECT().GetTriggerThrottling(trigger.ID).Times(1).Return(time.Unix(0, 0), time.Unix(0, 0))
+		dataBase.EXPECT().GetSubscriptionThrottling(trigger.ID, subID).Times(1).Return(time.Unix(0, 0), time.Unix(0, 0))
 		dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Times(1).Return(moira.SubscriptionData{}, fmt.Errorf("Error while read subscription"))
 
 		notification := scheduler.ScheduleNotification(now, event, trigger, contact, plottingData, false, 0)
 		So(notification, ShouldResemble, &expected)
 	})
+
+	Convey("Test escalation, should send message after escalation offset", t, func() {
+		expected2 := expected
+		expected2.Timestamp = now.Add(15 * time.Minute).Unix()
+		expected2.Escalation = 2
+
+		escalation := moira.EscalationData{Contacts: []string{contact.ID}, OffsetInMinutes: 15}
+		notification := scheduler.ScheduleEscalation(now, event, trigger, contact, plottingData, escalation, 2)
+		So(notification, ShouldResemble, &expected2)
+	})
 }
 
 func TestSubscriptionSchedule(t *testing.T) {
@@ -131,14 +152,14 @@ func TestSubscriptionSchedule(t *testing.T) {
 	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
 	logger, _ := logging.GetLogger("Scheduler")
 	notifierMetrics := metrics.ConfigureNotifierMetrics(metrics.NewDummyRegistry(), "notifier")
-	scheduler := NewScheduler(dataBase, logger, notifierMetrics)
+	scheduler := NewScheduler(dataBase, logger, notifierMetrics, nil)
 
 	Convey("Throttling disabled", t, func() {
 		now := time.Unix(1441187115, 0)
 		subscription.ThrottlingEnabled = false
 		Convey("When current time is allowed, should send notification now", func() {
 			subscription.Schedule = schedule1
-			dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+			dataBase.EXPECT().GetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID).Return(time.Unix(0, 0), time.Unix(0, 0))
 			dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
 
 			next, throttled := scheduler.calculateNextDelivery(now, &event)
@@ -148,7 +169,7 @@ func TestSubscriptionSchedule(t *testing.T) {
 
 		Convey("When allowed time is today, should send notification at the beginning of allowed interval", func() {
 			subscription.Schedule = schedule2
-			dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+			dataBase.EXPECT().GetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID).Return(time.Unix(0, 0), time.Unix(0, 0))
 			dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
 
 			next, throttled := scheduler.calculateNextDelivery(now, &event)
@@ -159,7 +180,7 @@ func TestSubscriptionSchedule(t *testing.T) {
 		Convey("When allowed time is in a future day, should send notification at the beginning of allowed interval", func() {
 			now = time.Unix(1441101600, 0)
 			subscription.Schedule = schedule1
-			dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+			dataBase.EXPECT().GetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID).Return(time.Unix(0, 0), time.Unix(0, 0))
 			dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
 
 			next, throttled := scheduler.calculateNextDelivery(now, &event)
@@ -169,7 +190,7 @@ func TestSubscriptionSchedule(t *testing.T) {
 
 		Convey("Trigger already alarm fatigue, but now throttling disabled, should send notification now", func() {
 			subscription.Schedule = schedule1
-			dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(1441187215, 0), time.Unix(0, 0))
+			dataBase.EXPECT().GetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID).Return(time.Unix(1441187215, 0), time.Unix(0, 0))
 			dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
 
 			next, throttled := scheduler.calculateNextDelivery(now, &event)
@@ -183,7 +204,7 @@ func TestSubscriptionSchedule(t *testing.T) {
 		subscription.ThrottlingEnabled = true
 
 		Convey("Has trigger events count slightly less than low throttling level, should next timestamp now minutes, but throttling", func() {
-			dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+			dataBase.EXPECT().GetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID).Return(time.Unix(0, 0), time.Unix(0, 0))
 			dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
 			dataBase.EXPECT().GetNotificationEventCount(event.TriggerID, now.Add(-time.Hour*3).Unix()).Return(int64(13))
 			dataBase.EXPECT().GetNotificationEventCount(event.TriggerID, now.Add(-time.Hour).Unix()).Return(int64(9))
@@ -194,11 +215,11 @@ func TestSubscriptionSchedule(t *testing.T) {
 		})
 
 		Convey("Has trigger events count event more than low throttling level, should next timestamp in 30 minutes", func() {
-			dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+			dataBase.EXPECT().GetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID).Return(time.Unix(0, 0), time.Unix(0, 0))
 			dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
 			dataBase.EXPECT().GetNotificationEventCount(event.TriggerID, now.Add(-time.Hour*3).Unix()).Return(int64(10))
 			dataBase.EXPECT().GetNotificationEventCount(event.TriggerID, now.Add(-time.Hour).Unix()).Return(int64(10))
-			dataBase.EXPECT().SetTriggerThrottling(event.TriggerID, now.Add(time.Hour/2)).Return(nil)
+			dataBase.EXPECT().SetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID, now.Add(time.Hour/2)).Return(nil)
 
 			next, throttled := scheduler.calculateNextDelivery(now, &event)
 			So(next, ShouldResemble, time.Unix(1441135800, 0))
@@ -206,18 +227,42 @@ func TestSubscriptionSchedule(t *testing.T) {
 		})
 
 		Convey("Has trigger event more than high throttling level, should next timestamp in 1 hour", func() {
-			dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+			dataBase.EXPECT().GetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID).Return(time.Unix(0, 0), time.Unix(0, 0))
 			dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
 			dataBase.EXPECT().GetNotificationEventCount(event.TriggerID, now.Add(-time.Hour*3).Unix()).Return(int64(20))
-			dataBase.EXPECT().SetTriggerThrottling(event.TriggerID, now.Add(time.Hour)).Return(nil)
+			dataBase.EXPECT().SetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID, now.Add(time.Hour)).Return(nil)
 
 			next, throttled := scheduler.calculateNextDelivery(now, &event)
 			So(next, ShouldResemble, now.Add(time.Hour))
 			So(throttled, ShouldBeTrue)
 		})
 
+		Convey("Subscription throttling levels override default ones", func() {
+			subscription.ThrottlingLevels = []moira.ThrottlingLevel{{Duration: 7200, Delay: 600, Count: 5}}
+			defer func() { subscription.ThrottlingLevels = nil }()
+			dataBase.EXPECT().GetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID).Return(time.Unix(0, 0), time.Unix(0, 0))
+			dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
+			dataBase.EXPECT().GetNotificationEventCount(event.TriggerID, now.Add(-time.Hour*2).Unix()).Return(int64(5))
+			dataBase.EXPECT().SetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID, now.Add(10*time.Minute)).Return(nil)
+
+			next, throttled := scheduler.calculateNextDelivery(now, &event)
+			So(next, ShouldResemble, now.Add(10*time.Minute))
+			So(throttled, ShouldBeTrue)
+		})
+
+		Convey("Throttling levels from config override default ones", func() {
+			scheduler := NewScheduler(dataBase, logger, notifierMetrics, []moira.ThrottlingLevel{{Duration: 600, Delay: 300, Count: 3}})
+			dataBase.EXPECT().GetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID).Return(time.Unix(0, 0), time.Unix(0, 0))
+			dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
+			dataBase.EXPECT().GetNotificationEventCount(event.TriggerID, now.Add(-10*time.Minute).Unix()).Return(int64(2))
+
+			next, throttled := scheduler.calculateNextDelivery(now, &event)
+			So(next, ShouldResemble, now)
+			So(throttled, ShouldBeTrue)
+		})
+
 		Convey("Trigger already alarm fatigue, should has old throttled value", func() {
-			dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(1441148000, 0), time.Unix(0, 0))
+			dataBase.EXPECT().GetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID).Return(time.Unix(1441148000, 0), time.Unix(0, 0))
 			dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
 
 			next, throttled := scheduler.calculateNextDelivery(now, &event)
@@ -233,7 +278,7 @@ func TestSubscriptionSchedule(t *testing.T) {
 			now := time.Unix(1441191600, 0)
 			subscription.ThrottlingEnabled = false
 			subscription.Schedule = schedule3
-			dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+			dataBase.EXPECT().GetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID).Return(time.Unix(0, 0), time.Unix(0, 0))
 			dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
 
 			next, throttled := scheduler.calculateNextDelivery(now, &event)
@@ -247,7 +292,7 @@ func TestSubscriptionSchedule(t *testing.T) {
 			now := time.Unix(1441144800, 0)
 			subscription.ThrottlingEnabled = false
 			subscription.Schedule = schedule3
-			dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+			dataBase.EXPECT().GetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID).Return(time.Unix(0, 0), time.Unix(0, 0))
 			dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
 
 			next, throttled := scheduler.calculateNextDelivery(now, &event)
@@ -261,7 +306,7 @@ func TestSubscriptionSchedule(t *testing.T) {
 			now := time.Unix(1441148400, 0)
 			subscription.ThrottlingEnabled = false
 			subscription.Schedule = schedule3
-			dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+			dataBase.EXPECT().GetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID).Return(time.Unix(0, 0), time.Unix(0, 0))
 			dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
 
 			next, throttled := scheduler.calculateNextDelivery(now, &event)
@@ -275,7 +320,7 @@ func TestSubscriptionSchedule(t *testing.T) {
 			now := time.Unix(1441141200, 0)
 			subscription.ThrottlingEnabled = false
 			subscription.Schedule = schedule3
-			dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+			dataBase.EXPECT().GetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID).Return(time.Unix(0, 0), time.Unix(0, 0))
 			dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
 
 			next, throttled := scheduler.calculateNextDelivery(now, &event)
@@ -289,7 +334,7 @@ func TestSubscriptionSchedule(t *testing.T) {
 			now := time.Unix(1441141140, 0)
 			subscription.ThrottlingEnabled = false
 			subscription.Schedule = schedule3
-			dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+			dataBase.EXPECT().GetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID).Return(time.Unix(0, 0), time.Unix(0, 0))
 			dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
 
 			next, throttled := scheduler.calculateNextDelivery(now, &event)
@@ -303,7 +348,7 @@ func TestSubscriptionSchedule(t *testing.T) {
 			now := time.Unix(1441148340, 0)
 			subscription.ThrottlingEnabled = false
 			subscription.Schedule = schedule3
-			dataBase.EXPECT().GetTriggerThrottling(event.TriggerID).Return(time.Unix(0, 0), time.Unix(0, 0))
+			dataBase.EXPECT().GetSubscriptionThrottling(event.TriggerID, *event.SubscriptionID).Return(time.Unix(0, 0), time.Unix(0, 0))
 			dataBase.EXPECT().GetSubscription(*event.SubscriptionID).Return(subscription, nil)
 
 			next, throttled := scheduler.calculateNextDelivery(now, &event)
@@ -359,3 +404,11 @@ var schedule3 = moira.ScheduleData{
 		{Enabled: true},
 	},
 }
+
+func TestGetDigestDeliveryTime(t *testing.T) {
+	Convey("Delivery time is rounded up to digest interval", t, func() {
+		So(getDigestDeliveryTime(time.Unix(7200, 0), 3600), ShouldResemble, time.Unix(7200, 0))
+		So(getDigestDeliveryTime(time.Unix(7201, 0), 3600), ShouldResemble, time.Unix(10800, 0))
+		So(getDigestDeliveryTime(time.Unix(10799, 0), 3600), ShouldResemble, time.Unix(10800, 0))
+	})
+}
@@ -2,18 +2,26 @@ package selfstate
 
 import (
 	"fmt"
+
+	"github.com/moira-alert/moira"
 )
 
 // Config is representation of self state worker settings like moira admins contacts and threshold values for checked services
 type Config struct {
 	Enabled                        bool
 	RemoteTriggersEnabled          bool
+	RemoteClusters                 []moira.ClusterID
+	PrometheusTriggersEnabled      bool
 	RedisDisconnectDelaySeconds    int64
 	LastMetricReceivedDelaySeconds int64
 	LastCheckDelaySeconds          int64
 	LastRemoteCheckDelaySeconds    int64
-	NoticeIntervalSeconds          int64
-	Contacts                       []map[string]string
+	// LastPrometheusCheckDelaySeconds is a max delay of prometheus triggers checks to alert admins
+	LastPrometheusCheckDelaySeconds int64
+	NoticeIntervalSeconds           int64
+	// FailedNotificationsThreshold is a count of undelivered notifications in dead-letter queue to alert admins, 0 disables check
+	FailedNotificationsThreshold int64
+	Contacts                     []map[string]string
 }
 
 func (config *Config) checkConfig(senders map[string]bool) error {
@@ -33,3 +41,14 @@ func (config *Config) checkConfig(senders map[string]bool) error {
 	}
 	return nil
 }
+
+// getRemoteClusters returns remote clusters to check, default cluster is checked if no clusters were given
+func (config *Config) getRemoteClusters() []moira.ClusterID {
+	if !config.RemoteTriggersEnabled {
+		return []moira.ClusterID{}
+	}
+	if len(config.RemoteClusters) == 0 {
+		return []moira.ClusterID{moira.DefaultCluster}
+	}
+	return config.RemoteClusters
+}
@@ -16,10 +16,11 @@ import (
 var defaultCheckInterval = time.Second * 10
 
 const (
-	redisDisconnectedErrorMessage  = "Redis disconnected"
-	filterStateErrorMessage        = "Moira-Filter does not receive metrics"
-	checkerStateErrorMessage       = "Moira-Checker does not check triggers"
-	remoteCheckerStateErrorMessage = "Moira-Remote-Checker does not check remote triggers"
+	redisDisconnectedErrorMessage = "Redis disconnected"
+	filterStateErrorMessage       = "Moira-Filter does not receive metrics"
+	checkerStateErrorMessage      = "Moira-Checker does not check triggers"
+	prometheusCheckerErrorMessage = "Moira-Prometheus-Checker does not check prometheus triggers"
+	failedNotificationsMessage    = "Moira-Notifier failed to deliver notifications"
 )
 
 const selfStateLockName = "moira-self-state-monitor"
@@ -37,12 +38,17 @@ type SelfCheckWorker struct {
 func (selfCheck *SelfCheckWorker) selfStateChecker(stop <-chan struct{}) error {
 	selfCheck.Logger.Info("Moira Notifier Self State Monitor started")
 
-	var metricsCount, checksCount, remoteChecksCount int64
+	var metricsCount, checksCount, prometheusChecksCount int64
 	lastMetricReceivedTS := time.Now().Unix()
 	redisLastCheckTS := time.Now().Unix()
 	lastCheckTS := time.Now().Unix()
-	lastRemoteCheckTS := time.Now().Unix()
+	lastPrometheusCheckTS := time.Now().Unix()
 	nextSendErrorMessage := time.Now().Unix()
+	remoteChecksCount := make(map[moira.ClusterID]int64)
+	lastRemoteCheckTS := make(map[moira.ClusterID]int64)
+	for _, clusterID := range selfCheck.Config.getRemoteClusters() {
+		lastRemoteCheckTS[clusterID] = time.Now().Unix()
+	}
 
 	checkTicker := time.NewTicker(defaultCheckInterval)
 	defer checkTicker.Stop()
@@ -53,7 +59,7 @@ func (selfCheck *SelfCheckWorker) selfStateChecker(stop <-chan struct{}) error {
 			selfCheck.Logger.Info("Moira Notifier Self State Monitor stopped")
 			return nil
 		case <-checkTicker.C:
-			selfCheck.check(time.Now().Unix(), &lastMetricReceivedTS, &redisLastCheckTS, &lastCheckTS, &lastRemoteCheckTS, &nextSendErrorMessage, &metricsCount, &checksCount, &remoteChecksCount)
+			selfCheck.check(time.Now().Unix(), &lastMetricReceivedTS, &redisLastCheckTS, &lastCheckTS, &nextSendErrorMessage, &metricsCount, &checksCount, &lastPrometheusCheckTS, &prometheusChecksCount, lastRemoteCheckTS, remoteChecksCount)
 		}
 	}
 }
@@ -97,14 +103,19 @@ func (selfCheck *SelfCheckWorker) Stop() error {
 	return selfCheck.tomb.Wait()
 }
 
-func (selfCheck *SelfCheckWorker) check(nowTS int64, lastMetricReceivedTS, redisLastCheckTS, lastCheckTS, lastRemoteCheckTS, nextSendErrorMessage, metricsCount, checksCount, remoteChecksCount *int64) {
+func (selfCheck *SelfCheckWorker) check(nowTS int64, lastMetricReceivedTS, redisLastCheckTS, lastCheckTS, nextSendErrorMessage, metricsCount, checksCount, lastPrometheusCheckTS, prometheusChecksCount *int64, lastRemoteCheckTS, remoteChecksCount map[moira.ClusterID]int64) {
 	var events []moira.NotificationEvent
-	var rcc int64
+	remoteClusters := selfCheck.Config.getRemoteClusters()
+	rcc := make(map[moira.ClusterID]int64, len(remoteClusters))
 
 	mc, _ := selfCheck.DB.GetMetricsUpdatesCount()
 	cc, err := selfCheck.DB.GetChecksUpdatesCount()
-	if selfCheck.Config.RemoteTriggersEnabled {
-		rcc, _ = selfCheck.DB.GetRemoteChecksUpdatesCount()
+	for _, clusterID := range remoteClusters {
+		rcc[clusterID], _ = selfCheck.DB.GetRemoteChecksUpdatesCount(clusterID)
+	}
+	var pcc int64
+	if selfCheck.Config.PrometheusTriggersEnabled {
+		pcc, _ = selfCheck.DB.GetPrometheusChecksUpdatesCount()
 	}
 	if err == nil {
 		*redisLastCheckTS = nowTS
@@ -116,10 +127,14 @@ func (selfCheck *SelfCheckWorker) check(nowTS int64, lastMetricReceivedTS, redis
 			*checksCount = cc
 			*lastCheckTS = nowTS
 		}
-		if selfCheck.Config.RemoteTriggersEnabled {
-			if *remoteChecksCount != rcc {
-				*remoteChecksCount = rcc
-				*lastRemoteCheckTS = nowTS
+		if *prometheusChecksCount != pcc {
+			*prometheusChecksCount = pcc
+			*lastPrometheusCheckTS = nowTS
+		}
+		for _, clusterID := range remoteClusters {
+			if remoteChecksCount[clusterID] != rcc[clusterID] {
+				remoteChecksCount[clusterID] = rcc[clusterID]
+				lastRemoteCheckTS[clusterID] = nowTS
 			}
 		}
 	}
@@ -145,11 +160,25 @@ func (selfCheck *SelfCheckWorker) check(nowTS int64, lastMetricReceivedTS, redis
 			selfCheck.setNotifierState(moira.SelfStateERROR)
 		}
 
-		if selfCheck.Config.RemoteTriggersEnabled {
-			if *lastRemoteCheckTS < nowTS-selfCheck.Config.LastRemoteCheckDelaySeconds && err == nil {
-				interval := nowTS - *lastRemoteCheckTS
-				selfCheck.Logger.Errorf("%s more than %ds. Send message.", remoteCheckerStateErrorMessage, interval)
-				appendNotificationEvents(&events, remoteCheckerStateErrorMessage, interval)
+		for _, clusterID := range remoteClusters {
+			if lastRemoteCheckTS[clusterID] < nowTS-selfCheck.Config.LastRemoteCheckDelaySeconds && err == nil {
+				interval := nowTS - lastRemoteCheckTS[clusterID]
+				message := remoteCheckerStateErrorMessage(clusterID)
+				selfCheck.Logger.Errorf("%s more than %ds. Send message.", message, interval)
+				appendNotificationEvents(&events, message, interval)
+			}
+		}
+
+		if selfCheck.Config.PrometheusTriggersEnabled && *lastPrometheusCheckTS < nowTS-selfCheck.Config.LastPrometheusCheckDelaySeconds && err == nil {
+			interval := nowTS - *lastPrometheusCheckTS
+			selfCheck.Logger.Errorf("%s more than %ds. Send message.", prometheusCheckerErrorMessage, interval)
+			appendNotificationEvents(&events, prometheusCheckerErrorMessage, interval)
+		}
+
+		if selfCheck.Config.FailedNotificationsThreshold > 0 && err == nil {
+			if count, _ := selfCheck.DB.GetFailedNotificationsCount(); count >= selfCheck.Config.FailedNotificationsThreshold {
+				selfCheck.Logger.Errorf("%s: %d notifications in dead-letter queue. Send message.", failedNotificationsMessage, count)
+				appendNotificationEvents(&events, failedNotificationsMessage, count)
 			}
 		}
 
@@ -207,6 +236,14 @@ func (selfCheck *SelfCheckWorker) setNotifierState(state string) {
 	}
 }
 
+func remoteCheckerStateErrorMessage(clusterID moira.ClusterID) string {
+	const message = "Moira-Remote-Checker does not check remote triggers"
+	if clusterID == moira.DefaultCluster {
+		return message
+	}
+	return fmt.Sprintf("%s of cluster %s", message, clusterID)
+}
+
 func notifierStateErrorMessage(state string) string {
 	const template = "Moira-Notifier does not send messages. State: %v"
 	return fmt.Sprintf(template, state)
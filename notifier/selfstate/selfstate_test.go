@@ -32,13 +32,16 @@ func TestDatabaseDisconnected(t *testing.T) {
 	var (
 		metricsCount         int64
 		checksCount          int64
-		remoteChecksCount    int64
 		lastMetricReceivedTS int64
 		redisLastCheckTS     int64
 		lastCheckTS          int64
-		lastRemoteCheckTS    int64
 		nextSendErrorMessage int64
+
+		prometheusChecksCount int64
+		lastPrometheusCheckTS int64
 	)
+	remoteChecksCount := make(map[moira.ClusterID]int64)
+	lastRemoteCheckTS := make(map[moira.ClusterID]int64)
 
 	// _, selfStateWorker, database, notif, conf, mockCtrl := configureWorker(t)
 	mock := configureWorker(t, false)
@@ -55,7 +58,7 @@ func TestDatabaseDisconnected(t *testing.T) {
 			now := time.Now()
 			redisLastCheckTS = now.Add(-time.Second * 11).Unix()
 			lastCheckTS = now.Unix()
-			lastRemoteCheckTS = now.Unix()
+			lastRemoteCheckTS[moira.DefaultCluster] = now.Unix()
 			nextSendErrorMessage = now.Add(-time.Second * 5).Unix()
 			lastMetricReceivedTS = now.Unix()
 			appendNotificationEvents(&events, redisDisconnectedErrorMessage, now.Unix()-redisLastCheckTS)
@@ -63,7 +66,7 @@ func TestDatabaseDisconnected(t *testing.T) {
 			expectedPackage := configureNotificationPackage(adminContact, &events)
 
 			mock.notif.EXPECT().Send(&expectedPackage, &sendingWG)
-			mock.selfCheckWorker.check(now.Unix(), &lastMetricReceivedTS, &redisLastCheckTS, &lastCheckTS, &lastRemoteCheckTS, &nextSendErrorMessage, &metricsCount, &checksCount, &remoteChecksCount)
+			mock.selfCheckWorker.check(now.Unix(), &lastMetricReceivedTS, &redisLastCheckTS, &lastCheckTS, &nextSendErrorMessage, &metricsCount, &checksCount, &lastPrometheusCheckTS, &prometheusChecksCount, lastRemoteCheckTS, remoteChecksCount)
 
 			So(lastMetricReceivedTS, ShouldEqual, now.Unix())
 			So(lastCheckTS, ShouldEqual, now.Unix())
@@ -84,13 +87,16 @@ func TestMoiraCacheDoesNotReceivedNewMetrics(t *testing.T) {
 	var (
 		metricsCount         int64
 		checksCount          int64
-		remoteChecksCount    int64
 		lastMetricReceivedTS int64
 		redisLastCheckTS     int64
 		lastCheckTS          int64
-		lastRemoteCheckTS    int64
 		nextSendErrorMessage int64
+
+		prometheusChecksCount int64
+		lastPrometheusCheckTS int64
 	)
+	remoteChecksCount := make(map[moira.ClusterID]int64)
+	lastRemoteCheckTS := make(map[moira.ClusterID]int64)
 
 	mock := configureWorker(t, false)
 	mock.selfCheckWorker.Start()
@@ -103,7 +109,7 @@ func TestMoiraCacheDoesNotReceivedNewMetrics(t *testing.T) {
 		now := time.Now()
 		redisLastCheckTS = now.Unix()
 		lastCheckTS = now.Unix()
-		lastRemoteCheckTS = now.Unix()
+		lastRemoteCheckTS[moira.DefaultCluster] = now.Unix()
 		nextSendErrorMessage = now.Add(-time.Second * 5).Unix()
 		lastMetricReceivedTS = now.Add(-time.Second * 61).Unix()
 		metricsCount = 1
@@ -116,7 +122,7 @@ func TestMoiraCacheDoesNotReceivedNewMetrics(t *testing.T) {
 		mock.database.EXPECT().SetNotifierState(moira.SelfStateERROR).Return(nil)
 		mock.database.EXPECT().GetNotifierState().Return(moira.SelfStateERROR, nil)
 		mock.notif.EXPECT().Send(&expectedPackage, &sendingWG)
-		mock.selfCheckWorker.check(callingNow.Unix(), &lastMetricReceivedTS, &redisLastCheckTS, &lastCheckTS, &lastRemoteCheckTS, &nextSendErrorMessage, &metricsCount, &checksCount, &remoteChecksCount)
+		mock.selfCheckWorker.check(callingNow.Unix(), &lastMetricReceivedTS, &redisLastCheckTS, &lastCheckTS, &nextSendErrorMessage, &metricsCount, &checksCount, &lastPrometheusCheckTS, &prometheusChecksCount, lastRemoteCheckTS, remoteChecksCount)
 
 		So(lastMetricReceivedTS, ShouldEqual, now.Add(-time.Second*61).Unix())
 		So(lastCheckTS, ShouldEqual, callingNow.Unix())
@@ -136,13 +142,16 @@ func TestMoiraCheckerDoesNotChecksTriggers(t *testing.T) {
 	var (
 		metricsCount         int64
 		checksCount          int64
-		remoteChecksCount    int64
 		lastMetricReceivedTS int64
 		redisLastCheckTS     int64
 		lastCheckTS          int64
-		lastRemoteCheckTS    int64
 		nextSendErrorMessage int64
+
+		prometheusChecksCount int64
+		lastPrometheusCheckTS int64
 	)
+	remoteChecksCount := make(map[moira.ClusterID]int64)
+	lastRemoteCheckTS := make(map[moira.ClusterID]int64)
 
 	mock := configureWorker(t, false)
 	mock.selfCheckWorker.Start()
@@ -155,7 +164,7 @@ func TestMoiraCheckerDoesNotChecksTriggers(t *testing.T) {
 		now := time.Now()
 		redisLastCheckTS = now.Unix()
 		lastCheckTS = now.Add(-time.Second * 121).Unix()
-		lastRemoteCheckTS = now.Unix()
+		lastRemoteCheckTS[moira.DefaultCluster] = now.Unix()
 		nextSendErrorMessage = now.Add(-time.Second * 5).Unix()
 		lastMetricReceivedTS = now.Unix()
 		checksCount = 1
@@ -168,7 +177,7 @@ func TestMoiraCheckerDoesNotChecksTriggers(t *testing.T) {
 		mock.database.EXPECT().SetNotifierState(moira.SelfStateERROR).Return(nil)
 		mock.database.EXPECT().GetNotifierState().Return(moira.SelfStateERROR, nil)
 		mock.notif.EXPECT().Send(&expectedPackage, &sendingWG)
-		mock.selfCheckWorker.check(callingNow.Unix(), &lastMetricReceivedTS, &redisLastCheckTS, &lastCheckTS, &lastRemoteCheckTS, &nextSendErrorMessage, &metricsCount, &checksCount, &remoteChecksCount)
+		mock.selfCheckWorker.check(callingNow.Unix(), &lastMetricReceivedTS, &redisLastCheckTS, &lastCheckTS, &nextSendErrorMessage, &metricsCount, &checksCount, &lastPrometheusCheckTS, &prometheusChecksCount, lastRemoteCheckTS, remoteChecksCount)
 
 		So(lastMetricReceivedTS, ShouldEqual, callingNow.Unix())
 		So(lastCheckTS, ShouldEqual, now.Add(-time.Second*121).Unix())
@@ -188,13 +197,16 @@ func TestMoiraCheckerDoesNotChecksRemoteTriggers(t *testing.T) {
 	var (
 		metricsCount         int64
 		checksCount          int64
-		remoteChecksCount    int64
 		lastMetricReceivedTS int64
 		redisLastCheckTS     int64
 		lastCheckTS          int64
-		lastRemoteCheckTS    int64
 		nextSendErrorMessage int64
+
+		prometheusChecksCount int64
+		lastPrometheusCheckTS int64
 	)
+	remoteChecksCount := make(map[moira.ClusterID]int64)
+	lastRemoteCheckTS := make(map[moira.ClusterID]int64)
 
 	mock := configureWorker(t, true)
 	mock.selfCheckWorker.Start()
@@ -203,27 +215,134 @@ func TestMoiraCheckerDoesNotChecksRemoteTriggers(t *testing.T) {
 		var sendingWG sync.WaitGroup
 		mock.database.EXPECT().GetMetricsUpdatesCount().Return(int64(1), nil)
 		mock.database.EXPECT().GetChecksUpdatesCount().Return(int64(1), nil)
-		mock.database.EXPECT().GetRemoteChecksUpdatesCount().Return(int64(1), nil)
+		mock.database.EXPECT().GetRemoteChecksUpdatesCount(moira.DefaultCluster).Return(int64(1), nil)
 
 		now := time.Now()
 		redisLastCheckTS = now.Unix()
 		lastCheckTS = now.Unix()
-		lastRemoteCheckTS = now.Add(-time.Second * 121).Unix()
+		lastRemoteCheckTS[moira.DefaultCluster] = now.Add(-time.Second * 121).Unix()
 		nextSendErrorMessage = now.Add(-time.Second * 5).Unix()
 		lastMetricReceivedTS = now.Unix()
 		checksCount = 1
-		remoteChecksCount = 1
+		remoteChecksCount[moira.DefaultCluster] = 1
 
 		callingNow := now.Add(time.Second * 2)
-		appendNotificationEvents(&events, remoteCheckerStateErrorMessage, callingNow.Unix()-lastRemoteCheckTS)
+		appendNotificationEvents(&events, remoteCheckerStateErrorMessage(moira.DefaultCluster), callingNow.Unix()-lastRemoteCheckTS[moira.DefaultCluster])
 		expectedPackage := configureNotificationPackage(adminContact, &events)
 
 		mock.database.EXPECT().GetNotifierState().Return(moira.SelfStateOK, nil)
 		mock.notif.EXPECT().Send(&expectedPackage, &sendingWG)
-		mock.selfCheckWorker.check(callingNow.Unix(), &lastMetricReceivedTS, &redisLastCheckTS, &lastCheckTS, &lastRemoteCheckTS, &nextSendErrorMessage, &metricsCount, &checksCount, &remoteChecksCount)
+		mock.selfCheckWorker.check(callingNow.Unix(), &lastMetricReceivedTS, &redisLastCheckTS, &lastCheckTS, &nextSendErrorMessage, &metricsCount, &checksCount, &lastPrometheusCheckTS, &prometheusChecksCount, lastRemoteCheckTS, remoteChecksCount)
 
 		So(lastMetricReceivedTS, ShouldEqual, callingNow.Unix())
-		So(lastRemoteCheckTS, ShouldEqual, now.Add(-time.Second*121).Unix())
+		So(lastRemoteCheckTS[moira.DefaultCluster], ShouldEqual, now.Add(-time.Second*121).Unix())
+		So(redisLastCheckTS, ShouldEqual, callingNow.Unix())
+		So(nextSendErrorMessage, ShouldEqual, callingNow.Unix()+mock.conf.NoticeIntervalSeconds)
+	})
+	mock.selfCheckWorker.Stop()
+	mock.mockCtrl.Finish()
+}
+
+func TestFailedNotificationsQueueGrows(t *testing.T) {
+	adminContact := map[string]string{
+		"type":  "admin-mail",
+		"value": "admin@company.com",
+	}
+
+	var (
+		metricsCount         int64
+		checksCount          int64
+		lastMetricReceivedTS int64
+		redisLastCheckTS     int64
+		lastCheckTS          int64
+		nextSendErrorMessage int64
+
+		prometheusChecksCount int64
+		lastPrometheusCheckTS int64
+	)
+	remoteChecksCount := make(map[moira.ClusterID]int64)
+	lastRemoteCheckTS := make(map[moira.ClusterID]int64)
+
+	mock := configureWorker(t, false)
+	mock.selfCheckWorker.Config.FailedNotificationsThreshold = 10
+	mock.selfCheckWorker.Start()
+	Convey("Should notify admin", t, func() {
+		var events []moira.NotificationEvent
+		var sendingWG sync.WaitGroup
+		mock.database.EXPECT().GetMetricsUpdatesCount().Return(int64(1), nil)
+		mock.database.EXPECT().GetChecksUpdatesCount().Return(int64(1), nil)
+		mock.database.EXPECT().GetFailedNotificationsCount().Return(int64(15), nil)
+
+		now := time.Now()
+		redisLastCheckTS = now.Unix()
+		lastCheckTS = now.Unix()
+		nextSendErrorMessage = now.Add(-time.Second * 5).Unix()
+		lastMetricReceivedTS = now.Unix()
+		metricsCount = 1
+		checksCount = 1
+
+		appendNotificationEvents(&events, failedNotificationsMessage, 15)
+		expectedPackage := configureNotificationPackage(adminContact, &events)
+
+		mock.database.EXPECT().GetNotifierState().Return(moira.SelfStateOK, nil)
+		mock.notif.EXPECT().Send(&expectedPackage, &sendingWG)
+		mock.selfCheckWorker.check(now.Unix(), &lastMetricReceivedTS, &redisLastCheckTS, &lastCheckTS, &nextSendErrorMessage, &metricsCount, &checksCount, &lastPrometheusCheckTS, &prometheusChecksCount, lastRemoteCheckTS, remoteChecksCount)
+
+		So(nextSendErrorMessage, ShouldEqual, now.Unix()+mock.conf.NoticeIntervalSeconds)
+	})
+	mock.selfCheckWorker.Stop()
+	mock.mockCtrl.Finish()
+}
+
+func TestMoiraCheckerDoesNotChecksPrometheusTriggers(t *testing.T) {
+	adminContact := map[string]string{
+		"type":  "admin-mail",
+		"value": "admin@company.com",
+	}
+
+	var (
+		metricsCount         int64
+		checksCount          int64
+		lastMetricReceivedTS int64
+		redisLastCheckTS     int64
+		lastCheckTS          int64
+		nextSendErrorMessage int64
+
+		prometheusChecksCount int64
+		lastPrometheusCheckTS int64
+	)
+	remoteChecksCount := make(map[moira.ClusterID]int64)
+	lastRemoteCheckTS := make(map[moira.ClusterID]int64)
+
+	mock := configureWorker(t, false)
+	mock.selfCheckWorker.Config.PrometheusTriggersEnabled = true
+	mock.selfCheckWorker.Config.LastPrometheusCheckDelaySeconds = 120
+	mock.selfCheckWorker.Start()
+	Convey("Should notify admin", t, func() {
+		var events []moira.NotificationEvent
+		var sendingWG sync.WaitGroup
+		mock.database.EXPECT().GetMetricsUpdatesCount().Return(int64(1), nil)
+		mock.database.EXPECT().GetChecksUpdatesCount().Return(int64(1), nil)
+		mock.database.EXPECT().GetPrometheusChecksUpdatesCount().Return(int64(1), nil)
+
+		now := time.Now()
+		redisLastCheckTS = now.Unix()
+		lastCheckTS = now.Unix()
+		lastPrometheusCheckTS = now.Add(-time.Second * 121).Unix()
+		nextSendErrorMessage = now.Add(-time.Second * 5).Unix()
+		lastMetricReceivedTS = now.Unix()
+		checksCount = 1
+		prometheusChecksCount = 1
+
+		callingNow := now.Add(time.Second * 2)
+		appendNotificationEvents(&events, prometheusCheckerErrorMessage, callingNow.Unix()-lastPrometheusCheckTS)
+		expectedPackage := configureNotificationPackage(adminContact, &events)
+
+		mock.database.EXPECT().GetNotifierState().Return(moira.SelfStateOK, nil)
+		mock.notif.EXPECT().Send(&expectedPackage, &sendingWG)
+		mock.selfCheckWorker.check(callingNow.Unix(), &lastMetricReceivedTS, &redisLastCheckTS, &lastCheckTS, &nextSendErrorMessage, &metricsCount, &checksCount, &lastPrometheusCheckTS, &prometheusChecksCount, lastRemoteCheckTS, remoteChecksCount)
+
+		So(lastPrometheusCheckTS, ShouldEqual, now.Add(-time.Second*121).Unix())
 		So(redisLastCheckTS, ShouldEqual, callingNow.Unix())
 		So(nextSendErrorMessage, ShouldEqual, callingNow.Unix()+mock.conf.NoticeIntervalSeconds)
 	})
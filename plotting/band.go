@@ -0,0 +1,67 @@
+package plotting
+
+import (
+	"github.com/beevee/go-chart"
+	"github.com/beevee/go-chart/drawing"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/anomaly"
+	metricSource "github.com/moira-alert/moira/metric_source"
+)
+
+// bandSerie is a name that indicates bound of anomaly trigger expected values band
+const bandSerie = "band"
+
+// getBandsData returns lower and upper bounds of expected values of anomaly trigger metrics,
+// band width is WARN value number of standard deviations or ERROR value if trigger has no WARN value
+func getBandsData(trigger *moira.Trigger, metricsData []*metricSource.MetricData, history map[string]*metricSource.MetricData) []*metricSource.MetricData {
+	bandsData := make([]*metricSource.MetricData, 0)
+	if trigger.TriggerType != moira.AnomalyTrigger || len(history) == 0 {
+		return bandsData
+	}
+	deviations, _ := getBandDeviations(trigger)
+	if deviations == 0 {
+		return bandsData
+	}
+	for _, metricData := range metricsData {
+		lower, upper := anomaly.GetBand(metricData, history[metricData.Name], trigger.GetAnomalySettings(), deviations)
+		bandsData = append(bandsData, lower, upper)
+	}
+	return bandsData
+}
+
+// getBandDeviations returns number of standard deviations bounding expected values band and its threshold type
+func getBandDeviations(trigger *moira.Trigger) (float64, string) {
+	if trigger.WarnValue != nil {
+		return *trigger.WarnValue, "WARN"
+	}
+	if trigger.ErrorValue != nil {
+		return *trigger.ErrorValue, "ERROR"
+	}
+	return 0, ""
+}
+
+// getBandSeriesList returns series of expected values bands bounds
+func getBandSeriesList(trigger *moira.Trigger, bandsData []*metricSource.MetricData, theme moira.PlotTheme) []chart.Series {
+	bandSeriesList := make([]chart.Series, 0)
+	if len(bandsData) == 0 {
+		return bandSeriesList
+	}
+	_, thresholdType := getBandDeviations(trigger)
+	bandStyle := theme.GetThresholdStyle(thresholdType)
+	bandStyle.FillColor = drawing.Color{}
+	for _, bandData := range bandsData {
+		for _, curve := range describePlotCurves(bandData) {
+			if len(curve.values) == 0 {
+				continue
+			}
+			bandSeriesList = append(bandSeriesList, chart.TimeSeries{
+				Name:    bandSerie,
+				YAxis:   chart.YAxisSecondary,
+				Style:   bandStyle,
+				XValues: curve.timeStamps,
+				YValues: curve.values,
+			})
+		}
+	}
+	return bandSeriesList
+}
@@ -0,0 +1,89 @@
+package plotting
+
+import (
+	"math"
+	"testing"
+
+	"github.com/beevee/go-chart"
+	"github.com/moira-alert/moira"
+	metricSource "github.com/moira-alert/moira/metric_source"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetBandsData(t *testing.T) {
+	warnValue := 2.0
+	errorValue := 3.0
+	metricsData := []*metricSource.MetricData{
+		metricSource.MakeMetricData("first", []float64{1, 2}, 10, 110),
+		metricSource.MakeMetricData("second", []float64{1, 2}, 10, 110),
+	}
+	history := map[string]*metricSource.MetricData{
+		"first": metricSource.MakeMetricData("first", []float64{9, 10, 11, 10}, 10, 0),
+	}
+	trigger := &moira.Trigger{
+		TriggerType: moira.AnomalyTrigger,
+		WarnValue:   &warnValue,
+		ErrorValue:  &errorValue,
+		Anomaly:     &moira.AnomalySettings{Seasons: 1, SeasonLength: 100, Window: 10},
+	}
+
+	Convey("Bands are built for each metric by WARN value", t, func() {
+		bandsData := getBandsData(trigger, metricsData, history)
+		So(bandsData, ShouldHaveLength, 4)
+		So(bandsData[0].Values[0], ShouldAlmostEqual, 10-2*math.Sqrt(2.0/3))
+		So(bandsData[1].Values[0], ShouldAlmostEqual, 10+2*math.Sqrt(2.0/3))
+		So(math.IsNaN(bandsData[2].Values[0]), ShouldBeTrue)
+		So(math.IsNaN(bandsData[3].Values[0]), ShouldBeTrue)
+	})
+
+	Convey("No bands without history or for other trigger types", t, func() {
+		So(getBandsData(trigger, metricsData, nil), ShouldBeEmpty)
+		So(getBandsData(&moira.Trigger{TriggerType: moira.RisingTrigger, WarnValue: &warnValue}, metricsData, history), ShouldBeEmpty)
+	})
+}
+
+func TestGetBandDeviations(t *testing.T) {
+	warnValue := 2.0
+	errorValue := 3.0
+
+	Convey("Band deviations are resolved from WARN value first", t, func() {
+		deviations, thresholdType := getBandDeviations(&moira.Trigger{WarnValue: &warnValue, ErrorValue: &errorValue})
+		So(deviations, ShouldEqual, warnValue)
+		So(thresholdType, ShouldEqual, "WARN")
+
+		deviations, thresholdType = getBandDeviations(&moira.Trigger{ErrorValue: &errorValue})
+		So(deviations, ShouldEqual, errorValue)
+		So(thresholdType, ShouldEqual, "ERROR")
+
+		deviations, thresholdType = getBandDeviations(&moira.Trigger{})
+		So(deviations, ShouldEqual, 0)
+		So(thresholdType, ShouldBeEmpty)
+	})
+}
+
+func TestGetBandSeriesList(t *testing.T) {
+	warnValue := 2.0
+	trigger := &moira.Trigger{TriggerType: moira.AnomalyTrigger, WarnValue: &warnValue}
+	theme, err := getPlotTheme(darkPlotTheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Band series are split by absent values", t, func() {
+		bandsData := []*metricSource.MetricData{
+			metricSource.MakeMetricData("metric", []float64{1, math.NaN(), 3, 4}, 10, 0),
+			metricSource.MakeMetricData("metric", []float64{math.NaN(), math.NaN()}, 10, 0),
+		}
+		bandSeriesList := getBandSeriesList(trigger, bandsData, theme)
+		So(bandSeriesList, ShouldHaveLength, 2)
+		for _, bandSeries := range bandSeriesList {
+			So(bandSeries.GetName(), ShouldEqual, bandSerie)
+			So(bandSeries.GetYAxis(), ShouldEqual, chart.YAxisSecondary)
+			So(bandSeries.GetStyle().FillColor.IsZero(), ShouldBeTrue)
+		}
+	})
+
+	Convey("No band series without bands data", t, func() {
+		So(getBandSeriesList(trigger, nil, theme), ShouldBeEmpty)
+	})
+}
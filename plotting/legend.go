@@ -29,7 +29,7 @@ func getPlotLegend(c *chart.Chart, legendStyle chart.Style, plotWidth int) chart
 				if _, isAnnotationSeries := s.(chart.AnnotationSeries); !isAnnotationSeries {
 					legendLabel := s.GetName()
 					_, isFound := foundLabels[legendLabel]
-					if !isFound && legendLabel != thresholdSerie {
+					if !isFound && legendLabel != thresholdSerie && legendLabel != bandSerie {
 						foundLabels[legendLabel] = true
 						legendLabel = sanitizeLabelName(legendLabel, maxLabelLength)
 						labels = append(labels, legendLabel)
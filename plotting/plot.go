@@ -44,13 +44,14 @@ func GetPlotTemplate(theme string, location *time.Location) (*Plot, error) {
 	}, nil
 }
 
-// GetRenderable returns go-chart to render
-func (plot *Plot) GetRenderable(trigger *moira.Trigger, metricsData []*metricSource.MetricData) (chart.Chart, error) {
+// GetRenderable returns go-chart to render, history is used to draw expected values band of anomaly trigger metrics
+func (plot *Plot) GetRenderable(trigger *moira.Trigger, metricsData []*metricSource.MetricData, history map[string]*metricSource.MetricData) (chart.Chart, error) {
 	var renderable chart.Chart
 
 	plotSeries := make([]chart.Series, 0)
 
-	limits := resolveLimits(metricsData)
+	bandsData := getBandsData(trigger, metricsData, history)
+	limits := resolveLimits(append(bandsData, metricsData...))
 
 	curveSeriesList := getCurveSeriesList(metricsData, plot.theme)
 	if len(curveSeriesList) == 0 {
@@ -64,6 +65,9 @@ func (plot *Plot) GetRenderable(trigger *moira.Trigger, metricsData []*metricSou
 	thresholdSeriesList := getThresholdSeriesList(trigger, plot.theme, limits)
 	plotSeries = append(plotSeries, thresholdSeriesList...)
 
+	bandSeriesList := getBandSeriesList(trigger, bandsData, plot.theme)
+	plotSeries = append(plotSeries, bandSeriesList...)
+
 	gridStyle := plot.theme.GetGridStyle()
 
 	yAxisValuesFormatter, maxMarkLen := getYAxisValuesFormatter(limits)
@@ -38,7 +38,7 @@ func newThreshold(triggerType, thresholdType string, thresholdValue, higherLimit
 // getThresholdSeriesList returns collection of thresholds and annotations
 func getThresholdSeriesList(trigger *moira.Trigger, theme moira.PlotTheme, limits plotLimits) []chart.Series {
 	thresholdSeriesList := make([]chart.Series, 0)
-	if trigger.TriggerType == moira.ExpressionTrigger {
+	if trigger.TriggerType == moira.ExpressionTrigger || trigger.TriggerType == moira.AnomalyTrigger {
 		return thresholdSeriesList
 	}
 	plotThresholds := generateThresholds(trigger, limits)
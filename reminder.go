@@ -0,0 +1,61 @@
+package moira
+
+import "fmt"
+
+const (
+	// DefaultReminderInterval is a default interval in seconds between reminders about trigger or metric staying in bad state
+	DefaultReminderInterval int64 = 86400
+	// MinReminderInterval is a min interval in seconds between reminders, reminder message reports bad state duration in hours
+	MinReminderInterval int64 = 3600
+)
+
+// GetDefaultReminders returns reminder intervals used when neither checker config nor trigger define them
+func GetDefaultReminders() map[State]int64 {
+	return map[State]int64{
+		StateERROR:  DefaultReminderInterval,
+		StateNODATA: DefaultReminderInterval,
+	}
+}
+
+// GetReminderInterval returns interval in seconds between reminders about given state.
+// Trigger reminders override default ones state by state, zero interval disables reminders about the state
+func (trigger *Trigger) GetReminderInterval(state State, defaults map[State]int64) int64 {
+	if interval, ok := trigger.Reminders[state]; ok {
+		return interval
+	}
+	return defaults[state]
+}
+
+// ValidateReminders checks that reminders are set for bad states only and intervals are either 0 or at least MinReminderInterval
+func ValidateReminders(reminders map[State]int64) error {
+	for state, interval := range reminders {
+		switch state {
+		case StateWARN, StateERROR, StateNODATA, StateEXCEPTION:
+		default:
+			return fmt.Errorf("reminders can't be set for state '%s', allowable states: '%s', '%s', '%s', '%s'",
+				state, StateWARN, StateERROR, StateNODATA, StateEXCEPTION)
+		}
+		if interval != 0 && interval < MinReminderInterval {
+			return fmt.Errorf("reminder interval for state '%s' should be 0 or at least %d seconds", state, MinReminderInterval)
+		}
+	}
+	return nil
+}
+
+// getOrdinal returns English ordinal number representation, e.g. 1st, 2nd, 3rd, 11th
+func getOrdinal(number int64) string {
+	suffix := "th"
+	switch number % 100 {
+	case 11, 12, 13:
+	default:
+		switch number % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", number, suffix)
+}
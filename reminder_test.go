@@ -0,0 +1,59 @@
+package moira
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetReminderInterval(t *testing.T) {
+	defaults := GetDefaultReminders()
+
+	Convey("Trigger without reminders uses defaults", t, func() {
+		trigger := Trigger{}
+		So(trigger.GetReminderInterval(StateERROR, defaults), ShouldEqual, DefaultReminderInterval)
+		So(trigger.GetReminderInterval(StateNODATA, defaults), ShouldEqual, DefaultReminderInterval)
+		So(trigger.GetReminderInterval(StateWARN, defaults), ShouldEqual, 0)
+		So(trigger.GetReminderInterval(StateERROR, nil), ShouldEqual, 0)
+	})
+
+	Convey("Trigger reminders override defaults state by state", t, func() {
+		trigger := Trigger{Reminders: map[State]int64{StateERROR: 7200, StateWARN: 7200, StateNODATA: 0}}
+		So(trigger.GetReminderInterval(StateERROR, defaults), ShouldEqual, 7200)
+		So(trigger.GetReminderInterval(StateWARN, defaults), ShouldEqual, 7200)
+		So(trigger.GetReminderInterval(StateNODATA, defaults), ShouldEqual, 0)
+		So(trigger.GetReminderInterval(StateOK, defaults), ShouldEqual, 0)
+	})
+}
+
+func TestGetOrdinal(t *testing.T) {
+	Convey("Ordinal numbers", t, func() {
+		So(getOrdinal(1), ShouldEqual, "1st")
+		So(getOrdinal(2), ShouldEqual, "2nd")
+		So(getOrdinal(3), ShouldEqual, "3rd")
+		So(getOrdinal(4), ShouldEqual, "4th")
+		So(getOrdinal(11), ShouldEqual, "11th")
+		So(getOrdinal(12), ShouldEqual, "12th")
+		So(getOrdinal(13), ShouldEqual, "13th")
+		So(getOrdinal(21), ShouldEqual, "21st")
+		So(getOrdinal(102), ShouldEqual, "102nd")
+		So(getOrdinal(111), ShouldEqual, "111th")
+	})
+}
+
+func TestValidateReminders(t *testing.T) {
+	Convey("Valid reminders", t, func() {
+		So(ValidateReminders(map[State]int64{StateERROR: 86400, StateWARN: 0, StateNODATA: MinReminderInterval}), ShouldBeNil)
+		So(ValidateReminders(nil), ShouldBeNil)
+	})
+
+	Convey("Reminder for not bad state", t, func() {
+		So(ValidateReminders(map[State]int64{StateOK: 86400}), ShouldNotBeNil)
+	})
+
+	Convey("Reminder interval shorter than min one", t, func() {
+		err := ValidateReminders(map[State]int64{StateERROR: 60})
+		So(err, ShouldResemble, fmt.Errorf("reminder interval for state 'ERROR' should be 0 or at least 3600 seconds"))
+	})
+}
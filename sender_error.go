@@ -0,0 +1,53 @@
+package moira
+
+import (
+	"errors"
+	"time"
+)
+
+// SenderPermanentError is returned by sender if notification can not be delivered by resending,
+// e.g. contact does not exist or receiver rejects request, so notification is not resent
+type SenderPermanentError struct {
+	Err error
+}
+
+// Error returns sending error message
+func (err SenderPermanentError) Error() string {
+	return err.Err.Error()
+}
+
+// Unwrap returns sending error
+func (err SenderPermanentError) Unwrap() error {
+	return err.Err
+}
+
+// IsSenderPermanentError checks whether sending error is permanent
+func IsSenderPermanentError(err error) bool {
+	var permanentError SenderPermanentError
+	return errors.As(err, &permanentError)
+}
+
+// SenderRetryAfterError is returned by sender if receiver asks to resend notification not earlier than after given delay
+type SenderRetryAfterError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+// Error returns sending error message
+func (err SenderRetryAfterError) Error() string {
+	return err.Err.Error()
+}
+
+// Unwrap returns sending error
+func (err SenderRetryAfterError) Unwrap() error {
+	return err.Err
+}
+
+// GetSenderRetryAfter returns delay requested by receiver before resending or zero if sending error has no delay
+func GetSenderRetryAfter(err error) time.Duration {
+	var retryAfterError SenderRetryAfterError
+	if errors.As(err, &retryAfterError) {
+		return retryAfterError.RetryAfter
+	}
+	return 0
+}
@@ -0,0 +1,21 @@
+package moira
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIsSenderPermanentError(t *testing.T) {
+	Convey("Permanent error", t, func() {
+		err := SenderPermanentError{Err: fmt.Errorf("invalid contact")}
+		So(err.Error(), ShouldEqual, "invalid contact")
+		So(IsSenderPermanentError(err), ShouldBeTrue)
+	})
+
+	Convey("Other errors", t, func() {
+		So(IsSenderPermanentError(fmt.Errorf("timeout")), ShouldBeFalse)
+		So(IsSenderPermanentError(nil), ShouldBeFalse)
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/templating"
 	"github.com/moira-alert/moira/worker"
 )
 
@@ -24,6 +25,7 @@ type Sender struct {
 	session   *discordgo.Session
 	frontURI  string
 	botUserID string
+	renderer  *templating.Renderer
 }
 
 // Init reads the yaml config
@@ -40,6 +42,10 @@ func (sender *Sender) Init(senderSettings map[string]string, logger moira.Logger
 	sender.logger = logger
 	sender.frontURI = senderSettings["front_uri"]
 	sender.location = location
+	sender.renderer, err = templating.NewRenderer(senderSettings, logger, location)
+	if err != nil {
+		return err
+	}
 
 	handleMsg := func(s *discordgo.Session, m *discordgo.MessageCreate) {
 		channel, err := s.Channel(m.ChannelID)
@@ -23,7 +23,9 @@ var (
 // SendEvents implements pushover build and send message functionality
 func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plot []byte, throttled bool) error {
 	data := &discordgo.MessageSend{}
-	data.Content = sender.buildMessage(events, trigger, throttled)
+	data.Content = sender.renderer.RenderOrDefault(events, contact, trigger, throttled, messageMaxCharacters, func() string {
+		return sender.buildMessage(events, trigger, throttled)
+	})
 	if len(plot) > 0 {
 		data.File = sender.buildPlot(plot)
 		data.Embed = &discordgo.MessageEmbed{
@@ -0,0 +1,197 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/moira-alert/moira"
+)
+
+type issueFields struct {
+	Project     issueProject  `json:"project"`
+	IssueType   issueTypeName `json:"issuetype"`
+	Summary     string        `json:"summary"`
+	Description string        `json:"description"`
+	Labels      []string      `json:"labels,omitempty"`
+}
+
+type issueProject struct {
+	Key string `json:"key"`
+}
+
+type issueTypeName struct {
+	Name string `json:"name"`
+}
+
+type transition struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	To   struct {
+		Name string `json:"name"`
+	} `json:"to"`
+}
+
+// createIssue creates issue and returns its key,
+// see https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-issues/#api-rest-api-2-issue-post
+func (sender *Sender) createIssue(fields *issueFields) (string, error) {
+	var response struct {
+		Key string `json:"key"`
+	}
+	request := map[string]interface{}{"fields": fields}
+	if err := sender.doJSON(http.MethodPost, "/rest/api/2/issue", request, &response); err != nil {
+		return "", err
+	}
+	if response.Key == "" {
+		return "", fmt.Errorf("jira responded with empty issue key")
+	}
+	return response.Key, nil
+}
+
+// findIssue returns key of last created unresolved issue in project with given label or empty key if there is no one,
+// see https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-issue-search/#api-rest-api-2-search-get
+func (sender *Sender) findIssue(project, label string) (string, error) {
+	var response struct {
+		Issues []struct {
+			Key string `json:"key"`
+		} `json:"issues"`
+	}
+	query := url.Values{}
+	query.Set("jql", fmt.Sprintf("project = %s AND labels = %s AND statusCategory != Done ORDER BY created DESC",
+		quoteJQL(project), quoteJQL(label)))
+	query.Set("fields", "key")
+	query.Set("maxResults", "1")
+	if err := sender.doJSON(http.MethodGet, "/rest/api/2/search?"+query.Encode(), nil, &response); err != nil {
+		return "", err
+	}
+	if len(response.Issues) == 0 {
+		return "", nil
+	}
+	return response.Issues[0].Key, nil
+}
+
+// addComment adds comment to issue,
+// see https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-issue-comments/#api-rest-api-2-issue-issueidorkey-comment-post
+func (sender *Sender) addComment(issueKey, body string) error {
+	request := map[string]string{"body": body}
+	return sender.doJSON(http.MethodPost, fmt.Sprintf("/rest/api/2/issue/%s/comment", url.PathEscape(issueKey)), request, nil)
+}
+
+// resolveIssue performs issue transition with configured name or to status with configured name,
+// see https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-issues/#api-rest-api-2-issue-issueidorkey-transitions-post
+func (sender *Sender) resolveIssue(issueKey string) error {
+	path := fmt.Sprintf("/rest/api/2/issue/%s/transitions", url.PathEscape(issueKey))
+	var response struct {
+		Transitions []transition `json:"transitions"`
+	}
+	if err := sender.doJSON(http.MethodGet, path, nil, &response); err != nil {
+		return err
+	}
+	for _, transition := range response.Transitions {
+		if strings.EqualFold(transition.Name, sender.resolveTransition) || strings.EqualFold(transition.To.Name, sender.resolveTransition) {
+			request := map[string]interface{}{"transition": map[string]string{"id": transition.ID}}
+			return sender.doJSON(http.MethodPost, path, request, nil)
+		}
+	}
+	return fmt.Errorf("issue has no transition '%s'", sender.resolveTransition)
+}
+
+// addAttachment attaches file to issue,
+// see https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-issue-attachments/#api-rest-api-2-issue-issueidorkey-attachments-post
+func (sender *Sender) addAttachment(issueKey, fileName string, content []byte) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		return err
+	}
+	if _, err = part.Write(content); err != nil {
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	_, err = sender.do(http.MethodPost, fmt.Sprintf("/rest/api/2/issue/%s/attachments", url.PathEscape(issueKey)), writer.FormDataContentType(), &body)
+	return err
+}
+
+// getProject checks that project exists and is available,
+// see https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-projects/#api-rest-api-2-project-projectidorkey-get
+func (sender *Sender) getProject(project string) error {
+	return sender.doJSON(http.MethodGet, "/rest/api/2/project/"+url.PathEscape(project), nil, nil)
+}
+
+// quoteJQL quotes string value to use it in JQL query
+func quoteJQL(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+func (sender *Sender) doJSON(method, path string, request, result interface{}) error {
+	var body io.Reader
+	if request != nil {
+		requestBody, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(requestBody)
+	}
+	responseBody, err := sender.do(method, path, "application/json", body)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	if err = json.Unmarshal(responseBody, result); err != nil {
+		return fmt.Errorf("failed to decode response: %s", err.Error())
+	}
+	return nil
+}
+
+func (sender *Sender) do(method, path, contentType string, body io.Reader) ([]byte, error) {
+	request, err := http.NewRequest(method, sender.url+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if sender.user != "" {
+		request.SetBasicAuth(sender.user, sender.apiToken)
+	} else {
+		request.Header.Set("Authorization", "Bearer "+sender.apiToken)
+	}
+	request.Header.Set("Content-Type", contentType)
+	request.Header.Set("User-Agent", "Moira")
+	// required by jira to accept attachments
+	request.Header.Set("X-Atlassian-Token", "no-check")
+
+	response, err := sender.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %s", err.Error())
+	}
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		err = fmt.Errorf("jira responded with code %d: %s", response.StatusCode, string(responseBody))
+		if !isRetryableResponseCode(response.StatusCode) {
+			return nil, moira.SenderPermanentError{Err: err}
+		}
+		return nil, err
+	}
+	return responseBody, nil
+}
+
+// isRetryableResponseCode checks whether request can succeed on retry, client errors are permanent
+// except request timeout and rate limiting
+func isRetryableResponseCode(statusCode int) bool {
+	return statusCode < http.StatusBadRequest || statusCode >= http.StatusInternalServerError ||
+		statusCode == http.StatusRequestTimeout || statusCode == http.StatusTooManyRequests
+}
@@ -0,0 +1,211 @@
+package jira
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	"github.com/moira-alert/moira/templating"
+)
+
+const (
+	defaultIssueType         = "Task"
+	defaultResolveTransition = "Done"
+)
+
+// Sender implements moira sender interface via Jira REST api. It opens issue about trigger when its metric
+// goes to one of open states, comments issue on further events and resolves it when all metrics recover.
+// Contact value is a project key optionally followed by issue type, e.g. 'OPS' or 'OPS:Incident'
+type Sender struct {
+	DataBase          moira.Database
+	url               string
+	user              string
+	apiToken          string
+	issueType         string
+	resolveTransition string
+	openStates        map[moira.State]bool
+	frontURI          string
+	logger            moira.Logger
+	location          *time.Location
+	client            *http.Client
+	renderer          *templating.Renderer
+}
+
+// Init read yaml config
+func (sender *Sender) Init(senderSettings map[string]string, logger moira.Logger, location *time.Location, dateTimeFormat string) error {
+	sender.url = strings.TrimSuffix(senderSettings["url"], "/")
+	if sender.url == "" {
+		return fmt.Errorf("can not read jira url from config")
+	}
+	sender.apiToken = senderSettings["api_token"]
+	if sender.apiToken == "" {
+		return fmt.Errorf("can not read jira api_token from config")
+	}
+	sender.user = senderSettings["user"]
+	sender.issueType = senderSettings["issue_type"]
+	if sender.issueType == "" {
+		sender.issueType = defaultIssueType
+	}
+	sender.resolveTransition = senderSettings["resolve_transition"]
+	if sender.resolveTransition == "" {
+		sender.resolveTransition = defaultResolveTransition
+	}
+	openStates, err := parseOpenStates(senderSettings["open_states"])
+	if err != nil {
+		return err
+	}
+	sender.openStates = openStates
+	renderer, err := templating.NewRenderer(senderSettings, logger, location)
+	if err != nil {
+		return err
+	}
+	sender.renderer = renderer
+	sender.frontURI = senderSettings["front_uri"]
+	sender.logger = logger
+	sender.location = location
+	sender.client = &http.Client{Timeout: 30 * time.Second}
+	return nil
+}
+
+// SendEvents implements Sender interface Send
+func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plot []byte, throttled bool) error {
+	project, issueType := sender.parseContactValue(contact.Value)
+	if trigger.ID == "" {
+		// test notification has no trigger to open issue about, so just check project is available
+		if err := sender.getProject(project); err != nil {
+			return fmt.Errorf("failed to get jira project %s: %w", project, err)
+		}
+		return nil
+	}
+
+	issue, err := sender.DataBase.GetTriggerIssue(trigger.ID, contact.ID)
+	saved := err == nil
+	if err != nil && err != database.ErrNil {
+		return fmt.Errorf("failed to get %s jira issue: %w", trigger.ID, err)
+	}
+	// events are already described by issue which was created by failed sending attempt
+	commented := false
+	if issue.Opening {
+		if issue.Key, err = sender.findIssue(project, getIssueLabel(trigger.ID, contact.ID)); err != nil {
+			return fmt.Errorf("failed to find %s jira issue in project %s: %w", trigger.ID, project, err)
+		}
+		commented = issue.Key != ""
+	}
+	for _, event := range events {
+		if event.State == moira.StateOK {
+			issue.RemoveMetric(event.Metric)
+		} else if sender.openStates[event.State] {
+			issue.AddMetric(event.Metric)
+		}
+	}
+	// recovery was commented by failed sending attempt if issue is still being resolved
+	commented = commented || issue.Resolving && issue.IsRecovered()
+
+	description := sender.renderer.RenderOrDefault(events, contact, trigger, throttled, descriptionMaxCharacters, func() string {
+		return sender.buildDescription(events, trigger, throttled)
+	})
+	if issue.Key == "" {
+		if !issue.IsRecovered() {
+			return sender.openIssue(&issue, project, issueType, events, trigger, description, plot, contact.ID)
+		}
+		if saved {
+			return sender.DataBase.RemoveTriggerIssue(trigger.ID, contact.ID)
+		}
+		return nil
+	}
+
+	issue.Opening = false
+	if !commented {
+		if err := sender.addComment(issue.Key, description); err != nil {
+			return fmt.Errorf("failed to comment jira issue %s: %w", issue.Key, err)
+		}
+	}
+	if !issue.IsRecovered() {
+		issue.Resolving = false
+		return sender.DataBase.SaveTriggerIssue(trigger.ID, contact.ID, &issue)
+	}
+	if !issue.Resolving {
+		issue.Resolving = true
+		if err := sender.DataBase.SaveTriggerIssue(trigger.ID, contact.ID, &issue); err != nil {
+			return fmt.Errorf("failed to save %s jira issue %s: %w", trigger.ID, issue.Key, err)
+		}
+	}
+	if err := sender.resolveIssue(issue.Key); err != nil {
+		return fmt.Errorf("failed to resolve jira issue %s: %w", issue.Key, err)
+	}
+	return sender.DataBase.RemoveTriggerIssue(trigger.ID, contact.ID)
+}
+
+// openIssue creates issue labeled with trigger and contact IDs. Issue is saved as opening before creation,
+// so if its key is not saved after, retry finds created issue by label instead of creating another one
+func (sender *Sender) openIssue(issue *moira.TrackerIssue, project, issueType string, events moira.NotificationEvents, trigger moira.TriggerData, description string, plot []byte, contactID string) error {
+	issue.Opening = true
+	if err := sender.DataBase.SaveTriggerIssue(trigger.ID, contactID, issue); err != nil {
+		return fmt.Errorf("failed to save %s jira issue: %w", trigger.ID, err)
+	}
+	key, err := sender.createIssue(&issueFields{
+		Project:     issueProject{Key: project},
+		IssueType:   issueTypeName{Name: issueType},
+		Summary:     sender.buildSummary(events, trigger),
+		Description: description,
+		Labels:      append(getLabels(trigger.Tags), getIssueLabel(trigger.ID, contactID)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open %s jira issue in project %s: %w", trigger.ID, project, err)
+	}
+	issue.Key = key
+	issue.Opening = false
+	if err := sender.DataBase.SaveTriggerIssue(trigger.ID, contactID, issue); err != nil {
+		return fmt.Errorf("failed to save %s jira issue %s: %w", trigger.ID, key, err)
+	}
+	if len(plot) > 0 {
+		if err := sender.addAttachment(key, fmt.Sprintf("%s.png", trigger.ID), plot); err != nil {
+			sender.logger.Warningf("Could not attach the plot image to jira issue %s: %s", key, err.Error())
+		}
+	}
+	return nil
+}
+
+// parseContactValue returns contact project key and issue type, sender issue type is used if contact has no one
+func (sender *Sender) parseContactValue(value string) (string, string) {
+	parts := strings.SplitN(value, ":", 2)
+	project := strings.TrimSpace(parts[0])
+	if len(parts) == 2 && strings.TrimSpace(parts[1]) != "" {
+		return project, strings.TrimSpace(parts[1])
+	}
+	return project, sender.issueType
+}
+
+// parseOpenStates parses comma separated states to open issue on, issues are opened on ERROR state by default
+func parseOpenStates(value string) (map[moira.State]bool, error) {
+	if value == "" {
+		return map[moira.State]bool{moira.StateERROR: true}, nil
+	}
+	openStates := make(map[moira.State]bool)
+	for _, state := range strings.Split(value, ",") {
+		switch openState := moira.State(strings.TrimSpace(state)); openState {
+		case moira.StateWARN, moira.StateERROR, moira.StateNODATA, moira.StateEXCEPTION:
+			openStates[openState] = true
+		default:
+			return nil, fmt.Errorf("jira issues can not be opened on state '%s'", state)
+		}
+	}
+	return openStates, nil
+}
+
+// getIssueLabel returns label which identifies issues opened about trigger by contact
+func getIssueLabel(triggerID, contactID string) string {
+	return strings.Join(strings.Fields(fmt.Sprintf("moira-%s-%s", triggerID, contactID)), "_")
+}
+
+// getLabels converts trigger tags to jira labels which can not contain spaces
+func getLabels(tags []string) []string {
+	labels := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		labels = append(labels, strings.Join(strings.Fields(tag), "_"))
+	}
+	return labels
+}
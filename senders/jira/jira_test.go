@@ -0,0 +1,316 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	"github.com/moira-alert/moira/logging/go-logging"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestInit(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test")
+	Convey("Init tests", t, func() {
+		sender := Sender{}
+
+		Convey("Empty url", func() {
+			err := sender.Init(map[string]string{"api_token": "token"}, logger, nil, "")
+			So(err, ShouldResemble, fmt.Errorf("can not read jira url from config"))
+		})
+
+		Convey("Empty api_token", func() {
+			err := sender.Init(map[string]string{"url": "http://jira.url"}, logger, nil, "")
+			So(err, ShouldResemble, fmt.Errorf("can not read jira api_token from config"))
+		})
+
+		Convey("Wrong open state", func() {
+			err := sender.Init(map[string]string{"url": "http://jira.url", "api_token": "token", "open_states": "ERROR,OK"}, logger, nil, "")
+			So(err, ShouldResemble, fmt.Errorf("jira issues can not be opened on state 'OK'"))
+		})
+
+		Convey("Default settings", func() {
+			err := sender.Init(map[string]string{"url": "http://jira.url/", "api_token": "token"}, logger, nil, "")
+			So(err, ShouldBeNil)
+			So(sender.url, ShouldEqual, "http://jira.url")
+			So(sender.issueType, ShouldEqual, defaultIssueType)
+			So(sender.resolveTransition, ShouldEqual, defaultResolveTransition)
+			So(sender.openStates, ShouldResemble, map[moira.State]bool{moira.StateERROR: true})
+		})
+
+		Convey("Full settings", func() {
+			err := sender.Init(map[string]string{
+				"url":                "http://jira.url",
+				"user":               "moira@example.com",
+				"api_token":          "token",
+				"issue_type":         "Incident",
+				"resolve_transition": "Resolved",
+				"open_states":        "WARN, ERROR",
+			}, logger, nil, "")
+			So(err, ShouldBeNil)
+			So(sender.user, ShouldEqual, "moira@example.com")
+			So(sender.issueType, ShouldEqual, "Incident")
+			So(sender.resolveTransition, ShouldEqual, "Resolved")
+			So(sender.openStates, ShouldResemble, map[moira.State]bool{moira.StateWARN: true, moira.StateERROR: true})
+		})
+	})
+}
+
+func TestParseContactValue(t *testing.T) {
+	sender := Sender{issueType: defaultIssueType}
+	Convey("Parse contact value", t, func() {
+		project, issueType := sender.parseContactValue("OPS")
+		So(project, ShouldEqual, "OPS")
+		So(issueType, ShouldEqual, defaultIssueType)
+
+		project, issueType = sender.parseContactValue("OPS:Incident")
+		So(project, ShouldEqual, "OPS")
+		So(issueType, ShouldEqual, "Incident")
+
+		project, issueType = sender.parseContactValue("OPS:")
+		So(project, ShouldEqual, "OPS")
+		So(issueType, ShouldEqual, defaultIssueType)
+	})
+}
+
+func TestBuildDescription(t *testing.T) {
+	location, _ := time.LoadLocation("UTC")
+	sender := Sender{location: location, frontURI: "http://moira.url"}
+	value := float64(97.4458331200185)
+	event := moira.NotificationEvent{Value: &value, Timestamp: 150000000, Metric: "Metric", OldState: moira.StateOK, State: moira.StateERROR}
+	trigger := moira.TriggerData{ID: "TriggerID", Name: "Name", Tags: []string{"tag1", "tag 2"}, Desc: "desc"}
+
+	Convey("Build description", t, func() {
+		Convey("Print events and trigger link", func() {
+			actual := sender.buildDescription(moira.NotificationEvents{event}, trigger, false)
+			So(actual, ShouldEqual, "desc\n{noformat}\n02:40: Metric = 97.4458331200185 (OK to ERROR)\n{noformat}\n[Open in Moira|http://moira.url/trigger/TriggerID]")
+		})
+
+		Convey("Print throttling message", func() {
+			actual := sender.buildDescription(moira.NotificationEvents{event}, trigger, true)
+			So(actual, ShouldEndWith, throttleMsg)
+		})
+
+		Convey("Many events are limited", func() {
+			events := make(moira.NotificationEvents, 0)
+			for i := 0; i < 1000; i++ {
+				events = append(events, event)
+			}
+			actual := sender.buildDescription(events, trigger, false)
+			So(len([]rune(actual)), ShouldBeLessThanOrEqualTo, descriptionMaxCharacters)
+			So(actual, ShouldContainSubstring, "{noformat}\n...and")
+		})
+
+		Convey("Summary and labels", func() {
+			So(sender.buildSummary(moira.NotificationEvents{event}, trigger), ShouldEqual, "ERROR Name [tag1][tag 2]")
+			So(getLabels(trigger.Tags), ShouldResemble, []string{"tag1", "tag_2"})
+		})
+	})
+}
+
+func TestSendEvents(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test")
+	location, _ := time.LoadLocation("UTC")
+
+	value := float64(1)
+	errorEvent := moira.NotificationEvent{Value: &value, Timestamp: 150000000, Metric: "Metric", OldState: moira.StateOK, State: moira.StateERROR}
+	warnEvent := moira.NotificationEvent{Value: &value, Timestamp: 150000000, Metric: "Metric", OldState: moira.StateOK, State: moira.StateWARN}
+	okEvent := moira.NotificationEvent{Value: &value, Timestamp: 150000000, Metric: "Metric", OldState: moira.StateERROR, State: moira.StateOK}
+	trigger := moira.TriggerData{ID: "TriggerID", Name: "Name", Tags: []string{"tag"}}
+	contact := moira.ContactData{ID: "ContactID", Type: "jira", Value: "OPS:Incident"}
+
+	var requests []string
+	var created map[string]issueFields
+	var searchQuery string
+	var foundIssues string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if user, token, ok := request.BasicAuth(); !ok || user != "moira" || token != "token" {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		requests = append(requests, request.Method+" "+request.URL.Path)
+		switch request.Method + " " + request.URL.Path {
+		case "POST /rest/api/2/issue":
+			body, _ := ioutil.ReadAll(request.Body)
+			json.Unmarshal(body, &created)
+			writer.WriteHeader(http.StatusCreated)
+			writer.Write([]byte(`{"id":"10000","key":"OPS-1"}`))
+		case "POST /rest/api/2/issue/OPS-1/comment":
+			writer.WriteHeader(http.StatusCreated)
+			writer.Write([]byte(`{"id":"1"}`))
+		case "GET /rest/api/2/issue/OPS-1/transitions":
+			writer.Write([]byte(`{"transitions":[{"id":"11","name":"Start","to":{"name":"In Progress"}},{"id":"31","name":"Close","to":{"name":"Done"}}]}`))
+		case "POST /rest/api/2/issue/OPS-1/transitions":
+			writer.WriteHeader(http.StatusNoContent)
+		case "POST /rest/api/2/issue/OPS-1/attachments":
+			writer.Write([]byte(`[]`))
+		case "GET /rest/api/2/search":
+			searchQuery = request.URL.Query().Get("jql")
+			writer.Write([]byte(`{"issues":[` + foundIssues + `]}`))
+		case "GET /rest/api/2/project/OPS":
+			writer.Write([]byte(`{"key":"OPS"}`))
+		case "GET /rest/api/2/project/BUSY":
+			writer.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			writer.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	sender := Sender{DataBase: dataBase}
+	_ = sender.Init(map[string]string{"url": server.URL, "user": "moira", "api_token": "token"}, logger, location, "")
+
+	Convey("Send events", t, func() {
+		requests = nil
+		created = nil
+		searchQuery = ""
+		foundIssues = ""
+
+		Convey("Test notification checks project", func() {
+			err := sender.SendEvents(moira.NotificationEvents{errorEvent}, contact, moira.TriggerData{}, nil, false)
+			So(err, ShouldBeNil)
+			So(requests, ShouldResemble, []string{"GET /rest/api/2/project/OPS"})
+		})
+
+		Convey("Client errors are permanent", func() {
+			err := sender.SendEvents(moira.NotificationEvents{errorEvent}, moira.ContactData{Value: "UNKNOWN"}, moira.TriggerData{}, nil, false)
+			So(err, ShouldNotBeNil)
+			So(moira.IsSenderPermanentError(err), ShouldBeTrue)
+		})
+
+		Convey("Server errors are not permanent", func() {
+			err := sender.SendEvents(moira.NotificationEvents{errorEvent}, moira.ContactData{Value: "BUSY"}, moira.TriggerData{}, nil, false)
+			So(err, ShouldNotBeNil)
+			So(moira.IsSenderPermanentError(err), ShouldBeFalse)
+		})
+
+		Convey("Issue is opened on open state", func() {
+			dataBase.EXPECT().GetTriggerIssue(trigger.ID, contact.ID).Return(moira.TrackerIssue{}, database.ErrNil)
+			gomock.InOrder(
+				dataBase.EXPECT().SaveTriggerIssue(trigger.ID, contact.ID, &moira.TrackerIssue{Metrics: []string{"Metric"}, Opening: true}).Return(nil),
+				dataBase.EXPECT().SaveTriggerIssue(trigger.ID, contact.ID, &moira.TrackerIssue{Key: "OPS-1", Metrics: []string{"Metric"}}).Return(nil),
+			)
+			err := sender.SendEvents(moira.NotificationEvents{errorEvent}, contact, trigger, []byte("plot"), false)
+			So(err, ShouldBeNil)
+			So(requests, ShouldResemble, []string{"POST /rest/api/2/issue", "POST /rest/api/2/issue/OPS-1/attachments"})
+			fields := created["fields"]
+			So(fields.Project.Key, ShouldEqual, "OPS")
+			So(fields.IssueType.Name, ShouldEqual, "Incident")
+			So(fields.Summary, ShouldEqual, "ERROR Name [tag]")
+			So(fields.Labels, ShouldResemble, []string{"tag", "moira-TriggerID-ContactID"})
+		})
+
+		Convey("Issue is not opened if opening marker can not be saved", func() {
+			dataBase.EXPECT().GetTriggerIssue(trigger.ID, contact.ID).Return(moira.TrackerIssue{}, database.ErrNil)
+			dataBase.EXPECT().SaveTriggerIssue(trigger.ID, contact.ID, &moira.TrackerIssue{Metrics: []string{"Metric"}, Opening: true}).Return(fmt.Errorf("oops"))
+			err := sender.SendEvents(moira.NotificationEvents{errorEvent}, contact, trigger, nil, false)
+			So(err.Error(), ShouldEqual, "failed to save TriggerID jira issue: oops")
+			So(requests, ShouldBeEmpty)
+		})
+
+		Convey("Issue created by failed attempt is found instead of opening another one", func() {
+			foundIssues = `{"id":"10000","key":"OPS-1"}`
+			dataBase.EXPECT().GetTriggerIssue(trigger.ID, contact.ID).Return(moira.TrackerIssue{Metrics: []string{"Metric"}, Opening: true}, nil)
+			dataBase.EXPECT().SaveTriggerIssue(trigger.ID, contact.ID, &moira.TrackerIssue{Key: "OPS-1", Metrics: []string{"Metric"}}).Return(nil)
+			err := sender.SendEvents(moira.NotificationEvents{errorEvent}, contact, trigger, nil, false)
+			So(err, ShouldBeNil)
+			So(requests, ShouldResemble, []string{"GET /rest/api/2/search"})
+			So(searchQuery, ShouldEqual, `project = "OPS" AND labels = "moira-TriggerID-ContactID" AND statusCategory != Done ORDER BY created DESC`)
+		})
+
+		Convey("Issue which failed attempt did not create is opened", func() {
+			dataBase.EXPECT().GetTriggerIssue(trigger.ID, contact.ID).Return(moira.TrackerIssue{Metrics: []string{"Metric"}, Opening: true}, nil)
+			gomock.InOrder(
+				dataBase.EXPECT().SaveTriggerIssue(trigger.ID, contact.ID, &moira.TrackerIssue{Metrics: []string{"Metric"}, Opening: true}).Return(nil),
+				dataBase.EXPECT().SaveTriggerIssue(trigger.ID, contact.ID, &moira.TrackerIssue{Key: "OPS-1", Metrics: []string{"Metric"}}).Return(nil),
+			)
+			err := sender.SendEvents(moira.NotificationEvents{errorEvent}, contact, trigger, nil, false)
+			So(err, ShouldBeNil)
+			So(requests, ShouldResemble, []string{"GET /rest/api/2/search", "POST /rest/api/2/issue"})
+		})
+
+		Convey("Opening marker is removed if metrics recovered before issue was created", func() {
+			dataBase.EXPECT().GetTriggerIssue(trigger.ID, contact.ID).Return(moira.TrackerIssue{Metrics: []string{"Metric"}, Opening: true}, nil)
+			dataBase.EXPECT().RemoveTriggerIssue(trigger.ID, contact.ID).Return(nil)
+			err := sender.SendEvents(moira.NotificationEvents{okEvent}, contact, trigger, nil, false)
+			So(err, ShouldBeNil)
+			So(requests, ShouldResemble, []string{"GET /rest/api/2/search"})
+		})
+
+		Convey("Issue is not opened on other states", func() {
+			dataBase.EXPECT().GetTriggerIssue(trigger.ID, contact.ID).Return(moira.TrackerIssue{}, database.ErrNil)
+			err := sender.SendEvents(moira.NotificationEvents{warnEvent}, contact, trigger, nil, false)
+			So(err, ShouldBeNil)
+			So(requests, ShouldBeEmpty)
+		})
+
+		Convey("Opened issue is commented", func() {
+			dataBase.EXPECT().GetTriggerIssue(trigger.ID, contact.ID).Return(moira.TrackerIssue{Key: "OPS-1", Metrics: []string{"Metric"}}, nil)
+			dataBase.EXPECT().SaveTriggerIssue(trigger.ID, contact.ID, &moira.TrackerIssue{Key: "OPS-1", Metrics: []string{"Metric"}}).Return(nil)
+			err := sender.SendEvents(moira.NotificationEvents{warnEvent}, contact, trigger, nil, false)
+			So(err, ShouldBeNil)
+			So(requests, ShouldResemble, []string{"POST /rest/api/2/issue/OPS-1/comment"})
+		})
+
+		Convey("Issue is resolved when all metrics recover", func() {
+			dataBase.EXPECT().GetTriggerIssue(trigger.ID, contact.ID).Return(moira.TrackerIssue{Key: "OPS-1", Metrics: []string{"Metric"}}, nil)
+			gomock.InOrder(
+				dataBase.EXPECT().SaveTriggerIssue(trigger.ID, contact.ID, &moira.TrackerIssue{Key: "OPS-1", Metrics: []string{}, Resolving: true}).Return(nil),
+				dataBase.EXPECT().RemoveTriggerIssue(trigger.ID, contact.ID).Return(nil),
+			)
+			err := sender.SendEvents(moira.NotificationEvents{okEvent}, contact, trigger, nil, false)
+			So(err, ShouldBeNil)
+			So(requests, ShouldResemble, []string{
+				"POST /rest/api/2/issue/OPS-1/comment",
+				"GET /rest/api/2/issue/OPS-1/transitions",
+				"POST /rest/api/2/issue/OPS-1/transitions",
+			})
+		})
+
+		Convey("Recovery is not commented again when resolution is retried", func() {
+			dataBase.EXPECT().GetTriggerIssue(trigger.ID, contact.ID).Return(moira.TrackerIssue{Key: "OPS-1", Metrics: []string{}, Resolving: true}, nil)
+			dataBase.EXPECT().RemoveTriggerIssue(trigger.ID, contact.ID).Return(nil)
+			err := sender.SendEvents(moira.NotificationEvents{okEvent}, contact, trigger, nil, false)
+			So(err, ShouldBeNil)
+			So(requests, ShouldResemble, []string{
+				"GET /rest/api/2/issue/OPS-1/transitions",
+				"POST /rest/api/2/issue/OPS-1/transitions",
+			})
+		})
+
+		Convey("Issue which is being resolved is commented if metrics go to bad state again", func() {
+			dataBase.EXPECT().GetTriggerIssue(trigger.ID, contact.ID).Return(moira.TrackerIssue{Key: "OPS-1", Metrics: []string{}, Resolving: true}, nil)
+			dataBase.EXPECT().SaveTriggerIssue(trigger.ID, contact.ID, &moira.TrackerIssue{Key: "OPS-1", Metrics: []string{"Metric"}}).Return(nil)
+			err := sender.SendEvents(moira.NotificationEvents{errorEvent}, contact, trigger, nil, false)
+			So(err, ShouldBeNil)
+			So(requests, ShouldResemble, []string{"POST /rest/api/2/issue/OPS-1/comment"})
+		})
+
+		Convey("Unknown resolve transition", func() {
+			sender.resolveTransition = "Resolved"
+			defer func() { sender.resolveTransition = defaultResolveTransition }()
+			dataBase.EXPECT().GetTriggerIssue(trigger.ID, contact.ID).Return(moira.TrackerIssue{Key: "OPS-1", Metrics: []string{"Metric"}}, nil)
+			dataBase.EXPECT().SaveTriggerIssue(trigger.ID, contact.ID, &moira.TrackerIssue{Key: "OPS-1", Metrics: []string{}, Resolving: true}).Return(nil)
+			err := sender.SendEvents(moira.NotificationEvents{okEvent}, contact, trigger, nil, false)
+			So(err.Error(), ShouldEqual, "failed to resolve jira issue OPS-1: issue has no transition 'Resolved'")
+		})
+
+		Convey("Database error", func() {
+			dataBase.EXPECT().GetTriggerIssue(trigger.ID, contact.ID).Return(moira.TrackerIssue{}, fmt.Errorf("oops"))
+			err := sender.SendEvents(moira.NotificationEvents{errorEvent}, contact, trigger, nil, false)
+			So(err.Error(), ShouldEqual, "failed to get TriggerID jira issue: oops")
+			So(requests, ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,93 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+	"github.com/moira-alert/moira/templating"
+)
+
+const (
+	summaryMaxCharacters     = 255
+	descriptionMaxCharacters = 30000
+	throttleMsg              = "\nPlease, *fix your system or tune this trigger* to generate less events."
+)
+
+func (sender *Sender) buildSummary(events moira.NotificationEvents, trigger moira.TriggerData) string {
+	summary := fmt.Sprintf("%s %s %s", events.GetSubjectState(), trigger.Name, trigger.GetTags())
+	return templating.Truncate(strings.TrimSpace(summary), summaryMaxCharacters)
+}
+
+// buildDescription builds issue description or comment in jira text formatting notation
+func (sender *Sender) buildDescription(events moira.NotificationEvents, trigger moira.TriggerData, throttled bool) string {
+	var description strings.Builder
+
+	footer := ""
+	if triggerURI := trigger.GetTriggerURI(sender.frontURI); triggerURI != "" {
+		footer = fmt.Sprintf("\n[Open in Moira|%s]", triggerURI)
+	}
+	if throttled {
+		footer += throttleMsg
+	}
+
+	desc := trigger.Desc
+	if desc != "" {
+		desc += "\n"
+	}
+	descLen := len([]rune(desc))
+
+	eventsString := sender.buildEventsString(events, -1)
+	eventsStringLen := len([]rune(eventsString))
+
+	descNewLen, eventsNewLen := senders.CalculateMessagePartsLength(descriptionMaxCharacters-len([]rune(footer)), descLen, eventsStringLen)
+	if descLen != descNewLen {
+		desc = string([]rune(desc)[:descNewLen]) + "...\n"
+	}
+	if eventsNewLen != eventsStringLen {
+		eventsString = sender.buildEventsString(events, eventsNewLen)
+	}
+
+	description.WriteString(desc)
+	description.WriteString(eventsString)
+	description.WriteString(footer)
+	return description.String()
+}
+
+// buildEventsString builds the string from moira events and limits it to charsForEvents.
+// if charsForEvents is negative buildEventsString does not limit the events string
+func (sender *Sender) buildEventsString(events moira.NotificationEvents, charsForEvents int) string {
+	const block = "{noformat}"
+	var eventsString strings.Builder
+	eventsString.WriteString(block)
+	eventsStringLen := len(block)
+
+	var tailString string
+	eventsLenLimitReached := false
+	eventsPrinted := 0
+	for _, event := range events {
+		line := fmt.Sprintf("\n%s: %s = %s (%s to %s)", event.FormatTimestamp(sender.location), event.Metric, event.GetMetricValue(), event.OldState, event.State)
+		if msg := event.CreateMessage(sender.location); len(msg) > 0 {
+			line += fmt.Sprintf(". %s", msg)
+		}
+
+		tailString = fmt.Sprintf("\n...and %d more events.", len(events)-eventsPrinted)
+		tailStringLen := len("\n"+block) + len([]rune(tailString))
+		lineLen := len([]rune(line))
+		if !(charsForEvents < 0) && (eventsStringLen+lineLen > charsForEvents-tailStringLen) {
+			eventsLenLimitReached = true
+			break
+		}
+
+		eventsString.WriteString(line)
+		eventsStringLen += lineLen
+		eventsPrinted++
+	}
+	eventsString.WriteString("\n" + block)
+
+	if eventsLenLimitReached {
+		eventsString.WriteString(tailString)
+	}
+	return eventsString.String()
+}
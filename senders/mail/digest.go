@@ -0,0 +1,96 @@
+package mail
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+
+	"github.com/moira-alert/moira"
+	"gopkg.in/gomail.v2"
+)
+
+type digestData struct {
+	State     moira.State
+	Throttled bool
+	Triggers  []*triggerData
+}
+
+// SendDigest implements DigestSender interface SendDigest
+func (sender *Sender) SendDigest(events moira.NotificationEvents, contact moira.ContactData, triggers map[string]moira.TriggerData, throttled bool) error {
+	message := sender.makeDigestMessage(events, contact, triggers, throttled)
+	return sender.dialAndSend(message)
+}
+
+func (sender *Sender) makeDigestMessage(events moira.NotificationEvents, contact moira.ContactData, triggers map[string]moira.TriggerData, throttled bool) *gomail.Message {
+	state := events.GetSubjectState()
+	groups := events.GroupByTrigger()
+
+	subject := fmt.Sprintf("%s Moira digest: %d triggers (%d)", state, len(groups), len(events))
+
+	templateData := digestData{
+		State:     state,
+		Throttled: throttled,
+		Triggers:  make([]*triggerData, 0, len(groups)),
+	}
+	for _, triggerEvents := range groups {
+		trigger := triggers[triggerEvents[0].TriggerID]
+		templateData.Triggers = append(templateData.Triggers, &triggerData{
+			Link:         trigger.GetTriggerURI(sender.FrontURI),
+			TriggerName:  trigger.Name,
+			Tags:         trigger.GetTags(),
+			TriggerState: triggerEvents.GetSubjectState(),
+			Items:        sender.makeTemplateRows(triggerEvents, trigger),
+		})
+	}
+
+	m := gomail.NewMessage()
+	m.SetHeader("From", sender.From)
+	m.SetHeader("To", contact.Value)
+	m.SetHeader("Subject", subject)
+	m.AddAlternativeWriter("text/html", func(w io.Writer) error {
+		return digestTemplate.Execute(w, templateData)
+	})
+	return m
+}
+
+var digestTemplate = template.Must(template.New("digest").Parse(`
+<!doctype html>
+<html>
+<head>
+    <meta name="viewport" content="width=device-width">
+    <meta http-equiv="Content-Type" content="text/html; charset=UTF-8">
+    <title>Moira Alert</title>
+    <style media="all" type="text/css">
+        tr.OK { color: #228007; }
+        tr.WARN { color: #D97E00; }
+        tr.ERROR, tr.NODATA, tr.EXCEPTION { color: #CE0014; }
+        tr.TEST { color: #228007; }
+    </style>
+</head>
+<body style="margin: 0; font-family: 'Segoe UI', 'Helvetica Neue', Helvetica, Arial, sans-serif; font-size: 14px; line-height: 1.6em; padding: 20px; background-color: #f6f6f6;">
+    <div style="max-width: 780px; margin: 0 auto; padding: 30px; background: #ffffff; border: 1px solid #e9e9e9; border-radius: 3px;">
+        <h1 style="color: #333333; font-weight: 600; line-height: 1.4em; margin: 0 0 5px 0; font-size: 30px;">{{ .State }}! Moira digest</h1>
+        {{ if .Throttled }}
+        <p style="border: 1px solid #D0021B; color: #D0021B; padding: 12px; font-size: 18px;">Please, fix your system or tune these triggers to generate less events.</p>
+        {{ end }}
+        {{ range .Triggers }}
+        <hr style="border: 0; border-top: 1px solid #ccc;">
+        <h2 style="color: #333333; font-weight: 600; margin: 0 0 5px 0; font-size: 20px;">
+            {{ .TriggerState }} {{ if .Link }}<a href="{{ .Link }}" style="color: #3072C4;">{{ .TriggerName }}</a>{{ else }}{{ .TriggerName }}{{ end }}
+        </h2>
+        <h4 style="color: #9B9B9B; font-weight: 600; margin: 0 0 5px 0; font-size: 16px;">{{ .Tags }}</h4>
+        <table style="width: 100%; border-collapse: collapse;" width="100%">
+            <tr style="font-size: 12px; font-weight: 700; color: #9B9B9B; text-align: left;">
+                <th>Timestamp</th><th>Target</th><th>Value</th><th>State</th><th>Note</th>
+            </tr>
+            {{ range .Items }}
+            <tr class="{{ .State }}">
+                <td>{{ .Timestamp }}</td><td>{{ .Metric }}</td><td>{{ .Value }}</td><td>{{ .Oldstate }}-{{ .State }}</td><td>{{ .Message }}</td>
+            </tr>
+            {{ end }}
+        </table>
+        {{ end }}
+    </div>
+</body>
+</html>
+`))
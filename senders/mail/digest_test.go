@@ -0,0 +1,46 @@
+package mail
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/logging/go-logging"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMakeDigestMessage(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test")
+	location, _ := time.LoadLocation("UTC")
+	sender := Sender{
+		FrontURI: "http://localhost",
+		From:     "test@notifier",
+		location: location,
+		logger:   logger,
+	}
+	contact := moira.ContactData{Type: "email", Value: "mail1@example.com", DigestInterval: 300}
+	triggers := map[string]moira.TriggerData{
+		"trigger-1": {ID: "trigger-1", Name: "first trigger", Tags: []string{"first"}},
+		"trigger-2": {ID: "trigger-2", Name: "second trigger", Tags: []string{"second"}},
+	}
+	events := moira.NotificationEvents{
+		{TriggerID: "trigger-1", Metric: "metric.one", State: moira.StateWARN, OldState: moira.StateOK},
+		{TriggerID: "trigger-2", Metric: "metric.two", State: moira.StateERROR, OldState: moira.StateOK},
+		{TriggerID: "trigger-1", Metric: "metric.three", State: moira.StateWARN, OldState: moira.StateOK},
+	}
+
+	Convey("Make digest message", t, func() {
+		message := sender.makeDigestMessage(events, contact, triggers, false)
+		So(message.GetHeader("To")[0], ShouldEqual, contact.Value)
+		So(message.GetHeader("Subject")[0], ShouldEqual, "ERROR Moira digest: 2 triggers (3)")
+
+		messageStr := new(bytes.Buffer)
+		_, err := message.WriteTo(messageStr)
+		So(err, ShouldBeNil)
+		So(messageStr.String(), ShouldContainSubstring, "http://localhost/trigger/trigger-1")
+		So(messageStr.String(), ShouldContainSubstring, "http://localhost/trigger/trigger-2")
+		So(messageStr.String(), ShouldContainSubstring, "metric.three")
+		So(messageStr.String(), ShouldNotContainSubstring, "tune these triggers")
+	})
+}
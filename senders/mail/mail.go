@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/templating"
 )
 
 // Sender implements moira sender interface via pushover
@@ -73,14 +74,24 @@ func parseTemplate(templateFilePath string) (name string, parsedTemplate *templa
 		return templateName, parsedTemplate, err
 	}
 	templateName := filepath.Base(templateFilePath)
-	parsedTemplate, err = template.New(templateName).Funcs(template.FuncMap{
-		"htmlSafe": func(html string) template.HTML {
-			return template.HTML(html)
-		},
-	}).ParseFiles(templateFilePath)
+	parsedTemplate, err = template.New(templateName).Funcs(getTemplateFuncs()).ParseFiles(templateFilePath)
 	return templateName, parsedTemplate, err
 }
 
+// parseContactTemplate parses HTML message template of contact
+func parseContactTemplate(contact moira.ContactData) (*template.Template, error) {
+	return template.New(contact.ID).Funcs(getTemplateFuncs()).Parse(contact.MessageTemplate)
+}
+
+// getTemplateFuncs returns functions of shared message templates along with htmlSafe one
+func getTemplateFuncs() template.FuncMap {
+	funcs := template.FuncMap(templating.Funcs())
+	funcs["htmlSafe"] = func(html string) template.HTML {
+		return template.HTML(html)
+	}
+	return funcs
+}
+
 func (sender *Sender) tryDial() error {
 	t, err := smtp.Dial(fmt.Sprintf("%s:%d", sender.SMTPHost, sender.SMTPPort))
 	if err != nil {
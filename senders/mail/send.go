@@ -13,6 +13,7 @@ import (
 	"github.com/russross/blackfriday/v2"
 
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/templating"
 	"gopkg.in/gomail.v2"
 )
 
@@ -36,6 +37,8 @@ type triggerData struct {
 	TriggerState moira.State
 	Items        []*templateRow
 	PlotCID      string
+	// Data is a data model of shared message templates, e.g. {{ .Trigger.Name }} or {{ range .Events }}{{ .Metric }}{{ end }}
+	*templating.Data
 }
 
 // SendEvents implements Sender interface Send
@@ -57,20 +60,8 @@ func (sender *Sender) makeMessage(events moira.NotificationEvents, contact moira
 		TriggerName:  trigger.Name,
 		Tags:         tags,
 		TriggerState: state,
-		Items:        make([]*templateRow, 0, len(events)),
-	}
-
-	for _, event := range events {
-		templateData.Items = append(templateData.Items, &templateRow{
-			Metric:     event.Metric,
-			Timestamp:  time.Unix(event.Timestamp, 0).In(sender.location).Format(sender.dateTimeFormat),
-			Oldstate:   event.OldState,
-			State:      event.State,
-			Value:      strconv.FormatFloat(moira.UseFloat64(event.Value), 'f', -1, 64),
-			WarnValue:  strconv.FormatFloat(trigger.WarnValue, 'f', -1, 64),
-			ErrorValue: strconv.FormatFloat(trigger.ErrorValue, 'f', -1, 64),
-			Message:    event.CreateMessage(sender.location),
-		})
+		Items:        sender.makeTemplateRows(events, trigger),
+		Data:         templating.NewData(events, contact, trigger, sender.FrontURI, sender.location, throttled),
 	}
 
 	m := gomail.NewMessage()
@@ -87,6 +78,10 @@ func (sender *Sender) makeMessage(events moira.NotificationEvents, contact moira
 		}))
 	}
 
+	if body, ok := sender.renderContactTemplate(contact, trigger, templateData); ok {
+		m.SetBody("text/html", body)
+		return m
+	}
 	m.AddAlternativeWriter("text/html", func(w io.Writer) error {
 		return sender.Template.ExecuteTemplate(w, sender.TemplateName, templateData)
 	})
@@ -94,6 +89,42 @@ func (sender *Sender) makeMessage(events moira.NotificationEvents, contact moira
 	return m
 }
 
+// renderContactTemplate renders HTML message with template of contact, returns false if contact has no template
+// or template can not be rendered and message should be built with sender template
+func (sender *Sender) renderContactTemplate(contact moira.ContactData, trigger moira.TriggerData, templateData triggerData) (string, bool) {
+	if contact.MessageTemplate == "" {
+		return "", false
+	}
+	contactTemplate, err := parseContactTemplate(contact)
+	if err != nil {
+		sender.logger.Warningf("Failed to parse %s message template of contact %s: %s", trigger.ID, contact.ID, err.Error())
+		return "", false
+	}
+	var body strings.Builder
+	if err := contactTemplate.Execute(&body, templateData); err != nil {
+		sender.logger.Warningf("Failed to render %s message template of contact %s: %s", trigger.ID, contact.ID, err.Error())
+		return "", false
+	}
+	return body.String(), true
+}
+
+func (sender *Sender) makeTemplateRows(events moira.NotificationEvents, trigger moira.TriggerData) []*templateRow {
+	rows := make([]*templateRow, 0, len(events))
+	for _, event := range events {
+		rows = append(rows, &templateRow{
+			Metric:     event.Metric,
+			Timestamp:  time.Unix(event.Timestamp, 0).In(sender.location).Format(sender.dateTimeFormat),
+			Oldstate:   event.OldState,
+			State:      event.State,
+			Value:      strconv.FormatFloat(moira.UseFloat64(event.Value), 'f', -1, 64),
+			WarnValue:  strconv.FormatFloat(trigger.WarnValue, 'f', -1, 64),
+			ErrorValue: strconv.FormatFloat(trigger.ErrorValue, 'f', -1, 64),
+			Message:    event.CreateMessage(sender.location),
+		})
+	}
+	return rows
+}
+
 func formatDescription(desc string) template.HTML {
 	htmlDesc := blackfriday.Run([]byte(desc))
 	htmlDescWithbr := strings.Replace(string(htmlDesc), "\n", "<br/>", -1)
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"fmt"
 	"html/template"
+	"io/ioutil"
+	"mime/quotedprintable"
+	"strings"
 	"testing"
 	"time"
 
@@ -119,3 +122,65 @@ func TestEmptyTriggerID(t *testing.T) {
 		//fmt.Println(messageStr.String())
 	})
 }
+
+func TestMakeMessageWithContactTemplate(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test")
+	contact := moira.ContactData{
+		ID:    "ContactID-000000000000001",
+		Type:  "email",
+		Value: "mail1@example.com",
+	}
+
+	trigger := moira.TriggerData{
+		ID:   "triggerID-0000000000001",
+		Name: "<script>alert(1)</script>",
+		Tags: []string{"test-tag-1"},
+	}
+
+	location, _ := time.LoadLocation("UTC")
+	templateName := "mail"
+
+	sender := Sender{
+		FrontURI:     "http://localhost",
+		From:         "test@notifier",
+		SMTPHost:     "localhost",
+		SMTPPort:     25,
+		Template:     template.Must(template.New(templateName).Funcs(getTemplateFuncs()).Parse(defaultTemplate)),
+		TemplateName: templateName,
+		location:     location,
+		logger:       logger,
+	}
+
+	writeBody := func(contact moira.ContactData) string {
+		message := sender.makeMessage(generateTestEvents(1, trigger.ID), contact, trigger, nil, false)
+		messageStr := new(bytes.Buffer)
+		_, err := message.WriteTo(messageStr)
+		So(err, ShouldBeNil)
+		encodedBody := strings.SplitN(messageStr.String(), "\r\n\r\n", 2)[1]
+		body, err := ioutil.ReadAll(quotedprintable.NewReader(strings.NewReader(encodedBody)))
+		So(err, ShouldBeNil)
+		return string(body)
+	}
+
+	Convey("Make message", t, func() {
+		Convey("Sender template escapes trigger name", func() {
+			body := writeBody(contact)
+			So(body, ShouldNotContainSubstring, "<script>")
+			So(body, ShouldContainSubstring, "&lt;script&gt;")
+		})
+
+		Convey("Contact template renders shared and mail data with escaping", func() {
+			contact.MessageTemplate = `<b>{{ .Trigger.Name }}</b> {{ .TriggerName }} {{ range .Events }}{{ .Metric | upper }}{{ end }}`
+			body := writeBody(contact)
+			So(body, ShouldNotContainSubstring, "<script>")
+			So(body, ShouldContainSubstring, "<b>&lt;script&gt;alert(1)&lt;/script&gt;</b> &lt;script&gt;")
+			So(body, ShouldContainSubstring, "METRIC NUMBER #0")
+		})
+
+		Convey("Invalid contact template falls back to sender template", func() {
+			contact.MessageTemplate = `{{ .Trigger.Name`
+			body := writeBody(contact)
+			So(body, ShouldContainSubstring, "http://localhost/trigger/triggerID-0000000000001")
+		})
+	})
+}
@@ -0,0 +1,88 @@
+package senders
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moira-alert/moira"
+)
+
+// MarkdownThrottleMsg returns warning appended to messages of throttled triggers, bold is a markdown bold text marker of messenger
+func MarkdownThrottleMsg(bold string) string {
+	return fmt.Sprintf("\nPlease, %sfix your system or tune this trigger%s to generate less events.", bold, bold)
+}
+
+// BuildMarkdownMessage builds message of trigger description and events in code block which fits maxChars characters.
+// Description and events are cut if message is too long, warning is appended to message if trigger is throttled
+func BuildMarkdownMessage(events moira.NotificationEvents, trigger moira.TriggerData, throttled bool, bold string, maxChars int, location *time.Location) string {
+	var message strings.Builder
+
+	throttleMsg := ""
+	if throttled {
+		throttleMsg = MarkdownThrottleMsg(bold)
+	}
+
+	desc := trigger.Desc
+	if desc != "" {
+		desc += "\n"
+	}
+	descLen := len([]rune(desc))
+
+	eventsString := buildMarkdownEvents(events, -1, throttleMsg, location)
+	eventsStringLen := len([]rune(eventsString))
+
+	descNewLen, eventsNewLen := CalculateMessagePartsLength(maxChars, descLen, eventsStringLen)
+
+	if descLen != descNewLen {
+		desc = string([]rune(desc)[:descNewLen]) + "...\n"
+	}
+	if eventsNewLen != eventsStringLen {
+		eventsString = buildMarkdownEvents(events, eventsNewLen, throttleMsg, location)
+	}
+
+	message.WriteString(desc)
+	message.WriteString(eventsString)
+	return message.String()
+}
+
+// buildMarkdownEvents builds the string from moira events and limits it to charsForEvents.
+// if charsForEvents is negative buildMarkdownEvents does not limit the events string
+func buildMarkdownEvents(events moira.NotificationEvents, charsForEvents int, throttleMsg string, location *time.Location) string {
+	charsLeftForEvents := charsForEvents - len([]rune(throttleMsg))
+
+	var eventsString strings.Builder
+	eventsString.WriteString("```")
+	eventsStringLen := len("```")
+
+	var tailString string
+	eventsLenLimitReached := false
+	eventsPrinted := 0
+	for _, event := range events {
+		line := fmt.Sprintf("\n%s: %s = %s (%s to %s)", event.FormatTimestamp(location), event.Metric, event.GetMetricValue(), event.OldState, event.State)
+		if msg := event.CreateMessage(location); len(msg) > 0 {
+			line += fmt.Sprintf(". %s", msg)
+		}
+
+		tailString = fmt.Sprintf("\n...and %d more events.", len(events)-eventsPrinted)
+		tailStringLen := len([]rune("\n```")) + len([]rune(tailString))
+		lineLen := len([]rune(line))
+		if !(charsForEvents < 0) && (eventsStringLen+lineLen > charsLeftForEvents-tailStringLen) {
+			eventsLenLimitReached = true
+			break
+		}
+
+		eventsString.WriteString(line)
+		eventsStringLen += lineLen
+		eventsPrinted++
+	}
+	eventsString.WriteString("\n```")
+
+	if eventsLenLimitReached {
+		eventsString.WriteString(tailString)
+	}
+
+	eventsString.WriteString(throttleMsg)
+
+	return eventsString.String()
+}
@@ -0,0 +1,46 @@
+package senders
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildMarkdownMessage(t *testing.T) {
+	location, _ := time.LoadLocation("UTC")
+	value := float64(97.4458331200185)
+	event := moira.NotificationEvent{
+		Value:     &value,
+		Timestamp: 150000000,
+		Metric:    "Metric",
+		OldState:  moira.StateOK,
+		State:     moira.StateNODATA,
+	}
+
+	Convey("Build markdown message", t, func() {
+		Convey("Print description and events", func() {
+			actual := BuildMarkdownMessage(moira.NotificationEvents{event}, moira.TriggerData{Desc: "desc"}, false, "*", 100, location)
+			So(actual, ShouldEqual, "desc\n```\n02:40: Metric = 97.4458331200185 (OK to NODATA)\n```")
+		})
+
+		Convey("Print throttling message with given bold marker", func() {
+			actual := BuildMarkdownMessage(moira.NotificationEvents{event}, moira.TriggerData{}, true, "**", 200, location)
+			So(actual, ShouldEqual, "```\n02:40: Metric = 97.4458331200185 (OK to NODATA)\n```\nPlease, **fix your system or tune this trigger** to generate less events.")
+		})
+
+		Convey("Long description and many events fit in message limit", func() {
+			events := make(moira.NotificationEvents, 0)
+			for i := 0; i < 100; i++ {
+				events = append(events, event)
+			}
+			actual := BuildMarkdownMessage(events, moira.TriggerData{Desc: strings.Repeat("a", 1000)}, true, "*", 1000, location)
+			So(len([]rune(actual)), ShouldBeLessThanOrEqualTo, 1000)
+			So(actual, ShouldContainSubstring, "...\n```")
+			So(actual, ShouldContainSubstring, "more events.")
+			So(actual, ShouldEndWith, MarkdownThrottleMsg("*"))
+		})
+	})
+}
@@ -0,0 +1,113 @@
+package matrix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	textMsgType  = "m.text"
+	imageMsgType = "m.image"
+	htmlFormat   = "org.matrix.custom.html"
+)
+
+type message struct {
+	MsgType       string     `json:"msgtype"`
+	Body          string     `json:"body"`
+	Format        string     `json:"format,omitempty"`
+	FormattedBody string     `json:"formatted_body,omitempty"`
+	URL           string     `json:"url,omitempty"`
+	Info          *imageInfo `json:"info,omitempty"`
+}
+
+type imageInfo struct {
+	MimeType string `json:"mimetype"`
+	Size     int    `json:"size"`
+}
+
+type roomAliasResponse struct {
+	RoomID string `json:"room_id"`
+}
+
+type uploadResponse struct {
+	ContentURI string `json:"content_uri"`
+}
+
+// resolveRoomAlias returns id of room with given alias,
+// see https://spec.matrix.org/v1.2/client-server-api/#get_matrixclientv3directoryroomroomalias
+func (sender *Sender) resolveRoomAlias(alias string) (string, error) {
+	var response roomAliasResponse
+	if err := sender.do(http.MethodGet, "/_matrix/client/v3/directory/room/"+url.PathEscape(alias), "", nil, &response); err != nil {
+		return "", err
+	}
+	if response.RoomID == "" {
+		return "", fmt.Errorf("homeserver responded with empty room id")
+	}
+	return response.RoomID, nil
+}
+
+// sendMessage sends message event to room,
+// see https://spec.matrix.org/v1.2/client-server-api/#put_matrixclientv3roomsroomidsendeventtypetxnid
+func (sender *Sender) sendMessage(roomID string, message *message) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	txnID, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+	path := fmt.Sprintf("/_matrix/client/v3/rooms/%s/send/m.room.message/%s", url.PathEscape(roomID), txnID.String())
+	return sender.do(http.MethodPut, path, "application/json", bytes.NewReader(body), nil)
+}
+
+// uploadMedia uploads png image to media repository and returns its mxc content uri,
+// see https://spec.matrix.org/v1.2/client-server-api/#post_matrixmediav3upload
+func (sender *Sender) uploadMedia(fileName string, content []byte) (string, error) {
+	var response uploadResponse
+	path := "/_matrix/media/v3/upload?filename=" + url.QueryEscape(fileName)
+	if err := sender.do(http.MethodPost, path, "image/png", bytes.NewReader(content), &response); err != nil {
+		return "", err
+	}
+	return response.ContentURI, nil
+}
+
+func (sender *Sender) do(method, path, contentType string, body io.Reader, result interface{}) error {
+	request, err := http.NewRequest(method, sender.homeserverURL+path, body)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "Bearer "+sender.accessToken)
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	request.Header.Set("User-Agent", "Moira")
+
+	response, err := sender.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %s", err.Error())
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("homeserver responded with code %d: %s", response.StatusCode, string(responseBody))
+	}
+	if result == nil {
+		return nil
+	}
+	if err = json.Unmarshal(responseBody, result); err != nil {
+		return fmt.Errorf("failed to decode response: %s", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package matrix
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	"github.com/moira-alert/moira/templating"
+)
+
+const messenger = "matrix"
+
+// Sender implements moira sender interface via Matrix client-server api.
+// Contact value is a room id like '!room:example.com' or a room alias like '#room:example.com'
+type Sender struct {
+	DataBase      moira.Database
+	homeserverURL string
+	accessToken   string
+	frontURI      string
+	logger        moira.Logger
+	location      *time.Location
+	client        *http.Client
+	renderer      *templating.Renderer
+}
+
+// Init read yaml config
+func (sender *Sender) Init(senderSettings map[string]string, logger moira.Logger, location *time.Location, dateTimeFormat string) error {
+	sender.homeserverURL = strings.TrimSuffix(senderSettings["homeserver_url"], "/")
+	if sender.homeserverURL == "" {
+		return fmt.Errorf("can not read matrix homeserver_url from config")
+	}
+	sender.accessToken = senderSettings["access_token"]
+	if sender.accessToken == "" {
+		return fmt.Errorf("can not read matrix access_token from config")
+	}
+	renderer, err := templating.NewRenderer(senderSettings, logger, location)
+	if err != nil {
+		return err
+	}
+	sender.renderer = renderer
+	sender.frontURI = senderSettings["front_uri"]
+	sender.logger = logger
+	sender.location = location
+	sender.client = &http.Client{Timeout: 30 * time.Second}
+	return nil
+}
+
+// SendEvents implements Sender interface Send
+func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plot []byte, throttled bool) error {
+	roomID, err := sender.getRoomID(contact.Value)
+	if err != nil {
+		return err
+	}
+	message := sender.buildMessage(events, contact, trigger, throttled)
+	sender.logger.Debugf("Calling matrix homeserver with room %s and message body %s", roomID, message.Body)
+	if err := sender.sendMessage(roomID, message); err != nil {
+		return fmt.Errorf("failed to send %s event message to matrix room %s: %s", trigger.ID, contact.Value, err.Error())
+	}
+	if len(plot) > 0 {
+		if err := sender.sendPlot(roomID, plot, trigger.ID); err != nil {
+			sender.logger.Warningf("Could not send the plot image to matrix room %s: %s", contact.Value, err.Error())
+		}
+	}
+	return nil
+}
+
+// getRoomID returns room id of contact. Room aliases are resolved by homeserver once and cached in database
+func (sender *Sender) getRoomID(contactValue string) (string, error) {
+	if !strings.HasPrefix(contactValue, "#") {
+		return contactValue, nil
+	}
+	// database treats usernames starting with '#' as telegram channels, so aliases are stored without it
+	alias := strings.TrimPrefix(contactValue, "#")
+	roomID, err := sender.DataBase.GetIDByUsername(messenger, alias)
+	if err == nil {
+		return roomID, nil
+	}
+	if err != database.ErrNil {
+		return "", fmt.Errorf("failed to get room id of %s: %s", contactValue, err.Error())
+	}
+	roomID, err = sender.resolveRoomAlias(contactValue)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve room alias %s: %s", contactValue, err.Error())
+	}
+	if err := sender.DataBase.SetUsernameID(messenger, alias, roomID); err != nil {
+		sender.logger.Warningf("Failed to cache room id of %s: %s", contactValue, err.Error())
+	}
+	return roomID, nil
+}
+
+func (sender *Sender) sendPlot(roomID string, plot []byte, triggerID string) error {
+	fileName := fmt.Sprintf("%s.png", triggerID)
+	contentURI, err := sender.uploadMedia(fileName, plot)
+	if err != nil {
+		return err
+	}
+	return sender.sendMessage(roomID, &message{
+		MsgType: imageMsgType,
+		Body:    fileName,
+		URL:     contentURI,
+		Info:    &imageInfo{MimeType: "image/png", Size: len(plot)},
+	})
+}
@@ -0,0 +1,203 @@
+package matrix
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	"github.com/moira-alert/moira/logging/go-logging"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestInit(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test")
+	Convey("Init tests", t, func() {
+		sender := Sender{}
+
+		Convey("Empty homeserver_url", func() {
+			err := sender.Init(map[string]string{"access_token": "token"}, logger, nil, "")
+			So(err, ShouldResemble, fmt.Errorf("can not read matrix homeserver_url from config"))
+		})
+
+		Convey("Empty access_token", func() {
+			err := sender.Init(map[string]string{"homeserver_url": "http://matrix.url"}, logger, nil, "")
+			So(err, ShouldResemble, fmt.Errorf("can not read matrix access_token from config"))
+		})
+
+		Convey("Full settings", func() {
+			err := sender.Init(map[string]string{"homeserver_url": "http://matrix.url/", "access_token": "token", "front_uri": "http://moira.url"}, logger, nil, "")
+			So(err, ShouldBeNil)
+			So(sender.homeserverURL, ShouldEqual, "http://matrix.url")
+			So(sender.accessToken, ShouldEqual, "token")
+			So(sender.frontURI, ShouldEqual, "http://moira.url")
+		})
+	})
+}
+
+func TestBuildMessage(t *testing.T) {
+	location, _ := time.LoadLocation("UTC")
+	sender := Sender{location: location, frontURI: "http://moira.url"}
+	value := float64(97.4458331200185)
+	event := moira.NotificationEvent{
+		Value:     &value,
+		Timestamp: 150000000,
+		Metric:    "Metric",
+		OldState:  moira.StateOK,
+		State:     moira.StateERROR,
+	}
+	trigger := moira.TriggerData{ID: "TriggerID", Name: "Name <1>", Tags: []string{"tag1"}, Desc: "**desc**"}
+
+	Convey("Build message", t, func() {
+		Convey("Print formatted and plain body", func() {
+			actual := sender.buildMessage(moira.NotificationEvents{event}, moira.ContactData{}, trigger, false)
+			So(actual, ShouldResemble, &message{
+				MsgType:       textMsgType,
+				Body:          "ERROR Name <1> [tag1] (1)\n\n**desc**\n\n02:40: Metric = 97.4458331200185 (OK to ERROR)\n\nhttp://moira.url/trigger/TriggerID",
+				Format:        htmlFormat,
+				FormattedBody: `<strong>ERROR</strong> <a href="http://moira.url/trigger/TriggerID">Name &lt;1&gt;</a> [tag1] (1)<p><strong>desc</strong></p>` + "\n<pre><code>02:40: Metric = 97.4458331200185 (OK to ERROR)</code></pre>",
+			})
+		})
+
+		Convey("Print throttling message", func() {
+			actual := sender.buildMessage(moira.NotificationEvents{event}, moira.ContactData{}, moira.TriggerData{Name: "Name"}, true)
+			So(actual.Body, ShouldEndWith, throttleMsg)
+			So(actual.FormattedBody, ShouldEndWith, throttleHTMLMsg)
+		})
+
+		Convey("Many events are limited", func() {
+			events := make(moira.NotificationEvents, 0)
+			for i := 0; i < 500; i++ {
+				events = append(events, event)
+			}
+			actual := sender.buildMessage(events, moira.ContactData{}, trigger, false)
+			So(len([]rune(actual.Body)), ShouldBeLessThanOrEqualTo, messageMaxCharacters)
+			So(actual.Body, ShouldContainSubstring, "more events.")
+			So(actual.FormattedBody, ShouldContainSubstring, "more events.")
+		})
+	})
+}
+
+func TestGetRoomID(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test")
+
+	var resolved []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		resolved = append(resolved, request.URL.Path)
+		writer.Write([]byte(`{"room_id":"!resolved:matrix.url"}`))
+	}))
+	defer server.Close()
+
+	sender := Sender{DataBase: dataBase}
+	_ = sender.Init(map[string]string{"homeserver_url": server.URL, "access_token": "token"}, logger, nil, "")
+
+	Convey("Get room id", t, func() {
+		resolved = nil
+
+		Convey("Room id is used as is", func() {
+			roomID, err := sender.getRoomID("!room:matrix.url")
+			So(err, ShouldBeNil)
+			So(roomID, ShouldEqual, "!room:matrix.url")
+			So(resolved, ShouldBeEmpty)
+		})
+
+		Convey("Cached room alias", func() {
+			dataBase.EXPECT().GetIDByUsername(messenger, "room:matrix.url").Return("!cached:matrix.url", nil)
+			roomID, err := sender.getRoomID("#room:matrix.url")
+			So(err, ShouldBeNil)
+			So(roomID, ShouldEqual, "!cached:matrix.url")
+			So(resolved, ShouldBeEmpty)
+		})
+
+		Convey("Not cached room alias is resolved and cached", func() {
+			dataBase.EXPECT().GetIDByUsername(messenger, "room:matrix.url").Return("", database.ErrNil)
+			dataBase.EXPECT().SetUsernameID(messenger, "room:matrix.url", "!resolved:matrix.url").Return(nil)
+			roomID, err := sender.getRoomID("#room:matrix.url")
+			So(err, ShouldBeNil)
+			So(roomID, ShouldEqual, "!resolved:matrix.url")
+			So(resolved, ShouldResemble, []string{"/_matrix/client/v3/directory/room/#room:matrix.url"})
+		})
+
+		Convey("Database error", func() {
+			dataBase.EXPECT().GetIDByUsername(messenger, "room:matrix.url").Return("", fmt.Errorf("oops"))
+			_, err := sender.getRoomID("#room:matrix.url")
+			So(err, ShouldResemble, fmt.Errorf("failed to get room id of #room:matrix.url: oops"))
+		})
+	})
+}
+
+func TestSendEvents(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test")
+	location, _ := time.LoadLocation("UTC")
+	value := float64(1)
+	events := moira.NotificationEvents{{Value: &value, Timestamp: 150000000, Metric: "Metric", OldState: moira.StateOK, State: moira.StateERROR}}
+	trigger := moira.TriggerData{ID: "TriggerID", Name: "Name"}
+	contact := moira.ContactData{Type: "matrix", Value: "!room:matrix.url"}
+
+	Convey("Send events", t, func() {
+		var messages []message
+		var uploads []string
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if request.Header.Get("Authorization") != "Bearer token" {
+				writer.WriteHeader(http.StatusUnauthorized)
+				writer.Write([]byte(`{"errcode":"M_UNKNOWN_TOKEN"}`))
+				return
+			}
+			switch {
+			case request.Method == http.MethodPut && strings.HasPrefix(request.URL.Path, "/_matrix/client/v3/rooms/!room:matrix.url/send/m.room.message/"):
+				body, _ := ioutil.ReadAll(request.Body)
+				var actual message
+				json.Unmarshal(body, &actual)
+				messages = append(messages, actual)
+				writer.Write([]byte(`{"event_id":"$event"}`))
+			case request.Method == http.MethodPost && request.URL.Path == "/_matrix/media/v3/upload":
+				uploads = append(uploads, request.URL.Query().Get("filename"))
+				writer.Write([]byte(`{"content_uri":"mxc://matrix.url/plot"}`))
+			default:
+				writer.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		sender := Sender{}
+		So(sender.Init(map[string]string{"homeserver_url": server.URL, "access_token": "token"}, logger, location, ""), ShouldBeNil)
+
+		Convey("Without plot", func() {
+			So(sender.SendEvents(events, contact, trigger, nil, false), ShouldBeNil)
+			So(uploads, ShouldBeEmpty)
+			So(messages, ShouldHaveLength, 1)
+			So(messages[0].MsgType, ShouldEqual, textMsgType)
+			So(messages[0].Format, ShouldEqual, htmlFormat)
+		})
+
+		Convey("With plot uploaded to media repository", func() {
+			So(sender.SendEvents(events, contact, trigger, []byte("plot"), false), ShouldBeNil)
+			So(uploads, ShouldResemble, []string{"TriggerID.png"})
+			So(messages, ShouldHaveLength, 2)
+			So(messages[1], ShouldResemble, message{
+				MsgType: imageMsgType,
+				Body:    "TriggerID.png",
+				URL:     "mxc://matrix.url/plot",
+				Info:    &imageInfo{MimeType: "image/png", Size: 4},
+			})
+		})
+
+		Convey("Homeserver error", func() {
+			sender.accessToken = "wrong"
+			err := sender.SendEvents(events, contact, trigger, nil, false)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "homeserver responded with code 401")
+		})
+	})
+}
@@ -0,0 +1,118 @@
+package matrix
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+	blackfriday "github.com/russross/blackfriday/v2"
+)
+
+const (
+	messageMaxCharacters = 8000
+	throttleMsg          = "Please, fix your system or tune this trigger to generate less events."
+	throttleHTMLMsg      = "<p>Please, <strong>fix your system or tune this trigger</strong> to generate less events.</p>"
+)
+
+// buildMessage builds message with HTML formatted body and plain text fallback.
+// Message rendered with user-defined template is sent as plain text
+func (sender *Sender) buildMessage(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, throttled bool) *message {
+	// empty default message means there is no user-defined template and HTML formatted message is built
+	rendered := sender.renderer.RenderOrDefault(events, contact, trigger, throttled, messageMaxCharacters, func() string { return "" })
+	if rendered != "" {
+		return &message{MsgType: textMsgType, Body: rendered}
+	}
+
+	state := events.GetSubjectState()
+	tags := trigger.GetTags()
+	triggerURI := trigger.GetTriggerURI(sender.frontURI)
+	title := fmt.Sprintf("%s %s %s (%d)", state, trigger.Name, tags, len(events))
+
+	desc := trigger.Desc
+	descLen := len([]rune(desc))
+	lines := sender.buildEventLines(events)
+	eventsLen := getLinesLength(lines)
+	descNewLen, eventsNewLen := senders.CalculateMessagePartsLength(messageMaxCharacters-len([]rune(title))-len(triggerURI)-len(throttleMsg), descLen, eventsLen)
+	if descNewLen != descLen {
+		desc = string([]rune(desc)[:descNewLen]) + "..."
+	}
+	printLines, tail := limitEventLines(lines, eventsNewLen)
+
+	var plain, formatted strings.Builder
+	plain.WriteString(title)
+	formatted.WriteString(fmt.Sprintf("<strong>%s</strong> ", state))
+	if triggerURI != "" {
+		formatted.WriteString(fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(triggerURI), html.EscapeString(trigger.Name)))
+	} else {
+		formatted.WriteString(html.EscapeString(trigger.Name))
+	}
+	formatted.WriteString(fmt.Sprintf(" %s (%d)", html.EscapeString(tags), len(events)))
+
+	if desc != "" {
+		plain.WriteString("\n\n" + desc)
+		formatted.WriteString(string(blackfriday.Run([]byte(desc))))
+	}
+
+	plain.WriteString("\n\n" + strings.Join(printLines, "\n"))
+	formatted.WriteString("<pre><code>" + html.EscapeString(strings.Join(printLines, "\n")) + "</code></pre>")
+	if tail != "" {
+		plain.WriteString("\n" + tail)
+		formatted.WriteString(fmt.Sprintf("<p>%s</p>", tail))
+	}
+
+	if triggerURI != "" {
+		plain.WriteString("\n\n" + triggerURI)
+	}
+
+	if throttled {
+		plain.WriteString("\n\n" + throttleMsg)
+		formatted.WriteString(throttleHTMLMsg)
+	}
+
+	return &message{
+		MsgType:       textMsgType,
+		Body:          plain.String(),
+		Format:        htmlFormat,
+		FormattedBody: formatted.String(),
+	}
+}
+
+func (sender *Sender) buildEventLines(events moira.NotificationEvents) []string {
+	lines := make([]string, 0, len(events))
+	for _, event := range events {
+		line := fmt.Sprintf("%s: %s = %s (%s to %s)", event.FormatTimestamp(sender.location), event.Metric, event.GetMetricValue(), event.OldState, event.State)
+		if msg := event.CreateMessage(sender.location); len(msg) > 0 {
+			line += fmt.Sprintf(". %s", msg)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// limitEventLines returns event lines fitting in maxChars characters and tail about not printed events
+func limitEventLines(lines []string, maxChars int) ([]string, string) {
+	if getLinesLength(lines) <= maxChars {
+		return lines, ""
+	}
+	charsCount := 0
+	for i, line := range lines {
+		tail := fmt.Sprintf("...and %d more events.", len(lines)-i)
+		lineLen := len([]rune(line)) + 1
+		if charsCount+lineLen > maxChars-len(tail) {
+			return lines[:i], tail
+		}
+		charsCount += lineLen
+	}
+	return lines, ""
+}
+
+// getLinesLength returns count of characters in lines joined by new line
+func getLinesLength(lines []string) int {
+	length := 0
+	for _, line := range lines {
+		length += len([]rune(line)) + 1
+	}
+	return length
+}
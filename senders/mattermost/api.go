@@ -0,0 +1,105 @@
+package mattermost
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+)
+
+type post struct {
+	ChannelID string    `json:"channel_id"`
+	Message   string    `json:"message"`
+	FileIDs   []string  `json:"file_ids,omitempty"`
+	Props     postProps `json:"props"`
+}
+
+type postProps struct {
+	Attachments []attachment `json:"attachments"`
+}
+
+type attachment struct {
+	Fallback  string `json:"fallback"`
+	Color     string `json:"color"`
+	Title     string `json:"title"`
+	TitleLink string `json:"title_link,omitempty"`
+	Text      string `json:"text"`
+	ImageURL  string `json:"image_url,omitempty"`
+}
+
+type fileUploadResponse struct {
+	FileInfos []struct {
+		ID string `json:"id"`
+	} `json:"file_infos"`
+}
+
+// createPost creates post in mattermost channel, see https://api.mattermost.com/#tag/posts/operation/CreatePost
+func (sender *Sender) createPost(message *post) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = sender.do(http.MethodPost, "/api/v4/posts", "application/json", bytes.NewReader(body))
+	return err
+}
+
+// uploadFile uploads file to mattermost channel and returns file id to attach it to post,
+// see https://api.mattermost.com/#tag/files/operation/UploadFile
+func (sender *Sender) uploadFile(channelID, fileName string, content []byte) (string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("channel_id", channelID); err != nil {
+		return "", err
+	}
+	part, err := writer.CreateFormFile("files", fileName)
+	if err != nil {
+		return "", err
+	}
+	if _, err = part.Write(content); err != nil {
+		return "", err
+	}
+	if err = writer.Close(); err != nil {
+		return "", err
+	}
+
+	responseBody, err := sender.do(http.MethodPost, "/api/v4/files", writer.FormDataContentType(), &body)
+	if err != nil {
+		return "", err
+	}
+	var response fileUploadResponse
+	if err = json.Unmarshal(responseBody, &response); err != nil {
+		return "", fmt.Errorf("failed to decode response: %s", err.Error())
+	}
+	if len(response.FileInfos) == 0 {
+		return "", fmt.Errorf("mattermost responded without uploaded file info")
+	}
+	return response.FileInfos[0].ID, nil
+}
+
+func (sender *Sender) do(method, path, contentType string, body io.Reader) ([]byte, error) {
+	request, err := http.NewRequest(method, sender.url+path, body)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Authorization", "Bearer "+sender.apiToken)
+	request.Header.Set("Content-Type", contentType)
+	request.Header.Set("User-Agent", "Moira")
+
+	response, err := sender.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %s", err.Error())
+	}
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("server responded with code %d: %s", response.StatusCode, string(responseBody))
+	}
+	return responseBody, nil
+}
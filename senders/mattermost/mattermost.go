@@ -0,0 +1,118 @@
+package mattermost
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+	"github.com/moira-alert/moira/templating"
+)
+
+const (
+	messageMaxCharacters = 4000
+	boldMarker           = "**"
+)
+
+var stateColors = map[moira.State]string{
+	moira.StateOK:        "#2ba35b",
+	moira.StateWARN:      "#ffb020",
+	moira.StateERROR:     "#e5413d",
+	moira.StateNODATA:    "#737373",
+	moira.StateEXCEPTION: "#c10000",
+	moira.StateTEST:      "#3d8ee5",
+}
+
+// Sender implements moira sender interface via Mattermost api v4. Contact value is a channel id
+type Sender struct {
+	ImageStores          map[string]moira.ImageStore
+	url                  string
+	apiToken             string
+	frontURI             string
+	logger               moira.Logger
+	location             *time.Location
+	client               *http.Client
+	imageStore           moira.ImageStore
+	imageStoreConfigured bool
+	renderer             *templating.Renderer
+}
+
+// Init read yaml config
+func (sender *Sender) Init(senderSettings map[string]string, logger moira.Logger, location *time.Location, dateTimeFormat string) error {
+	sender.url = strings.TrimSuffix(senderSettings["url"], "/")
+	if sender.url == "" {
+		return fmt.Errorf("can not read mattermost url from config")
+	}
+	sender.apiToken = senderSettings["api_token"]
+	if sender.apiToken == "" {
+		return fmt.Errorf("can not read mattermost api_token from config")
+	}
+	renderer, err := templating.NewRenderer(senderSettings, logger, location)
+	if err != nil {
+		return err
+	}
+	sender.renderer = renderer
+	if _, ok := senderSettings["image_store"]; ok {
+		_, sender.imageStore, sender.imageStoreConfigured = senders.ReadImageStoreConfig(senderSettings, sender.ImageStores, logger)
+	}
+	sender.frontURI = senderSettings["front_uri"]
+	sender.logger = logger
+	sender.location = location
+	sender.client = &http.Client{Timeout: 30 * time.Second}
+	return nil
+}
+
+// SendEvents implements Sender interface Send
+func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plot []byte, throttled bool) error {
+	message := &post{
+		ChannelID: contact.Value,
+		Props:     postProps{Attachments: []attachment{sender.buildAttachment(events, contact, trigger, throttled)}},
+	}
+	if len(plot) > 0 {
+		sender.attachPlot(message, plot, trigger.ID)
+	}
+	sender.logger.Debugf("Calling mattermost with message body %s", message.Props.Attachments[0].Text)
+	if err := sender.createPost(message); err != nil {
+		return fmt.Errorf("failed to send %s event message to mattermost channel %s: %s", trigger.ID, contact.Value, err.Error())
+	}
+	return nil
+}
+
+// attachPlot adds link to plot stored in image store or plot uploaded to mattermost to post
+func (sender *Sender) attachPlot(message *post, plot []byte, triggerID string) {
+	if sender.imageStoreConfigured {
+		imageURL, err := sender.imageStore.StoreImage(plot)
+		if err == nil {
+			message.Props.Attachments[0].ImageURL = imageURL
+			return
+		}
+		sender.logger.Warningf("Could not store the plot image in the image store: %s", err.Error())
+	}
+	fileID, err := sender.uploadFile(message.ChannelID, fmt.Sprintf("%s.png", triggerID), plot)
+	if err != nil {
+		sender.logger.Warningf("Could not upload the plot image to mattermost: %s", err.Error())
+		return
+	}
+	message.FileIDs = []string{fileID}
+}
+
+func (sender *Sender) buildAttachment(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, throttled bool) attachment {
+	state := events.GetSubjectState()
+	title := fmt.Sprintf("%s %s %s (%d)", state, trigger.Name, trigger.GetTags(), len(events))
+	text := sender.renderer.RenderOrDefault(events, contact, trigger, throttled, messageMaxCharacters, func() string {
+		return sender.buildMessage(events, trigger, throttled)
+	})
+	return attachment{
+		Fallback:  title,
+		Color:     stateColors[state],
+		Title:     title,
+		TitleLink: trigger.GetTriggerURI(sender.frontURI),
+		Text:      text,
+	}
+}
+
+func (sender *Sender) buildMessage(events moira.NotificationEvents, trigger moira.TriggerData, throttled bool) string {
+	return senders.BuildMarkdownMessage(events, trigger, throttled, boldMarker, messageMaxCharacters, sender.location)
+}
@@ -0,0 +1,169 @@
+package mattermost
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/logging/go-logging"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	"github.com/moira-alert/moira/senders"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestInit(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test")
+	Convey("Init tests", t, func() {
+		sender := Sender{}
+
+		Convey("Empty url", func() {
+			err := sender.Init(map[string]string{"api_token": "token"}, logger, nil, "")
+			So(err, ShouldResemble, fmt.Errorf("can not read mattermost url from config"))
+		})
+
+		Convey("Empty api_token", func() {
+			err := sender.Init(map[string]string{"url": "http://mattermost.url"}, logger, nil, "")
+			So(err, ShouldResemble, fmt.Errorf("can not read mattermost api_token from config"))
+		})
+
+		Convey("Has url and api_token", func() {
+			err := sender.Init(map[string]string{"url": "http://mattermost.url/", "api_token": "token", "front_uri": "http://moira.url"}, logger, nil, "")
+			So(err, ShouldBeNil)
+			So(sender.url, ShouldEqual, "http://mattermost.url")
+			So(sender.apiToken, ShouldEqual, "token")
+			So(sender.frontURI, ShouldEqual, "http://moira.url")
+			So(sender.imageStoreConfigured, ShouldBeFalse)
+		})
+	})
+}
+
+func TestBuildMessage(t *testing.T) {
+	location, _ := time.LoadLocation("UTC")
+	sender := Sender{location: location, frontURI: "http://moira.url"}
+	value := float64(97.4458331200185)
+	event := moira.NotificationEvent{
+		Value:     &value,
+		Timestamp: 150000000,
+		Metric:    "Metric",
+		OldState:  moira.StateOK,
+		State:     moira.StateNODATA,
+	}
+	trigger := moira.TriggerData{ID: "TriggerID", Name: "Name", Tags: []string{"tag1"}, Desc: "**desc**"}
+
+	Convey("Build message", t, func() {
+		Convey("Print description and events", func() {
+			actual := sender.buildMessage(moira.NotificationEvents{event}, trigger, false)
+			expected := "**desc**\n```\n02:40: Metric = 97.4458331200185 (OK to NODATA)\n```"
+			So(actual, ShouldEqual, expected)
+		})
+
+		Convey("Print throttling message", func() {
+			actual := sender.buildMessage(moira.NotificationEvents{event}, moira.TriggerData{}, true)
+			expected := "```\n02:40: Metric = 97.4458331200185 (OK to NODATA)\n```" + senders.MarkdownThrottleMsg(boldMarker)
+			So(actual, ShouldEqual, expected)
+		})
+
+		Convey("Long description and many events fit in message limit", func() {
+			events := make(moira.NotificationEvents, 0)
+			for i := 0; i < 200; i++ {
+				events = append(events, event)
+			}
+			longTrigger := moira.TriggerData{Desc: strings.Repeat("a", 3000)}
+			actual := sender.buildMessage(events, longTrigger, false)
+			So(len([]rune(actual)), ShouldBeLessThanOrEqualTo, messageMaxCharacters)
+			So(actual, ShouldContainSubstring, "...\n```")
+			So(actual, ShouldContainSubstring, "more events.")
+		})
+	})
+
+	Convey("Build attachment", t, func() {
+		actual := sender.buildAttachment(moira.NotificationEvents{event}, moira.ContactData{}, trigger, false)
+		So(actual.Title, ShouldEqual, "NODATA Name [tag1] (1)")
+		So(actual.TitleLink, ShouldEqual, "http://moira.url/trigger/TriggerID")
+		So(actual.Color, ShouldEqual, stateColors[moira.StateNODATA])
+	})
+}
+
+func TestSendEvents(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test")
+	location, _ := time.LoadLocation("UTC")
+	value := float64(1)
+	events := moira.NotificationEvents{{Value: &value, Timestamp: 150000000, Metric: "Metric", OldState: moira.StateOK, State: moira.StateERROR}}
+	trigger := moira.TriggerData{ID: "TriggerID", Name: "Name"}
+	contact := moira.ContactData{Type: "mattermost", Value: "channelID"}
+
+	Convey("Send events", t, func() {
+		var posts []post
+		var uploads []string
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if request.Header.Get("Authorization") != "Bearer token" {
+				writer.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			switch request.URL.Path {
+			case "/api/v4/files":
+				request.ParseMultipartForm(1024)
+				uploads = append(uploads, request.FormValue("channel_id"))
+				writer.WriteHeader(http.StatusCreated)
+				writer.Write([]byte(`{"file_infos":[{"id":"fileID"}]}`))
+			case "/api/v4/posts":
+				body, _ := ioutil.ReadAll(request.Body)
+				var actual post
+				json.Unmarshal(body, &actual)
+				posts = append(posts, actual)
+				writer.WriteHeader(http.StatusCreated)
+			default:
+				writer.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		sender := Sender{}
+		So(sender.Init(map[string]string{"url": server.URL, "api_token": "token"}, logger, location, ""), ShouldBeNil)
+
+		Convey("Without plot", func() {
+			So(sender.SendEvents(events, contact, trigger, nil, false), ShouldBeNil)
+			So(uploads, ShouldBeEmpty)
+			So(posts, ShouldHaveLength, 1)
+			So(posts[0].ChannelID, ShouldEqual, "channelID")
+			So(posts[0].FileIDs, ShouldBeEmpty)
+			So(posts[0].Props.Attachments[0].Color, ShouldEqual, stateColors[moira.StateERROR])
+		})
+
+		Convey("With plot uploaded to mattermost", func() {
+			So(sender.SendEvents(events, contact, trigger, []byte("plot"), false), ShouldBeNil)
+			So(uploads, ShouldResemble, []string{"channelID"})
+			So(posts, ShouldHaveLength, 1)
+			So(posts[0].FileIDs, ShouldResemble, []string{"fileID"})
+		})
+
+		Convey("With plot stored in image store", func() {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			imageStore := mock_moira_alert.NewMockImageStore(mockCtrl)
+			imageStore.EXPECT().IsEnabled().Return(true)
+			imageStore.EXPECT().StoreImage([]byte("plot")).Return("http://images.url/plot.png", nil)
+
+			sender := Sender{ImageStores: map[string]moira.ImageStore{"s3": imageStore}}
+			So(sender.Init(map[string]string{"url": server.URL, "api_token": "token", "image_store": "s3"}, logger, location, ""), ShouldBeNil)
+			So(sender.SendEvents(events, contact, trigger, []byte("plot"), false), ShouldBeNil)
+			So(uploads, ShouldBeEmpty)
+			So(posts, ShouldHaveLength, 1)
+			So(posts[0].Props.Attachments[0].ImageURL, ShouldEqual, "http://images.url/plot.png")
+		})
+
+		Convey("Server error", func() {
+			sender.apiToken = "wrong"
+			err := sender.SendEvents(events, contact, trigger, nil, false)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "server responded with code 401")
+		})
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/templating"
 	"github.com/russross/blackfriday/v2"
 )
 
@@ -42,6 +43,7 @@ type Sender struct {
 	logger    moira.Logger
 	location  *time.Location
 	client    *http.Client
+	renderer  *templating.Renderer
 }
 
 // Init initialises settings required for full functionality
@@ -54,6 +56,10 @@ func (sender *Sender) Init(senderSettings map[string]string, logger moira.Logger
 		return fmt.Errorf("max_events should be an integer: %w", err)
 	}
 	sender.maxEvents = maxEvents
+	sender.renderer, err = templating.NewRenderer(senderSettings, logger, location)
+	if err != nil {
+		return err
+	}
 	sender.client = &http.Client{
 		Timeout: time.Duration(30) * time.Second,
 	}
@@ -143,6 +149,12 @@ func (sender *Sender) buildMessage(events moira.NotificationEvents, trigger moir
 func (sender *Sender) buildRequest(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plot []byte, throttled bool) (*http.Request, error) {
 
 	messageCard := sender.buildMessage(events, trigger, throttled)
+	// empty default message means there is no user-defined template and built message card is sent as is
+	message := sender.renderer.RenderOrDefault(events, contact, trigger, throttled, 0, func() string { return "" })
+	if message != "" {
+		// user-defined message replaces trigger description and events facts
+		messageCard.Sections = []Section{{ActivityText: message, Facts: []Fact{}}}
+	}
 	requestURL := contact.Value
 	requestBody, err := json.Marshal(messageCard)
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/templating"
 
 	"github.com/gregdel/pushover"
 )
@@ -13,12 +14,14 @@ import (
 const printEventsCount int = 5
 const titleLimit = 250
 const urlLimit = 512
+const messageLimit = 1024
 
 // Sender implements moira sender interface via pushover
 type Sender struct {
 	logger   moira.Logger
 	location *time.Location
 	client   *pushover.Pushover
+	renderer *templating.Renderer
 
 	apiToken string
 	frontURI string
@@ -34,6 +37,11 @@ func (sender *Sender) Init(senderSettings map[string]string, logger moira.Logger
 	sender.logger = logger
 	sender.frontURI = senderSettings["front_uri"]
 	sender.location = location
+	renderer, err := templating.NewRenderer(senderSettings, logger, location)
+	if err != nil {
+		return err
+	}
+	sender.renderer = renderer
 	return nil
 }
 
@@ -51,8 +59,11 @@ func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.
 }
 
 func (sender *Sender) makePushoverMessage(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plot []byte, throttled bool) *pushover.Message {
+	text := sender.renderer.RenderOrDefault(events, contact, trigger, throttled, messageLimit, func() string {
+		return sender.buildMessage(events, throttled)
+	})
 	pushoverMessage := &pushover.Message{
-		Message:   sender.buildMessage(events, throttled),
+		Message:   text,
 		Title:     sender.buildTitle(events, trigger),
 		Priority:  sender.getMessagePriority(events),
 		Retry:     5 * time.Minute,
@@ -9,6 +9,7 @@ import (
 	"github.com/gregdel/pushover"
 	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/logging/go-logging"
+	"github.com/moira-alert/moira/templating"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -23,17 +24,27 @@ func TestSender_Init(t *testing.T) {
 
 	Convey("Settings has api_token", t, func() {
 		sender := Sender{}
-		err := sender.Init(map[string]string{"api_token": "123"}, logger, nil, "")
+		settings := map[string]string{"api_token": "123"}
+		err := sender.Init(settings, logger, nil, "")
 		So(err, ShouldBeNil)
-		So(sender, ShouldResemble, Sender{apiToken: "123", client: pushover.New("123"), logger: logger})
+		renderer, _ := templating.NewRenderer(settings, logger, nil)
+		So(sender, ShouldResemble, Sender{apiToken: "123", client: pushover.New("123"), logger: logger, renderer: renderer})
+	})
+
+	Convey("Settings has invalid template file", t, func() {
+		sender := Sender{}
+		err := sender.Init(map[string]string{"api_token": "123", "template_file": "not_existing.tmpl"}, logger, nil, "")
+		So(err, ShouldNotBeNil)
 	})
 
 	Convey("Settings has all data", t, func() {
 		sender := Sender{}
 		location, _ := time.LoadLocation("UTC")
-		err := sender.Init(map[string]string{"api_token": "123", "front_uri": "321"}, logger, location, "")
+		settings := map[string]string{"api_token": "123", "front_uri": "321"}
+		err := sender.Init(settings, logger, location, "")
 		So(err, ShouldBeNil)
-		So(sender, ShouldResemble, Sender{apiToken: "123", client: pushover.New("123"), frontURI: "321", logger: logger, location: location})
+		renderer, _ := templating.NewRenderer(settings, logger, location)
+		So(sender, ShouldResemble, Sender{apiToken: "123", client: pushover.New("123"), frontURI: "321", logger: logger, location: location, renderer: renderer})
 	})
 }
 
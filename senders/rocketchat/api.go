@@ -0,0 +1,101 @@
+package rocketchat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+)
+
+type postMessage struct {
+	Channel     string       `json:"channel,omitempty"`
+	RoomID      string       `json:"roomId,omitempty"`
+	Alias       string       `json:"alias,omitempty"`
+	Attachments []attachment `json:"attachments"`
+}
+
+type attachment struct {
+	Color     string `json:"color"`
+	Title     string `json:"title"`
+	TitleLink string `json:"title_link,omitempty"`
+	Text      string `json:"text"`
+	ImageURL  string `json:"image_url,omitempty"`
+}
+
+type response struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+	Message struct {
+		RoomID string `json:"rid"`
+	} `json:"message"`
+}
+
+// postMessage posts message to rocketchat room and returns room id to upload files to it,
+// see https://developer.rocket.chat/reference/api/rest-api/endpoints/core-endpoints/chat-endpoints/postmessage
+func (sender *Sender) postMessage(message *postMessage) (string, error) {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return "", err
+	}
+	result, err := sender.do("/api/v1/chat.postMessage", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	return result.Message.RoomID, nil
+}
+
+// uploadFile uploads file to rocketchat room,
+// see https://developer.rocket.chat/reference/api/rest-api/endpoints/core-endpoints/rooms-endpoints/upload-file-to-a-room
+func (sender *Sender) uploadFile(roomID, fileName string, content []byte) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		return err
+	}
+	if _, err = part.Write(content); err != nil {
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	_, err = sender.do("/api/v1/rooms.upload/"+url.PathEscape(roomID), writer.FormDataContentType(), &body)
+	return err
+}
+
+func (sender *Sender) do(path, contentType string, body io.Reader) (*response, error) {
+	request, err := http.NewRequest(http.MethodPost, sender.url+path, body)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("X-User-Id", sender.userID)
+	request.Header.Set("X-Auth-Token", sender.apiToken)
+	request.Header.Set("Content-Type", contentType)
+	request.Header.Set("User-Agent", "Moira")
+
+	httpResponse, err := sender.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %s", err.Error())
+	}
+	if httpResponse.StatusCode < http.StatusOK || httpResponse.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("server responded with code %d: %s", httpResponse.StatusCode, string(responseBody))
+	}
+	result := &response{}
+	if err = json.Unmarshal(responseBody, result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %s", err.Error())
+	}
+	if !result.Success {
+		return nil, fmt.Errorf("server responded with error: %s", result.Error)
+	}
+	return result, nil
+}
@@ -0,0 +1,130 @@
+package rocketchat
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/senders"
+	"github.com/moira-alert/moira/templating"
+)
+
+const (
+	messageMaxCharacters = 5000
+	boldMarker           = "*"
+)
+
+var stateColors = map[moira.State]string{
+	moira.StateOK:        "#2ba35b",
+	moira.StateWARN:      "#ffb020",
+	moira.StateERROR:     "#e5413d",
+	moira.StateNODATA:    "#737373",
+	moira.StateEXCEPTION: "#c10000",
+	moira.StateTEST:      "#3d8ee5",
+}
+
+// Sender implements moira sender interface via Rocket.Chat REST api.
+// Contact value is a channel name starting with '#', user name starting with '@' or a room id
+type Sender struct {
+	ImageStores          map[string]moira.ImageStore
+	url                  string
+	userID               string
+	apiToken             string
+	frontURI             string
+	logger               moira.Logger
+	location             *time.Location
+	client               *http.Client
+	imageStore           moira.ImageStore
+	imageStoreConfigured bool
+	renderer             *templating.Renderer
+}
+
+// Init read yaml config
+func (sender *Sender) Init(senderSettings map[string]string, logger moira.Logger, location *time.Location, dateTimeFormat string) error {
+	sender.url = strings.TrimSuffix(senderSettings["url"], "/")
+	if sender.url == "" {
+		return fmt.Errorf("can not read rocketchat url from config")
+	}
+	sender.userID = senderSettings["user_id"]
+	if sender.userID == "" {
+		return fmt.Errorf("can not read rocketchat user_id from config")
+	}
+	sender.apiToken = senderSettings["api_token"]
+	if sender.apiToken == "" {
+		return fmt.Errorf("can not read rocketchat api_token from config")
+	}
+	renderer, err := templating.NewRenderer(senderSettings, logger, location)
+	if err != nil {
+		return err
+	}
+	sender.renderer = renderer
+	if _, ok := senderSettings["image_store"]; ok {
+		_, sender.imageStore, sender.imageStoreConfigured = senders.ReadImageStoreConfig(senderSettings, sender.ImageStores, logger)
+	}
+	sender.frontURI = senderSettings["front_uri"]
+	sender.logger = logger
+	sender.location = location
+	sender.client = &http.Client{Timeout: 30 * time.Second}
+	return nil
+}
+
+// SendEvents implements Sender interface Send
+func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plot []byte, throttled bool) error {
+	message := sender.buildPostMessage(events, contact, trigger, throttled)
+	uploadPlot := len(plot) > 0
+	if uploadPlot && sender.imageStoreConfigured {
+		imageURL, err := sender.imageStore.StoreImage(plot)
+		if err != nil {
+			sender.logger.Warningf("Could not store the plot image in the image store: %s", err.Error())
+		} else {
+			message.Attachments[0].ImageURL = imageURL
+			uploadPlot = false
+		}
+	}
+	sender.logger.Debugf("Calling rocketchat with message body %s", message.Attachments[0].Text)
+	roomID, err := sender.postMessage(message)
+	if err != nil {
+		return fmt.Errorf("failed to send %s event message to rocketchat [%s]: %s", trigger.ID, contact.Value, err.Error())
+	}
+	if uploadPlot {
+		if err := sender.uploadFile(roomID, fmt.Sprintf("%s.png", trigger.ID), plot); err != nil {
+			sender.logger.Warningf("Could not upload the plot image to rocketchat: %s", err.Error())
+		}
+	}
+	return nil
+}
+
+func (sender *Sender) buildPostMessage(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, throttled bool) *postMessage {
+	state := events.GetSubjectState()
+	text := sender.renderer.RenderOrDefault(events, contact, trigger, throttled, messageMaxCharacters, func() string {
+		return sender.buildMessage(events, trigger, throttled)
+	})
+	message := &postMessage{
+		Alias: "Moira",
+		Attachments: []attachment{
+			{
+				Color:     stateColors[state],
+				Title:     fmt.Sprintf("%s %s %s (%d)", state, trigger.Name, trigger.GetTags(), len(events)),
+				TitleLink: trigger.GetTriggerURI(sender.frontURI),
+				Text:      text,
+			},
+		},
+	}
+	if isRoomName(contact.Value) {
+		message.Channel = contact.Value
+	} else {
+		message.RoomID = contact.Value
+	}
+	return message
+}
+
+func (sender *Sender) buildMessage(events moira.NotificationEvents, trigger moira.TriggerData, throttled bool) string {
+	return senders.BuildMarkdownMessage(events, trigger, throttled, boldMarker, messageMaxCharacters, sender.location)
+}
+
+// isRoomName returns true if contact value is a channel or user name rather than room id
+func isRoomName(contactValue string) bool {
+	return strings.HasPrefix(contactValue, "#") || strings.HasPrefix(contactValue, "@")
+}
@@ -0,0 +1,180 @@
+package rocketchat
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/logging/go-logging"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	"github.com/moira-alert/moira/senders"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestInit(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test")
+	Convey("Init tests", t, func() {
+		sender := Sender{}
+
+		Convey("Empty url", func() {
+			err := sender.Init(map[string]string{"user_id": "user", "api_token": "token"}, logger, nil, "")
+			So(err, ShouldResemble, fmt.Errorf("can not read rocketchat url from config"))
+		})
+
+		Convey("Empty user_id", func() {
+			err := sender.Init(map[string]string{"url": "http://rocketchat.url", "api_token": "token"}, logger, nil, "")
+			So(err, ShouldResemble, fmt.Errorf("can not read rocketchat user_id from config"))
+		})
+
+		Convey("Empty api_token", func() {
+			err := sender.Init(map[string]string{"url": "http://rocketchat.url", "user_id": "user"}, logger, nil, "")
+			So(err, ShouldResemble, fmt.Errorf("can not read rocketchat api_token from config"))
+		})
+
+		Convey("Full settings", func() {
+			err := sender.Init(map[string]string{"url": "http://rocketchat.url/", "user_id": "user", "api_token": "token", "front_uri": "http://moira.url"}, logger, nil, "")
+			So(err, ShouldBeNil)
+			So(sender.url, ShouldEqual, "http://rocketchat.url")
+			So(sender.userID, ShouldEqual, "user")
+			So(sender.apiToken, ShouldEqual, "token")
+			So(sender.frontURI, ShouldEqual, "http://moira.url")
+		})
+	})
+}
+
+func TestBuildMessage(t *testing.T) {
+	location, _ := time.LoadLocation("UTC")
+	sender := Sender{location: location, frontURI: "http://moira.url"}
+	value := float64(97.4458331200185)
+	event := moira.NotificationEvent{
+		Value:     &value,
+		Timestamp: 150000000,
+		Metric:    "Metric",
+		OldState:  moira.StateOK,
+		State:     moira.StateWARN,
+	}
+	trigger := moira.TriggerData{ID: "TriggerID", Name: "Name", Tags: []string{"tag1"}, Desc: "*desc*"}
+
+	Convey("Build message", t, func() {
+		Convey("Print description and events", func() {
+			actual := sender.buildMessage(moira.NotificationEvents{event}, trigger, false)
+			expected := "*desc*\n```\n02:40: Metric = 97.4458331200185 (OK to WARN)\n```"
+			So(actual, ShouldEqual, expected)
+		})
+
+		Convey("Print throttling message", func() {
+			actual := sender.buildMessage(moira.NotificationEvents{event}, moira.TriggerData{}, true)
+			expected := "```\n02:40: Metric = 97.4458331200185 (OK to WARN)\n```" + senders.MarkdownThrottleMsg(boldMarker)
+			So(actual, ShouldEqual, expected)
+		})
+
+		Convey("Long description and many events fit in message limit", func() {
+			events := make(moira.NotificationEvents, 0)
+			for i := 0; i < 200; i++ {
+				events = append(events, event)
+			}
+			longTrigger := moira.TriggerData{Desc: strings.Repeat("a", 4000)}
+			actual := sender.buildMessage(events, longTrigger, false)
+			So(len([]rune(actual)), ShouldBeLessThanOrEqualTo, messageMaxCharacters)
+			So(actual, ShouldContainSubstring, "more events.")
+		})
+	})
+
+	Convey("Build post message", t, func() {
+		Convey("Channel contact", func() {
+			actual := sender.buildPostMessage(moira.NotificationEvents{event}, moira.ContactData{Value: "#channel"}, trigger, false)
+			So(actual.Channel, ShouldEqual, "#channel")
+			So(actual.RoomID, ShouldBeEmpty)
+			So(actual.Attachments[0].Title, ShouldEqual, "WARN Name [tag1] (1)")
+			So(actual.Attachments[0].TitleLink, ShouldEqual, "http://moira.url/trigger/TriggerID")
+			So(actual.Attachments[0].Color, ShouldEqual, stateColors[moira.StateWARN])
+		})
+
+		Convey("Room id contact", func() {
+			actual := sender.buildPostMessage(moira.NotificationEvents{event}, moira.ContactData{Value: "roomID"}, trigger, false)
+			So(actual.Channel, ShouldBeEmpty)
+			So(actual.RoomID, ShouldEqual, "roomID")
+		})
+	})
+}
+
+func TestSendEvents(t *testing.T) {
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test")
+	location, _ := time.LoadLocation("UTC")
+	value := float64(1)
+	events := moira.NotificationEvents{{Value: &value, Timestamp: 150000000, Metric: "Metric", OldState: moira.StateOK, State: moira.StateERROR}}
+	trigger := moira.TriggerData{ID: "TriggerID", Name: "Name"}
+	contact := moira.ContactData{Type: "rocketchat", Value: "#channel"}
+
+	Convey("Send events", t, func() {
+		var messages []postMessage
+		var uploads []string
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if request.Header.Get("X-User-Id") != "user" || request.Header.Get("X-Auth-Token") != "token" {
+				writer.WriteHeader(http.StatusUnauthorized)
+				writer.Write([]byte(`{"success":false,"error":"unauthorized"}`))
+				return
+			}
+			switch {
+			case request.URL.Path == "/api/v1/chat.postMessage":
+				body, _ := ioutil.ReadAll(request.Body)
+				var actual postMessage
+				json.Unmarshal(body, &actual)
+				messages = append(messages, actual)
+				writer.Write([]byte(`{"success":true,"message":{"rid":"roomID"}}`))
+			case strings.HasPrefix(request.URL.Path, "/api/v1/rooms.upload/"):
+				uploads = append(uploads, strings.TrimPrefix(request.URL.Path, "/api/v1/rooms.upload/"))
+				writer.Write([]byte(`{"success":true}`))
+			default:
+				writer.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		sender := Sender{}
+		So(sender.Init(map[string]string{"url": server.URL, "user_id": "user", "api_token": "token"}, logger, location, ""), ShouldBeNil)
+
+		Convey("Without plot", func() {
+			So(sender.SendEvents(events, contact, trigger, nil, false), ShouldBeNil)
+			So(uploads, ShouldBeEmpty)
+			So(messages, ShouldHaveLength, 1)
+			So(messages[0].Channel, ShouldEqual, "#channel")
+			So(messages[0].Attachments[0].Color, ShouldEqual, stateColors[moira.StateERROR])
+		})
+
+		Convey("With plot uploaded to rocketchat room", func() {
+			So(sender.SendEvents(events, contact, trigger, []byte("plot"), false), ShouldBeNil)
+			So(messages, ShouldHaveLength, 1)
+			So(uploads, ShouldResemble, []string{"roomID"})
+		})
+
+		Convey("With plot stored in image store", func() {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			imageStore := mock_moira_alert.NewMockImageStore(mockCtrl)
+			imageStore.EXPECT().IsEnabled().Return(true)
+			imageStore.EXPECT().StoreImage([]byte("plot")).Return("http://images.url/plot.png", nil)
+
+			sender := Sender{ImageStores: map[string]moira.ImageStore{"s3": imageStore}}
+			So(sender.Init(map[string]string{"url": server.URL, "user_id": "user", "api_token": "token", "image_store": "s3"}, logger, location, ""), ShouldBeNil)
+			So(sender.SendEvents(events, contact, trigger, []byte("plot"), false), ShouldBeNil)
+			So(uploads, ShouldBeEmpty)
+			So(messages, ShouldHaveLength, 1)
+			So(messages[0].Attachments[0].ImageURL, ShouldEqual, "http://images.url/plot.png")
+		})
+
+		Convey("Server error", func() {
+			sender.apiToken = "wrong"
+			err := sender.SendEvents(events, contact, trigger, nil, false)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "server responded with code 401")
+		})
+	})
+}
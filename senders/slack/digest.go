@@ -0,0 +1,50 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moira-alert/moira"
+)
+
+const digestThrottleMsg = "\nPlease, *fix your system or tune these triggers* to generate less events."
+
+// SendDigest implements DigestSender interface SendDigest
+func (sender *Sender) SendDigest(events moira.NotificationEvents, contact moira.ContactData, triggers map[string]moira.TriggerData, throttled bool) error {
+	message := sender.buildDigestMessage(events, triggers, throttled)
+	emoji := sender.getStateEmoji(events.GetSubjectState())
+	_, _, err := sender.sendMessage(message, nil, contact.Value, "digest", useDirectMessaging(contact.Value), emoji)
+	return err
+}
+
+// buildDigestMessage builds message with title and events of every trigger,
+// triggers which don't fit in message length limit are only counted
+func (sender *Sender) buildDigestMessage(events moira.NotificationEvents, triggers map[string]moira.TriggerData, throttled bool) string {
+	var message strings.Builder
+	groups := events.GroupByTrigger()
+
+	title := fmt.Sprintf("*%s* Moira digest: %d triggers (%d events)\n", events.GetSubjectState(), len(groups), len(events))
+	message.WriteString(title)
+
+	charsLeft := messageMaxCharacters - len([]rune(title))
+	if throttled {
+		charsLeft -= len([]rune(digestThrottleMsg))
+	}
+	for i, triggerEvents := range groups {
+		trigger := triggers[triggerEvents[0].TriggerID]
+		section := sender.buildTitle(triggerEvents, trigger) + sender.buildEventsString(triggerEvents, -1, false) + "\n"
+		tail := fmt.Sprintf("...and %d more triggers.\n", len(groups)-i)
+		sectionLen := len([]rune(section))
+		if sectionLen > charsLeft-len([]rune(tail)) {
+			message.WriteString(tail)
+			break
+		}
+		message.WriteString(section)
+		charsLeft -= sectionLen
+	}
+
+	if throttled {
+		message.WriteString(digestThrottleMsg)
+	}
+	return message.String()
+}
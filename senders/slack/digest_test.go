@@ -0,0 +1,50 @@
+package slack
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildDigestMessage(t *testing.T) {
+	location, _ := time.LoadLocation("UTC")
+	sender := Sender{location: location, frontURI: "http://moira.url"}
+	value := float64(123)
+
+	triggers := map[string]moira.TriggerData{
+		"first":  {ID: "first", Name: "First", Tags: []string{"tag1"}},
+		"second": {ID: "second", Name: "Second", Tags: []string{"tag2"}},
+	}
+	events := moira.NotificationEvents{
+		{TriggerID: "first", Value: &value, Timestamp: 150000000, Metric: "Metric1", OldState: moira.StateOK, State: moira.StateWARN},
+		{TriggerID: "second", Value: &value, Timestamp: 150000000, Metric: "Metric2", OldState: moira.StateOK, State: moira.StateERROR},
+	}
+
+	Convey("Build digest message", t, func() {
+		Convey("Print events of all triggers", func() {
+			actual := sender.buildDigestMessage(events, triggers, false)
+			expected := "*ERROR* Moira digest: 2 triggers (2 events)\n" +
+				"*WARN* <http://moira.url/trigger/first|First> [tag1]\n```\n02:40: Metric1 = 123 (OK to WARN)```\n" +
+				"*ERROR* <http://moira.url/trigger/second|Second> [tag2]\n```\n02:40: Metric2 = 123 (OK to ERROR)```\n"
+			So(actual, ShouldResemble, expected)
+		})
+
+		Convey("Print throttling message", func() {
+			actual := sender.buildDigestMessage(events, triggers, true)
+			So(actual, ShouldEndWith, digestThrottleMsg)
+		})
+
+		Convey("Triggers exceeding message limit are counted", func() {
+			manyEvents := make(moira.NotificationEvents, 0)
+			for i := 0; i < 100; i++ {
+				manyEvents = append(manyEvents, moira.NotificationEvent{TriggerID: "first", Value: &value, Metric: strings.Repeat("a", 50), State: moira.StateWARN})
+			}
+			manyEvents = append(manyEvents, events[1])
+			actual := sender.buildDigestMessage(manyEvents, triggers, false)
+			So(actual, ShouldEqual, "*ERROR* Moira digest: 2 triggers (101 events)\n...and 2 more triggers.\n")
+		})
+	})
+}
@@ -10,6 +10,7 @@ import (
 	slackdown "github.com/karriereat/blackfriday-slack"
 	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/senders"
+	"github.com/moira-alert/moira/templating"
 	blackfriday "github.com/russross/blackfriday/v2"
 
 	"github.com/nlopes/slack"
@@ -24,6 +25,10 @@ const (
 	testEmoji      = ":moira-state-test:"
 
 	messageMaxCharacters = 4000
+
+	// AckCallbackID identifies interactive acknowledge button in slack callbacks, button value is a trigger ID
+	AckCallbackID = "moira-ack"
+	ackActionName = "ack"
 )
 
 var stateEmoji = map[moira.State]string{
@@ -37,11 +42,13 @@ var stateEmoji = map[moira.State]string{
 
 // Sender implements moira sender interface via slack
 type Sender struct {
-	frontURI string
-	useEmoji bool
-	logger   moira.Logger
-	location *time.Location
-	client   *slack.Client
+	frontURI      string
+	useEmoji      bool
+	useAckButtons bool
+	logger        moira.Logger
+	location      *time.Location
+	client        *slack.Client
+	renderer      *templating.Renderer
 }
 
 // Init read yaml config
@@ -51,19 +58,28 @@ func (sender *Sender) Init(senderSettings map[string]string, logger moira.Logger
 		return fmt.Errorf("can not read slack api_token from config")
 	}
 	sender.useEmoji, _ = strconv.ParseBool(senderSettings["use_emoji"])
+	sender.useAckButtons, _ = strconv.ParseBool(senderSettings["use_ack_buttons"])
 	sender.logger = logger
 	sender.frontURI = senderSettings["front_uri"]
 	sender.location = location
 	sender.client = slack.New(apiToken)
+	renderer, err := templating.NewRenderer(senderSettings, logger, location)
+	if err != nil {
+		return err
+	}
+	sender.renderer = renderer
 	return nil
 }
 
 // SendEvents implements Sender interface Send
 func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plot []byte, throttled bool) error {
-	message := sender.buildMessage(events, trigger, throttled)
+	message := sender.renderer.RenderOrDefault(events, contact, trigger, throttled, messageMaxCharacters, func() string {
+		return sender.buildMessage(events, trigger, throttled)
+	})
 	useDirectMessaging := useDirectMessaging(contact.Value)
 	emoji := sender.getStateEmoji(events.GetSubjectState())
-	channelID, threadTimestamp, err := sender.sendMessage(message, contact.Value, trigger.ID, useDirectMessaging, emoji)
+	attachments := sender.buildAttachments(events, trigger)
+	channelID, threadTimestamp, err := sender.sendMessage(message, attachments, contact.Value, trigger.ID, useDirectMessaging, emoji)
 	if err != nil {
 		return err
 	}
@@ -174,7 +190,29 @@ func (sender *Sender) buildEventsString(events moira.NotificationEvents, charsFo
 	return eventsString
 }
 
-func (sender *Sender) sendMessage(message string, contact string, triggerID string, useDirectMessaging bool, emoji string) (string, string, error) {
+// buildAttachments returns attachment with acknowledge button if ack buttons are enabled and events can be acknowledged.
+// Slack app interactive components request URL must be set to Moira api /api/ack/slack for button to work
+func (sender *Sender) buildAttachments(events moira.NotificationEvents, trigger moira.TriggerData) []slack.Attachment {
+	if !sender.useAckButtons || trigger.ID == "" || !events.CanBeAcknowledged() {
+		return nil
+	}
+	return []slack.Attachment{
+		{
+			CallbackID: AckCallbackID,
+			Fallback:   "Acknowledge trigger in Moira",
+			Actions: []slack.AttachmentAction{
+				{
+					Name:  ackActionName,
+					Text:  "Acknowledge",
+					Type:  "button",
+					Value: trigger.ID,
+				},
+			},
+		},
+	}
+}
+
+func (sender *Sender) sendMessage(message string, attachments []slack.Attachment, contact string, triggerID string, useDirectMessaging bool, emoji string) (string, string, error) {
 	params := slack.PostMessageParameters{
 		Username:  "Moira",
 		AsUser:    useDirectMessaging,
@@ -182,7 +220,7 @@ func (sender *Sender) sendMessage(message string, contact string, triggerID stri
 		Markdown:  true,
 	}
 	sender.logger.Debugf("Calling slack with message body %s", message)
-	channelID, threadTimestamp, err := sender.client.PostMessage(contact, slack.MsgOptionText(message, false), slack.MsgOptionPostMessageParameters(params))
+	channelID, threadTimestamp, err := sender.client.PostMessage(contact, slack.MsgOptionText(message, false), slack.MsgOptionAttachments(attachments...), slack.MsgOptionPostMessageParameters(params))
 	if err != nil {
 		return channelID, threadTimestamp, fmt.Errorf("failed to send %s event message to slack [%s]: %s", triggerID, contact, err.Error())
 	}
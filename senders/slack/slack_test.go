@@ -8,6 +8,7 @@ import (
 
 	"github.com/moira-alert/moira"
 	"github.com/moira-alert/moira/logging/go-logging"
+	"github.com/moira-alert/moira/templating"
 	"github.com/nlopes/slack"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -26,32 +27,33 @@ func TestInit(t *testing.T) {
 		Convey("has api_token", func() {
 			senderSettings["api_token"] = "123"
 			client := slack.New("123")
+			renderer, _ := templating.NewRenderer(senderSettings, logger, nil)
 
 			Convey("use_emoji not set", func() {
 				err := sender.Init(senderSettings, logger, nil, "")
 				So(err, ShouldBeNil)
-				So(sender, ShouldResemble, Sender{logger: logger, client: client})
+				So(sender, ShouldResemble, Sender{logger: logger, client: client, renderer: renderer})
 			})
 
 			Convey("use_emoji set to false", func() {
 				senderSettings["use_emoji"] = "false"
 				err := sender.Init(senderSettings, logger, nil, "")
 				So(err, ShouldBeNil)
-				So(sender, ShouldResemble, Sender{logger: logger, client: client})
+				So(sender, ShouldResemble, Sender{logger: logger, client: client, renderer: renderer})
 			})
 
 			Convey("use_emoji set to true", func() {
 				senderSettings["use_emoji"] = "true"
 				err := sender.Init(senderSettings, logger, nil, "")
 				So(err, ShouldBeNil)
-				So(sender, ShouldResemble, Sender{logger: logger, useEmoji: true, client: client})
+				So(sender, ShouldResemble, Sender{logger: logger, useEmoji: true, client: client, renderer: renderer})
 			})
 
 			Convey("use_emoji set to something wrong", func() {
 				senderSettings["use_emoji"] = "123"
 				err := sender.Init(senderSettings, logger, nil, "")
 				So(err, ShouldBeNil)
-				So(sender, ShouldResemble, Sender{logger: logger, useEmoji: false, client: client})
+				So(sender, ShouldResemble, Sender{logger: logger, useEmoji: false, client: client, renderer: renderer})
 			})
 		})
 	})
@@ -240,3 +242,28 @@ some other text italic text
 		})
 	})
 }
+
+func TestBuildAttachments(t *testing.T) {
+	trigger := moira.TriggerData{ID: "triggerID-00000000000001"}
+	problemEvents := moira.NotificationEvents{{State: moira.StateOK}, {State: moira.StateERROR}}
+
+	Convey("Build attachments", t, func() {
+		Convey("Ack buttons are disabled", func() {
+			sender := Sender{}
+			So(sender.buildAttachments(problemEvents, trigger), ShouldBeNil)
+		})
+
+		Convey("Ack buttons are enabled", func() {
+			sender := Sender{useAckButtons: true}
+			attachments := sender.buildAttachments(problemEvents, trigger)
+			So(attachments, ShouldHaveLength, 1)
+			So(attachments[0].CallbackID, ShouldResemble, AckCallbackID)
+			So(attachments[0].Actions, ShouldHaveLength, 1)
+			So(attachments[0].Actions[0].Type, ShouldResemble, "button")
+			So(attachments[0].Actions[0].Value, ShouldResemble, trigger.ID)
+
+			So(sender.buildAttachments(moira.NotificationEvents{{State: moira.StateOK}}, trigger), ShouldBeNil)
+			So(sender.buildAttachments(moira.NotificationEvents{{State: moira.StateTEST}}, moira.TriggerData{}), ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,53 @@
+package telegram
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/moira-alert/moira"
+)
+
+const digestThrottleMsg = "\nPlease, fix your system or tune these triggers to generate less events."
+
+// SendDigest implements DigestSender interface SendDigest
+func (sender *Sender) SendDigest(events moira.NotificationEvents, contact moira.ContactData, triggers map[string]moira.TriggerData, throttled bool) error {
+	message := sender.buildDigestMessage(events, triggers, throttled)
+	sender.logger.Debugf("Calling telegram api with chat_id %s and digest message body %s", contact.Value, message)
+	chat, err := sender.getChat(contact.Value)
+	if err != nil {
+		return err
+	}
+	if err := sender.sendAsMessage(chat, message, nil); err != nil {
+		return fmt.Errorf("failed to send digest to telegram contact %s: %s. ", contact.Value, err)
+	}
+	return nil
+}
+
+// buildDigestMessage builds message with events of every trigger separated by blank line,
+// triggers which don't fit in message length limit are only counted
+func (sender *Sender) buildDigestMessage(events moira.NotificationEvents, triggers map[string]moira.TriggerData, throttled bool) string {
+	var buffer bytes.Buffer
+	groups := events.GroupByTrigger()
+	state := events.GetSubjectState()
+
+	title := fmt.Sprintf("%s%s Moira digest: %d triggers (%d)\n", emojiStates[state], state, len(groups), len(events))
+	buffer.WriteString(title)
+
+	charsLeft := messageMaxCharacters - additionalInfoCharactersCount - len([]rune(title))
+	for i, triggerEvents := range groups {
+		trigger := triggers[triggerEvents[0].TriggerID]
+		section := "\n" + sender.buildMessage(triggerEvents, trigger, false, messageMaxCharacters)
+		sectionLen := len([]rune(section))
+		if sectionLen > charsLeft {
+			buffer.WriteString(fmt.Sprintf("\n...and %d more triggers.\n", len(groups)-i))
+			break
+		}
+		buffer.WriteString(section)
+		charsLeft -= sectionLen
+	}
+
+	if throttled {
+		buffer.WriteString(digestThrottleMsg)
+	}
+	return buffer.String()
+}
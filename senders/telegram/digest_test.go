@@ -0,0 +1,63 @@
+package telegram
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildDigestMessage(t *testing.T) {
+	location, _ := time.LoadLocation("UTC")
+	sender := Sender{location: location, frontURI: "http://moira.url"}
+	value := float64(97.4458331200185)
+
+	triggers := map[string]moira.TriggerData{
+		"first":  {ID: "first", Name: "First", Tags: []string{"tag1"}},
+		"second": {ID: "second", Name: "Second", Tags: []string{"tag2"}},
+	}
+	events := moira.NotificationEvents{
+		{TriggerID: "first", Value: &value, Timestamp: 150000000, Metric: "Metric1", OldState: moira.StateOK, State: moira.StateWARN},
+		{TriggerID: "second", Value: &value, Timestamp: 150000000, Metric: "Metric2", OldState: moira.StateOK, State: moira.StateNODATA},
+	}
+
+	Convey("Build digest message", t, func() {
+		Convey("Print events of all triggers", func() {
+			actual := sender.buildDigestMessage(events, triggers, false)
+			expected := `💣NODATA Moira digest: 2 triggers (2)
+
+⚠WARN First [tag1] (1)
+
+02:40: Metric1 = 97.4458331200185 (OK to WARN)
+
+http://moira.url/trigger/first
+
+💣NODATA Second [tag2] (1)
+
+02:40: Metric2 = 97.4458331200185 (OK to NODATA)
+
+http://moira.url/trigger/second
+`
+			So(actual, ShouldResemble, expected)
+		})
+
+		Convey("Print throttling message", func() {
+			actual := sender.buildDigestMessage(events, triggers, true)
+			So(actual, ShouldEndWith, digestThrottleMsg)
+		})
+
+		Convey("Triggers exceeding message limit are counted", func() {
+			manyTriggers := make(map[string]moira.TriggerData)
+			manyEvents := make(moira.NotificationEvents, 0)
+			for _, id := range strings.Split("abcdefghijklmnopqrstuvwxyz", "") {
+				manyTriggers[id] = moira.TriggerData{ID: id, Name: strings.Repeat(id, 200)}
+				manyEvents = append(manyEvents, moira.NotificationEvent{TriggerID: id, Value: &value, Metric: "Metric", State: moira.StateWARN})
+			}
+			actual := sender.buildDigestMessage(manyEvents, manyTriggers, false)
+			So(len([]rune(actual)), ShouldBeLessThanOrEqualTo, messageMaxCharacters)
+			So(actual, ShouldContainSubstring, "more triggers.")
+		})
+	})
+}
@@ -0,0 +1,51 @@
+package telegram
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/tucnak/telebot.v2"
+
+	"github.com/moira-alert/moira"
+)
+
+// ackButton is an inline button attached to notifications about problems, it acknowledges the trigger
+var ackButton = telebot.InlineButton{
+	Unique: "ack",
+	Text:   "Acknowledge",
+}
+
+// handleAckCallback handles pressing of acknowledge button and notifies user about the result
+func (sender *Sender) handleAckCallback(callback *telebot.Callback) error {
+	responseText, err := sender.getAckResponse(callback)
+	if err != nil {
+		return err
+	}
+	return sender.bot.Respond(callback, &telebot.CallbackResponse{Text: responseText})
+}
+
+func (sender *Sender) getAckResponse(callback *telebot.Callback) (string, error) {
+	triggerID := callback.Data
+	if triggerID == "" {
+		return "Unknown trigger", nil
+	}
+	user := "telegram"
+	if callback.Sender != nil && callback.Sender.Username != "" {
+		user = "@" + callback.Sender.Username
+	}
+	ack := &moira.Acknowledgement{User: user, Timestamp: time.Now().Unix()}
+	if err := sender.DataBase.SetTriggerCheckAcknowledgement(triggerID, nil, ack); err != nil {
+		return "", fmt.Errorf("failed to acknowledge trigger %s: %s", triggerID, err.Error())
+	}
+	return fmt.Sprintf("Acknowledged by %s", user), nil
+}
+
+// getReplyMarkup returns inline keyboard with acknowledge button if notification events can be acknowledged
+func getReplyMarkup(events moira.NotificationEvents, trigger moira.TriggerData) *telebot.ReplyMarkup {
+	if trigger.ID == "" || !events.CanBeAcknowledged() {
+		return nil
+	}
+	button := ackButton
+	button.Data = trigger.ID
+	return &telebot.ReplyMarkup{InlineKeyboard: [][]telebot.InlineButton{{button}}}
+}
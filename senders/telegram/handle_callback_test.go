@@ -0,0 +1,67 @@
+package telegram
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/moira-alert/moira"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/tucnak/telebot.v2"
+)
+
+func TestGetAckResponse(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	triggerID := "triggerID-00000000000001"
+
+	Convey("Test get acknowledge response", t, func() {
+		sender := Sender{DataBase: dataBase}
+		Convey("Empty trigger ID", func() {
+			response, err := sender.getAckResponse(&telebot.Callback{})
+			So(err, ShouldBeNil)
+			So(response, ShouldResemble, "Unknown trigger")
+		})
+
+		Convey("Trigger is acknowledged by user", func() {
+			dataBase.EXPECT().SetTriggerCheckAcknowledgement(triggerID, nil, gomock.Any()).DoAndReturn(
+				func(triggerID string, metrics []string, ack *moira.Acknowledgement) error {
+					So(ack.User, ShouldResemble, "@username")
+					So(ack.Expiry, ShouldEqual, 0)
+					return nil
+				})
+			callback := &telebot.Callback{Data: triggerID, Sender: &telebot.User{Username: "username"}}
+			response, err := sender.getAckResponse(callback)
+			So(err, ShouldBeNil)
+			So(response, ShouldResemble, "Acknowledged by @username")
+		})
+
+		Convey("Error", func() {
+			dataBase.EXPECT().SetTriggerCheckAcknowledgement(triggerID, nil, gomock.Any()).Return(fmt.Errorf("oops"))
+			response, err := sender.getAckResponse(&telebot.Callback{Data: triggerID})
+			So(err, ShouldNotBeNil)
+			So(response, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestGetReplyMarkup(t *testing.T) {
+	trigger := moira.TriggerData{ID: "triggerID-00000000000001"}
+
+	Convey("Test get reply markup", t, func() {
+		Convey("Problem events have acknowledge button", func() {
+			markup := getReplyMarkup(moira.NotificationEvents{{State: moira.StateERROR}}, trigger)
+			So(markup, ShouldNotBeNil)
+			So(markup.InlineKeyboard, ShouldHaveLength, 1)
+			So(markup.InlineKeyboard[0][0].Unique, ShouldResemble, ackButton.Unique)
+			So(markup.InlineKeyboard[0][0].Data, ShouldResemble, trigger.ID)
+		})
+
+		Convey("OK and test events have no buttons", func() {
+			So(getReplyMarkup(moira.NotificationEvents{{State: moira.StateOK}}, trigger), ShouldBeNil)
+			So(getReplyMarkup(moira.NotificationEvents{{State: moira.StateTEST}}, moira.TriggerData{}), ShouldBeNil)
+		})
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/templating"
 	"github.com/moira-alert/moira/worker"
 	"gopkg.in/tucnak/telebot.v2"
 )
@@ -35,6 +36,7 @@ type Sender struct {
 	frontURI string
 	bot      *telebot.Bot
 	location *time.Location
+	renderer *templating.Renderer
 }
 
 // Init loads yaml config, configures and starts telegram bot
@@ -49,6 +51,10 @@ func (sender *Sender) Init(senderSettings map[string]string, logger moira.Logger
 	sender.logger = logger
 	sender.location = location
 	var err error
+	sender.renderer, err = templating.NewRenderer(senderSettings, logger, location)
+	if err != nil {
+		return err
+	}
 	sender.bot, err = telebot.NewBot(telebot.Settings{
 		Token:  sender.apiToken,
 		Poller: &telebot.LongPoller{Timeout: pollerTimeout},
@@ -62,6 +68,11 @@ func (sender *Sender) Init(senderSettings map[string]string, logger moira.Logger
 			sender.logger.Errorf("Error handling incoming message: %s", err.Error())
 		}
 	})
+	sender.bot.Handle(&ackButton, func(callback *telebot.Callback) {
+		if err := sender.handleAckCallback(callback); err != nil {
+			sender.logger.Errorf("Error handling acknowledge callback: %s", err.Error())
+		}
+	})
 	go sender.runTelebot()
 	return nil
 }
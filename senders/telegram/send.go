@@ -7,6 +7,7 @@ import (
 	"gopkg.in/tucnak/telebot.v2"
 
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
 )
 
 type messageType string
@@ -32,13 +33,16 @@ var characterLimits = map[messageType]int{
 // SendEvents implements Sender interface Send
 func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plot []byte, throttled bool) error {
 	msgType := getMessageType(plot)
-	message := sender.buildMessage(events, trigger, throttled, characterLimits[msgType])
+	message := sender.renderer.RenderOrDefault(events, contact, trigger, throttled, characterLimits[msgType], func() string {
+		return sender.buildMessage(events, trigger, throttled, characterLimits[msgType])
+	})
 	sender.logger.Debugf("Calling telegram api with chat_id %s and message body %s", contact.Value, message)
 	chat, err := sender.getChat(contact.Value)
 	if err != nil {
 		return err
 	}
-	if err := sender.talk(chat, message, plot, msgType); err != nil {
+	markup := getReplyMarkup(events, trigger)
+	if err := sender.talk(chat, message, plot, msgType, markup); err != nil {
 		return fmt.Errorf("failed to send message to telegram contact %s: %s. ", contact.Value, err)
 	}
 	return nil
@@ -88,6 +92,10 @@ func (sender *Sender) buildMessage(events moira.NotificationEvents, trigger moir
 
 func (sender *Sender) getChat(username string) (*telebot.Chat, error) {
 	uid, err := sender.DataBase.GetIDByUsername(messenger, username)
+	if err == database.ErrNil {
+		// user or chat has not started conversation with bot, so resending does not help
+		return nil, moira.SenderPermanentError{Err: fmt.Errorf("failed to get username uuid: chat %s is not found", username)}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get username uuid: %s", err.Error())
 	}
@@ -99,24 +107,24 @@ func (sender *Sender) getChat(username string) (*telebot.Chat, error) {
 }
 
 // talk processes one talk
-func (sender *Sender) talk(chat *telebot.Chat, message string, plot []byte, messageType messageType) error {
+func (sender *Sender) talk(chat *telebot.Chat, message string, plot []byte, messageType messageType, markup *telebot.ReplyMarkup) error {
 	if messageType == Photo {
-		return sender.sendAsPhoto(chat, plot, message)
+		return sender.sendAsPhoto(chat, plot, message, markup)
 	}
-	return sender.sendAsMessage(chat, message)
+	return sender.sendAsMessage(chat, message, markup)
 }
 
-func (sender *Sender) sendAsMessage(chat *telebot.Chat, message string) error {
-	_, err := sender.bot.Send(chat, message)
+func (sender *Sender) sendAsMessage(chat *telebot.Chat, message string, markup *telebot.ReplyMarkup) error {
+	_, err := sender.bot.Send(chat, message, &telebot.SendOptions{ReplyMarkup: markup})
 	if err != nil {
 		return fmt.Errorf("can't send event message [%s] to %v: %s", message, chat.ID, err.Error())
 	}
 	return nil
 }
 
-func (sender *Sender) sendAsPhoto(chat *telebot.Chat, plot []byte, caption string) error {
+func (sender *Sender) sendAsPhoto(chat *telebot.Chat, plot []byte, caption string, markup *telebot.ReplyMarkup) error {
 	photo := telebot.Photo{File: telebot.FromReader(bytes.NewReader(plot)), Caption: caption}
-	_, err := photo.Send(sender.bot, chat, &telebot.SendOptions{})
+	_, err := photo.Send(sender.bot, chat, &telebot.SendOptions{ReplyMarkup: markup})
 	if err != nil {
 		return fmt.Errorf("can't send event plot to %v: %s", chat.ID, err.Error())
 	}
@@ -5,7 +5,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/database"
+	mock_moira_alert "github.com/moira-alert/moira/mock/moira-alert"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -111,3 +114,25 @@ http://moira.url/trigger/TriggerID
 		})
 	})
 }
+
+func TestGetChat(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dataBase := mock_moira_alert.NewMockDatabase(mockCtrl)
+	sender := Sender{DataBase: dataBase}
+
+	Convey("Get chat", t, func() {
+		Convey("Not existing chat is permanent error", func() {
+			dataBase.EXPECT().GetIDByUsername(messenger, "@user").Return("", database.ErrNil)
+			_, err := sender.getChat("@user")
+			So(err, ShouldResemble, moira.SenderPermanentError{Err: fmt.Errorf("failed to get username uuid: chat @user is not found")})
+		})
+
+		Convey("Database error is not permanent", func() {
+			dataBase.EXPECT().GetIDByUsername(messenger, "@user").Return("", fmt.Errorf("oops"))
+			_, err := sender.getChat("@user")
+			So(err, ShouldResemble, fmt.Errorf("failed to get username uuid: oops"))
+			So(moira.IsSenderPermanentError(err), ShouldBeFalse)
+		})
+	})
+}
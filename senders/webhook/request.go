@@ -2,20 +2,35 @@ package webhook
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/templating"
 )
 
+// signatureHeader contains request timestamp and HMAC-SHA256 of '<timestamp>.<body>' signed with sender secret,
+// e.g. 't=1600000000,v1=5257a869e7ecebeda32affa62cdca3fa51cad7e77a0e56ff536d0ce8e108d8bd'
+const signatureHeader = "X-Moira-Signature"
+
 func (sender *Sender) buildRequest(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plot []byte, throttled bool) (*http.Request, error) {
 	if sender.url == moira.VariableContactValue {
 		sender.log.Warningf("%s is potentially dangerous url template, api contact validation is advised", sender.url)
 	}
-	requestURL := buildRequestURL(sender.url, trigger, contact)
-	requestBody, err := buildRequestBody(events, contact, trigger, plot, throttled)
+	data := templating.NewData(events, contact, trigger, sender.frontURI, sender.location, throttled)
+	requestURL, err := templating.Execute(sender.urlTemplate, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render url template: %s", err.Error())
+	}
+	requestURL = buildRequestURL(requestURL, trigger, contact)
+	requestBody, err := sender.buildRequestBody(events, contact, trigger, plot, throttled)
 	if err != nil {
 		return nil, err
 	}
@@ -33,6 +48,18 @@ func (sender *Sender) buildRequest(events moira.NotificationEvents, contact moir
 	return request, nil
 }
 
+// buildRequestBody renders request body with user-defined template of contact or sender, builds default payload if there is no template
+func (sender *Sender) buildRequestBody(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plot []byte, throttled bool) ([]byte, error) {
+	requestBody, err := sender.renderer.Render(events, contact, trigger, throttled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render body template: %s", err.Error())
+	}
+	if requestBody != "" {
+		return []byte(requestBody), nil
+	}
+	return buildRequestBody(events, contact, trigger, plot, throttled)
+}
+
 func buildRequestBody(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, plot []byte, throttled bool) ([]byte, error) {
 	requestPayload := payload{
 		Trigger: toTriggerData(trigger),
@@ -61,3 +88,27 @@ func buildRequestURL(template string, trigger moira.TriggerData, contact moira.C
 	}
 	return template
 }
+
+// signRequest sets request signature header if sender has a secret
+func (sender *Sender) signRequest(request *http.Request, timestamp int64) error {
+	if sender.secret == "" {
+		return nil
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return err
+	}
+	requestBody, err := ioutil.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	request.Header.Set(signatureHeader, fmt.Sprintf("t=%d,v1=%s", timestamp, sign(sender.secret, timestamp, requestBody)))
+	return nil
+}
+
+func sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -5,19 +5,31 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"text/template"
 	"time"
 
 	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/templating"
 )
 
-// Sender implements moira sender interface via webhook
+// maxRetryAfter limits delay before resending requested by server
+const maxRetryAfter = time.Hour
+
+// Sender implements moira sender interface via webhook.
+// Request url and body can be templated, body is signed with HMAC if secret is set.
+// Failed requests are resent by notifier according to sender retry policy and Retry-After header of response
 type Sender struct {
-	url      string
-	user     string
-	password string
-	headers  map[string]string
-	client   *http.Client
-	log      moira.Logger
+	url         string
+	urlTemplate *template.Template
+	user        string
+	password    string
+	secret      string
+	headers     map[string]string
+	frontURI    string
+	location    *time.Location
+	renderer    *templating.Renderer
+	client      *http.Client
+	log         moira.Logger
 }
 
 // Init read yaml config
@@ -32,7 +44,14 @@ func (sender *Sender) Init(senderSettings map[string]string, logger moira.Logger
 		return fmt.Errorf("can not read url from config")
 	}
 
+	urlTemplate, err := templating.Parse("url", sender.url)
+	if err != nil {
+		return fmt.Errorf("can not parse url template: %s", err.Error())
+	}
+	sender.urlTemplate = urlTemplate
+
 	sender.user, sender.password = senderSettings["user"], senderSettings["password"]
+	sender.secret = senderSettings["secret"]
 
 	sender.headers = map[string]string{
 		"User-Agent":   "Moira",
@@ -41,13 +60,18 @@ func (sender *Sender) Init(senderSettings map[string]string, logger moira.Logger
 
 	timeout := 30
 	if timeoutRaw, ok := senderSettings["timeout"]; ok {
-		var err error
 		timeout, err = strconv.Atoi(timeoutRaw)
 		if err != nil {
 			return fmt.Errorf("can not read timeout from config: %s", err.Error())
 		}
 	}
 
+	sender.renderer, err = templating.NewRenderer(senderSettings, logger, location)
+	if err != nil {
+		return err
+	}
+	sender.frontURI = senderSettings["front_uri"]
+	sender.location = location
 	sender.log = logger
 	sender.client = &http.Client{
 		Timeout:   time.Duration(timeout) * time.Second,
@@ -65,7 +89,23 @@ func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.
 	}
 
 	if err != nil {
-		return fmt.Errorf("failed to build request: %s", err.Error())
+		return moira.SenderPermanentError{Err: fmt.Errorf("failed to build request: %s", err.Error())}
+	}
+
+	retryAfter, retryable, err := sender.sendRequest(request)
+	if err != nil && !retryable {
+		return moira.SenderPermanentError{Err: err}
+	}
+	if err != nil && retryAfter > 0 {
+		return moira.SenderRetryAfterError{Err: err, RetryAfter: retryAfter}
+	}
+	return err
+}
+
+// sendRequest performs signed request, returns delay requested by server in Retry-After header and whether request can be retried
+func (sender *Sender) sendRequest(request *http.Request) (time.Duration, bool, error) {
+	if err := sender.signRequest(request, time.Now().Unix()); err != nil {
+		return 0, false, fmt.Errorf("failed to sign request: %s", err.Error())
 	}
 
 	response, err := sender.client.Do(request)
@@ -74,7 +114,7 @@ func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.
 	}
 
 	if err != nil {
-		return fmt.Errorf("failed to perform request: %s", err.Error())
+		return 0, true, fmt.Errorf("failed to perform request: %s", err.Error())
 	}
 
 	if !isAllowedResponseCode(response.StatusCode) {
@@ -85,10 +125,27 @@ func (sender *Sender) SendEvents(events moira.NotificationEvents, contact moira.
 		} else {
 			serverResponse = string(responseBody)
 		}
-		return fmt.Errorf("invalid status code: %d, server response: %s", response.StatusCode, serverResponse)
+		retryable := response.StatusCode == http.StatusRequestTimeout || response.StatusCode == http.StatusTooManyRequests ||
+			response.StatusCode >= http.StatusInternalServerError
+		return parseRetryAfter(response.Header.Get("Retry-After")), retryable, fmt.Errorf("invalid status code: %d, server response: %s", response.StatusCode, serverResponse)
 	}
 
-	return nil
+	return 0, false, nil
+}
+
+// parseRetryAfter parses Retry-After header value which is either delay in seconds or HTTP date,
+// returns zero if value is invalid. Delay is limited by maxRetryAfter
+func parseRetryAfter(value string) time.Duration {
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = time.Until(date)
+	}
+	if delay > maxRetryAfter {
+		return maxRetryAfter
+	}
+	return delay
 }
 
 func isAllowedResponseCode(responseCode int) bool {
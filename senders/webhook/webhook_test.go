@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"encoding/base64"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -130,3 +131,92 @@ func getLastLine(longString string) (string, error) {
 	}
 	return lastLine, nil
 }
+
+func TestSender_SendEventsWithTemplatesAndSignature(t *testing.T) {
+	Convey("Receive templated and signed webhook", t, func() {
+		var paths, signatures, bodies []string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			paths = append(paths, r.URL.EscapedPath())
+			signatures = append(signatures, r.Header.Get(signatureHeader))
+			bodies = append(bodies, string(body))
+		}))
+		defer ts.Close()
+
+		senderSettings := map[string]string{
+			"name":   "testWebhook",
+			"url":    ts.URL + "/{{ pathescape .Trigger.Name }}/" + moira.VariableContactID,
+			"secret": "secret",
+		}
+		sender := Sender{}
+		So(sender.Init(senderSettings, logger, time.UTC, ""), ShouldBeNil)
+
+		contact := testContact
+		contact.MessageTemplate = `{"text": {{ json .Trigger.Name }}, "events": {{ len .Events }}}`
+		So(sender.SendEvents(testEvents, contact, testTrigger, testPlot, false), ShouldBeNil)
+
+		So(paths, ShouldResemble, []string{"/triggerName%20for%20test/contactID"})
+		So(bodies, ShouldResemble, []string{`{"text": "triggerName for test", "events": 5}`})
+		So(signatures, ShouldHaveLength, 1)
+		var timestamp int64
+		var signature string
+		_, err := fmt.Sscanf(strings.Replace(signatures[0], ",", " ", 1), "t=%d v1=%s", &timestamp, &signature)
+		So(err, ShouldBeNil)
+		So(signature, ShouldEqual, sign("secret", timestamp, []byte(bodies[0])))
+	})
+}
+
+func TestSender_SendEventsErrors(t *testing.T) {
+	Convey("Failed webhook", t, func() {
+		var status int
+		var requests int
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if status == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "5")
+			}
+			w.WriteHeader(status)
+		}))
+		defer ts.Close()
+
+		sender := Sender{}
+		So(sender.Init(map[string]string{"name": "testWebhook", "url": ts.URL}, logger, time.UTC, ""), ShouldBeNil)
+
+		Convey("Server errors are left to notifier retry policy", func() {
+			status = http.StatusBadGateway
+			err := sender.SendEvents(testEvents, testContact, testTrigger, testPlot, false)
+			So(err, ShouldResemble, fmt.Errorf("invalid status code: 502, server response: "))
+			So(moira.IsSenderPermanentError(err), ShouldBeFalse)
+			So(requests, ShouldEqual, 1)
+		})
+
+		Convey("Retry-After is returned with error", func() {
+			status = http.StatusTooManyRequests
+			err := sender.SendEvents(testEvents, testContact, testTrigger, testPlot, false)
+			So(moira.GetSenderRetryAfter(err), ShouldEqual, 5*time.Second)
+			So(moira.IsSenderPermanentError(err), ShouldBeFalse)
+			So(requests, ShouldEqual, 1)
+		})
+
+		Convey("Client errors are permanent", func() {
+			status = http.StatusBadRequest
+			err := sender.SendEvents(testEvents, testContact, testTrigger, testPlot, false)
+			So(moira.IsSenderPermanentError(err), ShouldBeTrue)
+			So(requests, ShouldEqual, 1)
+		})
+
+		Reset(func() {
+			requests = 0
+		})
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	Convey("Parse Retry-After", t, func() {
+		So(parseRetryAfter(""), ShouldEqual, 0)
+		So(parseRetryAfter("120"), ShouldEqual, 2*time.Minute)
+		So(parseRetryAfter("soon"), ShouldEqual, 0)
+		So(parseRetryAfter(time.Now().Add(30*time.Minute).UTC().Format(http.TimeFormat)), ShouldBeGreaterThan, 29*time.Minute)
+		So(parseRetryAfter("86400"), ShouldEqual, maxRetryAfter)
+	})
+}
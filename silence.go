@@ -0,0 +1,53 @@
+package moira
+
+import (
+	"path"
+)
+
+// Silence suppresses notifications about events of triggers marked with all silence tags
+// and metrics matching silence metric pattern while silence is active
+type Silence struct {
+	ID        string   `json:"id"`
+	Tags      []string `json:"tags,omitempty"`
+	Metric    string   `json:"metric,omitempty"`
+	StartTime int64    `json:"start_time"`
+	EndTime   int64    `json:"end_time"`
+	User      string   `json:"user"`
+	Comment   string   `json:"comment"`
+	CreatedAt int64    `json:"created_at"`
+}
+
+// IsActive checks that silence is active at given timestamp
+func (silence *Silence) IsActive(timestamp int64) bool {
+	return silence.StartTime <= timestamp && timestamp < silence.EndTime
+}
+
+// Matches checks that silence matches event of trigger with given tags. Metric is a metric name of metric event
+// or empty string for trigger event, silences with metric pattern never match trigger events.
+// Metric pattern is a shell glob, for example 'my.*.metric' or 'my.metric.[ab]'
+func (silence *Silence) Matches(tags []string, metric string) bool {
+	if len(silence.Tags) == 0 && silence.Metric == "" {
+		return false
+	}
+	if !Subset(silence.Tags, tags) {
+		return false
+	}
+	if silence.Metric == "" {
+		return true
+	}
+	if metric == "" {
+		return false
+	}
+	matched, err := path.Match(silence.Metric, metric)
+	return err == nil && matched
+}
+
+// GetMatchingSilence returns first silence active at given timestamp and matching event of trigger with given tags and metric
+func GetMatchingSilence(silences []*Silence, tags []string, metric string, timestamp int64) *Silence {
+	for _, silence := range silences {
+		if silence.IsActive(timestamp) && silence.Matches(tags, metric) {
+			return silence
+		}
+	}
+	return nil
+}
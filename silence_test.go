@@ -0,0 +1,59 @@
+package moira
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSilenceMatches(t *testing.T) {
+	Convey("Silence without matchers matches nothing", t, func() {
+		silence := Silence{}
+		So(silence.Matches([]string{"tag"}, "my.metric"), ShouldBeFalse)
+		So(silence.Matches([]string{"tag"}, ""), ShouldBeFalse)
+	})
+
+	Convey("Silence by tags", t, func() {
+		silence := Silence{Tags: []string{"first", "second"}}
+		So(silence.Matches([]string{"first", "second", "third"}, "my.metric"), ShouldBeTrue)
+		So(silence.Matches([]string{"first", "second"}, ""), ShouldBeTrue)
+		So(silence.Matches([]string{"first"}, "my.metric"), ShouldBeFalse)
+	})
+
+	Convey("Silence by metric pattern", t, func() {
+		silence := Silence{Metric: "my.*.metric"}
+		So(silence.Matches([]string{"tag"}, "my.server.metric"), ShouldBeTrue)
+		So(silence.Matches([]string{"tag"}, "my.server.other"), ShouldBeFalse)
+		So(silence.Matches([]string{"tag"}, ""), ShouldBeFalse)
+	})
+
+	Convey("Silence by tags and metric pattern", t, func() {
+		silence := Silence{Tags: []string{"tag"}, Metric: "my.metric.[ab]"}
+		So(silence.Matches([]string{"tag"}, "my.metric.a"), ShouldBeTrue)
+		So(silence.Matches([]string{"tag"}, "my.metric.c"), ShouldBeFalse)
+		So(silence.Matches([]string{"other"}, "my.metric.a"), ShouldBeFalse)
+	})
+
+	Convey("Invalid metric pattern matches nothing", t, func() {
+		silence := Silence{Metric: "my.[metric"}
+		So(silence.Matches([]string{"tag"}, "my.[metric"), ShouldBeFalse)
+	})
+}
+
+func TestGetMatchingSilence(t *testing.T) {
+	expired := &Silence{ID: "expired", Tags: []string{"tag"}, StartTime: 10, EndTime: 20}
+	future := &Silence{ID: "future", Tags: []string{"tag"}, StartTime: 100, EndTime: 200}
+	active := &Silence{ID: "active", Tags: []string{"tag"}, StartTime: 10, EndTime: 100}
+	silences := []*Silence{expired, future, active}
+
+	Convey("Active matching silence is returned", t, func() {
+		So(GetMatchingSilence(silences, []string{"tag"}, "my.metric", 50), ShouldEqual, active)
+		So(GetMatchingSilence(silences, []string{"tag"}, "my.metric", 100), ShouldEqual, future)
+	})
+
+	Convey("No matching silence", t, func() {
+		So(GetMatchingSilence(silences, []string{"other"}, "my.metric", 50), ShouldBeNil)
+		So(GetMatchingSilence(silences, []string{"tag"}, "my.metric", 300), ShouldBeNil)
+		So(GetMatchingSilence(nil, []string{"tag"}, "my.metric", 50), ShouldBeNil)
+	})
+}
@@ -0,0 +1,67 @@
+package templating
+
+import (
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/moira-alert/moira"
+)
+
+// Renderer renders sender messages with user-defined templates.
+// Message template of contact overrides template configured for sender
+type Renderer struct {
+	template *template.Template
+	frontURI string
+	location *time.Location
+	logger   moira.Logger
+}
+
+// NewRenderer creates renderer with message template file set in sender settings
+func NewRenderer(senderSettings map[string]string, logger moira.Logger, location *time.Location) (*Renderer, error) {
+	tmpl, err := ParseFile(senderSettings[SettingsKey])
+	if err != nil {
+		return nil, fmt.Errorf("can not parse message template: %s", err.Error())
+	}
+	return &Renderer{
+		template: tmpl,
+		frontURI: senderSettings["front_uri"],
+		location: location,
+		logger:   logger,
+	}, nil
+}
+
+// Render renders message of notification package, returns empty string if neither contact nor sender have a template
+func (renderer *Renderer) Render(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, throttled bool) (string, error) {
+	if renderer == nil {
+		return "", nil
+	}
+	tmpl := renderer.template
+	if contact.MessageTemplate != "" {
+		contactTemplate, err := Parse(contact.ID, contact.MessageTemplate)
+		if err != nil {
+			return "", fmt.Errorf("can not parse message template of contact %s: %s", contact.ID, err.Error())
+		}
+		tmpl = contactTemplate
+	}
+	if tmpl == nil {
+		return "", nil
+	}
+	return Execute(tmpl, NewData(events, contact, trigger, renderer.frontURI, renderer.location, throttled))
+}
+
+// RenderOrDefault renders message of notification package and truncates it to maxChars characters if maxChars is positive.
+// Message built with buildDefault is returned if neither contact nor sender have a template or template can not be rendered
+func (renderer *Renderer) RenderOrDefault(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, throttled bool, maxChars int, buildDefault func() string) string {
+	message, err := renderer.Render(events, contact, trigger, throttled)
+	if err != nil {
+		renderer.logger.Warningf("Failed to render %s message template: %s", trigger.ID, err.Error())
+	}
+	if message == "" {
+		return buildDefault()
+	}
+	if maxChars > 0 {
+		return Truncate(message, maxChars)
+	}
+	return message
+}
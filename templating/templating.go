@@ -0,0 +1,159 @@
+package templating
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/moira-alert/moira"
+)
+
+// SettingsKey is a sender settings key of the message template file path
+const SettingsKey = "template_file"
+
+var funcs = template.FuncMap{
+	"join":       strings.Join,
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"json":       toJSON,
+	"pathescape": url.PathEscape,
+}
+
+// Data is a data model available in message templates, e.g. {{ .Trigger.Name }} or {{ range .Events }}{{ .Metric }}{{ end }}
+type Data struct {
+	// State is the most severe state of notification events
+	State string
+	// Trigger is a trigger notification events belong to
+	Trigger Trigger
+	// Events are notification events in order of occurrence
+	Events []Event
+	// Contact is a contact notification is sent to
+	Contact Contact
+	// FrontURI is a Moira web UI address
+	FrontURI string
+	// PlotURL is an address of trigger plot rendered by Moira api, empty for test notifications
+	PlotURL string
+	// Throttled is true if trigger generates too many events and next notifications are delayed
+	Throttled bool
+}
+
+// Trigger is a trigger data available in message templates
+type Trigger struct {
+	ID   string
+	Name string
+	Desc string
+	Tags []string
+	// URI is an address of trigger page in Moira web UI, empty for test notifications
+	URI string
+}
+
+// Event is a notification event data available in message templates
+type Event struct {
+	Metric    string
+	Value     string
+	OldState  string
+	State     string
+	Timestamp int64
+	// Time is an event timestamp formatted in notifier location
+	Time string
+	// Message is an event message, e.g. reminder or acknowledge
+	Message        string
+	IsTriggerEvent bool
+}
+
+// Contact is a contact data available in message templates
+type Contact struct {
+	Type  string
+	Value string
+}
+
+// NewData builds message template data from notification package
+func NewData(events moira.NotificationEvents, contact moira.ContactData, trigger moira.TriggerData, frontURI string, location *time.Location, throttled bool) *Data {
+	data := &Data{
+		State: string(events.GetSubjectState()),
+		Trigger: Trigger{
+			ID:   trigger.ID,
+			Name: trigger.Name,
+			Desc: trigger.Desc,
+			Tags: trigger.Tags,
+			URI:  trigger.GetTriggerURI(frontURI),
+		},
+		Events: make([]Event, 0, len(events)),
+		Contact: Contact{
+			Type:  contact.Type,
+			Value: contact.Value,
+		},
+		FrontURI:  frontURI,
+		Throttled: throttled,
+	}
+	if trigger.ID != "" {
+		data.PlotURL = fmt.Sprintf("%s/api/trigger/%s/render", frontURI, trigger.ID)
+	}
+	for _, event := range events {
+		data.Events = append(data.Events, Event{
+			Metric:         event.Metric,
+			Value:          event.GetMetricValue(),
+			OldState:       string(event.OldState),
+			State:          string(event.State),
+			Timestamp:      event.Timestamp,
+			Time:           event.FormatTimestamp(location),
+			Message:        event.CreateMessage(location),
+			IsTriggerEvent: event.IsTriggerEvent,
+		})
+	}
+	return data
+}
+
+// Funcs returns functions available in message templates, e.g. to register them in html templates
+func Funcs() map[string]interface{} {
+	result := make(map[string]interface{}, len(funcs))
+	for name, function := range funcs {
+		result[name] = function
+	}
+	return result
+}
+
+// Parse parses message template text
+func Parse(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(funcs).Parse(text)
+}
+
+// ParseFile parses message template file, returns nil template if path is empty
+func ParseFile(path string) (*template.Template, error) {
+	if path == "" {
+		return nil, nil
+	}
+	return template.New(filepath.Base(path)).Funcs(funcs).ParseFiles(path)
+}
+
+// Execute renders message template with given data
+func Execute(tmpl *template.Template, data *Data) (string, error) {
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// toJSON marshals value to use it in JSON payload templates, e.g. {"name": {{ json .Trigger.Name }}}
+func toJSON(value interface{}) (string, error) {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// Truncate cuts message to maxChars characters
+func Truncate(message string, maxChars int) string {
+	runes := []rune(message)
+	if len(runes) <= maxChars {
+		return message
+	}
+	return string(runes[:maxChars-3]) + "..."
+}
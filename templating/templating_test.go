@@ -0,0 +1,133 @@
+package templating
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moira-alert/moira"
+	"github.com/moira-alert/moira/logging/go-logging"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRender(t *testing.T) {
+	location, _ := time.LoadLocation("UTC")
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test")
+	value := float64(97.4458331200185)
+	trigger := moira.TriggerData{ID: "triggerID", Name: "Trigger", Tags: []string{"first", "second"}}
+	events := moira.NotificationEvents{
+		{Timestamp: 150000000, Metric: "Metric", Value: &value, OldState: moira.StateOK, State: moira.StateWARN},
+	}
+	contact := moira.ContactData{ID: "contactID", Type: "slack", Value: "#channel"}
+
+	Convey("Renderer without templates returns empty message", t, func() {
+		renderer, err := NewRenderer(map[string]string{}, logger, location)
+		So(err, ShouldBeNil)
+		message, err := renderer.Render(events, contact, trigger, false)
+		So(err, ShouldBeNil)
+		So(message, ShouldBeEmpty)
+	})
+
+	Convey("Nil renderer returns empty message", t, func() {
+		var renderer *Renderer
+		message, err := renderer.Render(events, contact, trigger, false)
+		So(err, ShouldBeNil)
+		So(message, ShouldBeEmpty)
+	})
+
+	Convey("Sender template", t, func() {
+		dir, err := ioutil.TempDir("", "templating")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "message.tmpl")
+		text := `{{ .State }} {{ .Trigger.Name }} [{{ join .Trigger.Tags ", " }}]{{ range .Events }}
+{{ .Time }}: {{ .Metric }} = {{ .Value }} ({{ .OldState }} to {{ .State }}){{ end }}
+{{ .Trigger.URI }} {{ .PlotURL }}`
+		So(ioutil.WriteFile(path, []byte(text), 0644), ShouldBeNil)
+
+		renderer, err := NewRenderer(map[string]string{SettingsKey: path, "front_uri": "http://moira.url"}, logger, location)
+		So(err, ShouldBeNil)
+
+		Convey("Renders message", func() {
+			message, err := renderer.Render(events, contact, trigger, false)
+			So(err, ShouldBeNil)
+			So(message, ShouldEqual, `WARN Trigger [first, second]
+02:40: Metric = 97.4458331200185 (OK to WARN)
+http://moira.url/trigger/triggerID http://moira.url/api/trigger/triggerID/render`)
+		})
+
+		Convey("Contact template overrides sender template", func() {
+			contact.MessageTemplate = "{{ upper .Contact.Type }}: {{ len .Events }} events"
+			message, err := renderer.Render(events, contact, trigger, false)
+			So(err, ShouldBeNil)
+			So(message, ShouldEqual, "SLACK: 1 events")
+		})
+
+		Convey("JSON and path escaping functions", func() {
+			contact.MessageTemplate = `{"name": {{ json .Trigger.Name }}, "tags": {{ json .Trigger.Tags }}, "path": "{{ pathescape .Contact.Value }}"}`
+			message, err := renderer.Render(events, contact, trigger, false)
+			So(err, ShouldBeNil)
+			So(message, ShouldEqual, `{"name": "Trigger", "tags": ["first","second"], "path": "%23channel"}`)
+		})
+
+		Convey("Invalid contact template", func() {
+			contact.MessageTemplate = "{{ .Trigger.Name"
+			_, err := renderer.Render(events, contact, trigger, false)
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Not existing template file", t, func() {
+		_, err := NewRenderer(map[string]string{SettingsKey: "not_existing.tmpl"}, logger, location)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestRenderOrDefault(t *testing.T) {
+	location, _ := time.LoadLocation("UTC")
+	logger, _ := logging.ConfigureLog("stdout", "debug", "test")
+	renderer, _ := NewRenderer(map[string]string{"front_uri": "http://moira.url"}, logger, location)
+	value := float64(97.4458331200185)
+	events := moira.NotificationEvents{
+		{Timestamp: 150000000, Metric: "Metric", Value: &value, OldState: moira.StateOK, State: moira.StateWARN},
+	}
+	trigger := moira.TriggerData{ID: "TriggerID", Name: "Name"}
+	buildDefault := func() string { return "default" }
+
+	Convey("Contact without template gets default message", t, func() {
+		message := renderer.RenderOrDefault(events, moira.ContactData{}, trigger, false, 10, buildDefault)
+		So(message, ShouldEqual, "default")
+	})
+
+	Convey("Contact with invalid template gets default message", t, func() {
+		contact := moira.ContactData{MessageTemplate: "{{ .State"}
+		message := renderer.RenderOrDefault(events, contact, trigger, false, 10, buildDefault)
+		So(message, ShouldEqual, "default")
+	})
+
+	Convey("Contact with template gets rendered message", t, func() {
+		contact := moira.ContactData{MessageTemplate: "{{ .State }} <{{ .Trigger.URI }}|{{ .Trigger.Name }}>"}
+		message := renderer.RenderOrDefault(events, contact, trigger, false, 100, buildDefault)
+		So(message, ShouldEqual, "WARN <http://moira.url/trigger/TriggerID|Name>")
+	})
+
+	Convey("Long rendered message is truncated", t, func() {
+		contact := moira.ContactData{MessageTemplate: strings.Repeat("x", 11)}
+		So(renderer.RenderOrDefault(events, contact, trigger, false, 10, buildDefault), ShouldEqual, "xxxxxxx...")
+		So(renderer.RenderOrDefault(events, contact, trigger, false, 0, buildDefault), ShouldEqual, strings.Repeat("x", 11))
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	Convey("Short message is not truncated", t, func() {
+		So(Truncate("message", 7), ShouldEqual, "message")
+	})
+
+	Convey("Long message is truncated", t, func() {
+		So(Truncate("long message", 7), ShouldEqual, "long...")
+		So(Truncate("сообщение", 6), ShouldEqual, "соо...")
+	})
+}
@@ -0,0 +1,37 @@
+package moira
+
+// TrackerIssue is an issue opened in issue tracker about trigger. Metrics are trigger metrics gone to bad state
+// since issue was opened and not recovered yet, issue is resolved when all of them recover.
+// Opening and Resolving mark issue creation and resolution which were started but may have not been completed,
+// so sender retrying notification can finish them without opening duplicate issue or commenting twice
+type TrackerIssue struct {
+	Key       string   `json:"key"`
+	Metrics   []string `json:"metrics"`
+	Opening   bool     `json:"opening,omitempty"`
+	Resolving bool     `json:"resolving,omitempty"`
+}
+
+// AddMetric marks metric as not recovered
+func (issue *TrackerIssue) AddMetric(metric string) {
+	for _, issueMetric := range issue.Metrics {
+		if issueMetric == metric {
+			return
+		}
+	}
+	issue.Metrics = append(issue.Metrics, metric)
+}
+
+// RemoveMetric marks metric as recovered
+func (issue *TrackerIssue) RemoveMetric(metric string) {
+	for i, issueMetric := range issue.Metrics {
+		if issueMetric == metric {
+			issue.Metrics = append(issue.Metrics[:i], issue.Metrics[i+1:]...)
+			return
+		}
+	}
+}
+
+// IsRecovered checks that all issue metrics recovered
+func (issue *TrackerIssue) IsRecovered() bool {
+	return len(issue.Metrics) == 0
+}
@@ -0,0 +1,27 @@
+package moira
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTrackerIssueMetrics(t *testing.T) {
+	Convey("Issue metrics", t, func() {
+		issue := TrackerIssue{Key: "KEY-1"}
+		So(issue.IsRecovered(), ShouldBeTrue)
+
+		issue.AddMetric("first")
+		issue.AddMetric("second")
+		issue.AddMetric("first")
+		So(issue.Metrics, ShouldResemble, []string{"first", "second"})
+		So(issue.IsRecovered(), ShouldBeFalse)
+
+		issue.RemoveMetric("first")
+		issue.RemoveMetric("unknown")
+		So(issue.Metrics, ShouldResemble, []string{"second"})
+
+		issue.RemoveMetric("second")
+		So(issue.IsRecovered(), ShouldBeTrue)
+	})
+}